@@ -17,9 +17,9 @@ func (app *OKChainApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDe
 	protocol.GetEngine().GetCurrentProtocol().CheckStopped()
 
 	resp := app.BaseApp.DeliverTx(req)
-	if (protocol.GetEngine().GetCurrentProtocol().GetBackendKeeper().Config.EnableBackend ||
+	if (protocol.GetEngine().GetCurrentProtocol().GetBackendKeeper().SyncEnabled() ||
 		protocol.GetEngine().GetCurrentProtocol().GetStreamKeeper().AnalysisEnable()) && resp.IsOK() {
-		app.syncTx(req.Tx)
+		app.syncTx(req.Tx, resp.Events)
 	}
 
 	return resp
@@ -68,13 +68,13 @@ func (app *OKChainApp) Commit() abci.ResponseCommit {
 }
 
 // sync txBytes to backend module
-func (app *OKChainApp) syncTx(txBytes []byte) {
+func (app *OKChainApp) syncTx(txBytes []byte, events []abci.Event) {
 	if tx, err := auth.DefaultTxDecoder(protocol.GetEngine().GetCurrentProtocol().GetCodec())(txBytes); err == nil {
 		if stdTx, ok := tx.(auth.StdTx); ok {
 			txHash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
 			app.Logger().Debug(fmt.Sprintf("[Sync Tx(%s) to backend module]", txHash))
 			ctx := app.GetState(baseapp.RunTxModeDeliver()).Context()
-			protocol.GetEngine().GetCurrentProtocol().GetBackendKeeper().SyncTx(ctx, &stdTx, txHash,
+			protocol.GetEngine().GetCurrentProtocol().GetBackendKeeper().SyncTx(ctx, &stdTx, txHash, events,
 				ctx.BlockHeader().Time.Unix())
 			protocol.GetEngine().GetCurrentProtocol().GetStreamKeeper().SyncTx(ctx, &stdTx, txHash,
 				ctx.BlockHeader().Time.Unix())
@@ -18,8 +18,9 @@ var (
 	protocolsEngine *appProtocolEngine
 
 	// init monitor prometheus metrics
-	orderMetrics  = monitor.DefaultOrderMetrics(monitor.DefaultPrometheusConfig())
-	streamMetrics = monitor.DefaultStreamMetrics(monitor.DefaultPrometheusConfig())
+	orderMetrics   = monitor.DefaultOrderMetrics(monitor.DefaultPrometheusConfig())
+	streamMetrics  = monitor.DefaultStreamMetrics(monitor.DefaultPrometheusConfig())
+	backendMetrics = monitor.DefaultBackendMetrics(monitor.DefaultPrometheusConfig())
 )
 
 // GetEngine gets the Singleton application protocol engine
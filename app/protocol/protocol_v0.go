@@ -20,15 +20,19 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/okex/okchain/app/utils"
+	"github.com/okex/okchain/x/ammswap"
 	"github.com/okex/okchain/x/backend"
+	backendcfg "github.com/okex/okchain/x/backend/config"
 	"github.com/okex/okchain/x/common/proto"
 	"github.com/okex/okchain/x/common/version"
 	"github.com/okex/okchain/x/dex"
 	dexClient "github.com/okex/okchain/x/dex/client"
 	distr "github.com/okex/okchain/x/distribution"
+	"github.com/okex/okchain/x/farm"
 	"github.com/okex/okchain/x/genutil"
 	"github.com/okex/okchain/x/gov"
 	"github.com/okex/okchain/x/gov/keeper"
+	"github.com/okex/okchain/x/htlc"
 	"github.com/okex/okchain/x/order"
 	"github.com/okex/okchain/x/params"
 	paramsclient "github.com/okex/okchain/x/params/client"
@@ -73,6 +77,9 @@ var (
 		token.AppModuleBasic{},
 		dex.AppModuleBasic{},
 		order.AppModuleBasic{},
+		ammswap.AppModuleBasic{},
+		farm.AppModuleBasic{},
+		htlc.AppModuleBasic{},
 		backend.AppModuleBasic{},
 		upgrade.AppModuleBasic{},
 		stream.AppModuleBasic{},
@@ -90,6 +97,9 @@ var (
 		order.ModuleName:          nil,
 		backend.ModuleName:        nil,
 		dex.ModuleName:            nil,
+		ammswap.ModuleName:        {supply.Minter, supply.Burner},
+		farm.ModuleName:           nil,
+		htlc.ModuleName:           nil,
 	}
 )
 
@@ -119,6 +129,9 @@ type ProtocolV0 struct {
 	tokenKeeper    token.Keeper
 	dexKeeper      dex.Keeper
 	orderKeeper    order.Keeper
+	swapKeeper     ammswap.Keeper
+	farmKeeper     farm.Keeper
+	htlcKeeper     htlc.Keeper
 	protocolKeeper proto.ProtocolKeeper
 	backendKeeper  backend.Keeper
 	streamKeeper   stream.Keeper
@@ -247,6 +260,8 @@ func (p *ProtocolV0) produceKeepers() {
 		p.logger.Error(fmt.Sprintf("the config of OKChain was parsed error : %s", err.Error()))
 		panic(err)
 	}
+	// the order and token keepers collect the block data for the backend database or the block log
+	collectBackendData := appConfig.BackendConfig.EnableBackend || backendcfg.BlockLogEnabled()
 
 	// 1.init params keeper and subspaces
 	p.paramsKeeper = params.NewKeeper(
@@ -264,10 +279,16 @@ func (p *ProtocolV0) produceKeepers() {
 	orderSubspace := p.paramsKeeper.Subspace(order.DefaultParamspace)
 	upgradeSubspace := p.paramsKeeper.Subspace(upgrade.DefaultParamspace)
 	dexSubspace := p.paramsKeeper.Subspace(dex.DefaultParamspace)
+	swapSubspace := p.paramsKeeper.Subspace(ammswap.DefaultParamspace)
+	farmSubspace := p.paramsKeeper.Subspace(farm.DefaultParamspace)
 
 	// 2.add keepers
 	p.accountKeeper = auth.NewAccountKeeper(p.cdc, p.keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
-	p.bankKeeper = bank.NewBaseKeeper(p.accountKeeper, bankSubspace, bank.DefaultCodespace, p.moduleAccountAddrs())
+	// the bank keeper refuses to move the frozen balances for the bank module and all the modules using supply
+	p.bankKeeper = token.NewFreezableBankKeeper(
+		bank.NewBaseKeeper(p.accountKeeper, bankSubspace, bank.DefaultCodespace, p.moduleAccountAddrs()),
+		p.keys[token.StoreKey], p.keys[token.KeyLock],
+	)
 	p.paramsKeeper.SetBankKeeper(p.bankKeeper)
 	p.supplyKeeper = supply.NewKeeper(p.cdc, p.keys[supply.StoreKey], p.accountKeeper, p.bankKeeper, maccPerms)
 	stakingKeeper := staking.NewKeeper(p.cdc, p.keys[staking.StoreKey], p.tkeys[staking.TStoreKey],
@@ -292,7 +313,7 @@ func (p *ProtocolV0) produceKeepers() {
 	p.tokenKeeper = token.NewKeeper(
 		p.bankKeeper, p.paramsKeeper, tokenSubspace, auth.FeeCollectorName, p.supplyKeeper,
 		p.keys[token.StoreKey], p.keys[token.KeyLock],
-		p.cdc, appConfig.BackendConfig.EnableBackend)
+		p.cdc, collectBackendData)
 
 	p.dexKeeper = dex.NewKeeper(auth.FeeCollectorName, p.supplyKeeper, dexSubspace, p.tokenKeeper, &stakingKeeper,
 		p.bankKeeper, p.keys[dex.StoreKey], p.keys[dex.TokenPairStoreKey], p.cdc)
@@ -300,14 +321,31 @@ func (p *ProtocolV0) produceKeepers() {
 	p.orderKeeper = order.NewKeeper(
 		p.tokenKeeper, p.supplyKeeper, p.paramsKeeper, p.dexKeeper, orderSubspace, auth.FeeCollectorName,
 		p.keys[order.OrderStoreKey],
-		p.cdc, appConfig.BackendConfig.EnableBackend, orderMetrics,
+		p.cdc, collectBackendData, orderMetrics,
 	)
 
+	p.swapKeeper = ammswap.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.dexKeeper, p.keys[ammswap.StoreKey],
+		swapSubspace, p.cdc)
+	p.orderKeeper.SetSwapKeeper(p.swapKeeper)
+
+	p.farmKeeper = farm.NewKeeper(p.supplyKeeper, p.dexKeeper, p.orderKeeper, p.keys[farm.StoreKey],
+		farmSubspace, p.cdc)
+	p.htlcKeeper = htlc.NewKeeper(p.supplyKeeper, p.keys[htlc.StoreKey], p.cdc)
+
 	p.streamKeeper = stream.NewKeeper(p.orderKeeper, p.tokenKeeper, p.dexKeeper, p.accountKeeper, p.cdc, p.logger,
 		appConfig, streamMetrics)
 
 	p.backendKeeper = backend.NewKeeper(p.orderKeeper, p.tokenKeeper, p.dexKeeper, p.streamKeeper.GetMarketKeeper(),
-		p.cdc, p.logger, appConfig.BackendConfig)
+		p.cdc, p.logger, appConfig.BackendConfig, backendMetrics)
+	if backendcfg.BlockLogEnabled() {
+		if err := p.backendKeeper.OpenBlockLog(backendcfg.BlockLogDir()); err != nil {
+			p.logger.Error(fmt.Sprintf("failed to open the backend block log: %s", err.Error()))
+			panic(err)
+		}
+	}
+	if interval := backendcfg.DepthSnapshotInterval(); interval > 0 {
+		p.backendKeeper.EnableDepthSnapshots(interval, backendcfg.DepthSnapshotSize())
+	}
 
 	// 3.register the proposal types
 	govRouter := gov.NewRouter()
@@ -362,10 +400,14 @@ func (p *ProtocolV0) setManager() {
 		distr.NewAppModule(p.distrKeeper, p.supplyKeeper),
 		gov.NewAppModule(version.ProtocolVersionV0, p.govKeeper, p.supplyKeeper),
 		order.NewAppModule(version.ProtocolVersionV0, p.orderKeeper, p.supplyKeeper),
-		token.NewAppModule(version.ProtocolVersionV0, p.tokenKeeper, p.supplyKeeper),
+		token.NewAppModule(version.ProtocolVersionV0, p.tokenKeeper, p.supplyKeeper,
+			token.NewSnapshotAccountKeeper(p.accountKeeper, p.keys[auth.StoreKey])),
 
 		// TODO
 		dex.NewAppModule(version.ProtocolVersionV0, p.dexKeeper, p.supplyKeeper),
+		ammswap.NewAppModule(version.ProtocolVersionV0, p.swapKeeper),
+		farm.NewAppModule(version.ProtocolVersionV0, p.farmKeeper),
+		htlc.NewAppModule(version.ProtocolVersionV0, p.htlcKeeper),
 		backend.NewAppModule(p.backendKeeper),
 		stream.NewAppModule(p.streamKeeper),
 		upgrade.NewAppModule(p.upgradeKeeper),
@@ -376,6 +418,8 @@ func (p *ProtocolV0) setManager() {
 		order.ModuleName,
 		token.ModuleName,
 		dex.ModuleName,
+		farm.ModuleName,
+		htlc.ModuleName,
 		mint.ModuleName,
 		distr.ModuleName,
 		slashing.ModuleName,
@@ -409,6 +453,9 @@ func (p *ProtocolV0) setManager() {
 		token.ModuleName,
 		dex.ModuleName,
 		order.ModuleName,
+		ammswap.ModuleName,
+		farm.ModuleName,
+		htlc.ModuleName,
 		upgrade.ModuleName,
 	)
 }
@@ -427,7 +474,7 @@ func (p *ProtocolV0) setAnteHandler() {
 		p.supplyKeeper,
 		auth.DefaultSigVerificationGasConsumer,
 		validateMsgHook(p.orderKeeper),
-		isSystemFreeHook,
+		order.IsSystemFree,
 	)
 	p.parent.PushAnteHandler(p.anteHandler)
 }
@@ -492,22 +539,6 @@ func validateMsgHook(orderKeeper order.Keeper) auth.ValidateMsgHandler {
 	}
 }
 
-func isSystemFreeHook(ctx sdk.Context, msgs []sdk.Msg) bool {
-	if ctx.BlockHeight() < 1 {
-		return true
-	}
-
-	for _, msg := range msgs {
-		switch msg.(type) {
-		case order.MsgNewOrders, order.MsgCancelOrders:
-		default:
-			return false
-		}
-	}
-
-	return true
-}
-
 // ExportGenesis exports the genesis state for whole protocol
 func (p *ProtocolV0) ExportGenesis(ctx sdk.Context) map[string]json.RawMessage {
 	return p.mm.ExportGenesis(ctx)
@@ -164,7 +164,7 @@ func TestProtocolV0_Stop(t *testing.T) {
 }
 
 func TestProtocolV0_Hooks(t *testing.T) {
-	///////////////////////////// test isSystemFreeHook /////////////////////////////
+	///////////////////////////// test IsSystemFree /////////////////////////////
 	var mockMsgs1, mockMsgs2, mockMsgs3 []sdk.Msg
 	mockMsgs1 = append(mockMsgs1, order.MsgNewOrders{})
 	mockMsgs2 = append(mockMsgs2, order.MsgNewOrders{}, token.MsgSend{})
@@ -172,15 +172,15 @@ func TestProtocolV0_Hooks(t *testing.T) {
 
 	// height < 1
 	mockContext := sdk.NewContext(nil, abci.Header{}, false, nil)
-	require.True(t, isSystemFreeHook(mockContext, mockMsgs1))
+	require.True(t, order.IsSystemFree(mockContext, mockMsgs1))
 
 	// condition 1
 	mockContext = mockContext.WithBlockHeight(1)
-	require.True(t, isSystemFreeHook(mockContext, mockMsgs1))
+	require.True(t, order.IsSystemFree(mockContext, mockMsgs1))
 
 	// condition 2
-	require.False(t, isSystemFreeHook(mockContext, mockMsgs2))
+	require.False(t, order.IsSystemFree(mockContext, mockMsgs2))
 
 	// condition 3
-	require.False(t, isSystemFreeHook(mockContext, mockMsgs3))
+	require.False(t, order.IsSystemFree(mockContext, mockMsgs3))
 }
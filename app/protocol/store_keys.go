@@ -7,7 +7,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okchain/x/ammswap"
 	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/farm"
+	"github.com/okex/okchain/x/htlc"
 	"github.com/okex/okchain/x/staking"
 
 	//distr "github.com/okex/okchain/x/distribution"
@@ -37,6 +40,9 @@ var (
 		order.OrderStoreKey,
 		upgrade.StoreKey,
 		dex.StoreKey, dex.TokenPairStoreKey,
+		ammswap.StoreKey,
+		farm.StoreKey,
+		htlc.StoreKey,
 	)
 
 	transientStoreKeysMap = sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey)
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/okex/okchain/app"
+	"github.com/okex/okchain/app/protocol"
+	"github.com/okex/okchain/x/backend"
+	backendcfg "github.com/okex/okchain/x/backend/config"
+	"github.com/okex/okchain/x/backend/orm"
+	backendtypes "github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/common/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	flagReindexFrom = "from"
+	flagReindexTo   = "to"
+
+	flagBlockLogDir   = "block-log-dir"
+	flagPruneBlockLog = "prune-block-log"
+	flagPollInterval  = "poll-interval"
+)
+
+// backendCmd gathers the maintenance commands of the backend database
+func backendCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backend",
+		Short: "Maintain the backend database",
+	}
+	cmd.AddCommand(reindexCmd(ctx), indexerCmd(ctx))
+	return cmd
+}
+
+func reindexCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Regenerate the backend data of committed blocks",
+		Long: `Replay the committed blocks [from, to] on top of the application state at height from-1 and regenerate
+their orders, deals, match results, fee details, transactions and klines in the backend database.
+
+The node must be stopped: its databases are opened read-only and the replayed state is thrown away.
+The replay loads the application state at height from-1, lower if the range is widened, so that state must not
+have been pruned: run the node with --pruning nothing, or reindex from a height its pruning strategy keeps.
+A reindex from 1 replays from genesis.
+The range is widened to whole seconds of block time, the existing backend data of the range is replaced,
+and an interrupted reindex of the same range resumes where it stopped.
+
+Example:
+$ okchaind backend reindex --from 1000 --to 2000
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return reindexBackend(ctx, viper.GetInt64(flagReindexFrom), viper.GetInt64(flagReindexTo))
+		},
+	}
+	cmd.Flags().Int64(flagReindexFrom, 1, "the first block height to reindex")
+	cmd.Flags().Int64(flagReindexTo, 0, "the last block height to reindex, 0 for the latest committed block")
+	return cmd
+}
+
+func indexerCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "indexer",
+		Short: "Fill the backend database from the block log of a node",
+		Long: `Run the backend out of the node process: apply the blocks appended to the block log by a node with
+enable_block_log = true in the backend section of okchaind.toml, and generate the klines, until interrupted.
+
+The node doesn't need enable_backend, the indexer writes to the database of the orm_engine of its own
+okchaind.toml. Every block is applied exactly once, a restarted indexer resumes after the last applied block.
+
+Example:
+$ okchaind backend indexer --block-log-dir /data/okchaind/data/backend_blocklog
+`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			dir := viper.GetString(flagBlockLogDir)
+			if dir == "" {
+				dir = backendcfg.BlockLogDir()
+			}
+			return runIndexer(ctx, dir, viper.GetBool(flagPruneBlockLog), viper.GetDuration(flagPollInterval))
+		},
+	}
+	cmd.Flags().String(flagBlockLogDir, "", "the directory of the block log, the one of the node home by default")
+	cmd.Flags().Bool(flagPruneBlockLog, false, "remove the segments of the block log once applied")
+	cmd.Flags().Duration(flagPollInterval, 500*time.Millisecond, "the interval to poll the block log for new blocks")
+	return cmd
+}
+
+func runIndexer(ctx *server.Context, dir string, pruneLog bool, interval time.Duration) error {
+	viper.Set("backend.enable_backend", true)
+	viper.Set("backend.enable_mkt_compute", true)
+	appConfig, err := config.ParseConfig()
+	if err != nil {
+		return err
+	}
+
+	keeper := backend.NewKeeper(nil, nil, nil, nil, app.MakeCodec(), ctx.Logger, appConfig.BackendConfig,
+		monitor.NopBackendMetrics())
+	defer keeper.Stop()
+	if keeper.Orm == nil {
+		return fmt.Errorf("failed to open the backend database, check the backend section of okchaind.toml")
+	}
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	indexer := backend.NewIndexer(keeper, dir, pruneLog)
+	ctx.Logger.Info(fmt.Sprintf("backend indexer started on %s after block %d", dir, indexer.Height()))
+	err = indexer.Run(stop, interval)
+	ctx.Logger.Info(fmt.Sprintf("backend indexer stopped after block %d", indexer.Height()))
+	return err
+}
+
+// reindexApp replays blocks through OKChainApp and feeds the delivered txs and the end blocks to the reindexer
+type reindexApp struct {
+	*app.OKChainApp
+	reindexer *backend.Reindexer
+}
+
+func (a *reindexApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	res := a.OKChainApp.DeliverTx(req)
+	if !res.IsOK() {
+		return res
+	}
+
+	tx, err := auth.DefaultTxDecoder(protocol.GetEngine().GetCurrentProtocol().GetCodec())(req.Tx)
+	if err != nil {
+		return res
+	}
+	if stdTx, ok := tx.(auth.StdTx); ok {
+		ctx := a.GetState(baseapp.RunTxModeDeliver()).Context()
+		a.reindexer.SyncTx(ctx, &stdTx, fmt.Sprintf("%X", tmhash.Sum(req.Tx)), res.Events)
+	}
+	return res
+}
+
+func (a *reindexApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
+	res := a.OKChainApp.EndBlock(req)
+	a.reindexer.EndBlock(a.GetState(baseapp.RunTxModeDeliver()).Context())
+	return res
+}
+
+func reindexBackend(ctx *server.Context, from, to int64) error {
+	cfg := ctx.Config
+	blockStoreDB, err := openReadOnlyDB("blockstore", cfg.DBDir())
+	if err != nil {
+		return err
+	}
+	defer blockStoreDB.Close()
+	stateDB, err := openReadOnlyDB("state", cfg.DBDir())
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	blockStore := tmstore.NewBlockStore(blockStoreDB)
+	if to == 0 {
+		to = blockStore.Height()
+	}
+	if from < 1 || from > to || to > blockStore.Height() {
+		return fmt.Errorf("invalid range [%d, %d], the blocks [1, %d] are committed", from, to, blockStore.Height())
+	}
+
+	// widen the range to whole seconds: orders, fee details and transactions are only stamped with the block time
+	start, end := from, to
+	for start > 1 && blockTime(blockStore, start-1) == blockTime(blockStore, start) {
+		start--
+	}
+	for end < blockStore.Height() && blockTime(blockStore, end+1) == blockTime(blockStore, end) {
+		end++
+	}
+
+	// the replay only keeps the per block data of the backend, it neither computes the klines nor writes live data
+	viper.Set("backend.enable_backend", true)
+	viper.Set("backend.enable_mkt_compute", false)
+
+	height, err := reindexResumeHeight(ctx, from, to, start)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "okchaind-reindex")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	db, err := openReplayDB(filepath.Join(cfg.RootDir, "data"), tmpDir, height == 1)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	okApp := app.NewOKChainApp(ctx.Logger, db, nil, false, 0, baseapp.SetPruning(store.PruneNothing))
+	keeper := protocol.GetEngine().GetCurrentProtocol().GetBackendKeeper()
+	defer keeper.Stop()
+	if keeper.Orm == nil {
+		return fmt.Errorf("failed to open the backend database, check the backend section of okchaind.toml")
+	}
+
+	if height <= end {
+		// the data of an interrupted block, if any, is dropped here as well
+		if err := keeper.Orm.DeleteBlockRange(height, end, blockTime(blockStore, height),
+			blockTime(blockStore, end)); err != nil {
+			return err
+		}
+		if err := replayBlocks(ctx, okApp, keeper, blockStore, stateDB, from, to, height, end); err != nil {
+			return err
+		}
+	}
+
+	endTS := blockTime(blockStore, end)
+	if maxTS := keeper.Orm.GetMergeResultMaxTimestamp(); maxTS > endTS {
+		endTS = maxTS
+	}
+	if err := keeper.Orm.RebuildKlines(blockTime(blockStore, start), endTS); err != nil {
+		return err
+	}
+
+	ctx.Logger.Info(fmt.Sprintf("backend reindexed from block %d to block %d", start, end))
+	return nil
+}
+
+// reindexResumeHeight returns the first block to replay, after the progress of an interrupted reindex of [from, to]
+func reindexResumeHeight(ctx *server.Context, from, to, start int64) (int64, error) {
+	appConfig, err := config.ParseConfig()
+	if err != nil {
+		return 0, err
+	}
+	o, err := orm.New(appConfig.BackendConfig.LogSQL, &appConfig.BackendConfig.OrmEngine, &ctx.Logger)
+	if err != nil {
+		return 0, err
+	}
+	defer o.Close()
+
+	if progress := o.GetReindexProgress(from, to); progress != nil {
+		return progress.Height + 1, nil
+	}
+	return start, nil
+}
+
+func replayBlocks(ctx *server.Context, okApp *app.OKChainApp, keeper backend.Keeper, blockStore *tmstore.BlockStore,
+	stateDB dbm.DB, from, to, start, end int64) error {
+	rApp := &reindexApp{OKChainApp: okApp, reindexer: backend.NewReindexer(keeper)}
+	conns := proxy.NewAppConns(proxy.NewLocalClientCreator(rApp))
+	if err := conns.Start(); err != nil {
+		return err
+	}
+	defer conns.Stop()
+
+	if err := okApp.LoadHeight(start - 1); err != nil {
+		return fmt.Errorf("failed to load the application state at height %d: %s", start-1, err.Error())
+	}
+	if start == 1 {
+		if err := initChain(ctx, conns.Consensus()); err != nil {
+			return err
+		}
+	}
+
+	for height := start; height <= end; height++ {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return fmt.Errorf("block %d is missing from the block store", height)
+		}
+		appHash, err := sm.ExecCommitBlock(conns.Consensus(), block, ctx.Logger, stateDB)
+		if err != nil {
+			return err
+		}
+		if next := blockStore.LoadBlockMeta(height + 1); next != nil && !bytes.Equal(next.Header.AppHash, appHash) {
+			return fmt.Errorf("app hash mismatch after replaying block %d: expected %X, got %X",
+				height, next.Header.AppHash, appHash)
+		}
+		if err := rApp.reindexer.Commit(); err != nil {
+			return fmt.Errorf("failed to write the backend data of block %d: %s", height, err.Error())
+		}
+
+		// only checkpoint at the end of a second, so that a resumed reindex starts on a whole second as well
+		if height == end || blockTime(blockStore, height+1) > block.Time.Unix() {
+			progress := &backendtypes.ReindexProgress{FromHeight: from, ToHeight: to, Height: height}
+			if err := keeper.Orm.SaveReindexProgress(progress); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func initChain(ctx *server.Context, conn proxy.AppConnConsensus) error {
+	genDoc, err := tmtypes.GenesisDocFromFile(ctx.Config.GenesisFile())
+	if err != nil {
+		return err
+	}
+
+	validators := make([]*tmtypes.Validator, len(genDoc.Validators))
+	for i, val := range genDoc.Validators {
+		validators[i] = tmtypes.NewValidator(val.PubKey, val.Power)
+	}
+	_, err = conn.InitChainSync(abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		ConsensusParams: tmtypes.TM2PB.ConsensusParams(genDoc.ConsensusParams),
+		Validators:      tmtypes.TM2PB.ValidatorUpdates(tmtypes.NewValidatorSet(validators)),
+		AppStateBytes:   genDoc.AppState,
+	})
+	return err
+}
+
+func blockTime(blockStore *tmstore.BlockStore, height int64) int64 {
+	return blockStore.LoadBlockMeta(height).Header.Time.Unix()
+}
+
+func openReadOnlyDB(name, dir string) (dbm.DB, error) {
+	db, err := dbm.NewGoLevelDBWithOpts(name, dir, &opt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s read-only, make sure the node is stopped: %s", name, err.Error())
+	}
+	return db, nil
+}
+
+// openReplayDB opens the application DB read-only under an overlay kept in tmpDir, or an empty base when the replay
+// starts from genesis
+func openReplayDB(dataDir, tmpDir string, fromGenesis bool) (*overlayDB, error) {
+	writes, err := dbm.NewGoLevelDB("writes", tmpDir)
+	if err != nil {
+		return nil, err
+	}
+	deletes, err := dbm.NewGoLevelDB("deletes", tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromGenesis {
+		return newOverlayDB(dbm.NewMemDB(), writes, deletes), nil
+	}
+	base, err := openReadOnlyDB("application", dataDir)
+	if err != nil {
+		return nil, err
+	}
+	return newOverlayDB(base, writes, deletes), nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// overlayDB is a copy-on-write view of a database opened read-only: reads fall through to the base database while
+// writes and deletes only land in the overlay, so that blocks can be replayed without touching the node's data
+type overlayDB struct {
+	base    dbm.DB
+	writes  dbm.DB
+	deletes dbm.DB
+}
+
+var _ dbm.DB = (*overlayDB)(nil)
+
+func newOverlayDB(base, writes, deletes dbm.DB) *overlayDB {
+	return &overlayDB{base: base, writes: writes, deletes: deletes}
+}
+
+func (db *overlayDB) Get(key []byte) []byte {
+	if value := db.writes.Get(key); value != nil {
+		return value
+	}
+	if db.deletes.Has(key) {
+		return nil
+	}
+	return db.base.Get(key)
+}
+
+func (db *overlayDB) Has(key []byte) bool {
+	return db.Get(key) != nil
+}
+
+func (db *overlayDB) Set(key, value []byte) {
+	db.deletes.Delete(key)
+	db.writes.Set(key, value)
+}
+
+func (db *overlayDB) SetSync(key, value []byte) {
+	db.Set(key, value)
+}
+
+func (db *overlayDB) Delete(key []byte) {
+	db.writes.Delete(key)
+	db.deletes.Set(key, []byte{})
+}
+
+func (db *overlayDB) DeleteSync(key []byte) {
+	db.Delete(key)
+}
+
+func (db *overlayDB) Iterator(start, end []byte) dbm.Iterator {
+	return newOverlayIterator(db, db.base.Iterator(start, end), db.writes.Iterator(start, end), false)
+}
+
+func (db *overlayDB) ReverseIterator(start, end []byte) dbm.Iterator {
+	return newOverlayIterator(db, db.base.ReverseIterator(start, end), db.writes.ReverseIterator(start, end), true)
+}
+
+func (db *overlayDB) Close() {
+	db.base.Close()
+	db.writes.Close()
+	db.deletes.Close()
+}
+
+func (db *overlayDB) NewBatch() dbm.Batch {
+	return &overlayBatch{db: db}
+}
+
+func (db *overlayDB) Print() {
+	db.writes.Print()
+}
+
+func (db *overlayDB) Stats() map[string]string {
+	return db.writes.Stats()
+}
+
+type overlayOperation struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type overlayBatch struct {
+	db  *overlayDB
+	ops []overlayOperation
+}
+
+func (b *overlayBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, overlayOperation{key: key, value: value})
+}
+
+func (b *overlayBatch) Delete(key []byte) {
+	b.ops = append(b.ops, overlayOperation{key: key, delete: true})
+}
+
+func (b *overlayBatch) Write() {
+	for _, op := range b.ops {
+		if op.delete {
+			b.db.Delete(op.key)
+		} else {
+			b.db.Set(op.key, op.value)
+		}
+	}
+	b.ops = nil
+}
+
+func (b *overlayBatch) WriteSync() {
+	b.Write()
+}
+
+func (b *overlayBatch) Close() {
+	b.ops = nil
+}
+
+// overlayIterator merges the iterators of the base database and the overlay, the overlay winning on equal keys and
+// the keys deleted in the overlay being skipped
+type overlayIterator struct {
+	db      *overlayDB
+	base    dbm.Iterator
+	top     dbm.Iterator
+	reverse bool
+}
+
+func newOverlayIterator(db *overlayDB, base, top dbm.Iterator, reverse bool) *overlayIterator {
+	it := &overlayIterator{db: db, base: base, top: top, reverse: reverse}
+	it.skipDeleted()
+	return it
+}
+
+func (it *overlayIterator) Domain() (start []byte, end []byte) {
+	return it.top.Domain()
+}
+
+func (it *overlayIterator) Valid() bool {
+	return it.base.Valid() || it.top.Valid()
+}
+
+func (it *overlayIterator) Next() {
+	current := it.current()
+	if current == it.top && it.base.Valid() && bytes.Equal(it.base.Key(), it.top.Key()) {
+		it.base.Next()
+	}
+	current.Next()
+	it.skipDeleted()
+}
+
+func (it *overlayIterator) Key() []byte {
+	return it.current().Key()
+}
+
+func (it *overlayIterator) Value() []byte {
+	return it.current().Value()
+}
+
+func (it *overlayIterator) Close() {
+	it.base.Close()
+	it.top.Close()
+}
+
+// current returns the iterator positioned on the next key in iteration order
+func (it *overlayIterator) current() dbm.Iterator {
+	if !it.base.Valid() {
+		return it.top
+	}
+	if !it.top.Valid() {
+		return it.base
+	}
+
+	cmp := bytes.Compare(it.base.Key(), it.top.Key())
+	if it.reverse {
+		cmp = -cmp
+	}
+	if cmp < 0 {
+		return it.base
+	}
+	return it.top
+}
+
+func (it *overlayIterator) skipDeleted() {
+	for it.base.Valid() {
+		key := it.base.Key()
+		if it.top.Valid() && bytes.Equal(it.top.Key(), key) {
+			return
+		}
+		if !it.db.deletes.Has(key) {
+			return
+		}
+		it.base.Next()
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func newTestOverlayDB() (*overlayDB, dbm.DB) {
+	base := dbm.NewMemDB()
+	for _, key := range []string{"a", "b", "c", "d"} {
+		base.Set([]byte(key), []byte("base-"+key))
+	}
+	return newOverlayDB(base, dbm.NewMemDB(), dbm.NewMemDB()), base
+}
+
+func iterateKeyValues(it dbm.Iterator) (kvs []string) {
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		kvs = append(kvs, string(it.Key())+"="+string(it.Value()))
+	}
+	return kvs
+}
+
+func TestOverlayDB_SetDelete(t *testing.T) {
+	db, base := newTestOverlayDB()
+
+	db.Set([]byte("b"), []byte("top-b"))
+	db.Set([]byte("e"), []byte("top-e"))
+	db.Delete([]byte("c"))
+	require.Equal(t, []byte("top-b"), db.Get([]byte("b")))
+	require.Equal(t, []byte("top-e"), db.Get([]byte("e")))
+	require.Nil(t, db.Get([]byte("c")))
+	require.False(t, db.Has([]byte("c")))
+	require.Equal(t, []byte("base-a"), db.Get([]byte("a")))
+
+	// a key set again after being deleted is visible, a key deleted after being set is not
+	db.Set([]byte("c"), []byte("top-c"))
+	require.Equal(t, []byte("top-c"), db.Get([]byte("c")))
+	db.Delete([]byte("e"))
+	require.False(t, db.Has([]byte("e")))
+
+	// batches go through the overlay too
+	batch := db.NewBatch()
+	batch.Delete([]byte("a"))
+	batch.Set([]byte("f"), []byte("top-f"))
+	batch.Write()
+	require.Nil(t, db.Get([]byte("a")))
+	require.Equal(t, []byte("top-f"), db.Get([]byte("f")))
+
+	// the base is never written
+	require.Equal(t, []byte("base-a"), base.Get([]byte("a")))
+	require.Equal(t, []byte("base-b"), base.Get([]byte("b")))
+	require.Equal(t, []byte("base-c"), base.Get([]byte("c")))
+	require.False(t, base.Has([]byte("f")))
+}
+
+func TestOverlayDB_Iterator(t *testing.T) {
+	db, _ := newTestOverlayDB()
+	// shadowed, deleted at both ends and in the middle, and added between and after the base keys
+	db.Set([]byte("b"), []byte("top-b"))
+	db.Delete([]byte("a"))
+	db.Delete([]byte("c"))
+	db.Delete([]byte("d"))
+	db.Set([]byte("bb"), []byte("top-bb"))
+	db.Set([]byte("e"), []byte("top-e"))
+
+	require.Equal(t, []string{"b=top-b", "bb=top-bb", "e=top-e"}, iterateKeyValues(db.Iterator(nil, nil)))
+	require.Equal(t, []string{"e=top-e", "bb=top-bb", "b=top-b"}, iterateKeyValues(db.ReverseIterator(nil, nil)))
+	require.Equal(t, []string{"b=top-b", "bb=top-bb"}, iterateKeyValues(db.Iterator([]byte("a"), []byte("c"))))
+	require.Equal(t, []string{"bb=top-bb", "b=top-b"},
+		iterateKeyValues(db.ReverseIterator([]byte("a"), []byte("c"))))
+
+	// a deleted key set again comes back in place of the base value
+	db.Set([]byte("c"), []byte("top-c"))
+	require.Equal(t, []string{"b=top-b", "bb=top-bb", "c=top-c", "e=top-e"}, iterateKeyValues(db.Iterator(nil, nil)))
+	require.Equal(t, []string{"e=top-e", "c=top-c", "bb=top-bb", "b=top-b"},
+		iterateKeyValues(db.ReverseIterator(nil, nil)))
+
+	// runs of deleted base keys between the overlay keys are skipped
+	db, _ = newTestOverlayDB()
+	db.Delete([]byte("b"))
+	db.Delete([]byte("c"))
+	require.Equal(t, []string{"a=base-a", "d=base-d"}, iterateKeyValues(db.Iterator(nil, nil)))
+	require.Equal(t, []string{"d=base-d", "a=base-a"}, iterateKeyValues(db.ReverseIterator(nil, nil)))
+
+	// everything deleted
+	for _, key := range []string{"a", "b", "c", "d"} {
+		db.Delete([]byte(key))
+	}
+	require.Empty(t, iterateKeyValues(db.Iterator(nil, nil)))
+	require.Empty(t, iterateKeyValues(db.ReverseIterator(nil, nil)))
+}
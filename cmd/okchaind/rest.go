@@ -7,6 +7,7 @@ import (
 	bankrest "github.com/cosmos/cosmos-sdk/x/bank/client/rest"
 	supplyrest "github.com/cosmos/cosmos-sdk/x/supply/client/rest"
 	backendrest "github.com/okex/okchain/x/backend/client/rest"
+	"github.com/okex/okchain/x/backend/push"
 	dexrest "github.com/okex/okchain/x/dex/client/rest"
 	dist "github.com/okex/okchain/x/distribution"
 	distrest "github.com/okex/okchain/x/distribution/client/rest"
@@ -50,4 +51,5 @@ func registerRoutesV2(rs *lcd.RestServer) {
 	orderrest.RegisterRoutesV2(rs.CliCtx, v2Router)
 	tokensrest.RegisterRoutesV2(rs.CliCtx, v2Router, token.ModuleName)
 	backendrest.RegisterRoutesV2(rs.CliCtx, v2Router)
+	backendrest.RegisterWebSocket(v2Router, push.GetHub())
 }
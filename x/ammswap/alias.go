@@ -0,0 +1,63 @@
+// nolint
+// aliases generated for the following subdirectories:
+// ALIASGEN: github.com/okex/okchain/x/ammswap/keeper
+// ALIASGEN: github.com/okex/okchain/x/ammswap/types
+package ammswap
+
+import (
+	"github.com/okex/okchain/x/ammswap/keeper"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/okex/okchain/x/common/version"
+)
+
+const (
+	ModuleName        = types.ModuleName
+	DefaultCodespace  = types.DefaultCodespace
+	DefaultParamspace = types.DefaultParamspace
+	QuerierRoute      = types.QuerierRoute
+	RouterKey         = types.RouterKey
+	StoreKey          = types.StoreKey
+)
+
+type (
+	// Keepers
+	Keeper              = keeper.Keeper
+	SupplyKeeper        = keeper.SupplyKeeper
+	TokenKeeper         = keeper.TokenKeeper
+	DexKeeper           = keeper.DexKeeper
+	ProtocolVersionType = version.ProtocolVersionType
+
+	// Messages
+	MsgAddLiquidity    = types.MsgAddLiquidity
+	MsgRemoveLiquidity = types.MsgRemoveLiquidity
+	MsgSwap            = types.MsgSwap
+	MsgRouteSwap       = types.MsgRouteSwap
+
+	//
+	Params         = types.Params
+	SwapTokenPair  = types.SwapTokenPair
+	SwapTokenPairs = types.SwapTokenPairs
+)
+
+var (
+	ModuleCdc = types.ModuleCdc
+
+	RegisterCodec = types.RegisterCodec
+	NewQuerier    = keeper.NewQuerier
+	NewKeeper     = keeper.NewKeeper
+	DefaultParams = types.DefaultParams
+
+	NewMsgAddLiquidity    = types.NewMsgAddLiquidity
+	NewMsgRemoveLiquidity = types.NewMsgRemoveLiquidity
+	NewMsgSwap            = types.NewMsgSwap
+	NewMsgRouteSwap       = types.NewMsgRouteSwap
+	NewSwapTokenPair      = types.NewSwapTokenPair
+	GetPoolTokenName      = types.GetPoolTokenName
+
+	ErrSwapTokenPairNotExist = types.ErrSwapTokenPairNotExist
+	ErrTokenPairNotListed    = types.ErrTokenPairNotListed
+	ErrInvalidSwapAmount     = types.ErrInvalidSwapAmount
+	ErrInsufficientLiquidity = types.ErrInsufficientLiquidity
+	ErrRouteNotFound         = types.ErrRouteNotFound
+	ErrInvalidPoolState      = types.ErrInvalidPoolState
+)
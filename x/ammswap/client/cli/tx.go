@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/spf13/cobra"
+)
+
+// ammswap flags
+const (
+	FlagMinLiquidity         = "min-liquidity"
+	FlagMaxBaseAmount        = "max-base-amount"
+	FlagQuoteAmount          = "quote-amount"
+	FlagLiquidity            = "liquidity"
+	FlagMinBaseAmount        = "min-base-amount"
+	FlagMinQuoteAmount       = "min-quote-amount"
+	FlagSellAmount           = "sell-amount"
+	FlagMinBoughtTokenAmount = "min-buy-amount"
+)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   "swap",
+		Short: "Liquidity pool management subcommands",
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		GetCmdAddLiquidity(cdc),
+		GetCmdRemoveLiquidity(cdc),
+		GetCmdSwap(cdc),
+		GetCmdRouteSwap(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdAddLiquidity implements the add liquidity command handler
+func GetCmdAddLiquidity(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-liquidity",
+		Short: "add liquidity to the pool of a token pair",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Add liquidity to the pool of a token pair listed on dex and get the pool token:
+
+$ okchaincli tx swap add-liquidity --max-base-amount 10xxb-123 --quote-amount 100okt --min-liquidity 0.001 --from mykey
+
+The pool is created by the first liquidity provider, who decides the initial price of the pool.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			minLiquidity, err := getDecFlag(flags.GetString(FlagMinLiquidity))
+			if err != nil {
+				return err
+			}
+			maxBaseAmount, err := getDecCoinFlag(flags.GetString(FlagMaxBaseAmount))
+			if err != nil {
+				return err
+			}
+			quoteAmount, err := getDecCoinFlag(flags.GetString(FlagQuoteAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgAddLiquidity(minLiquidity, maxBaseAmount, quoteAmount, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagMinLiquidity, "0", "minimum amount of the pool token to be minted")
+	cmd.Flags().String(FlagMaxBaseAmount, "", "maximum amount of the base asset to be deposited")
+	cmd.Flags().String(FlagQuoteAmount, "", "amount of the quote asset to be deposited")
+
+	return cmd
+}
+
+// GetCmdRemoveLiquidity implements the remove liquidity command handler
+func GetCmdRemoveLiquidity(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-liquidity",
+		Short: "remove liquidity from the pool of a token pair",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Burn the pool token and withdraw the assets from the pool of a token pair:
+
+$ okchaincli tx swap remove-liquidity --liquidity 1 --min-base-amount 10xxb-123 --min-quote-amount 100okt --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			liquidity, err := getDecFlag(flags.GetString(FlagLiquidity))
+			if err != nil {
+				return err
+			}
+			minBaseAmount, err := getDecCoinFlag(flags.GetString(FlagMinBaseAmount))
+			if err != nil {
+				return err
+			}
+			minQuoteAmount, err := getDecCoinFlag(flags.GetString(FlagMinQuoteAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRemoveLiquidity(liquidity, minBaseAmount, minQuoteAmount, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagLiquidity, "", "amount of the pool token to be burned")
+	cmd.Flags().String(FlagMinBaseAmount, "", "minimum amount of the base asset to be withdrawn")
+	cmd.Flags().String(FlagMinQuoteAmount, "", "minimum amount of the quote asset to be withdrawn")
+
+	return cmd
+}
+
+// GetCmdSwap implements the swap command handler
+func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "swap a token for the other token of the pool",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Sell a token into the pool of a token pair and buy the other token:
+
+$ okchaincli tx swap token --sell-amount 10xxb-123 --min-buy-amount 95okt --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			soldTokenAmount, err := getDecCoinFlag(flags.GetString(FlagSellAmount))
+			if err != nil {
+				return err
+			}
+			minBoughtTokenAmount, err := getDecCoinFlag(flags.GetString(FlagMinBoughtTokenAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSwap(soldTokenAmount, minBoughtTokenAmount, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSellAmount, "", "amount of the token to be sold")
+	cmd.Flags().String(FlagMinBoughtTokenAmount, "", "minimum amount of the token to be bought")
+
+	return cmd
+}
+
+// GetCmdRouteSwap implements the route swap command handler
+func GetCmdRouteSwap(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "route",
+		Short: "swap a token for any token through a route of pools",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Sell a token and buy the target token through the route of pools which buys the most,
+such as selling xxb-123 into the pool of xxb-123_okt and then selling the okt bought into the pool of yyb-456_okt:
+
+$ okchaincli tx swap route --sell-amount 10xxb-123 --min-buy-amount 95yyb-456 --from mykey
+
+All the swaps along the route fail together if less than the minimum amount of the target token is bought.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			soldTokenAmount, err := getDecCoinFlag(flags.GetString(FlagSellAmount))
+			if err != nil {
+				return err
+			}
+			minBoughtTokenAmount, err := getDecCoinFlag(flags.GetString(FlagMinBoughtTokenAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRouteSwap(soldTokenAmount, minBoughtTokenAmount, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSellAmount, "", "amount of the token to be sold")
+	cmd.Flags().String(FlagMinBoughtTokenAmount, "", "minimum amount of the target token to be bought")
+
+	return cmd
+}
+
+func getDecFlag(str string, err error) (sdk.Dec, error) {
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return sdk.NewDecFromStr(str)
+}
+
+func getDecCoinFlag(str string, err error) (sdk.DecCoin, error) {
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	return sdk.ParseDecCoin(str)
+}
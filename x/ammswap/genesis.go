@@ -0,0 +1,58 @@
+package ammswap
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all ammswap state that must be provided at genesis
+type GenesisState struct {
+	Params         Params         `json:"params"`
+	SwapTokenPairs SwapTokenPairs `json:"swap_token_pairs"`
+}
+
+// DefaultGenesisState - default GenesisState used by Cosmos Hub
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:         DefaultParams(),
+		SwapTokenPairs: nil,
+	}
+}
+
+// ValidateGenesis validates the ammswap genesis parameters
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	products := make(map[string]struct{}, len(data.SwapTokenPairs))
+	for _, swapTokenPair := range data.SwapTokenPairs {
+		if _, ok := products[swapTokenPair.Product]; ok {
+			return fmt.Errorf("duplicated swap token pair %s", swapTokenPair.Product)
+		}
+		products[swapTokenPair.Product] = struct{}{}
+		if swapTokenPair.BasePooledCoin.IsNegative() || swapTokenPair.QuotePooledCoin.IsNegative() {
+			return fmt.Errorf("invalid pooled coins of swap token pair %s", swapTokenPair.Product)
+		}
+	}
+	return nil
+}
+
+// InitGenesis initialize default parameters and the pools
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+	for _, swapTokenPair := range data.SwapTokenPairs {
+		keeper.SetSwapTokenPair(ctx, swapTokenPair)
+	}
+}
+
+// ExportGenesis writes the current store values
+// to a genesis file, which can be imported again
+// with InitGenesis
+func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
+	return GenesisState{
+		Params:         keeper.GetParams(ctx),
+		SwapTokenPairs: keeper.GetSwapTokenPairs(ctx),
+	}
+}
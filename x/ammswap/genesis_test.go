@@ -0,0 +1,33 @@
+package ammswap
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/keeper"
+	"github.com/okex/okchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesis(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+
+	swapTokenPair := NewSwapTokenPair(common.TestToken, common.NativeToken, GetPoolTokenName(1))
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(10)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(100)
+	genesisState := DefaultGenesisState()
+	genesisState.Params.FeeRate = sdk.MustNewDecFromStr("0.01")
+	genesisState.SwapTokenPairs = SwapTokenPairs{swapTokenPair}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	InitGenesis(ctx, k, genesisState)
+	require.Equal(t, genesisState, ExportGenesis(ctx, k))
+
+	// invalid genesis
+	genesisState.SwapTokenPairs = append(genesisState.SwapTokenPairs, swapTokenPair)
+	require.NotNil(t, ValidateGenesis(genesisState))
+	genesisState = DefaultGenesisState()
+	genesisState.Params.FeeRate = sdk.OneDec()
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
@@ -0,0 +1,246 @@
+package ammswap
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common/perf"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// NewHandler handles all "ammswap" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		logger := ctx.Logger().With("module", ModuleName)
+
+		var handlerFun func() sdk.Result
+		var name string
+		switch msg := msg.(type) {
+		case MsgAddLiquidity:
+			name = "handleMsgAddLiquidity"
+			handlerFun = func() sdk.Result {
+				return handleMsgAddLiquidity(ctx, k, msg, logger)
+			}
+		case MsgRemoveLiquidity:
+			name = "handleMsgRemoveLiquidity"
+			handlerFun = func() sdk.Result {
+				return handleMsgRemoveLiquidity(ctx, k, msg, logger)
+			}
+		case MsgSwap:
+			name = "handleMsgSwap"
+			handlerFun = func() sdk.Result {
+				return handleMsgSwap(ctx, k, msg, logger)
+			}
+		case MsgRouteSwap:
+			name = "handleMsgRouteSwap"
+			handlerFun = func() sdk.Result {
+				return handleMsgRouteSwap(ctx, k, msg, logger)
+			}
+		default:
+			errMsg := fmt.Sprintf("unrecognized ammswap message type: %T", msg)
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+
+		seq := perf.GetPerf().OnDeliverTxEnter(ctx, ModuleName, name)
+		defer perf.GetPerf().OnDeliverTxExit(ctx, ModuleName, name, seq)
+		return handlerFun()
+	}
+}
+
+func handleMsgAddLiquidity(ctx sdk.Context, keeper Keeper, msg MsgAddLiquidity, logger log.Logger) sdk.Result {
+	product := msg.GetProduct()
+	tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, product)
+	if tokenPair == nil {
+		return ErrTokenPairNotListed(product).Result()
+	}
+
+	swapTokenPair, isExist := keeper.GetSwapTokenPair(ctx, product)
+	if !isExist {
+		swapTokenPair = keeper.CreateSwapTokenPair(ctx, tokenPair)
+	}
+
+	poolTokenAmount := keeper.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName)
+	var liquidity sdk.Dec
+	baseAmount := msg.MaxBaseAmount
+	basePooled, quotePooled := swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount
+	switch {
+	case basePooled.IsZero() && quotePooled.IsZero() && poolTokenAmount.IsZero():
+		// the first liquidity provider sets the price of the pool
+		liquidity = msg.QuoteAmount.Amount
+	case !basePooled.IsPositive() || !quotePooled.IsPositive() || !poolTokenAmount.IsPositive():
+		return ErrInvalidPoolState(fmt.Sprintf("pooled %s, %s with pool token supply %s",
+			swapTokenPair.BasePooledCoin, swapTokenPair.QuotePooledCoin, poolTokenAmount)).Result()
+	default:
+		baseAmount = sdk.NewDecCoinFromDec(baseAmount.Denom,
+			msg.QuoteAmount.Amount.Mul(basePooled).QuoRoundUp(quotePooled))
+		liquidity = msg.QuoteAmount.Amount.MulTruncate(poolTokenAmount).QuoTruncate(quotePooled)
+		if baseAmount.Amount.GT(msg.MaxBaseAmount.Amount) {
+			return ErrInvalidSwapAmount(fmt.Sprintf("required base amount %s exceeds %s",
+				baseAmount, msg.MaxBaseAmount)).Result()
+		}
+	}
+	if !liquidity.IsPositive() {
+		return ErrInvalidSwapAmount("failed to mint any pool token").Result()
+	}
+	if liquidity.LT(msg.MinLiquidity) {
+		return ErrInvalidSwapAmount(fmt.Sprintf("liquidity %s is less than %s", liquidity, msg.MinLiquidity)).Result()
+	}
+
+	// transfer the assets into the pool
+	depositCoins := sdk.NewDecCoins(sdk.DecCoins{baseAmount, msg.QuoteAmount})
+	if err := keeper.SendCoinsToPool(ctx, depositCoins, msg.Sender); err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s): %s",
+			depositCoins, err.Error())).Result()
+	}
+	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(baseAmount)
+	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.QuoteAmount)
+	keeper.SetSwapTokenPair(ctx, swapTokenPair)
+
+	// mint the pool token to the liquidity provider
+	poolCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(swapTokenPair.PoolTokenName, liquidity)}
+	if err := keeper.MintPoolCoinsToUser(ctx, poolCoins, msg.Sender); err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("failed to mint pool token %s: %s", poolCoins, err.Error())).Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgAddLiquidity: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute("product", product),
+			sdk.NewAttribute("deposit", depositCoins.String()),
+			sdk.NewAttribute("liquidity", poolCoins.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRemoveLiquidity(ctx sdk.Context, keeper Keeper, msg MsgRemoveLiquidity, logger log.Logger) sdk.Result {
+	product := msg.GetProduct()
+	swapTokenPair, isExist := keeper.GetSwapTokenPair(ctx, product)
+	if !isExist {
+		return ErrSwapTokenPairNotExist(product).Result()
+	}
+
+	poolTokenAmount := keeper.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName)
+	if msg.Liquidity.GT(poolTokenAmount) {
+		return ErrInsufficientLiquidity(fmt.Sprintf("liquidity %s exceeds total %s",
+			msg.Liquidity, poolTokenAmount)).Result()
+	}
+
+	baseAmount, quoteAmount := swapTokenPair.BasePooledCoin, swapTokenPair.QuotePooledCoin
+	if msg.Liquidity.LT(poolTokenAmount) {
+		baseAmount.Amount = msg.Liquidity.MulTruncate(baseAmount.Amount).QuoTruncate(poolTokenAmount)
+		quoteAmount.Amount = msg.Liquidity.MulTruncate(quoteAmount.Amount).QuoTruncate(poolTokenAmount)
+	}
+	if baseAmount.IsLT(msg.MinBaseAmount) {
+		return ErrInvalidSwapAmount(fmt.Sprintf("base amount %s is less than %s",
+			baseAmount, msg.MinBaseAmount)).Result()
+	}
+	if quoteAmount.IsLT(msg.MinQuoteAmount) {
+		return ErrInvalidSwapAmount(fmt.Sprintf("quote amount %s is less than %s",
+			quoteAmount, msg.MinQuoteAmount)).Result()
+	}
+
+	// burn the pool token of the liquidity provider
+	poolCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(swapTokenPair.PoolTokenName, msg.Liquidity)}
+	if err := keeper.BurnPoolCoinsFromUser(ctx, poolCoins, msg.Sender); err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient pool token(need %s): %s",
+			poolCoins, err.Error())).Result()
+	}
+
+	// transfer the assets out of the pool
+	withdrawCoins := sdk.NewDecCoins(sdk.DecCoins{baseAmount, quoteAmount})
+	if err := keeper.SendCoinsFromPoolToAccount(ctx, withdrawCoins, msg.Sender); err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("failed to withdraw %s: %s", withdrawCoins, err.Error())).Result()
+	}
+	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(baseAmount)
+	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(quoteAmount)
+	keeper.SetSwapTokenPair(ctx, swapTokenPair)
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgRemoveLiquidity: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute("product", product),
+			sdk.NewAttribute("withdraw", withdrawCoins.String()),
+			sdk.NewAttribute("liquidity", poolCoins.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgSwap(ctx sdk.Context, keeper Keeper, msg MsgSwap, logger log.Logger) sdk.Result {
+	boughtTokenAmount, product, err := keeper.CalculateSwap(ctx, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom)
+	if err != nil {
+		return err.Result()
+	}
+	if boughtTokenAmount.IsLT(msg.MinBoughtTokenAmount) {
+		return ErrInvalidSwapAmount(fmt.Sprintf("bought amount %s is less than %s",
+			boughtTokenAmount, msg.MinBoughtTokenAmount)).Result()
+	}
+
+	if err := keeper.Swap(ctx, product, msg.SoldTokenAmount, boughtTokenAmount, msg.Sender); err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgSwap: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute("product", product),
+			sdk.NewAttribute("sold_token_amount", msg.SoldTokenAmount.String()),
+			sdk.NewAttribute("bought_token_amount", boughtTokenAmount.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRouteSwap(ctx sdk.Context, keeper Keeper, msg MsgRouteSwap, logger log.Logger) sdk.Result {
+	route, expectedAmount, err := keeper.FindSwapRoute(ctx, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom)
+	if err != nil {
+		return err.Result()
+	}
+	if expectedAmount.IsLT(msg.MinBoughtTokenAmount) {
+		return ErrInvalidSwapAmount(fmt.Sprintf("bought amount %s is less than %s",
+			expectedAmount, msg.MinBoughtTokenAmount)).Result()
+	}
+
+	// all the swaps along the route are reverted together with the tx if any of them fails
+	boughtTokenAmount, err := keeper.RouteSwap(ctx, route, msg.SoldTokenAmount, msg.Sender)
+	if err != nil {
+		return err.Result()
+	}
+	if boughtTokenAmount.IsLT(msg.MinBoughtTokenAmount) {
+		return ErrInvalidSwapAmount(fmt.Sprintf("bought amount %s is less than %s",
+			boughtTokenAmount, msg.MinBoughtTokenAmount)).Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgRouteSwap: "+
+		"BlockHeight: %d, Msg: %+v, Route: %v", ctx.BlockHeight(), msg, route))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute("route", strings.Join(route, ",")),
+			sdk.NewAttribute("sold_token_amount", msg.SoldTokenAmount.String()),
+			sdk.NewAttribute("bought_token_amount", boughtTokenAmount.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
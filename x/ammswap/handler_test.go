@@ -0,0 +1,258 @@
+package ammswap
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/keeper"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_HandleMsgAddLiquidity(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+	handler := NewHandler(k)
+	addr := testInput.TestAddrs[0]
+
+	// fail case : the token pair is not listed
+	msg := NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec("btc", sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)), addr)
+	require.Equal(t, types.CodeTokenPairNotListed, handler(ctx, msg).Code)
+
+	// successful case : the first liquidity provider creates the pool
+	msg = NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)), addr)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapTokenPair, isExist := k.GetSwapTokenPair(ctx, keeper.TestProduct)
+	require.True(t, isExist)
+	require.Equal(t, sdk.NewDec(10), swapTokenPair.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(100), swapTokenPair.QuotePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(100), k.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName))
+
+	// fail case : the required base amount exceeds max base amount
+	msg = NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(50)), addr)
+	require.Equal(t, types.CodeInvalidSwapAmount, handler(ctx, msg).Code)
+
+	// fail case : the liquidity is less than min liquidity
+	msg = NewMsgAddLiquidity(sdk.NewDec(51), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(5)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(50)), addr)
+	require.Equal(t, types.CodeInvalidSwapAmount, handler(ctx, msg).Code)
+
+	// fail case : insufficient coins
+	msg = NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10000)), addr)
+	require.Equal(t, sdk.CodeInsufficientCoins, handler(ctx, msg).Code)
+
+	// successful case : add liquidity at the price of the pool
+	msg = NewMsgAddLiquidity(sdk.NewDec(50), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(50)), testInput.TestAddrs[1])
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapTokenPair, _ = k.GetSwapTokenPair(ctx, keeper.TestProduct)
+	require.Equal(t, sdk.NewDec(15), swapTokenPair.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(150), swapTokenPair.QuotePooledCoin.Amount)
+	coins := testInput.TokenKeeper.GetCoins(ctx, testInput.TestAddrs[1])
+	require.Equal(t, sdk.NewDec(50), coins.AmountOf(swapTokenPair.PoolTokenName))
+	require.Equal(t, sdk.NewDec(995), coins.AmountOf(common.TestToken))
+}
+
+func TestHandler_HandleMsgAddLiquidityInvalidPool(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+	handler := NewHandler(k)
+	addr := testInput.TestAddrs[0]
+	msg := NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)), addr)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapTokenPair, _ := k.GetSwapTokenPair(ctx, keeper.TestProduct)
+
+	// fail case : one of the reserves is drained while the pool token is outstanding
+	drained := swapTokenPair
+	drained.BasePooledCoin.Amount = sdk.ZeroDec()
+	k.SetSwapTokenPair(ctx, drained)
+	require.Equal(t, types.CodeInvalidPoolState, handler(ctx, msg).Code)
+
+	// fail case : the reserves are left without any pool token
+	removeMsg := NewMsgRemoveLiquidity(sdk.NewDec(100), sdk.NewDecCoinFromDec(common.TestToken, sdk.ZeroDec()),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec()), addr)
+	k.SetSwapTokenPair(ctx, swapTokenPair)
+	require.True(t, handler(ctx, removeMsg).Code.IsOK())
+	k.SetSwapTokenPair(ctx, swapTokenPair)
+	require.Equal(t, types.CodeInvalidPoolState, handler(ctx, msg).Code)
+
+	// successful case : the pool emptied by the liquidity providers takes a new first deposit
+	swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount = sdk.ZeroDec(), sdk.ZeroDec()
+	k.SetSwapTokenPair(ctx, swapTokenPair)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	require.Equal(t, sdk.NewDec(100), k.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName))
+}
+
+func TestHandler_HandleMsgRemoveLiquidity(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+	handler := NewHandler(k)
+	addr := testInput.TestAddrs[0]
+
+	minBase := sdk.NewDecCoinFromDec(common.TestToken, sdk.ZeroDec())
+	minQuote := sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec())
+
+	// fail case : the pool not exist
+	msg := NewMsgRemoveLiquidity(sdk.OneDec(), minBase, minQuote, addr)
+	require.Equal(t, types.CodeSwapTokenPairNotExist, handler(ctx, msg).Code)
+
+	addMsg := NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)), addr)
+	require.True(t, handler(ctx, addMsg).Code.IsOK())
+
+	// fail case : the liquidity exceeds the total pool token
+	msg = NewMsgRemoveLiquidity(sdk.NewDec(101), minBase, minQuote, addr)
+	require.Equal(t, types.CodeInsufficientLiquidity, handler(ctx, msg).Code)
+
+	// fail case : the withdrawn amount is less than min amount
+	msg = NewMsgRemoveLiquidity(sdk.NewDec(10), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(2)), minQuote, addr)
+	require.Equal(t, types.CodeInvalidSwapAmount, handler(ctx, msg).Code)
+
+	// successful case
+	msg = NewMsgRemoveLiquidity(sdk.NewDec(10), minBase, minQuote, addr)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapTokenPair, _ := k.GetSwapTokenPair(ctx, keeper.TestProduct)
+	require.Equal(t, sdk.NewDec(9), swapTokenPair.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(90), swapTokenPair.QuotePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(90), k.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName))
+
+	// successful case : remove all the liquidity
+	msg = NewMsgRemoveLiquidity(sdk.NewDec(90), minBase, minQuote, addr)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapTokenPair, _ = k.GetSwapTokenPair(ctx, keeper.TestProduct)
+	require.True(t, swapTokenPair.IsEmpty())
+	coins := testInput.TokenKeeper.GetCoins(ctx, addr)
+	require.Equal(t, sdk.NewDec(1000), coins.AmountOf(common.TestToken))
+	require.Equal(t, sdk.NewDec(1000), coins.AmountOf(common.NativeToken))
+}
+
+func TestHandler_PoolTokenTotalSupply(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+	handler := NewHandler(k)
+	invariant := token.TotalSupplyInvariant(testInput.TokenKeeper, testInput.AccountKeeper)
+
+	addMsg := NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)), testInput.TestAddrs[0])
+	require.True(t, handler(ctx, addMsg).Code.IsOK())
+	swapTokenPair, _ := k.GetSwapTokenPair(ctx, keeper.TestProduct)
+	poolTokenName := swapTokenPair.PoolTokenName
+	require.Equal(t, sdk.NewDec(100), testInput.TokenKeeper.GetTokenInfo(ctx, poolTokenName).TotalSupply)
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	addMsg = NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(5)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(50)), testInput.TestAddrs[1])
+	require.True(t, handler(ctx, addMsg).Code.IsOK())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	removeMsg := NewMsgRemoveLiquidity(sdk.NewDec(30), sdk.NewDecCoinFromDec(common.TestToken, sdk.ZeroDec()),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec()), testInput.TestAddrs[0])
+	require.True(t, handler(ctx, removeMsg).Code.IsOK())
+	require.Equal(t, sdk.NewDec(120), testInput.TokenKeeper.GetTokenInfo(ctx, poolTokenName).TotalSupply)
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+}
+
+func TestHandler_HandleMsgSwap(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+	handler := NewHandler(k)
+	addr := testInput.TestAddrs[0]
+
+	// fail case : the pool not exist
+	msg := NewMsgSwap(sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.ZeroDec()), addr)
+	require.Equal(t, types.CodeSwapTokenPairNotExist, handler(ctx, msg).Code)
+
+	addMsg := NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)), addr)
+	require.True(t, handler(ctx, addMsg).Code.IsOK())
+
+	// fail case : the bought amount is less than min bought amount
+	msg = NewMsgSwap(sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)), addr)
+	require.Equal(t, types.CodeInvalidSwapAmount, handler(ctx, msg).Code)
+
+	// successful case
+	msg = NewMsgSwap(sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(9)), testInput.TestAddrs[1])
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapTokenPair, _ := k.GetSwapTokenPair(ctx, keeper.TestProduct)
+	require.Equal(t, sdk.NewDec(110), swapTokenPair.QuotePooledCoin.Amount)
+	require.True(t, swapTokenPair.K().GT(sdk.NewDec(10000)))
+	coins := testInput.TokenKeeper.GetCoins(ctx, testInput.TestAddrs[1])
+	require.Equal(t, sdk.NewDec(990), coins.AmountOf(common.NativeToken))
+	require.Equal(t, sdk.NewDec(1100).Sub(swapTokenPair.BasePooledCoin.Amount), coins.AmountOf(common.TestToken))
+}
+
+func TestHandler_HandleMsgRouteSwap(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+	handler := NewHandler(k)
+	addr := testInput.TestAddrs[0]
+	soldTokenAmount := sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10))
+
+	// fail case : no route to the target token
+	msg := NewMsgRouteSwap(soldTokenAmount, sdk.NewDecCoinFromDec("yyb", sdk.OneDec()), addr)
+	require.Equal(t, types.CodeRouteNotFound, handler(ctx, msg).Code)
+
+	keeper.AddTestPool(t, testInput, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000)))
+	keeper.AddTestPool(t, testInput, sdk.NewDecCoinFromDec("yyb", sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000)))
+	_, expected, err := k.FindSwapRoute(ctx, soldTokenAmount, "yyb")
+	require.Nil(t, err)
+
+	// fail case : the bought amount is less than min bought amount, and no pool is changed
+	msg = NewMsgRouteSwap(soldTokenAmount, expected.Add(sdk.NewDecCoinFromDec("yyb", sdk.OneDec())), addr)
+	require.Equal(t, types.CodeInvalidSwapAmount, handler(ctx, msg).Code)
+	swapTokenPair, _ := k.GetSwapTokenPair(ctx, keeper.TestProduct)
+	require.Equal(t, sdk.NewDec(100), swapTokenPair.BasePooledCoin.Amount)
+
+	// successful case
+	msg = NewMsgRouteSwap(soldTokenAmount, expected, addr)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	coins := testInput.TokenKeeper.GetCoins(ctx, addr)
+	require.Equal(t, sdk.NewDec(990), coins.AmountOf(common.TestToken))
+	require.Equal(t, expected.Amount, coins.AmountOf("yyb"))
+}
+
+func TestHandler_FrozenCoins(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.SwapKeeper
+	handler := NewHandler(k)
+	addr := testInput.TestAddrs[1]
+	addMsg := NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)), testInput.TestAddrs[0])
+	require.True(t, handler(ctx, addMsg).Code.IsOK())
+
+	// the frozen balances can't be moved into the pool
+	testInput.TokenKeeper.FreezeAccount(ctx, common.TestToken, addr)
+	soldTokenAmount := sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10))
+	minBought := sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec())
+	for _, msg := range []sdk.Msg{
+		NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)),
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)), addr),
+		NewMsgSwap(soldTokenAmount, minBought, addr),
+		NewMsgRouteSwap(soldTokenAmount, minBought, addr),
+	} {
+		res := handler(ctx, msg)
+		require.False(t, res.Code.IsOK(), msg.Type())
+		require.Contains(t, res.Log, "frozen", msg.Type())
+	}
+	require.Equal(t, sdk.NewDec(1000), testInput.TokenKeeper.GetCoins(ctx, addr).AmountOf(common.TestToken))
+
+	// the other tokens are still available
+	msg := NewMsgSwap(sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.ZeroDec()), addr)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+}
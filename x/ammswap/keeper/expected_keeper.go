@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply/exported"
+	dex "github.com/okex/okchain/x/dex/types"
+	token "github.com/okex/okchain/x/token/types"
+)
+
+type SupplyKeeper interface {
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress,
+		recipientModule string, amt sdk.Coins) sdk.Error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string,
+		recipientAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) sdk.Error
+	GetModuleAccount(ctx sdk.Context, moduleName string) exported.ModuleAccountI
+	GetModuleAddress(moduleName string) sdk.AccAddress
+	GetSupply(ctx sdk.Context) exported.SupplyI
+	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error
+	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error
+}
+
+type TokenKeeper interface {
+	TokenExist(ctx sdk.Context, symbol string) bool
+	NewToken(ctx sdk.Context, token token.Token)
+	UpdateTokenSupply(ctx sdk.Context, symbol string, supply sdk.Dec)
+}
+
+type DexKeeper interface {
+	GetTokenPair(ctx sdk.Context, product string) *dex.TokenPair
+}
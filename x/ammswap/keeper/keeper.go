@@ -0,0 +1,132 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/okex/okchain/x/params"
+)
+
+// Keeper maintains the link to data storage and exposes getter/setter methods for the various parts of the state machine
+type Keeper struct {
+	supplyKeeper  SupplyKeeper
+	tokenKeeper   TokenKeeper
+	dexKeeper     DexKeeper
+	storeKey      sdk.StoreKey
+	paramSubspace params.Subspace // The reference to the Paramstore to get and set gov modifiable params
+	cdc           *codec.Codec    // The wire codec for binary encoding/decoding.
+}
+
+// NewKeeper creates new instances of the ammswap Keeper
+func NewKeeper(supplyKeeper SupplyKeeper, tokenKeeper TokenKeeper, dexKeeper DexKeeper, storeKey sdk.StoreKey,
+	paramSubspace params.Subspace, cdc *codec.Codec) Keeper {
+	return Keeper{
+		supplyKeeper:  supplyKeeper,
+		tokenKeeper:   tokenKeeper,
+		dexKeeper:     dexKeeper,
+		storeKey:      storeKey,
+		paramSubspace: paramSubspace.WithKeyTable(types.ParamKeyTable()),
+		cdc:           cdc,
+	}
+}
+
+func (k Keeper) GetSupplyKeeper() SupplyKeeper {
+	return k.supplyKeeper
+}
+
+func (k Keeper) GetTokenKeeper() TokenKeeper {
+	return k.tokenKeeper
+}
+
+func (k Keeper) GetDexKeeper() DexKeeper {
+	return k.dexKeeper
+}
+
+func (k Keeper) GetCDC() *codec.Codec {
+	return k.cdc
+}
+
+// GetSwapTokenPair returns the pool of the product
+func (k Keeper) GetSwapTokenPair(ctx sdk.Context, product string) (swapTokenPair types.SwapTokenPair, isExist bool) {
+	bytes := ctx.KVStore(k.storeKey).Get(types.GetSwapTokenPairKey(product))
+	if bytes == nil {
+		return
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bytes, &swapTokenPair)
+	return swapTokenPair, true
+}
+
+// SetSwapTokenPair saves the pool of the product to store
+func (k Keeper) SetSwapTokenPair(ctx sdk.Context, swapTokenPair types.SwapTokenPair) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetSwapTokenPairKey(swapTokenPair.Product), k.cdc.MustMarshalBinaryLengthPrefixed(swapTokenPair))
+}
+
+// GetSwapTokenPairs returns all the pools ordered by product
+func (k Keeper) GetSwapTokenPairs(ctx sdk.Context) (swapTokenPairs types.SwapTokenPairs) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.SwapTokenPairPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var swapTokenPair types.SwapTokenPair
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &swapTokenPair)
+		swapTokenPairs = append(swapTokenPairs, swapTokenPair)
+	}
+	return swapTokenPairs
+}
+
+// GetParams gets the params of ammswap module
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSubspace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the params of ammswap module
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSubspace.SetParamSet(ctx, &params)
+}
+
+// GetPoolTokenAmount returns the total supply of the pool token
+func (k Keeper) GetPoolTokenAmount(ctx sdk.Context, poolTokenName string) sdk.Dec {
+	return k.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(poolTokenName)
+}
+
+// MintPoolCoinsToUser mints the pool token to the liquidity provider
+func (k Keeper) MintPoolCoinsToUser(ctx sdk.Context, coins sdk.DecCoins, addr sdk.AccAddress) sdk.Error {
+	if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+		return err
+	}
+	k.updatePoolTokenSupply(ctx, coins)
+	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins)
+}
+
+// BurnPoolCoinsFromUser burns the pool token of the liquidity provider
+func (k Keeper) BurnPoolCoinsFromUser(ctx sdk.Context, coins sdk.DecCoins, addr sdk.AccAddress) sdk.Error {
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, coins); err != nil {
+		return err
+	}
+	if err := k.supplyKeeper.BurnCoins(ctx, types.ModuleName, coins); err != nil {
+		return err
+	}
+	k.updatePoolTokenSupply(ctx, coins)
+	return nil
+}
+
+// updatePoolTokenSupply keeps the total supply of the pool tokens registered in the token module up to date
+func (k Keeper) updatePoolTokenSupply(ctx sdk.Context, coins sdk.DecCoins) {
+	for _, coin := range coins {
+		k.tokenKeeper.UpdateTokenSupply(ctx, coin.Denom, k.GetPoolTokenAmount(ctx, coin.Denom))
+	}
+}
+
+// SendCoinsToPool sends the coins of addr into the pools
+func (k Keeper) SendCoinsToPool(ctx sdk.Context, coins sdk.DecCoins, addr sdk.AccAddress) sdk.Error {
+	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, coins)
+}
+
+// SendCoinsFromPoolToAccount sends the coins of the pools to addr
+func (k Keeper) SendCoinsFromPoolToAccount(ctx sdk.Context, coins sdk.DecCoins, addr sdk.AccAddress) sdk.Error {
+	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins)
+}
@@ -0,0 +1,114 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/token"
+	"github.com/stretchr/testify/require"
+)
+
+func initPool(t *testing.T, testInput TestInput, base, quote sdk.Dec) types.SwapTokenPair {
+	ctx, keeper := testInput.Ctx, testInput.SwapKeeper
+	swapTokenPair := keeper.CreateSwapTokenPair(ctx, testInput.DexKeeper.GetTokenPair(ctx, TestProduct))
+	swapTokenPair.BasePooledCoin.Amount = base
+	swapTokenPair.QuotePooledCoin.Amount = quote
+	keeper.SetSwapTokenPair(ctx, swapTokenPair)
+	coins := sdk.DecCoins{swapTokenPair.QuotePooledCoin, swapTokenPair.BasePooledCoin}
+	require.Nil(t, keeper.SendCoinsToPool(ctx, coins, testInput.TestAddrs[0]))
+	return swapTokenPair
+}
+
+func TestKeeper_SwapTokenPair(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.SwapKeeper
+
+	_, isExist := keeper.GetSwapTokenPair(ctx, TestProduct)
+	require.False(t, isExist)
+	require.Nil(t, keeper.GetSwapTokenPairs(ctx))
+
+	swapTokenPair := keeper.CreateSwapTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Equal(t, TestProduct, swapTokenPair.Product)
+	require.True(t, swapTokenPair.IsEmpty())
+	require.True(t, testInput.TokenKeeper.TokenExist(ctx, swapTokenPair.PoolTokenName))
+	poolToken := testInput.TokenKeeper.GetTokenInfo(ctx, swapTokenPair.PoolTokenName)
+	require.True(t, poolToken.Mintable)
+	require.Equal(t, testInput.SupplyKeeper.GetModuleAddress(types.ModuleName), poolToken.Owner)
+
+	got, isExist := keeper.GetSwapTokenPair(ctx, TestProduct)
+	require.True(t, isExist)
+	require.Equal(t, swapTokenPair, got)
+	require.Equal(t, types.SwapTokenPairs{swapTokenPair}, keeper.GetSwapTokenPairs(ctx))
+}
+
+func TestKeeper_CalculateSwap(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.SwapKeeper
+
+	// pool not exist
+	_, _, err := keeper.CalculateSwap(ctx, sdk.NewDecCoinFromDec(common.TestToken, sdk.OneDec()), common.NativeToken)
+	require.NotNil(t, err)
+
+	initPool(t, testInput, sdk.NewDec(100), sdk.NewDec(100))
+
+	// sell base for quote
+	bought, product, err := keeper.CalculateSwap(ctx, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)),
+		common.NativeToken)
+	require.Nil(t, err)
+	require.Equal(t, TestProduct, product)
+	require.Equal(t, common.NativeToken, bought.Denom)
+	require.True(t, bought.Amount.LT(sdk.NewDec(10)))
+
+	// sell quote for base
+	bought, product, err = keeper.CalculateSwap(ctx, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		common.TestToken)
+	require.Nil(t, err)
+	require.Equal(t, TestProduct, product)
+	require.Equal(t, common.TestToken, bought.Denom)
+
+	// swap
+	sold := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10))
+	require.Nil(t, keeper.Swap(ctx, product, sold, bought, testInput.TestAddrs[1]))
+	swapTokenPair, _ := keeper.GetSwapTokenPair(ctx, TestProduct)
+	require.Equal(t, sdk.NewDec(110), swapTokenPair.QuotePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(100).Sub(bought.Amount), swapTokenPair.BasePooledCoin.Amount)
+}
+
+func TestKeeper_SettleOrderBookTrade(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.SwapKeeper
+
+	soldByPool := sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1))
+	boughtByPool := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(20))
+	require.NotNil(t, keeper.SettleOrderBookTrade(ctx, TestProduct, soldByPool, boughtByPool))
+
+	initPool(t, testInput, sdk.NewDec(100), sdk.NewDec(1000))
+
+	// the pool sells base at the price of 20
+	sellable, buyable := keeper.GetPoolCapacity(ctx, TestProduct, sdk.NewDec(20))
+	require.True(t, sellable.GT(sdk.OneDec()))
+	require.True(t, buyable.IsZero())
+
+	// the coins locked by orders are kept in token module
+	lockedCoins := sdk.DecCoins{boughtByPool}
+	require.Nil(t, testInput.SupplyKeeper.SendCoinsFromAccountToModule(ctx, testInput.TestAddrs[1],
+		token.ModuleName, lockedCoins))
+	require.Nil(t, keeper.SettleOrderBookTrade(ctx, TestProduct, soldByPool, boughtByPool))
+	swapTokenPair, _ := keeper.GetSwapTokenPair(ctx, TestProduct)
+	require.Equal(t, sdk.NewDec(99), swapTokenPair.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(1020), swapTokenPair.QuotePooledCoin.Amount)
+	poolCoins := testInput.SupplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins()
+	require.Equal(t, sdk.NewDec(99), poolCoins.AmountOf(common.TestToken))
+	require.Equal(t, sdk.NewDec(1020), poolCoins.AmountOf(common.NativeToken))
+
+	// the constant product never decreases
+	require.NotNil(t, keeper.SettleOrderBookTrade(ctx, TestProduct,
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)), sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1))))
+
+	// mismatched denoms
+	require.NotNil(t, keeper.SettleOrderBookTrade(ctx, TestProduct,
+		sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1)), sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1))))
+}
@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case types.QuerySwapTokenPair:
+			return querySwapTokenPair(ctx, path[1:], keeper)
+		case types.QuerySwapTokenPairs:
+			return querySwapTokenPairs(ctx, keeper)
+		case types.QueryParameters:
+			return queryParams(ctx, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ammswap query endpoint")
+		}
+	}
+}
+
+// nolint: unparam
+func querySwapTokenPair(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("product is required")
+	}
+
+	swapTokenPair, isExist := keeper.GetSwapTokenPair(ctx, path[0])
+	if !isExist {
+		return nil, types.ErrSwapTokenPairNotExist(path[0])
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), swapTokenPair)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+// nolint: unparam
+func querySwapTokenPairs(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	swapTokenPairs := keeper.GetSwapTokenPairs(ctx)
+	if swapTokenPairs == nil {
+		swapTokenPairs = types.SwapTokenPairs{}
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), swapTokenPairs)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+// nolint: unparam
+func queryParams(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), keeper.GetParams(ctx))
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestQuerier(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.SwapKeeper
+	querier := NewQuerier(keeper)
+
+	// pool not exist
+	_, err := querier(ctx, []string{types.QuerySwapTokenPair, TestProduct}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	var swapTokenPairs types.SwapTokenPairs
+	res, err := querier(ctx, []string{types.QuerySwapTokenPairs}, abci.RequestQuery{})
+	require.Nil(t, err)
+	require.Nil(t, keeper.GetCDC().UnmarshalJSON(res, &swapTokenPairs))
+	require.Equal(t, 0, len(swapTokenPairs))
+
+	swapTokenPair := initPool(t, testInput, sdk.NewDec(10), sdk.NewDec(100))
+
+	var got types.SwapTokenPair
+	res, err = querier(ctx, []string{types.QuerySwapTokenPair, TestProduct}, abci.RequestQuery{})
+	require.Nil(t, err)
+	require.Nil(t, keeper.GetCDC().UnmarshalJSON(res, &got))
+	require.Equal(t, swapTokenPair, got)
+
+	res, err = querier(ctx, []string{types.QuerySwapTokenPairs}, abci.RequestQuery{})
+	require.Nil(t, err)
+	require.Nil(t, keeper.GetCDC().UnmarshalJSON(res, &swapTokenPairs))
+	require.Equal(t, types.SwapTokenPairs{swapTokenPair}, swapTokenPairs)
+
+	var params types.Params
+	res, err = querier(ctx, []string{types.QueryParameters}, abci.RequestQuery{})
+	require.Nil(t, err)
+	require.Nil(t, keeper.GetCDC().UnmarshalJSON(res, &params))
+	require.Equal(t, types.DefaultParams(), params)
+
+	_, err = querier(ctx, []string{"unknown"}, abci.RequestQuery{})
+	require.NotNil(t, err, fmt.Sprintf("unknown query endpoint should fail"))
+}
@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+)
+
+// FindSwapRoute returns the route of tokens, starting with the sold token and ending with boughtToken, through
+// which selling soldTokenAmount buys the most boughtToken. A route goes through at most types.MaxRouteHops
+// non-empty pools whose token pairs are still listed on dex, and never goes through a token twice.
+func (k Keeper) FindSwapRoute(ctx sdk.Context, soldTokenAmount sdk.DecCoin, boughtToken string) (
+	route []string, boughtTokenAmount sdk.DecCoin, err sdk.Error) {
+
+	pools := make(map[string][]types.SwapTokenPair)
+	for _, swapTokenPair := range k.GetSwapTokenPairs(ctx) {
+		if swapTokenPair.IsEmpty() || k.dexKeeper.GetTokenPair(ctx, swapTokenPair.Product) == nil {
+			continue
+		}
+		base, quote := swapTokenPair.BasePooledCoin.Denom, swapTokenPair.QuotePooledCoin.Denom
+		pools[base] = append(pools[base], swapTokenPair)
+		pools[quote] = append(pools[quote], swapTokenPair)
+	}
+
+	feeRate := k.GetParams(ctx).FeeRate
+	path := []string{soldTokenAmount.Denom}
+	visited := map[string]bool{soldTokenAmount.Denom: true}
+	bestAmount := sdk.ZeroDec()
+
+	var search func(soldAmount sdk.DecCoin)
+	search = func(soldAmount sdk.DecCoin) {
+		if soldAmount.Denom == boughtToken {
+			// a shorter route is kept when the amounts are equal
+			if soldAmount.Amount.GT(bestAmount) {
+				bestAmount = soldAmount.Amount
+				route = append([]string{}, path...)
+			}
+			return
+		}
+		if len(path) > types.MaxRouteHops {
+			return
+		}
+
+		for _, swapTokenPair := range pools[soldAmount.Denom] {
+			soldReserve, boughtReserve := swapTokenPair.BasePooledCoin, swapTokenPair.QuotePooledCoin
+			if soldAmount.Denom != soldReserve.Denom {
+				soldReserve, boughtReserve = boughtReserve, soldReserve
+			}
+			if visited[boughtReserve.Denom] {
+				continue
+			}
+			amount := types.CalculateBuyAmount(soldAmount.Amount, soldReserve.Amount, boughtReserve.Amount, feeRate)
+			if !amount.IsPositive() || amount.GTE(boughtReserve.Amount) {
+				continue
+			}
+
+			visited[boughtReserve.Denom] = true
+			path = append(path, boughtReserve.Denom)
+			search(sdk.NewDecCoinFromDec(boughtReserve.Denom, amount))
+			path = path[:len(path)-1]
+			visited[boughtReserve.Denom] = false
+		}
+	}
+	search(soldTokenAmount)
+
+	if len(route) == 0 {
+		return nil, boughtTokenAmount, types.ErrRouteNotFound(
+			fmt.Sprintf("failed to sell %s for %s", soldTokenAmount, boughtToken))
+	}
+	return route, sdk.NewDecCoinFromDec(boughtToken, bestAmount), nil
+}
+
+// RouteSwap sells soldTokenAmount of addr through the pools along the route, which is a list of tokens starting
+// with the sold token, and returns the amount of the last token bought
+func (k Keeper) RouteSwap(ctx sdk.Context, route []string, soldTokenAmount sdk.DecCoin, addr sdk.AccAddress) (
+	boughtTokenAmount sdk.DecCoin, err sdk.Error) {
+
+	if len(route) < 2 || route[0] != soldTokenAmount.Denom {
+		return boughtTokenAmount, types.ErrRouteNotFound(fmt.Sprintf("invalid route %v", route))
+	}
+
+	for _, boughtToken := range route[1:] {
+		var product string
+		boughtTokenAmount, product, err = k.CalculateSwap(ctx, soldTokenAmount, boughtToken)
+		if err != nil {
+			return boughtTokenAmount, err
+		}
+		if err = k.Swap(ctx, product, soldTokenAmount, boughtTokenAmount, addr); err != nil {
+			return boughtTokenAmount, err
+		}
+		soldTokenAmount = boughtTokenAmount
+	}
+	return boughtTokenAmount, nil
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeeper_FindSwapRoute(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.SwapKeeper
+	soldTokenAmount := sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10))
+
+	// fail case : no pool
+	_, _, err := keeper.FindSwapRoute(ctx, soldTokenAmount, "yyb")
+	require.Equal(t, types.CodeRouteNotFound, err.Code())
+
+	AddTestPool(t, testInput, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000)))
+	AddTestPool(t, testInput, sdk.NewDecCoinFromDec("yyb", sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000)))
+
+	// successful case : xxb -> okt -> yyb
+	route, boughtTokenAmount, err := keeper.FindSwapRoute(ctx, soldTokenAmount, "yyb")
+	require.Nil(t, err)
+	require.Equal(t, []string{common.TestToken, common.NativeToken, "yyb"}, route)
+	feeRate := keeper.GetParams(ctx).FeeRate
+	oktAmount := types.CalculateBuyAmount(sdk.NewDec(10), sdk.NewDec(100), sdk.NewDec(1000), feeRate)
+	yybAmount := types.CalculateBuyAmount(oktAmount, sdk.NewDec(1000), sdk.NewDec(1000), feeRate)
+	require.Equal(t, sdk.NewDecCoinFromDec("yyb", yybAmount), boughtTokenAmount)
+
+	// successful case : the direct pool buys more than the route through okt
+	AddTestPool(t, testInput, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec("yyb", sdk.NewDec(10000)))
+	route, boughtTokenAmount, err = keeper.FindSwapRoute(ctx, soldTokenAmount, "yyb")
+	require.Nil(t, err)
+	require.Equal(t, []string{common.TestToken, "yyb"}, route)
+	require.True(t, boughtTokenAmount.Amount.GT(yybAmount))
+
+	// successful case : the sold token is the quote token of the pools
+	route, _, err = keeper.FindSwapRoute(ctx, sdk.NewDecCoinFromDec("yyb", sdk.NewDec(10)), common.NativeToken)
+	require.Nil(t, err)
+	require.Equal(t, []string{"yyb", common.NativeToken}, route)
+}
+
+func TestKeeper_RouteSwap(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.SwapKeeper
+	addr := testInput.TestAddrs[0]
+	soldTokenAmount := sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10))
+
+	AddTestPool(t, testInput, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(100)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000)))
+	AddTestPool(t, testInput, sdk.NewDecCoinFromDec("yyb", sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000)))
+
+	// fail case : invalid route
+	_, err := keeper.RouteSwap(ctx, []string{"yyb", common.NativeToken}, soldTokenAmount, addr)
+	require.Equal(t, types.CodeRouteNotFound, err.Code())
+
+	route, expected, err := keeper.FindSwapRoute(ctx, soldTokenAmount, "yyb")
+	require.Nil(t, err)
+	boughtTokenAmount, err := keeper.RouteSwap(ctx, route, soldTokenAmount, addr)
+	require.Nil(t, err)
+	require.Equal(t, expected, boughtTokenAmount)
+
+	coins := testInput.TokenKeeper.GetCoins(ctx, addr)
+	require.Equal(t, sdk.NewDec(990), coins.AmountOf(common.TestToken))
+	require.Equal(t, sdk.NewDec(1000), coins.AmountOf(common.NativeToken))
+	require.Equal(t, boughtTokenAmount.Amount, coins.AmountOf("yyb"))
+
+	swapTokenPair, _ := keeper.GetSwapTokenPair(ctx, TestProduct)
+	require.Equal(t, sdk.NewDec(110), swapTokenPair.BasePooledCoin.Amount)
+	swapTokenPair, _ = keeper.GetSwapTokenPair(ctx, types.GetProduct("yyb", common.NativeToken))
+	require.Equal(t, sdk.NewDec(1000).Sub(boughtTokenAmount.Amount), swapTokenPair.BasePooledCoin.Amount)
+}
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/ammswap/types"
+	dex "github.com/okex/okchain/x/dex/types"
+	token "github.com/okex/okchain/x/token/types"
+)
+
+// CreateSwapTokenPair creates an empty pool of the token pair and registers its pool token
+func (k Keeper) CreateSwapTokenPair(ctx sdk.Context, tokenPair *dex.TokenPair) types.SwapTokenPair {
+	poolTokenName := types.GetPoolTokenName(tokenPair.ID)
+	if !k.tokenKeeper.TokenExist(ctx, poolTokenName) {
+		k.tokenKeeper.NewToken(ctx, token.Token{
+			Description:         fmt.Sprintf("liquidity pool token of %s", tokenPair.Name()),
+			Symbol:              poolTokenName,
+			OriginalSymbol:      strings.ToUpper(poolTokenName),
+			WholeName:           poolTokenName,
+			OriginalTotalSupply: sdk.ZeroDec(),
+			TotalSupply:         sdk.ZeroDec(),
+			Owner:               k.supplyKeeper.GetModuleAddress(types.ModuleName),
+			Mintable:            true,
+		})
+	}
+
+	swapTokenPair := types.NewSwapTokenPair(tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, poolTokenName)
+	k.SetSwapTokenPair(ctx, swapTokenPair)
+	return swapTokenPair
+}
+
+// GetPoolCapacity returns the base amount the pool of the product is able to sell to buy orders and to buy from
+// sell orders at the clearing price of the order book
+func (k Keeper) GetPoolCapacity(ctx sdk.Context, product string, price sdk.Dec) (sellable, buyable sdk.Dec) {
+	swapTokenPair, isExist := k.GetSwapTokenPair(ctx, product)
+	if !isExist || swapTokenPair.IsEmpty() {
+		return sdk.ZeroDec(), sdk.ZeroDec()
+	}
+	return types.CalculateOrderBookCapacity(swapTokenPair.BasePooledCoin.Amount,
+		swapTokenPair.QuotePooledCoin.Amount, price, k.GetParams(ctx).FeeRate)
+}
+
+// SettleOrderBookTrade settles an order filled against the pool of the product, where soldByPool is sent to
+// the token module which keeps the locked coins of the orders, and boughtByPool is sent from it to the pool
+func (k Keeper) SettleOrderBookTrade(ctx sdk.Context, product string, soldByPool, boughtByPool sdk.DecCoin) sdk.Error {
+	swapTokenPair, isExist := k.GetSwapTokenPair(ctx, product)
+	if !isExist {
+		return types.ErrSwapTokenPairNotExist(product)
+	}
+
+	oldK := swapTokenPair.K()
+	switch {
+	case soldByPool.Denom == swapTokenPair.BasePooledCoin.Denom && boughtByPool.Denom == swapTokenPair.QuotePooledCoin.Denom:
+		if soldByPool.Amount.GTE(swapTokenPair.BasePooledCoin.Amount) {
+			return types.ErrInsufficientLiquidity(swapTokenPair.BasePooledCoin.String())
+		}
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(soldByPool)
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(boughtByPool)
+	case soldByPool.Denom == swapTokenPair.QuotePooledCoin.Denom && boughtByPool.Denom == swapTokenPair.BasePooledCoin.Denom:
+		if soldByPool.Amount.GTE(swapTokenPair.QuotePooledCoin.Amount) {
+			return types.ErrInsufficientLiquidity(swapTokenPair.QuotePooledCoin.String())
+		}
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(soldByPool)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(boughtByPool)
+	default:
+		return types.ErrInvalidSwapAmount(fmt.Sprintf("%s and %s do not match %s", soldByPool, boughtByPool, product))
+	}
+
+	// the constant product never decreases
+	if swapTokenPair.K().LT(oldK) {
+		return types.ErrInsufficientLiquidity(fmt.Sprintf("failed to sell %s for %s", soldByPool, boughtByPool))
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, token.ModuleName,
+		sdk.DecCoins{soldByPool}); err != nil {
+		return err
+	}
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, token.ModuleName, types.ModuleName,
+		sdk.DecCoins{boughtByPool}); err != nil {
+		return err
+	}
+
+	k.SetSwapTokenPair(ctx, swapTokenPair)
+	return nil
+}
+
+// CalculateSwap returns the amount of boughtToken bought by selling soldTokenAmount into the pool of the two tokens
+// and the product of the pool
+func (k Keeper) CalculateSwap(ctx sdk.Context, soldTokenAmount sdk.DecCoin, boughtToken string) (
+	boughtTokenAmount sdk.DecCoin, product string, err sdk.Error) {
+
+	swapTokenPair, isExist := k.GetSwapTokenPair(ctx, types.GetProduct(soldTokenAmount.Denom, boughtToken))
+	if !isExist {
+		swapTokenPair, isExist = k.GetSwapTokenPair(ctx, types.GetProduct(boughtToken, soldTokenAmount.Denom))
+	}
+	if !isExist {
+		return boughtTokenAmount, product, types.ErrSwapTokenPairNotExist(types.GetProduct(soldTokenAmount.Denom, boughtToken))
+	}
+	if swapTokenPair.IsEmpty() {
+		return boughtTokenAmount, product, types.ErrInsufficientLiquidity(swapTokenPair.Product)
+	}
+
+	soldReserve, boughtReserve := swapTokenPair.BasePooledCoin, swapTokenPair.QuotePooledCoin
+	if soldTokenAmount.Denom != soldReserve.Denom {
+		soldReserve, boughtReserve = boughtReserve, soldReserve
+	}
+	amount := types.CalculateBuyAmount(soldTokenAmount.Amount, soldReserve.Amount, boughtReserve.Amount,
+		k.GetParams(ctx).FeeRate)
+	if !amount.IsPositive() || amount.GTE(boughtReserve.Amount) {
+		return boughtTokenAmount, product, types.ErrInsufficientLiquidity(
+			fmt.Sprintf("failed to sell %s into %s", soldTokenAmount, swapTokenPair.Product))
+	}
+	return sdk.NewDecCoinFromDec(boughtToken, amount), swapTokenPair.Product, nil
+}
+
+// Swap sends soldTokenAmount of addr into the pool of the product and boughtTokenAmount out of it
+func (k Keeper) Swap(ctx sdk.Context, product string, soldTokenAmount, boughtTokenAmount sdk.DecCoin,
+	addr sdk.AccAddress) sdk.Error {
+
+	swapTokenPair, isExist := k.GetSwapTokenPair(ctx, product)
+	if !isExist {
+		return types.ErrSwapTokenPairNotExist(product)
+	}
+
+	if err := k.SendCoinsToPool(ctx, sdk.DecCoins{soldTokenAmount}, addr); err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s): %s", soldTokenAmount, err.Error()))
+	}
+	if err := k.SendCoinsFromPoolToAccount(ctx, sdk.DecCoins{boughtTokenAmount}, addr); err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("failed to send %s: %s", boughtTokenAmount, err.Error()))
+	}
+
+	if soldTokenAmount.Denom == swapTokenPair.BasePooledCoin.Denom {
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(soldTokenAmount)
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(boughtTokenAmount)
+	} else {
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(soldTokenAmount)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(boughtTokenAmount)
+	}
+	k.SetSwapTokenPair(ctx, swapTokenPair)
+	return nil
+}
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/cosmos/cosmos-sdk/codec"
+
+// RegisterCodec registers concrete types on the Amino codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgAddLiquidity{}, "okchain/ammswap/MsgAddLiquidity", nil)
+	cdc.RegisterConcrete(MsgRemoveLiquidity{}, "okchain/ammswap/MsgRemoveLiquidity", nil)
+	cdc.RegisterConcrete(MsgSwap{}, "okchain/ammswap/MsgSwap", nil)
+	cdc.RegisterConcrete(MsgRouteSwap{}, "okchain/ammswap/MsgRouteSwap", nil)
+}
+
+// ModuleCdc represents generic sealed codec to be used throughout this module
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
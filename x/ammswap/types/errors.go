@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// const CodeType
+const (
+	CodeSwapTokenPairNotExist sdk.CodeType = 1
+	CodeTokenPairNotListed    sdk.CodeType = 2
+	CodeInvalidSwapAmount     sdk.CodeType = 3
+	CodeInsufficientLiquidity sdk.CodeType = 4
+	CodeRouteNotFound         sdk.CodeType = 5
+	CodeInvalidPoolState      sdk.CodeType = 6
+)
+
+// CodeType to Message
+func CodeToDefaultMsg(code sdk.CodeType) string {
+	switch code {
+	case CodeSwapTokenPairNotExist:
+		return "swap token pair not exist"
+	case CodeTokenPairNotListed:
+		return "token pair not listed on dex"
+	case CodeInvalidSwapAmount:
+		return "invalid swap amount"
+	case CodeInsufficientLiquidity:
+		return "insufficient liquidity"
+	case CodeRouteNotFound:
+		return "swap route not found"
+	case CodeInvalidPoolState:
+		return "invalid pool state"
+	default:
+		return fmt.Sprintf("unknown code %d", code)
+	}
+}
+
+func ErrSwapTokenPairNotExist(product string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeSwapTokenPairNotExist, CodeToDefaultMsg(CodeSwapTokenPairNotExist)+": %s", product)
+}
+
+func ErrTokenPairNotListed(product string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeTokenPairNotListed, CodeToDefaultMsg(CodeTokenPairNotListed)+": %s", product)
+}
+
+func ErrInvalidSwapAmount(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidSwapAmount, CodeToDefaultMsg(CodeInvalidSwapAmount)+": %s", msg)
+}
+
+func ErrInsufficientLiquidity(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInsufficientLiquidity, CodeToDefaultMsg(CodeInsufficientLiquidity)+": %s", msg)
+}
+
+func ErrRouteNotFound(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeRouteNotFound, CodeToDefaultMsg(CodeRouteNotFound)+": %s", msg)
+}
+
+func ErrInvalidPoolState(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidPoolState, CodeToDefaultMsg(CodeInvalidPoolState)+": %s", msg)
+}
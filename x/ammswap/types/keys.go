@@ -0,0 +1,47 @@
+package types
+
+import (
+	"fmt"
+)
+
+const (
+	// ModuleName is the name of the ammswap module
+	ModuleName        = "ammswap"
+	DefaultParamspace = ModuleName
+	DefaultCodespace  = ModuleName
+
+	// QuerierRoute is the querier route for the ammswap module
+	QuerierRoute = ModuleName
+
+	// RouterKey is the msg router key for the ammswap module
+	RouterKey = ModuleName
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	QuerySwapTokenPair  = "swapTokenPair"
+	QuerySwapTokenPairs = "swapTokenPairs"
+	QueryParameters     = "params"
+
+	// PoolTokenPrefix is the prefix of the pool token symbol, followed by the id of the token pair
+	PoolTokenPrefix = "lp"
+)
+
+var (
+	SwapTokenPairPrefix = []byte{0x01} // the prefix of the swap token pair's product
+)
+
+// GetSwapTokenPairKey returns store key of the swap token pair
+func GetSwapTokenPairKey(product string) []byte {
+	return append(SwapTokenPairPrefix, []byte(product)...)
+}
+
+// GetPoolTokenName returns the symbol of the pool token minted for the token pair with tokenPairID
+func GetPoolTokenName(tokenPairID uint64) string {
+	return fmt.Sprintf("%s%d", PoolTokenPrefix, tokenPairID)
+}
+
+// GetProduct returns the product name of a base and a quote asset
+func GetProduct(baseAsset, quoteAsset string) string {
+	return fmt.Sprintf("%s_%s", baseAsset, quoteAsset)
+}
@@ -0,0 +1,211 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgAddLiquidity    = "addLiquidity"
+	TypeMsgRemoveLiquidity = "removeLiquidity"
+	TypeMsgSwap            = "swap"
+	TypeMsgRouteSwap       = "routeSwap"
+
+	// MaxRouteHops is the max number of pools a route swap goes through
+	MaxRouteHops = 3
+)
+
+// MsgAddLiquidity deposits the base and quote asset into the pool of a token pair
+type MsgAddLiquidity struct {
+	MinLiquidity  sdk.Dec        `json:"min_liquidity"`   // minimum amount of pool token to be minted
+	MaxBaseAmount sdk.DecCoin    `json:"max_base_amount"` // maximum amount of base asset to be deposited
+	QuoteAmount   sdk.DecCoin    `json:"quote_amount"`    // amount of quote asset to be deposited
+	Sender        sdk.AccAddress `json:"sender"`
+}
+
+func NewMsgAddLiquidity(minLiquidity sdk.Dec, maxBaseAmount, quoteAmount sdk.DecCoin, sender sdk.AccAddress) MsgAddLiquidity {
+	return MsgAddLiquidity{
+		MinLiquidity:  minLiquidity,
+		MaxBaseAmount: maxBaseAmount,
+		QuoteAmount:   quoteAmount,
+		Sender:        sender,
+	}
+}
+
+// nolint
+func (msg MsgAddLiquidity) Route() string { return RouterKey }
+func (msg MsgAddLiquidity) Type() string  { return TypeMsgAddLiquidity }
+
+// Implements Msg.
+func (msg MsgAddLiquidity) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if !msg.MaxBaseAmount.IsValid() || !msg.MaxBaseAmount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.MaxBaseAmount.String())
+	}
+	if !msg.QuoteAmount.IsValid() || !msg.QuoteAmount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.QuoteAmount.String())
+	}
+	if msg.MaxBaseAmount.Denom == msg.QuoteAmount.Denom {
+		return ErrInvalidSwapAmount("base asset and quote asset should be different")
+	}
+	if msg.MinLiquidity.IsNil() || msg.MinLiquidity.IsNegative() {
+		return ErrInvalidSwapAmount("min liquidity should not be negative")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgAddLiquidity) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgAddLiquidity) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetProduct returns the name of the token pair
+func (msg MsgAddLiquidity) GetProduct() string {
+	return GetProduct(msg.MaxBaseAmount.Denom, msg.QuoteAmount.Denom)
+}
+
+// MsgRemoveLiquidity burns the pool token and withdraws the base and quote asset from the pool
+type MsgRemoveLiquidity struct {
+	Liquidity      sdk.Dec        `json:"liquidity"`        // amount of pool token to be burned
+	MinBaseAmount  sdk.DecCoin    `json:"min_base_amount"`  // minimum amount of base asset to be withdrawn
+	MinQuoteAmount sdk.DecCoin    `json:"min_quote_amount"` // minimum amount of quote asset to be withdrawn
+	Sender         sdk.AccAddress `json:"sender"`
+}
+
+func NewMsgRemoveLiquidity(liquidity sdk.Dec, minBaseAmount, minQuoteAmount sdk.DecCoin, sender sdk.AccAddress) MsgRemoveLiquidity {
+	return MsgRemoveLiquidity{
+		Liquidity:      liquidity,
+		MinBaseAmount:  minBaseAmount,
+		MinQuoteAmount: minQuoteAmount,
+		Sender:         sender,
+	}
+}
+
+// nolint
+func (msg MsgRemoveLiquidity) Route() string { return RouterKey }
+func (msg MsgRemoveLiquidity) Type() string  { return TypeMsgRemoveLiquidity }
+
+// Implements Msg.
+func (msg MsgRemoveLiquidity) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.Liquidity.IsNil() || !msg.Liquidity.IsPositive() {
+		return ErrInvalidSwapAmount("liquidity should be positive")
+	}
+	if !msg.MinBaseAmount.IsValid() {
+		return sdk.ErrInvalidCoins(msg.MinBaseAmount.String())
+	}
+	if !msg.MinQuoteAmount.IsValid() {
+		return sdk.ErrInvalidCoins(msg.MinQuoteAmount.String())
+	}
+	if msg.MinBaseAmount.Denom == msg.MinQuoteAmount.Denom {
+		return ErrInvalidSwapAmount("base asset and quote asset should be different")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRemoveLiquidity) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRemoveLiquidity) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetProduct returns the name of the token pair
+func (msg MsgRemoveLiquidity) GetProduct() string {
+	return GetProduct(msg.MinBaseAmount.Denom, msg.MinQuoteAmount.Denom)
+}
+
+// MsgSwap sells a token into the pool and buys the other token of the token pair
+type MsgSwap struct {
+	SoldTokenAmount      sdk.DecCoin    `json:"sold_token_amount"`       // amount of token to be sold
+	MinBoughtTokenAmount sdk.DecCoin    `json:"min_bought_token_amount"` // minimum amount of token to be bought
+	Sender               sdk.AccAddress `json:"sender"`
+}
+
+func NewMsgSwap(soldTokenAmount, minBoughtTokenAmount sdk.DecCoin, sender sdk.AccAddress) MsgSwap {
+	return MsgSwap{
+		SoldTokenAmount:      soldTokenAmount,
+		MinBoughtTokenAmount: minBoughtTokenAmount,
+		Sender:               sender,
+	}
+}
+
+// nolint
+func (msg MsgSwap) Route() string { return RouterKey }
+func (msg MsgSwap) Type() string  { return TypeMsgSwap }
+
+// Implements Msg.
+func (msg MsgSwap) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if !msg.SoldTokenAmount.IsValid() || !msg.SoldTokenAmount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.SoldTokenAmount.String())
+	}
+	if !msg.MinBoughtTokenAmount.IsValid() {
+		return sdk.ErrInvalidCoins(msg.MinBoughtTokenAmount.String())
+	}
+	if msg.SoldTokenAmount.Denom == msg.MinBoughtTokenAmount.Denom {
+		return ErrInvalidSwapAmount("sold token and bought token should be different")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgSwap) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgRouteSwap sells a token and buys the target token through a route of pools, which is found on chain
+type MsgRouteSwap struct {
+	SoldTokenAmount      sdk.DecCoin    `json:"sold_token_amount"`       // amount of token to be sold
+	MinBoughtTokenAmount sdk.DecCoin    `json:"min_bought_token_amount"` // minimum amount of target token to be bought
+	Sender               sdk.AccAddress `json:"sender"`
+}
+
+func NewMsgRouteSwap(soldTokenAmount, minBoughtTokenAmount sdk.DecCoin, sender sdk.AccAddress) MsgRouteSwap {
+	return MsgRouteSwap{
+		SoldTokenAmount:      soldTokenAmount,
+		MinBoughtTokenAmount: minBoughtTokenAmount,
+		Sender:               sender,
+	}
+}
+
+// nolint
+func (msg MsgRouteSwap) Route() string { return RouterKey }
+func (msg MsgRouteSwap) Type() string  { return TypeMsgRouteSwap }
+
+// Implements Msg.
+func (msg MsgRouteSwap) ValidateBasic() sdk.Error {
+	return MsgSwap(msg).ValidateBasic()
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRouteSwap) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRouteSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+	"github.com/okex/okchain/x/params"
+)
+
+const (
+	DefaultFeeRate = "0.003"
+)
+
+var (
+	KeyFeeRate = []byte("FeeRate")
+)
+
+// Params defines the parameters of the ammswap module
+type Params struct {
+	// fee rate of a swap, which is kept in the pool as the reward of liquidity providers
+	FeeRate sdk.Dec `json:"fee_rate"`
+}
+
+func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
+	return params.ParamSetPairs{
+		{Key: KeyFeeRate, Value: &p.FeeRate},
+	}
+}
+
+// ParamKeyTable for ammswap module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns a default set of parameters.
+func DefaultParams() Params {
+	return Params{
+		FeeRate: sdk.MustNewDecFromStr(DefaultFeeRate),
+	}
+}
+
+// Validate checks the fee rate is in [0, 1)
+func (p Params) Validate() error {
+	if p.FeeRate.IsNil() || p.FeeRate.IsNegative() || p.FeeRate.GTE(sdk.OneDec()) {
+		return fmt.Errorf("invalid fee rate: %s", p.FeeRate)
+	}
+	return nil
+}
+
+// String implements the stringer interface.
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params: \n")
+	sb.WriteString(fmt.Sprintf("FeeRate:%s\n", p.FeeRate))
+	return sb.String()
+}
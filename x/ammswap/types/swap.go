@@ -0,0 +1,99 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SwapTokenPair is the constant-product liquidity pool of a token pair listed on dex
+type SwapTokenPair struct {
+	Product         string      `json:"product"`           // name of the token pair, e.g. "xxb_okt"
+	BasePooledCoin  sdk.DecCoin `json:"base_pooled_coin"`  // base asset held by the pool
+	QuotePooledCoin sdk.DecCoin `json:"quote_pooled_coin"` // quote asset held by the pool
+	PoolTokenName   string      `json:"pool_token_name"`   // symbol of the token minted to liquidity providers
+}
+
+// NewSwapTokenPair creates an empty pool of the base and quote asset
+func NewSwapTokenPair(baseAsset, quoteAsset, poolTokenName string) SwapTokenPair {
+	return SwapTokenPair{
+		Product:         GetProduct(baseAsset, quoteAsset),
+		BasePooledCoin:  sdk.NewDecCoinFromDec(baseAsset, sdk.ZeroDec()),
+		QuotePooledCoin: sdk.NewDecCoinFromDec(quoteAsset, sdk.ZeroDec()),
+		PoolTokenName:   poolTokenName,
+	}
+}
+
+// String implements fmt.Stringer
+func (s SwapTokenPair) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`SwapTokenPair:
+  Product:          %s
+  BasePooledCoin:   %s
+  QuotePooledCoin:  %s
+  PoolTokenName:    %s`,
+		s.Product, s.BasePooledCoin, s.QuotePooledCoin, s.PoolTokenName))
+}
+
+// IsEmpty returns true if the pool holds none of its assets
+func (s SwapTokenPair) IsEmpty() bool {
+	return !s.BasePooledCoin.Amount.IsPositive() || !s.QuotePooledCoin.Amount.IsPositive()
+}
+
+// Price returns the quote amount per base amount in the pool
+func (s SwapTokenPair) Price() sdk.Dec {
+	if s.IsEmpty() {
+		return sdk.ZeroDec()
+	}
+	return s.QuotePooledCoin.Amount.Quo(s.BasePooledCoin.Amount)
+}
+
+// K returns the constant product of the pool
+func (s SwapTokenPair) K() sdk.Dec {
+	return s.BasePooledCoin.Amount.Mul(s.QuotePooledCoin.Amount)
+}
+
+type SwapTokenPairs []SwapTokenPair
+
+// String implements fmt.Stringer
+func (pairs SwapTokenPairs) String() string {
+	strs := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		strs = append(strs, pair.String())
+	}
+	return strings.Join(strs, "\n")
+}
+
+// CalculateBuyAmount returns the amount bought from a pool with boughtReserve by selling soldAmount into
+// soldReserve, after the fee is kept in the pool
+func CalculateBuyAmount(soldAmount, soldReserve, boughtReserve, feeRate sdk.Dec) sdk.Dec {
+	soldAfterFee := soldAmount.MulTruncate(sdk.OneDec().Sub(feeRate))
+	denominator := soldReserve.Add(soldAfterFee)
+	if !denominator.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	return soldAfterFee.MulTruncate(boughtReserve).QuoTruncate(denominator)
+}
+
+// CalculateOrderBookCapacity returns the base amount the pool is able to sell to buy orders and to buy from
+// sell orders at a fixed price, keeping a margin of feeRate so that the constant product never decreases
+//
+//	sellable = base - quote / (price * (1 - feeRate))
+//	buyable  = quote / price - base / (1 - feeRate)
+//
+// at most one of them is positive
+func CalculateOrderBookCapacity(base, quote, price, feeRate sdk.Dec) (sellable, buyable sdk.Dec) {
+	sellable, buyable = sdk.ZeroDec(), sdk.ZeroDec()
+	remainRate := sdk.OneDec().Sub(feeRate)
+	if !base.IsPositive() || !quote.IsPositive() || !price.IsPositive() || !remainRate.IsPositive() {
+		return
+	}
+
+	if s := base.Sub(quote.QuoRoundUp(price.MulTruncate(remainRate))); s.IsPositive() {
+		sellable = s
+	}
+	if b := quote.QuoTruncate(price).Sub(base.QuoRoundUp(remainRate)); b.IsPositive() {
+		buyable = b
+	}
+	return
+}
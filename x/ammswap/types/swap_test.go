@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateBuyAmount(t *testing.T) {
+	// no fee: 100 * 1000 / (1000 + 100)
+	bought := CalculateBuyAmount(sdk.NewDec(100), sdk.NewDec(1000), sdk.NewDec(1000), sdk.ZeroDec())
+	require.Equal(t, sdk.MustNewDecFromStr("90.90909090"), bought)
+
+	// fee kept in the pool
+	feeRate := sdk.MustNewDecFromStr("0.003")
+	bought = CalculateBuyAmount(sdk.NewDec(100), sdk.NewDec(1000), sdk.NewDec(1000), feeRate)
+	require.True(t, bought.LT(sdk.MustNewDecFromStr("90.90909090")))
+	newK := sdk.NewDec(1100).Mul(sdk.NewDec(1000).Sub(bought))
+	require.True(t, newK.GT(sdk.NewDec(1000000)))
+
+	// empty pool
+	require.True(t, CalculateBuyAmount(sdk.ZeroDec(), sdk.ZeroDec(), sdk.NewDec(1000), feeRate).IsZero())
+}
+
+func TestCalculateOrderBookCapacity(t *testing.T) {
+	base, quote := sdk.NewDec(100), sdk.NewDec(1000)
+	feeRate := sdk.MustNewDecFromStr("0.003")
+
+	// the pool price is 10, it sells base at a higher price
+	sellable, buyable := CalculateOrderBookCapacity(base, quote, sdk.NewDec(20), feeRate)
+	require.True(t, sellable.IsPositive())
+	require.True(t, buyable.IsZero())
+	price := sdk.NewDec(20)
+	newK := base.Sub(sellable).Mul(quote.Add(sellable.Mul(price)))
+	require.True(t, newK.GTE(base.Mul(quote)))
+
+	// it buys base at a lower price
+	price = sdk.NewDec(5)
+	sellable, buyable = CalculateOrderBookCapacity(base, quote, price, feeRate)
+	require.True(t, sellable.IsZero())
+	require.True(t, buyable.IsPositive())
+	newK = base.Add(buyable).Mul(quote.Sub(buyable.Mul(price)))
+	require.True(t, newK.GTE(base.Mul(quote)))
+
+	// it does not trade within the fee margin
+	sellable, buyable = CalculateOrderBookCapacity(base, quote, sdk.MustNewDecFromStr("10.01"), feeRate)
+	require.True(t, sellable.IsZero())
+	require.True(t, buyable.IsZero())
+
+	// empty pool
+	sellable, buyable = CalculateOrderBookCapacity(sdk.ZeroDec(), quote, price, feeRate)
+	require.True(t, sellable.IsZero())
+	require.True(t, buyable.IsZero())
+}
@@ -2,8 +2,8 @@ package backend
 
 import (
 	"fmt"
-	"strconv"
 
+	"github.com/okex/okchain/x/backend/push"
 	"github.com/okex/okchain/x/backend/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,6 +13,10 @@ import (
 
 // Called every block, check expired orders
 func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	if keeper.BlockLog != nil {
+		appendBlockLog(ctx, keeper)
+	}
+
 	if keeper.Config.EnableBackend && keeper.Config.EnableMktCompute {
 		keeper.Logger.Debug(fmt.Sprintf("begin backend endblocker: block---%d", ctx.BlockHeight()))
 		storeNewOrders(ctx, keeper)
@@ -20,8 +24,39 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 		storeDealAndMatchResult(ctx, keeper)
 		storeFeeDetails(keeper)
 		storeTransactions(keeper)
+		storeDepthSnapshots(ctx, keeper)
+		pushBlock(ctx, keeper, push.GetHub())
 		keeper.Flush()
 		keeper.Logger.Debug(fmt.Sprintf("end backend endblocker: block---%d", ctx.BlockHeight()))
+	} else if keeper.BlockLog != nil {
+		keeper.Flush()
+	}
+}
+
+// appendBlockLog hands the backend data of the block over to the indexer. A failure doesn't stop the node, the
+// indexer reports the missing blocks and they can be backfilled with `okchaind backend reindex`
+func appendBlockLog(ctx sdk.Context, keeper Keeper) {
+	defer types.PrintStackIfPanic()
+
+	entry := &types.BlockLogEntry{
+		Height:         ctx.BlockHeight(),
+		Timestamp:      ctx.BlockHeader().Time.Unix(),
+		UpdatedOrders:  GetUpdatedOrdersAtEndBlock(ctx, keeper.OrderKeeper),
+		FeeDetails:     keeper.TokenKeeper.GetFeeDetailList(),
+		Transactions:   keeper.Cache.GetTransactions(),
+		DepthSnapshots: keeper.TakeDepthSnapshots(ctx),
+	}
+
+	var err error
+	if entry.NewOrders, err = GetNewOrdersAtEndBlock(ctx, keeper.OrderKeeper); err == nil {
+		entry.Deals, entry.MatchResults, err = GetNewDealsAndMatchResultsAtEndBlock(ctx, keeper.OrderKeeper)
+	}
+	if err == nil {
+		err = keeper.BlockLog.Append(entry)
+	}
+	if err != nil {
+		keeper.Logger.Error(fmt.Sprintf("[backend] failed to append block %d to the block log, error: %s",
+			ctx.BlockHeight(), err.Error()))
 	}
 }
 
@@ -39,6 +74,21 @@ func storeTransactions(keeper Keeper) {
 	}
 }
 
+func storeDepthSnapshots(ctx sdk.Context, keeper Keeper) {
+	defer types.PrintStackIfPanic()
+
+	snapshots := keeper.TakeDepthSnapshots(ctx)
+	if len(snapshots) == 0 {
+		return
+	}
+	cnt, err := keeper.Orm.AddDepthSnapshots(snapshots)
+	if err != nil {
+		keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d depth snapshots, inserted Count %d, err: %+v", len(snapshots), cnt, err))
+	} else {
+		keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d depth snapshots, inserted Count %d", len(snapshots), cnt))
+	}
+}
+
 func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
 	timestamp := ctx.BlockHeader().Time.Unix()
 	keeper.Orm.MaxBlockTimestamp = timestamp
@@ -65,8 +115,7 @@ func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
 		}
 	}
 
-	ts := keeper.Orm.MaxBlockTimestamp
-	keeper.UpdateTickersBuffer(ts-types.SecondsInADay, ts+1, keeper.Cache.ProductsBuf)
+	keeper.UpdateTickers(ctx.BlockHeight(), timestamp, results)
 }
 
 func storeFeeDetails(keeper Keeper) {
@@ -123,39 +172,32 @@ func GetNewDealsAndMatchResultsAtEndBlock(ctx sdk.Context, orderKeeper types.Ord
 	deals := make([]*types.Deal, 0, totalDeals)
 	results := make([]*types.MatchResult, 0, len(result.ResultMap))
 	for product, matchResult := range result.ResultMap {
-		price, err := strconv.ParseFloat(matchResult.Price.String(), 64)
-		if err == nil && matchResult.BlockHeight == blockHeight {
-			if total, err := strconv.ParseFloat(matchResult.Quantity.String(), 64); err == nil {
-				results = append(results, &types.MatchResult{
-					BlockHeight: blockHeight,
-					Product:     product,
-					Price:       price,
-					Quantity:    total,
-					Timestamp:   ctx.BlockHeader().Time.Unix(),
-				})
-			}
-		} else {
-			return deals, results, err
+		if matchResult.BlockHeight != blockHeight {
+			return deals, results, nil
 		}
+		price := matchResult.Price.String()
+		results = append(results, &types.MatchResult{
+			BlockHeight: blockHeight,
+			Product:     product,
+			Price:       price,
+			Quantity:    matchResult.Quantity.String(),
+			Timestamp:   ctx.BlockHeader().Time.Unix(),
+		})
 
 		for _, record := range matchResult.Deals {
 			order := orderKeeper.GetOrder(ctx, record.OrderID)
-			if quantity, err := strconv.ParseFloat(record.Quantity.String(), 64); err == nil {
-
-				deal := &types.Deal{
-					BlockHeight: blockHeight,
-					OrderId:     record.OrderID,
-					Side:        record.Side,
-					Sender:      order.Sender.String(),
-					Product:     product,
-					Price:       price,
-					Quantity:    quantity,
-					Fee:         record.Fee,
-					Timestamp:   ctx.BlockHeader().Time.Unix(),
-				}
-				deals = append(deals, deal)
-
+			deal := &types.Deal{
+				BlockHeight: blockHeight,
+				OrderId:     record.OrderID,
+				Side:        record.Side,
+				Sender:      order.Sender.String(),
+				Product:     product,
+				Price:       price,
+				Quantity:    record.Quantity.String(),
+				Fee:         record.Fee,
+				Timestamp:   ctx.BlockHeader().Time.Unix(),
 			}
+			deals = append(deals, deal)
 		}
 	}
 	return deals, results, nil
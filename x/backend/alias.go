@@ -38,8 +38,10 @@ type (
 )
 
 var (
-	NewQuerier    = keeper.NewQuerier
-	NewKeeper     = keeper.NewKeeper
+	NewQuerier  = keeper.NewQuerier
+	NewKeeper   = keeper.NewKeeper
+	PruneTables = keeper.PruneTables
+	// Deprecated: use PruneTables
 	CleanUpKlines = keeper.CleanUpKlines
 
 	GenerateTx = types.GenerateTx
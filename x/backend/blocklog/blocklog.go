@@ -0,0 +1,68 @@
+// Package blocklog implements the block log, a local append-only log of the backend data of the committed blocks.
+// The node appends one entry per block, and `okchaind backend indexer` tails the log in another process to fill the
+// backend database, so that the validators don't run SQL in the consensus path.
+//
+// The log is a directory of segment files, each holding the entries of segmentBlocks consecutive heights as lines of
+// json and named after the height of its first entry.
+package blocklog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultSegmentBlocks is the number of blocks kept in a segment file
+	DefaultSegmentBlocks = 10000
+
+	segmentSuffix = ".log"
+)
+
+func segmentName(startHeight int64) string {
+	return fmt.Sprintf("%020d%s", startHeight, segmentSuffix)
+}
+
+// segments returns the start heights of the segment files in dir, in ascending order
+func segments(dir string) ([]int64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var starts []int64
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), segmentSuffix) {
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(f.Name(), segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	return starts, nil
+}
+
+// PruneBefore removes the segment files of dir whose entries are all at or below height
+func PruneBefore(dir string, height int64) error {
+	starts, err := segments(dir)
+	if err != nil {
+		return err
+	}
+	// a segment ends right before the next one starts, the last segment is still being written
+	for i := 0; i+1 < len(starts) && starts[i+1] <= height+1; i++ {
+		if err := os.Remove(filepath.Join(dir, segmentName(starts[i]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package blocklog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mockEntry(height int64) *types.BlockLogEntry {
+	return &types.BlockLogEntry{
+		Height:    height,
+		Timestamp: 1000 + height,
+		Deals: []*types.Deal{
+			{BlockHeight: height, OrderId: "ID1", Product: types.TestTokenPair, Price: "1.5", Quantity: "2"},
+		},
+	}
+}
+
+func readAll(t *testing.T, r *Reader) []int64 {
+	var heights []int64
+	for {
+		entry, err := r.Next()
+		require.Nil(t, err)
+		if entry == nil {
+			return heights
+		}
+		heights = append(heights, entry.Height)
+	}
+}
+
+func TestBlockLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blocklog")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	r := NewReader(dir, 0)
+	defer r.Close()
+	require.Empty(t, readAll(t, r))
+
+	w, err := NewWriter(dir, 3)
+	require.Nil(t, err)
+	for h := int64(1); h <= 4; h++ {
+		require.Nil(t, w.Append(mockEntry(h)))
+	}
+	// a replayed block is ignored
+	require.Nil(t, w.Append(mockEntry(4)))
+	require.Equal(t, int64(4), w.LastHeight())
+
+	entry, err := r.Next()
+	require.Nil(t, err)
+	require.Equal(t, mockEntry(1), entry)
+	require.Equal(t, []int64{2, 3, 4}, readAll(t, r))
+
+	// the node crashes in the middle of block 5
+	require.Nil(t, w.Close())
+	f, err := os.OpenFile(filepath.Join(dir, segmentName(4)), os.O_APPEND|os.O_WRONLY, 0644)
+	require.Nil(t, err)
+	_, err = f.Write([]byte(`{"height":5,"timest`))
+	require.Nil(t, err)
+	require.Nil(t, f.Close())
+	require.Empty(t, readAll(t, r))
+
+	w, err = NewWriter(dir, 3)
+	require.Nil(t, err)
+	defer w.Close()
+	require.Equal(t, int64(4), w.LastHeight())
+	for h := int64(5); h <= 8; h++ {
+		require.Nil(t, w.Append(mockEntry(h)))
+	}
+	require.Equal(t, []int64{5, 6, 7, 8}, readAll(t, r))
+
+	// a new reader starts from the segment of the next block
+	r2 := NewReader(dir, 6)
+	defer r2.Close()
+	require.Equal(t, []int64{7, 8}, readAll(t, r2))
+
+	starts, err := segments(dir)
+	require.Nil(t, err)
+	require.Equal(t, []int64{1, 4, 7}, starts)
+	require.Nil(t, PruneBefore(dir, 5))
+	starts, err = segments(dir)
+	require.Nil(t, err)
+	require.Equal(t, []int64{4, 7}, starts)
+	// the last segment is kept
+	require.Nil(t, PruneBefore(dir, 8))
+	starts, err = segments(dir)
+	require.Nil(t, err)
+	require.Equal(t, []int64{7}, starts)
+}
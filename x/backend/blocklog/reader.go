@@ -0,0 +1,132 @@
+package blocklog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/okex/okchain/x/backend/types"
+)
+
+// Reader tails the block log written by another process
+type Reader struct {
+	dir          string
+	file         *os.File
+	reader       *bufio.Reader
+	segmentStart int64
+	pending      []byte
+	height       int64
+	reopened     bool
+}
+
+// NewReader creates a reader returning the entries of the block log in dir above height
+func NewReader(dir string, height int64) *Reader {
+	return &Reader{dir: dir, height: height}
+}
+
+// Next returns the next entry of the block log, or nil if the writer hasn't appended it yet
+func (r *Reader) Next() (*types.BlockLogEntry, error) {
+	for {
+		if r.file == nil {
+			opened, err := r.open()
+			if err != nil || !opened {
+				return nil, err
+			}
+		}
+
+		line, err := r.reader.ReadBytes('\n')
+		r.pending = append(r.pending, line...)
+		if err == io.EOF && len(r.pending) == 0 {
+			next, err := r.nextSegment()
+			if err != nil || next == 0 {
+				return nil, err
+			}
+			// the writer never appends to a segment once it created the next one, but it may have appended the
+			// last entry of the current one since it was read
+			line, err = r.reader.ReadBytes('\n')
+			r.pending = append(r.pending, line...)
+			if err == io.EOF && len(r.pending) == 0 {
+				r.Close()
+				if err := r.openSegment(next); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		if err == io.EOF {
+			// the writer is in the middle of the entry
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var entry types.BlockLogEntry
+		err = json.Unmarshal(r.pending, &entry)
+		r.pending = nil
+		if err != nil && !r.reopened {
+			// the node truncated an incomplete entry after a crash and wrote over it, read the segment again
+			r.Close()
+			r.reopened = true
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("corrupted entry in block log segment %s: %s", segmentName(r.segmentStart), err.Error())
+		}
+		if entry.Height <= r.height {
+			continue
+		}
+		r.height, r.reopened = entry.Height, false
+		return &entry, nil
+	}
+}
+
+// open opens the segment holding the entry following r.height, it returns false if the log is still empty
+func (r *Reader) open() (bool, error) {
+	starts, err := segments(r.dir)
+	if err != nil || len(starts) == 0 {
+		return false, err
+	}
+
+	start := starts[0]
+	for _, s := range starts {
+		if s <= r.height+1 {
+			start = s
+		}
+	}
+	return true, r.openSegment(start)
+}
+
+// nextSegment returns the start height of the segment following the current one, 0 if there's none yet
+func (r *Reader) nextSegment() (int64, error) {
+	starts, err := segments(r.dir)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range starts {
+		if s > r.segmentStart {
+			return s, nil
+		}
+	}
+	return 0, nil
+}
+
+func (r *Reader) openSegment(start int64) error {
+	file, err := os.Open(filepath.Join(r.dir, segmentName(start)))
+	if err != nil {
+		return err
+	}
+	r.file, r.reader, r.segmentStart, r.pending = file, bufio.NewReader(file), start, nil
+	return nil
+}
+
+// Close closes the current segment file
+func (r *Reader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file, r.reader = nil, nil
+	return err
+}
@@ -0,0 +1,152 @@
+package blocklog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/okex/okchain/x/backend/types"
+)
+
+// Writer appends the entries of the committed blocks to the block log
+type Writer struct {
+	dir           string
+	segmentBlocks int64
+	file          *os.File
+	segmentStart  int64
+	lastHeight    int64
+}
+
+// NewWriter opens the block log in dir for appending, dropping the incomplete entry left by a crash if any
+func NewWriter(dir string, segmentBlocks int64) (*Writer, error) {
+	if segmentBlocks <= 0 {
+		return nil, fmt.Errorf("invalid segment size: %d blocks", segmentBlocks)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	w := &Writer{dir: dir, segmentBlocks: segmentBlocks}
+	starts, err := segments(dir)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(starts) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, segmentName(starts[i]))
+		lastHeight, size, err := lastEntry(path)
+		if err != nil {
+			return nil, err
+		}
+		if lastHeight == 0 {
+			// nothing complete was written to the segment
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		file, err := os.OpenFile(path, os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.Truncate(size); err != nil {
+			file.Close()
+			return nil, err
+		}
+		if _, err := file.Seek(size, io.SeekStart); err != nil {
+			file.Close()
+			return nil, err
+		}
+		w.file, w.segmentStart, w.lastHeight = file, starts[i], lastHeight
+		break
+	}
+	return w, nil
+}
+
+// lastEntry returns the height of the last complete entry of a segment file and the size of the file up to it
+func lastEntry(path string) (height, size int64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return height, size, nil
+		} else if err != nil {
+			return 0, 0, err
+		}
+
+		offset += int64(len(line))
+		var entry types.BlockLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return height, size, nil
+		}
+		height, size = entry.Height, offset
+	}
+}
+
+// LastHeight returns the height of the last entry of the block log, 0 if it's empty
+func (w *Writer) LastHeight() int64 {
+	return w.lastHeight
+}
+
+// Append writes the entry of a block and syncs it to the disk. The blocks already in the log, replayed by the node
+// after a restart, are ignored
+func (w *Writer) Append(entry *types.BlockLogEntry) error {
+	if entry.Height <= w.lastHeight {
+		return nil
+	}
+
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if w.file == nil || entry.Height >= w.segmentStart+w.segmentBlocks {
+		if err := w.rotate(entry.Height); err != nil {
+			return err
+		}
+	}
+	if _, err := w.file.Write(append(bz, '\n')); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.lastHeight = entry.Height
+	return nil
+}
+
+func (w *Writer) rotate(startHeight int64) error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	file, err := os.OpenFile(filepath.Join(w.dir, segmentName(startHeight)), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	w.file, w.segmentStart = file, startHeight
+	return nil
+}
+
+// Close closes the current segment file
+func (w *Writer) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
@@ -9,6 +9,7 @@ type Cache struct {
 	// persist in memory
 	LatestTicker map[string]*types.Ticker
 	ProductsBuf  []string
+	TickerWindow *TickerWindow
 }
 
 func NewCache() *Cache {
@@ -16,6 +17,7 @@ func NewCache() *Cache {
 		Transactions: make([]*types.Transaction, 0, 2000),
 		LatestTicker: make(map[string]*types.Ticker),
 		ProductsBuf:  make([]string, 0, 200),
+		TickerWindow: NewTickerWindow(),
 	}
 }
 
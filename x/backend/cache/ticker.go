@@ -0,0 +1,259 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/backend/types"
+)
+
+const tickerBucketSeconds = 60
+
+// TickerBucket is the trades of a product in one minute
+type TickerBucket struct {
+	Minute int64   `json:"minute"` // the start timestamp of the minute
+	Open   sdk.Dec `json:"open"`
+	Close  sdk.Dec `json:"close"`
+	High   sdk.Dec `json:"high"`
+	Low    sdk.Dec `json:"low"`
+	Volume sdk.Dec `json:"volume"`
+}
+
+// ProductTicker is the minute buckets of a product in the last 24 hours
+type ProductTicker struct {
+	Buckets []TickerBucket `json:"buckets"` // sorted by minute ascending
+	// LastPrice is the close of the latest trade, which is kept after its bucket leaves the window
+	LastPrice sdk.Dec `json:"last_price"`
+
+	// the aggregation of the buckets, updated along with them until a bucket holding the high or the low leaves
+	computed          bool
+	high, low, volume sdk.Dec
+}
+
+func (p *ProductTicker) compute() {
+	p.volume = sdk.ZeroDec()
+	for i, b := range p.Buckets {
+		if i == 0 || b.High.GT(p.high) {
+			p.high = b.High
+		}
+		if i == 0 || b.Low.LT(p.low) {
+			p.low = b.Low
+		}
+		p.volume = p.volume.Add(b.Volume)
+	}
+	p.computed = true
+}
+
+// TickerWindow maintains the rolling 24h tickers of the products incrementally from per minute buckets, so that
+// every block only touches the products matched in it and the buckets leaving the window
+type TickerWindow struct {
+	BlockHeight int64                     `json:"block_height"` // the latest block applied
+	Timestamp   int64                     `json:"timestamp"`    // the end of the window
+	Products    map[string]*ProductTicker `json:"products"`
+
+	savedAt int64
+}
+
+// NewTickerWindow creates an empty TickerWindow
+func NewTickerWindow() *TickerWindow {
+	return &TickerWindow{Products: make(map[string]*ProductTicker)}
+}
+
+// windowStart returns the minute of the oldest bucket in the window ending at timestamp
+func windowStart(timestamp int64) int64 {
+	return (timestamp-types.SecondsInADay)/tickerBucketSeconds*tickerBucketSeconds + tickerBucketSeconds
+}
+
+// Add adds the trades of a product summed up in a kline at timestamp to the window,
+// trades of the same minute must be added in time order
+func (w *TickerWindow) Add(product string, timestamp int64, open, close, high, low, volume sdk.Dec) {
+	minute := timestamp / tickerBucketSeconds * tickerBucketSeconds
+	if minute < windowStart(w.Timestamp) {
+		return
+	}
+	p := w.Products[product]
+	if p == nil {
+		p = &ProductTicker{LastPrice: close}
+		w.Products[product] = p
+	}
+
+	i := sort.Search(len(p.Buckets), func(i int) bool { return p.Buckets[i].Minute >= minute })
+	if i == len(p.Buckets) {
+		p.LastPrice = close
+	}
+	if i < len(p.Buckets) && p.Buckets[i].Minute == minute {
+		b := &p.Buckets[i]
+		if high.GT(b.High) {
+			b.High = high
+		}
+		if low.LT(b.Low) {
+			b.Low = low
+		}
+		b.Close = close
+		b.Volume = b.Volume.Add(volume)
+	} else {
+		p.Buckets = append(p.Buckets, TickerBucket{})
+		copy(p.Buckets[i+1:], p.Buckets[i:])
+		p.Buckets[i] = TickerBucket{Minute: minute, Open: open, Close: close, High: high, Low: low, Volume: volume}
+	}
+
+	if p.computed {
+		if len(p.Buckets) == 1 || high.GT(p.high) {
+			p.high = high
+		}
+		if len(p.Buckets) == 1 || low.LT(p.low) {
+			p.low = low
+		}
+		p.volume = p.volume.Add(volume)
+	}
+}
+
+// AddTrade adds a trade of a product at timestamp to the window
+func (w *TickerWindow) AddTrade(product string, timestamp int64, price, quantity sdk.Dec) {
+	w.Add(product, timestamp, price, price, price, price, quantity)
+}
+
+// SetLastPrice sets the price of a product without trades in the window
+func (w *TickerWindow) SetLastPrice(product string, price sdk.Dec) {
+	if p := w.Products[product]; p != nil {
+		p.LastPrice = price
+		return
+	}
+	w.Products[product] = &ProductTicker{LastPrice: price}
+}
+
+// Advance slides the window to end at timestamp and returns the products whose buckets left the window
+func (w *TickerWindow) Advance(timestamp int64) []string {
+	if timestamp <= w.Timestamp {
+		return nil
+	}
+	w.Timestamp = timestamp
+	start := windowStart(timestamp)
+
+	var changed []string
+	for product, p := range w.Products {
+		expired := 0
+		for expired < len(p.Buckets) && p.Buckets[expired].Minute < start {
+			b := p.Buckets[expired]
+			if p.computed {
+				p.volume = p.volume.Sub(b.Volume)
+				p.computed = b.High.LT(p.high) && b.Low.GT(p.low)
+			}
+			expired++
+		}
+		if expired > 0 {
+			p.Buckets = p.Buckets[expired:]
+			changed = append(changed, product)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Ticker returns the 24h ticker of a product, nil if the product was never traded
+func (w *TickerWindow) Ticker(product string) *types.Ticker {
+	p := w.Products[product]
+	if p == nil {
+		return nil
+	}
+
+	t := &types.Ticker{Symbol: product, Product: product, Timestamp: w.Timestamp}
+	if len(p.Buckets) == 0 {
+		// no trades in the last 24 hours
+		price := p.LastPrice.String()
+		t.Open, t.Close, t.High, t.Low, t.Price = price, price, price, price, price
+		t.Volume = sdk.ZeroDec().String()
+		t.Change = sdk.ZeroDec().String()
+		t.ChangePercentage = "0.00%"
+		return t
+	}
+
+	if !p.computed {
+		p.compute()
+	}
+	open, close := p.Buckets[0].Open, p.Buckets[len(p.Buckets)-1].Close
+	change := close.Sub(open)
+	changePercentage := 0.0
+	if !open.IsZero() {
+		changePercentage, _ = strconv.ParseFloat(change.MulInt64(100).Quo(open).String(), 64)
+	}
+	t.Open = open.String()
+	t.Close = close.String()
+	t.High = p.high.String()
+	t.Low = p.low.String()
+	t.Price = t.Close
+	t.Volume = p.volume.String()
+	t.Change = change.String()
+	t.ChangePercentage = fmt.Sprintf("%.2f", changePercentage) + "%"
+	return t
+}
+
+// Save writes the window into the file at path, replacing it at once
+func (w *TickerWindow) Save(path string) error {
+	bytes, err := w.Marshal()
+	if err != nil {
+		return err
+	}
+	return WriteTickerWindow(path, bytes)
+}
+
+// Marshal encodes the window to be written by WriteTickerWindow, the window counts as saved from then on
+func (w *TickerWindow) Marshal() ([]byte, error) {
+	bytes, err := json.Marshal(w)
+	if err != nil {
+		return nil, err
+	}
+	w.savedAt = w.Timestamp
+	return bytes, nil
+}
+
+// WriteTickerWindow writes an encoded window into the file at path, replacing it at once. The writes run through
+// their own temporary files, so that they can run concurrently
+func WriteTickerWindow(path string, bytes []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(bytes)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// SavedAt returns the end of the window when it was saved or loaded last
+func (w *TickerWindow) SavedAt() int64 {
+	return w.savedAt
+}
+
+// LoadTickerWindow reads the window saved in the file at path
+func LoadTickerWindow(path string) (*TickerWindow, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	w := NewTickerWindow()
+	if err := json.Unmarshal(bytes, w); err != nil {
+		return nil, err
+	}
+	if w.Products == nil {
+		w.Products = make(map[string]*ProductTicker)
+	}
+	w.savedAt = w.Timestamp
+	return w, nil
+}
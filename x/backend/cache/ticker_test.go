@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTickerWindow(t *testing.T) {
+	product := types.TestTokenPair
+	start := int64(1560181200) // 2019-06-10 15:40:00
+	w := NewTickerWindow()
+	require.Nil(t, w.Ticker(product))
+
+	w.AddTrade(product, start, sdk.NewDec(10), sdk.NewDec(1))
+	w.AddTrade(product, start+30, sdk.NewDec(12), sdk.NewDec(2))
+	w.AddTrade(product, start+60, sdk.NewDec(8), sdk.NewDec(3))
+	require.Nil(t, w.Advance(start+60))
+	require.Equal(t, 2, len(w.Products[product].Buckets))
+
+	ticker := w.Ticker(product)
+	require.Equal(t, "10.00000000", ticker.Open)
+	require.Equal(t, "8.00000000", ticker.Close)
+	require.Equal(t, "12.00000000", ticker.High)
+	require.Equal(t, "8.00000000", ticker.Low)
+	require.Equal(t, "6.00000000", ticker.Volume)
+	require.Equal(t, "-2.00000000", ticker.Change)
+	require.Equal(t, "-20.00%", ticker.ChangePercentage)
+
+	// the first minute holding the high leaves the window
+	changed := w.Advance(start + types.SecondsInADay)
+	require.Equal(t, []string{product}, changed)
+	ticker = w.Ticker(product)
+	require.Equal(t, "8.00000000", ticker.Open)
+	require.Equal(t, "8.00000000", ticker.High)
+	require.Equal(t, "3.00000000", ticker.Volume)
+	require.Equal(t, start+types.SecondsInADay, ticker.Timestamp)
+
+	// trades out of the window are ignored
+	w.AddTrade(product, start, sdk.NewDec(100), sdk.NewDec(1))
+	require.Equal(t, "8.00000000", w.Ticker(product).High)
+
+	// no trades in the last 24 hours
+	require.Equal(t, []string{product}, w.Advance(start+types.SecondsInADay+60))
+	ticker = w.Ticker(product)
+	require.Equal(t, "8.00000000", ticker.Open)
+	require.Equal(t, "8.00000000", ticker.Price)
+	require.Equal(t, "0.00000000", ticker.Volume)
+	require.Equal(t, "0.00%", ticker.ChangePercentage)
+
+	w.SetLastPrice("btc_"+common.NativeToken, sdk.NewDec(5))
+	require.Equal(t, "5.00000000", w.Ticker("btc_"+common.NativeToken).Close)
+}
+
+func TestTickerWindow_SaveLoad(t *testing.T) {
+	product := types.TestTokenPair
+	dir, err := ioutil.TempDir("", "tickers")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "data", "tickers.json")
+
+	_, err = LoadTickerWindow(path)
+	require.True(t, os.IsNotExist(err))
+
+	w := NewTickerWindow()
+	w.Add(product, 1560181200, sdk.NewDec(10), sdk.NewDec(11), sdk.NewDec(15), sdk.NewDec(9), sdk.NewDec(4))
+	w.Advance(1560181260)
+	w.BlockHeight = 100
+	require.Nil(t, w.Save(path))
+	require.Equal(t, int64(1560181260), w.SavedAt())
+
+	loaded, err := LoadTickerWindow(path)
+	require.Nil(t, err)
+	require.Equal(t, int64(100), loaded.BlockHeight)
+	require.Equal(t, w.SavedAt(), loaded.SavedAt())
+	require.Equal(t, w.Ticker(product), loaded.Ticker(product))
+
+	// an encoded window is written later, without temporary files left behind
+	w.Advance(1560181320)
+	bytes, err := w.Marshal()
+	require.Nil(t, err)
+	require.Equal(t, int64(1560181320), w.SavedAt())
+	require.Nil(t, WriteTickerWindow(path, bytes))
+	loaded, err = LoadTickerWindow(path)
+	require.Nil(t, err)
+	require.Equal(t, int64(1560181320), loaded.SavedAt())
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(files))
+}
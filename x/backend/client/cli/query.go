@@ -2,15 +2,18 @@ package cli
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/common"
 	orderTypes "github.com/okex/okchain/x/order/types"
 	"github.com/spf13/cobra"
 	"github.com/tendermint/tendermint/crypto/tmhash"
@@ -32,6 +35,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdCandles(queryRoute, cdc),
 		GetCmdTickers(queryRoute, cdc),
 		GetCmdTxList(queryRoute, cdc),
+		GetCmdPnL(queryRoute, cdc),
+		GetCmdDepthSnapshot(queryRoute, cdc),
 		GetBlockTxHashesCommand(queryRoute, cdc),
 	)
 
@@ -354,6 +359,119 @@ func GetCmdTxList(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdPnL queries the cost basis and pnl report of an address
+func GetCmdPnL(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pnl [addr]",
+		Short: "get the cost basis and realised/unrealised pnl of the products an address dealt on",
+		Long: `Get the cost basis and realised/unrealised pnl of the products an address dealt on.
+The cost basis is calculated by fifo or weighted-average (avg) method, and the unrealised pnl is
+valued at the latest price. The base tokens transferred in and out change the positions, and the
+order fees are deducted from the realised pnl in the totals. With --csv, the report is exported into the csv file instead of printed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			flags := cmd.Flags()
+			product, errProduct := flags.GetString("product")
+			method, errMethod := flags.GetString("method")
+			csvFile, errCSV := flags.GetString("csv")
+
+			mError := types.NewErrorsMerged(errProduct, errMethod, errCSV)
+			if mError != nil {
+				return mError
+			}
+
+			params := types.NewQueryPnLParams(args[0], product, method)
+			if err := types.ValidatePnLMethod(params.Method); err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPnLV2), bz)
+			if err != nil {
+				fmt.Printf("failed to get pnl report of %s :%v\n", args[0], err)
+				return nil
+			}
+			if csvFile == "" {
+				fmt.Println(string(res))
+				return nil
+			}
+
+			var report types.PnLReport
+			if err := common.JSONUnmarshalV2(res, &report); err != nil {
+				return err
+			}
+			if err := writePnLCSV(csvFile, report); err != nil {
+				return err
+			}
+			fmt.Printf("exported pnl of %d products of %s into %s\n", len(report.Products), args[0], csvFile)
+			return nil
+		},
+	}
+	cmd.Flags().StringP("product", "p", "", "filter pnl by product")
+	cmd.Flags().StringP("method", "", types.PnLMethodFIFO, "cost basis method, support fifo|avg")
+	cmd.Flags().String("csv", "", "export the report into the csv file")
+	return cmd
+}
+
+func writePnLCSV(file string, report types.PnLReport) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(report.CSVRecords()); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// GetCmdDepthSnapshot queries the book of a product at a past height or time
+func GetCmdDepthSnapshot(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "depth-snapshot [product]",
+		Short: "get the book of a product at a past height or time",
+		Long: `Get the book of a product stored by the latest depth snapshot taken at or before the height and the
+timestamp, or the latest one if neither is set. The depth snapshots are only stored by the nodes with
+depth_snapshot_interval set in the backend section of okchaind.toml.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			flags := cmd.Flags()
+			height, errHeight := flags.GetInt64("height")
+			timestamp, errTS := flags.GetInt64("timestamp")
+
+			mError := types.NewErrorsMerged(errHeight, errTS)
+			if mError != nil {
+				return mError
+			}
+
+			params := types.QueryDepthSnapshotParams{Product: args[0], Height: height, Timestamp: timestamp}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDepthSnapshotV2), bz)
+			if err != nil {
+				fmt.Printf("failed to get depth snapshot of %s :%v\n", args[0], err)
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().Int64P("height", "", 0, "get the book at the block height")
+	cmd.Flags().Int64P("timestamp", "", 0, "get the book at the unix timestamp")
+	return cmd
+}
+
 //GetBlockTxHashesCommand returns the tx hashes in the block of the given height
 func GetBlockTxHashesCommand(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// GenerateClient generates the go source of package pkg with a typed client of the GET operations of the spec,
+// a struct for every schema of the components and a method for every operation
+func GenerateClient(spec *Spec, pkg, generator string) ([]byte, error) {
+	g := &clientGenerator{spec: spec}
+	var names []string
+	for name := range spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := g.generateStruct(name, spec.Components.Schemas[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	errorType, err := g.errorType()
+	if err != nil {
+		return nil, err
+	}
+	g.printf(clientRuntime, errorType)
+
+	for _, entry := range spec.Operations() {
+		if entry.Method != "GET" {
+			return nil, fmt.Errorf("%s: unsupported method %s", entry.Operation.OperationID, entry.Method)
+		}
+		if err := g.generateOperation(entry.Path, entry.Operation); err != nil {
+			return nil, err
+		}
+	}
+
+	imports := []string{"bytes", "context", "encoding/json", "fmt", "io/ioutil", "net/http", "net/url", "strings"}
+	if strings.Contains(g.buf.String(), "strconv.") {
+		imports = append(imports, "strconv")
+		sort.Strings(imports)
+	}
+	var header strings.Builder
+	fmt.Fprintf(&header, "// Code generated by %s. DO NOT EDIT.\n\n", generator)
+	fmt.Fprintf(&header, "// Package %s is a typed client of %s %s, generated from its OpenAPI specification.\n", pkg, spec.Info.Title, spec.Info.Version)
+	fmt.Fprintf(&header, "package %s\n\nimport (\n", pkg)
+	for _, imp := range imports {
+		fmt.Fprintf(&header, "%q\n", imp)
+	}
+	header.WriteString(")\n\n")
+	return format.Source([]byte(header.String() + g.buf.String()))
+}
+
+const clientRuntime = `// Client is a client of the API
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client of the API served at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: http.DefaultClient}
+}
+
+// APIError is a response with an error status
+type APIError struct {
+	StatusCode int
+	Body       %[1]s
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status %%d: %%+v", e.StatusCode, e.Body)
+}
+
+// get decodes the json body of the response into result, which is left untouched if the body is empty
+func (c *Client) get(ctx context.Context, path string, query url.Values, result interface{}) error {
+	u := strings.TrimRight(c.BaseURL, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(body, &apiErr.Body)
+		return apiErr
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, result)
+}
+
+`
+
+type clientGenerator struct {
+	spec *Spec
+	buf  strings.Builder
+}
+
+func (g *clientGenerator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *clientGenerator) generateStruct(name string, schema *Schema) error {
+	if schema.Type != "object" {
+		return fmt.Errorf("schema %s is not an object", name)
+	}
+	var properties []string
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	g.printf("// %s is the %s schema of the API\ntype %s struct {\n", name, name, name)
+	for _, property := range properties {
+		typ, err := g.goType(schema.Properties[property])
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", name, property, err.Error())
+		}
+		g.printf("%s %s `json:\"%s\"`\n", goName(property), typ, property)
+	}
+	g.printf("}\n\n")
+	return nil
+}
+
+// errorType returns the type of the error responses, which must share a schema
+func (g *clientGenerator) errorType() (string, error) {
+	errorType := ""
+	for _, entry := range g.spec.Operations() {
+		for status, response := range entry.Operation.Responses {
+			media := response.Content[ContentTypeJSON]
+			if status == "200" || media == nil || media.Schema == nil {
+				continue
+			}
+			typ, err := g.goType(media.Schema)
+			if err != nil {
+				return "", err
+			}
+			if errorType != "" && errorType != typ {
+				return "", fmt.Errorf("error responses of both %s and %s", errorType, typ)
+			}
+			errorType = typ
+		}
+	}
+	if errorType == "" {
+		return "json.RawMessage", nil
+	}
+	return errorType, nil
+}
+
+func (g *clientGenerator) generateOperation(path string, op *Operation) error {
+	name := goName(op.OperationID)
+	result := "json.RawMessage"
+	if media := op.Responses["200"].Content[ContentTypeJSON]; media != nil && media.Schema != nil {
+		typ, err := g.goType(media.Schema)
+		if err != nil {
+			return fmt.Errorf("%s: %s", op.OperationID, err.Error())
+		}
+		// an object is nil if there's no data
+		if media.Schema.Ref != "" && !strings.HasPrefix(typ, "*") {
+			typ = "*" + typ
+		}
+		result = typ
+	}
+
+	var args, queries []string
+	var query []*Parameter
+	urlPath := "\"" + path + "\""
+	for _, p := range op.Parameters {
+		switch {
+		case p.ClientOmit:
+		case p.In == InPath:
+			arg := lowerFirst(goName(p.Name))
+			args = append(args, arg+" string")
+			urlPath = strings.Replace(urlPath, "{"+p.Name+"}", "\" + url.PathEscape("+arg+") + \"", 1)
+		case p.In == InQuery:
+			query = append(query, p)
+		}
+	}
+	urlPath = strings.Replace(urlPath, " + \"\"", "", -1)
+
+	paramsType := name + "Params"
+	if len(query) > 0 {
+		g.printf("// %s is the query parameters of %s\ntype %s struct {\n", paramsType, name, paramsType)
+		for _, p := range query {
+			typ, err := g.goType(p.Schema)
+			if err != nil {
+				return fmt.Errorf("%s: %s", op.OperationID, err.Error())
+			}
+			if p.Description != "" {
+				g.printf("// %s\n", p.Description)
+			}
+			g.printf("%s %s\n", goName(p.Name), typ)
+
+			value, zero := "params."+goName(p.Name), "\"\""
+			if typ == "int64" {
+				value, zero = "strconv.FormatInt("+value+", 10)", "0"
+			}
+			set := fmt.Sprintf("query.Set(%q, %s)\n", p.Name, value)
+			if !p.Required {
+				set = fmt.Sprintf("if params.%s != %s {\n%s}\n", goName(p.Name), zero, set)
+			}
+			queries = append(queries, set)
+		}
+		g.printf("}\n\n")
+		args = append(args, "params "+paramsType)
+	}
+
+	summary := op.Summary
+	if summary == "" {
+		summary = "calls " + op.OperationID
+	}
+	g.printf("// %s %s\n", name, lowerFirst(summary))
+	g.printf("func (c *Client) %s(%s) (%s, error) {\n", name, strings.Join(append([]string{"ctx context.Context"}, args...), ", "), result)
+	queryArg := "nil"
+	if len(queries) > 0 {
+		g.printf("query := url.Values{}\n%s", strings.Join(queries, ""))
+		queryArg = "query"
+	}
+	g.printf("var result %s\nerr := c.get(ctx, %s, %s, &result)\nreturn result, err\n}\n\n", result, urlPath, queryArg)
+	return nil
+}
+
+// goType returns the go type of the values of the schema, objects are referred to by pointers if they're nullable
+func (g *clientGenerator) goType(schema *Schema) (string, error) {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, refPrefix)
+		if _, err := g.spec.Resolve(schema); err != nil {
+			return "", err
+		}
+		if schema.Nullable {
+			return "*" + name, nil
+		}
+		return name, nil
+	}
+	switch schema.Type {
+	case "string":
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32", nil
+		}
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "array":
+		item, err := g.goType(schema.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	}
+	return "", fmt.Errorf("unsupported schema type %q", schema.Type)
+}
+
+// goName converts a json name like instrument_id to a go name like InstrumentId
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
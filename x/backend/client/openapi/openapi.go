@@ -0,0 +1,316 @@
+// Package openapi builds OpenAPI 3 specifications of REST routes from the go types of their responses,
+// validates responses against them and generates typed go clients of them.
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Version is the OpenAPI version of the specifications built
+const Version = "3.0.3"
+
+// Spec is an OpenAPI 3 document, limited to what the REST routes use
+type Spec struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info is the metadata of the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is the url the paths are relative to
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem is the operations of a path
+type PathItem struct {
+	Get  *Operation `json:"get,omitempty"`
+	Post *Operation `json:"post,omitempty"`
+}
+
+// Operation is an operation on a path
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or a query parameter of an operation
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+	// ClientOmit leaves the parameter out of the generated clients, e.g. it switches the response off json
+	ClientOmit bool `json:"x-client-omit,omitempty"`
+}
+
+// Response is a response of an operation
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the body of a response in a content type
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components holds the schemas referred to by the operations
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a json schema of a value
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+// the parameter locations and the content types
+const (
+	InPath  = "path"
+	InQuery = "query"
+
+	ContentTypeJSON = "application/json"
+	ContentTypeCSV  = "text/csv"
+
+	refPrefix = "#/components/schemas/"
+)
+
+// Param describes a parameter of a Route
+type Param struct {
+	Name        string
+	In          string
+	Description string
+	Type        string // string by default, or integer
+	Enum        []string
+	Required    bool
+	ClientOmit  bool
+}
+
+// Route describes a REST route by its parameters and the go value its handler writes into the body
+type Route struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Params      []Param
+	// Result is a value of the type of the response body, encoded with the struct tag TagKey
+	Result interface{}
+	TagKey string
+	// ContentTypes are the other content types of the successful response besides json
+	ContentTypes []string
+	// Errors are the status codes of the error responses, which are described by the error schema
+	Errors []int
+}
+
+// Builder builds a Spec from Routes
+type Builder struct {
+	spec        *Spec
+	errorSchema *Schema
+	tagKeys     map[string]string
+	tag         string
+}
+
+// NewBuilder creates a Builder of the spec of the API. The operations are tagged with tag and their errors are
+// described by the schema of errorResult encoded by json.
+func NewBuilder(info Info, serverURL, tag string, errorResult interface{}) *Builder {
+	b := &Builder{
+		spec: &Spec{
+			OpenAPI:    Version,
+			Info:       info,
+			Paths:      make(map[string]*PathItem),
+			Components: Components{Schemas: make(map[string]*Schema)},
+		},
+		tagKeys: make(map[string]string),
+		tag:     tag,
+	}
+	if serverURL != "" {
+		b.spec.Servers = []Server{{URL: serverURL}}
+	}
+	b.errorSchema = b.schemaOf(reflect.TypeOf(errorResult), "json")
+	return b
+}
+
+// Add adds the operation of the route to the spec, it panics if the route or its result is malformed
+func (b *Builder) Add(route Route) *Builder {
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Tags:        []string{b.tag},
+		Responses:   make(map[string]*Response),
+	}
+	for _, p := range route.Params {
+		schema := &Schema{Type: "string", Enum: p.Enum}
+		if p.Type != "" {
+			schema.Type = p.Type
+		}
+		if schema.Type == "integer" {
+			schema.Format = "int64"
+		}
+		op.Parameters = append(op.Parameters, &Parameter{Name: p.Name, In: p.In, Description: p.Description,
+			Required: p.Required || p.In == InPath, Schema: schema, ClientOmit: p.ClientOmit})
+	}
+
+	success := &Response{Description: "successful response", Content: make(map[string]*MediaType)}
+	if route.Result != nil {
+		success.Content[ContentTypeJSON] = &MediaType{Schema: b.schemaOf(reflect.TypeOf(route.Result), route.TagKey)}
+	}
+	for _, contentType := range route.ContentTypes {
+		success.Content[contentType] = &MediaType{Schema: &Schema{Type: "string"}}
+	}
+	op.Responses["200"] = success
+	for _, status := range route.Errors {
+		op.Responses[fmt.Sprint(status)] = &Response{Description: "error response",
+			Content: map[string]*MediaType{ContentTypeJSON: {Schema: b.errorSchema}}}
+	}
+
+	item := b.spec.Paths[route.Path]
+	if item == nil {
+		item = &PathItem{}
+		b.spec.Paths[route.Path] = item
+	}
+	switch route.Method {
+	case "GET":
+		item.Get = op
+	case "POST":
+		item.Post = op
+	default:
+		panic(fmt.Sprintf("unsupported method %s of %s", route.Method, route.Path))
+	}
+	return b
+}
+
+// Spec returns the spec built
+func (b *Builder) Spec() *Spec {
+	return b.spec
+}
+
+// schemaOf returns the schema of values of type t encoded with the struct tag tagKey, structs are added into
+// the components and referred to by their type names
+func (b *Builder) schemaOf(t reflect.Type, tagKey string) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := b.schemaOf(t.Elem(), tagKey)
+		schema.Nullable = true
+		return schema
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaOf(t.Elem(), tagKey), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Struct:
+		name := t.Name()
+		if key, ok := b.tagKeys[name]; ok {
+			if key != tagKey {
+				panic(fmt.Sprintf("%s is encoded with both %s and %s tags", name, key, tagKey))
+			}
+			return &Schema{Ref: refPrefix + name}
+		}
+		b.tagKeys[name] = tagKey
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: new(bool)}
+		b.spec.Components.Schemas[name] = schema
+		b.addProperties(schema, t, tagKey)
+		sort.Strings(schema.Required)
+		return &Schema{Ref: refPrefix + name}
+	default:
+		panic(fmt.Sprintf("unsupported type %s", t))
+	}
+}
+
+// addProperties adds the fields of t to the schema the same way as the encoders do: untagged fields are named
+// by their go names and the fields of untagged embedded structs are promoted
+func (b *Builder) addProperties(schema *Schema, t reflect.Type, tagKey string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(tagKey)
+		// the fields of unexported embedded structs are still promoted
+		if (field.PkgPath != "" && !field.Anonymous) || tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			b.addProperties(schema, field.Type, tagKey)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = b.schemaOf(field.Type, tagKey)
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// Operations returns the operations of the spec sorted by their ids, with their methods and paths
+func (s *Spec) Operations() []OperationEntry {
+	var entries []OperationEntry
+	for path, item := range s.Paths {
+		if item.Get != nil {
+			entries = append(entries, OperationEntry{Method: "GET", Path: path, Operation: item.Get})
+		}
+		if item.Post != nil {
+			entries = append(entries, OperationEntry{Method: "POST", Path: path, Operation: item.Post})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Operation.OperationID < entries[j].Operation.OperationID
+	})
+	return entries
+}
+
+// OperationEntry is an operation of a spec with its method and path
+type OperationEntry struct {
+	Method    string
+	Path      string
+	Operation *Operation
+}
+
+// Resolve returns the schema referred to by schema, or schema itself if it's not a reference
+func (s *Spec) Resolve(schema *Schema) (*Schema, error) {
+	if schema.Ref == "" {
+		return schema, nil
+	}
+	resolved, ok := s.Components.Schemas[strings.TrimPrefix(schema.Ref, refPrefix)]
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %s", schema.Ref)
+	}
+	return resolved, nil
+}
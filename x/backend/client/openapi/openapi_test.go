@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+type testBase struct {
+	ID string `v2:"id"`
+}
+
+type testItem struct {
+	testBase
+	Name    string   `v2:"name"`
+	Price   float64  `v2:"price,omitempty"`
+	Count   int32    `v2:"count"`
+	Tags    []string `v2:"tags"`
+	Next    *testItem
+	Skipped string `v2:"-"`
+	hidden  string
+}
+
+func testSpec() *Spec {
+	b := NewBuilder(Info{Title: "test", Version: "v1"}, "/api", "test", testError{})
+	b.Add(Route{Method: "GET", Path: "/items", OperationID: "getItems", Summary: "Returns the items",
+		Params: []Param{{Name: "limit", In: InQuery, Type: "integer", Required: true},
+			{Name: "side", In: InQuery, Enum: []string{"BUY", "SELL"}},
+			{Name: "format", In: InQuery, ClientOmit: true}},
+		Result: []testItem{}, TagKey: "v2", ContentTypes: []string{ContentTypeCSV}, Errors: []int{http.StatusBadRequest}})
+	b.Add(Route{Method: "GET", Path: "/items/{item_id}", OperationID: "getItem",
+		Params: []Param{{Name: "item_id", In: InPath}},
+		Result: testItem{}, TagKey: "v2"})
+	return b.Spec()
+}
+
+func TestBuilder(t *testing.T) {
+	spec := testSpec()
+	require.Equal(t, Version, spec.OpenAPI)
+	require.Equal(t, "/api", spec.Servers[0].URL)
+
+	item := spec.Components.Schemas["testItem"]
+	require.NotNil(t, item)
+	require.Equal(t, []string{"Next", "count", "id", "name", "tags"}, item.Required)
+	require.Equal(t, 6, len(item.Properties))
+	require.Equal(t, "int32", item.Properties["count"].Format)
+	require.True(t, item.Properties["tags"].Nullable)
+	require.True(t, item.Properties["Next"].Nullable)
+	require.Equal(t, refPrefix+"testItem", item.Properties["Next"].Ref)
+	require.False(t, *item.AdditionalProperties)
+	require.NotNil(t, spec.Components.Schemas["testError"])
+
+	ops := spec.Operations()
+	require.Equal(t, 2, len(ops))
+	require.Equal(t, "getItem", ops[0].Operation.OperationID)
+	require.True(t, ops[0].Operation.Parameters[0].Required)
+	require.Equal(t, "/items", ops[1].Path)
+	require.NotNil(t, ops[1].Operation.Responses["200"].Content[ContentTypeCSV])
+	require.NotNil(t, ops[1].Operation.Responses["400"])
+
+	// a type can't be encoded with two tags
+	require.Panics(t, func() {
+		NewBuilder(Info{}, "", "test", testError{}).Add(Route{Method: "GET", Path: "/e", Result: testItem{}, TagKey: "json"}).
+			Add(Route{Method: "GET", Path: "/f", Result: testItem{}, TagKey: "v2"})
+	})
+}
+
+func TestSpec_Validate(t *testing.T) {
+	spec := testSpec()
+	op := spec.Paths["/items"].Get
+
+	require.Nil(t, spec.Validate(op, http.StatusOK, []byte(`null`)))
+	require.Nil(t, spec.Validate(op, http.StatusOK, nil))
+	require.NotNil(t, spec.Validate(spec.Paths["/items/{item_id}"].Get, http.StatusOK, nil))
+	require.Nil(t, spec.Validate(op, http.StatusOK,
+		[]byte(`[{"id":"1","name":"a","count":2,"tags":null,"Next":{"id":"2","name":"b","price":1.5,"count":0,"tags":["x"],"Next":null}}]`)))
+	require.Nil(t, spec.Validate(op, http.StatusBadRequest, []byte(`{"code":1,"msg":"bad"}`)))
+
+	for body, msg := range map[string]string{
+		`{}`: "is not an array",
+		`[{"id":"1","name":"a","count":2,"tags":null}]`:                         "$[0].Next is missing",
+		`[{"id":"1","name":"a","count":2.5,"tags":null,"Next":null}]`:           "$[0].count is not an integer",
+		`[{"id":1,"name":"a","count":2,"tags":null,"Next":null}]`:               "$[0].id is not a string",
+		`[{"id":"1","name":"a","count":2,"tags":null,"Next":null,"x":1}]`:       "$[0].x is not documented",
+		`[{"id":"1","name":null,"count":2,"tags":null,"Next":null}]`:            "$[0].name is null",
+		`[{"id":"1","name":"a","count":2,"tags":[1],"Next":null}]`:              "$[0].tags[0] is not a string",
+		`[{"id":"1","name":"a","count":2,"tags":null,"Next":null,"price":"1"}]`: "$[0].price is not a number",
+	} {
+		err := spec.Validate(op, http.StatusOK, []byte(body))
+		require.NotNil(t, err, body)
+		require.Contains(t, err.Error(), msg)
+	}
+
+	require.NotNil(t, spec.Validate(op, http.StatusInternalServerError, []byte(`{}`)))
+	require.NotNil(t, spec.Validate(op, http.StatusOK, []byte(`[`)))
+
+	enum := &Schema{Type: "string", Enum: []string{"BUY", "SELL"}}
+	require.Nil(t, spec.validate(enum, "BUY", "$"))
+	require.NotNil(t, spec.validate(enum, "buy", "$"))
+}
+
+func TestGenerateClient(t *testing.T) {
+	src, err := GenerateClient(testSpec(), "testclient", "openapi_test")
+	require.Nil(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "client.go", src, 0)
+	require.Nil(t, err)
+
+	code := string(src)
+	for _, s := range []string{
+		"// Code generated by openapi_test. DO NOT EDIT.",
+		"package testclient",
+		"Body       testError",
+		"Next  *testItem",
+		"func (c *Client) GetItems(ctx context.Context, params GetItemsParams) ([]testItem, error)",
+		"func (c *Client) GetItem(ctx context.Context, itemId string) (*testItem, error)",
+		`"/items/"+url.PathEscape(itemId)`,
+		`query.Set("limit", strconv.FormatInt(params.Limit, 10))`,
+		`if params.Side != "" {`,
+	} {
+		require.True(t, strings.Contains(code, s), s)
+	}
+	require.False(t, strings.Contains(code, "params.Format"))
+}
@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Validate checks the json body of a response of the operation with status against the spec, an empty body is
+// taken as null like the generated clients do
+func (s *Spec) Validate(op *Operation, status int, body []byte) error {
+	response, ok := op.Responses[fmt.Sprint(status)]
+	if !ok {
+		return fmt.Errorf("%s: undocumented status %d", op.OperationID, status)
+	}
+	media, ok := response.Content[ContentTypeJSON]
+	if !ok || media.Schema == nil {
+		return fmt.Errorf("%s: no json body documented for status %d", op.OperationID, status)
+	}
+
+	var value interface{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("%s: invalid json body: %s", op.OperationID, err.Error())
+		}
+	}
+	if err := s.validate(media.Schema, value, "$"); err != nil {
+		return fmt.Errorf("%s: %s", op.OperationID, err.Error())
+	}
+	return nil
+}
+
+func (s *Spec) validate(schema *Schema, value interface{}, path string) error {
+	if value == nil {
+		if schema.Nullable {
+			return nil
+		}
+		return fmt.Errorf("%s is null", path)
+	}
+	schema, err := s.Resolve(schema)
+	if err != nil {
+		return err
+	}
+
+	switch schema.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s is not a string", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s is not a boolean", path)
+		}
+	case "integer":
+		if n, ok := value.(json.Number); !ok || strings.ContainsAny(n.String(), ".eE") {
+			return fmt.Errorf("%s is not an integer", path)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Errorf("%s is not a number", path)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not an array", path)
+		}
+		for i, item := range items {
+			if err := s.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not an object", path)
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s.%s is missing", path, name)
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			if !ok {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					return fmt.Errorf("%s.%s is not documented", path, name)
+				}
+				continue
+			}
+			if err := s.validate(property, object[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		str, _ := value.(string)
+		for _, e := range schema.Enum {
+			if e == str {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s is not one of %v", path, schema.Enum)
+	}
+	return nil
+}
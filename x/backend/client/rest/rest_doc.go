@@ -177,7 +177,8 @@ type TxListParam struct {
 	// Required: true
 	// in: query
 	Address string `json:"address"`
-	// tx type: 1:Transfer, 2:NewOrder, 3:CancelOrder
+	// tx type: 1:Transfer, 2:NewOrder, 3:CancelOrder, 4-14:Token, 20-25:Dex, 30-34:Staking, 40-42:Gov,
+	// 50-51:Distribution, 60-63:Farm, 70-72:AMM swap, 80-82:HTLC, 90:Upgrade, 99:Other
 	// Required: false
 	// in: query
 	Type int `json:"type"`
@@ -408,3 +409,72 @@ type TickerResponse struct {
 	// in: body
 	Data []types.Ticker `json:"data"`
 }
+
+// swagger:route GET /pnl backend getPnL
+//
+// Get the cost basis and realised/unrealised pnl of the products an address dealt on
+//
+//     Schemes: http, https
+//     Produces:
+//     - application/json
+//     - text/csv
+//     Responses:
+//       200: PnLResponse
+
+// swagger:parameters getPnL
+type PnLParam struct {
+	// user address
+	// Required: true
+	// in: query
+	Address string `json:"address"`
+	// token pair string
+	// Required: false
+	// in: query
+	InstrumentId string `json:"instrument_id"`
+	// cost basis method, fifo or avg, default fifo
+	// Required: false
+	// in: query
+	Method string `json:"method"`
+	// response format, json or csv, default json
+	// Required: false
+	// in: query
+	Format string `json:"format"`
+}
+
+// PnL Response
+// swagger:response PnLResponse
+type PnLResponse struct {
+	// in: body
+	Body types.PnLReport
+}
+
+// swagger:route GET /instruments/{instrument_id}/book_snapshot backend getBookSnapshot
+//
+// Get the book of the instrument stored by the latest depth snapshot taken at or before the height and the timestamp
+//
+//     Schemes: http, https
+//     Responses:
+//       200: BookSnapshotResponse
+
+// swagger:parameters getBookSnapshot
+type BookSnapshotParam struct {
+	// instrument or product name
+	// Required: true
+	// in: path
+	InstrumentId string `json:"instrument_id"`
+	// block height, the latest one if it's not set
+	// Required: false
+	// in: query
+	Height int64 `json:"height"`
+	// unix timestamp, the latest one if it's not set
+	// Required: false
+	// in: query
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Book Snapshot Response
+// swagger:response BookSnapshotResponse
+type BookSnapshotResponse struct {
+	// in: body
+	Body types.BookSnapshotV2
+}
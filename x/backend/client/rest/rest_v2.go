@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"encoding/csv"
 	"fmt"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -12,21 +13,12 @@ import (
 	"strings"
 )
 
+// RegisterRoutesV2 registers the routes v2 of the backend along with their OpenAPI specification at SpecV2Path
 func RegisterRoutesV2(cliCtx context.CLIContext, r *mux.Router) {
-	r.HandleFunc("/block_tx_hashes/{blockHeight}", blockTxHashesHandler(cliCtx)).Methods("GET")
-
-	r.HandleFunc("/instruments", instrumentsHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/instruments/ticker", tickerListHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/instruments/{instrument_id}/ticker", tickerHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/orders_pending", orderOpenListHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/orders/list/open", orderOpenListHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/orders/list/closed", orderClosedListHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/orders/{order_id}", orderHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/instruments/{instrument_id}/candles", candleHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/instruments/{instrument_id}matches", matchHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/fees", feesHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/deals", dealsHandlerV2(cliCtx)).Methods("GET")
-	r.HandleFunc("/transactions", txListHandlerV2(cliCtx)).Methods("GET")
+	for _, route := range routesV2() {
+		r.HandleFunc(route.Path, route.handler(cliCtx)).Methods(route.Method)
+	}
+	r.HandleFunc(SpecV2Path, specHandlerV2(cliCtx)).Methods("GET")
 }
 
 func txListHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
@@ -429,3 +421,82 @@ func orderHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
 		common.HandleSuccessResponseV2(w, res)
 	}
 }
+
+func pnlHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		product := r.URL.Query().Get("instrument_id")
+		method := r.URL.Query().Get("method")
+		format := r.URL.Query().Get("format")
+
+		// validate request
+		if address == "" {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorMissingRequiredParam)
+			return
+		}
+		if _, err := sdk.AccAddressFromBech32(address); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidAddress)
+			return
+		}
+		params := types.NewQueryPnLParams(address, product, method)
+		if err := types.ValidatePnLMethod(params.Method); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		if format != "" && format != "json" && format != "csv" {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryPnLV2), req)
+		if err != nil || format != "csv" {
+			common.HandleResponseV2(w, res, err)
+			return
+		}
+
+		var report types.PnLReport
+		if err := common.JSONUnmarshalV2(res, &report); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusInternalServerError, common.ErrorCodecFails)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=pnl_%s.csv", address))
+		if err := csv.NewWriter(w).WriteAll(report.CSVRecords()); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusInternalServerError, common.ErrorServerException)
+		}
+	}
+}
+
+func bookSnapshotHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		product := vars["instrument_id"]
+		height := r.URL.Query().Get("height")
+		timestamp := r.URL.Query().Get("timestamp")
+
+		// validate request
+		if product == "" {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorMissingRequiredParam)
+			return
+		}
+		params := types.QueryDepthSnapshotParams{Product: product}
+		var err error
+		if height != "" {
+			if params.Height, err = strconv.ParseInt(height, 10, 64); err != nil {
+				common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+				return
+			}
+		}
+		if timestamp != "" {
+			if params.Timestamp, err = strconv.ParseInt(timestamp, 10, 64); err != nil {
+				common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+				return
+			}
+		}
+
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryDepthSnapshotV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/okex/okchain/x/backend/client/openapi"
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/token"
+)
+
+// SpecV2Path is the path the OpenAPI specification of the routes v2 is served at
+const SpecV2Path = "/swagger"
+
+// routeV2 is a route v2 with its description in the OpenAPI specification
+type routeV2 struct {
+	openapi.Route
+	handler func(context.CLIContext) http.HandlerFunc
+}
+
+var (
+	paramInstrumentIDPath = openapi.Param{Name: "instrument_id", In: openapi.InPath, Description: "name of the token pair, e.g. xxb_okt"}
+	paramAddress          = openapi.Param{Name: "address", In: openapi.InQuery, Description: "bech32 address of the account", Required: true}
+	paramAfter            = openapi.Param{Name: "after", In: openapi.InQuery, Type: "integer", Description: "unix timestamp the results are after", Required: true}
+	paramBefore           = openapi.Param{Name: "before", In: openapi.InQuery, Type: "integer", Description: "unix timestamp the results are before", Required: true}
+	paramLimit            = openapi.Param{Name: "limit", In: openapi.InQuery, Type: "integer", Description: "max number of the results, 100 by default"}
+	errorsV2              = []int{http.StatusBadRequest, http.StatusInternalServerError}
+)
+
+// orderListParamsV2 are the query parameters of the order list routes
+func orderListParamsV2() []openapi.Param {
+	return []openapi.Param{
+		{Name: "instrument_id", In: openapi.InQuery, Description: "name of the token pair", Required: true},
+		{Name: "address", In: openapi.InQuery, Description: "bech32 address of the sender"},
+		{Name: "side", In: openapi.InQuery, Description: "side of the orders, BUY or SELL"},
+		{Name: "after", In: openapi.InQuery, Type: "integer", Description: "unix timestamp the orders are after"},
+		{Name: "before", In: openapi.InQuery, Type: "integer", Description: "unix timestamp the orders are before"},
+		paramLimit,
+	}
+}
+
+// routesV2 returns the routes v2, which are registered and described in the OpenAPI specification from the same list
+func routesV2() []routeV2 {
+	return []routeV2{
+		{openapi.Route{Method: "GET", Path: "/block_tx_hashes/{blockHeight}", OperationID: "getBlockTxHashes",
+			Summary: "Returns the hashes of the txs in a block, errors are returned with status 200 as the routes v1",
+			Params:  []openapi.Param{{Name: "blockHeight", In: openapi.InPath, Description: "height of the block"}},
+			Result:  []string{}, TagKey: "json"}, blockTxHashesHandler},
+
+		{openapi.Route{Method: "GET", Path: "/instruments", OperationID: "getInstruments",
+			Summary: "Returns all the instruments",
+			Result:  []types.InstrumentV2{}, TagKey: "json", Errors: errorsV2}, instrumentsHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/instruments/ticker", OperationID: "getTickerList",
+			Summary: "Returns the 24h tickers of all the instruments",
+			Result:  []types.TickerListItemV2{}, TagKey: "v2", Errors: errorsV2}, tickerListHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/instruments/{instrument_id}/ticker", OperationID: "getTicker",
+			Summary: "Returns the 24h ticker of an instrument",
+			Params:  []openapi.Param{paramInstrumentIDPath},
+			Result:  types.TickerV2{}, TagKey: "json", Errors: errorsV2}, tickerHandlerV2},
+
+		{openapi.Route{Method: "GET", Path: "/orders_pending", OperationID: "getPendingOrders",
+			Summary: "Returns the open orders of an instrument, the same as getOpenOrders",
+			Params:  orderListParamsV2(),
+			Result:  []types.OrderV2{}, TagKey: "json", Errors: errorsV2}, orderOpenListHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/orders/list/open", OperationID: "getOpenOrders",
+			Summary: "Returns the open orders of an instrument",
+			Params:  orderListParamsV2(),
+			Result:  []types.OrderV2{}, TagKey: "json", Errors: errorsV2}, orderOpenListHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/orders/list/closed", OperationID: "getClosedOrders",
+			Summary: "Returns the closed orders of an instrument",
+			Params:  orderListParamsV2(),
+			Result:  []types.OrderV2{}, TagKey: "json", Errors: errorsV2}, orderClosedListHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/orders/{order_id}", OperationID: "getOrder",
+			Summary: "Returns an order",
+			Params:  []openapi.Param{{Name: "order_id", In: openapi.InPath, Description: "id of the order"}},
+			Result:  types.OrderV2{}, TagKey: "json", Errors: errorsV2}, orderHandlerV2},
+
+		{openapi.Route{Method: "GET", Path: "/instruments/{instrument_id}/candles", OperationID: "getCandles",
+			Summary: "Returns the candles of an instrument as [timestamp, open, high, low, close, volume] arrays",
+			Params: []openapi.Param{paramInstrumentIDPath,
+				{Name: "granularity", In: openapi.InQuery, Type: "integer", Description: "seconds of a candle, 60 by default"},
+				{Name: "size", In: openapi.InQuery, Type: "integer", Description: "number of the candles, 100 by default"}},
+			Result: [][]string{}, TagKey: "json", Errors: errorsV2}, candleHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/instruments/{instrument_id}/matches", OperationID: "getMatches",
+			Summary: "Returns the match results of an instrument",
+			Params:  []openapi.Param{paramInstrumentIDPath, paramAfter, paramBefore, paramLimit},
+			Result:  []types.MatchResult{}, TagKey: "v2", Errors: errorsV2}, matchHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/instruments/{instrument_id}/book_snapshot", OperationID: "getBookSnapshot",
+			Summary: "Returns the latest book snapshot of an instrument at or before a height or a time",
+			Params: []openapi.Param{paramInstrumentIDPath,
+				{Name: "height", In: openapi.InQuery, Type: "integer", Description: "height of the block, the latest if 0"},
+				{Name: "timestamp", In: openapi.InQuery, Type: "integer", Description: "unix timestamp, the latest if 0"}},
+			Result: types.BookSnapshotV2{}, TagKey: "json", Errors: errorsV2}, bookSnapshotHandlerV2},
+
+		{openapi.Route{Method: "GET", Path: "/fees", OperationID: "getFees",
+			Summary: "Returns the fee details of an account",
+			Params:  []openapi.Param{paramAddress, paramAfter, paramBefore, paramLimit},
+			Result:  []token.FeeDetail{}, TagKey: "v2", Errors: errorsV2}, feesHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/deals", OperationID: "getDeals",
+			Summary: "Returns the deals of an account",
+			Params: []openapi.Param{paramAddress,
+				{Name: "instrument_id", In: openapi.InQuery, Description: "name of the token pair, all if empty"},
+				{Name: "side", In: openapi.InQuery, Description: "side of the deals case insensitively, all if empty", Enum: []string{"BUY", "SELL"}},
+				paramAfter, paramBefore, paramLimit},
+			Result: []types.Deal{}, TagKey: "v2", Errors: errorsV2}, dealsHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/transactions", OperationID: "getTransactions",
+			Summary: "Returns the transactions of an account",
+			Params: []openapi.Param{paramAddress,
+				{Name: "type", In: openapi.InQuery, Type: "integer", Description: "type of the transactions, all if 0"},
+				paramAfter, paramBefore, paramLimit},
+			Result: []types.Transaction{}, TagKey: "v2", Errors: errorsV2}, txListHandlerV2},
+		{openapi.Route{Method: "GET", Path: "/pnl", OperationID: "getPnL",
+			Summary: "Returns the cost basis and pnl report of an account",
+			Params: []openapi.Param{paramAddress,
+				{Name: "instrument_id", In: openapi.InQuery, Description: "name of the token pair, all if empty"},
+				{Name: "method", In: openapi.InQuery, Description: "cost basis method, fifo by default",
+					Enum: []string{types.PnLMethodFIFO, types.PnLMethodWeightedAverage}},
+				{Name: "format", In: openapi.InQuery, Description: "format of the report, json by default",
+					Enum: []string{"json", "csv"}, ClientOmit: true}},
+			Result: types.PnLReport{}, TagKey: "v2", ContentTypes: []string{openapi.ContentTypeCSV}, Errors: errorsV2}, pnlHandlerV2},
+	}
+}
+
+// SpecV2 returns the OpenAPI specification of the routes v2
+func SpecV2() *openapi.Spec {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "okchain backend REST API",
+		Description: "The market data and the account history indexed by the backend module",
+		Version:     "v2",
+	}, "/okchain/v2", "backend", common.ResponseErrorV2{})
+	for _, route := range routesV2() {
+		b.Add(route.Route)
+	}
+	return b.Spec()
+}
+
+func specHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	spec, err := json.MarshalIndent(SpecV2(), "", "  ")
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusInternalServerError, common.ErrorCodecFails)
+			return
+		}
+		common.HandleSuccessResponseV2(w, spec)
+	}
+}
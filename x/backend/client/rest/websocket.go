@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/okex/okchain/x/backend/push"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = 30 * time.Second
+	wsMaxMessageSize = 4096
+	wsSendBuffer     = 256
+
+	wsOpSubscribe   = "subscribe"
+	wsOpUnsubscribe = "unsubscribe"
+	wsOpPing        = "ping"
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// wsRequest is a message of a client, e.g. {"op": "subscribe", "args": ["ticker:xxb_okt", "depth:xxb_okt"]}
+type wsRequest struct {
+	Op   string   `json:"op"`
+	Args []string `json:"args"`
+}
+
+// wsEvent answers a wsRequest
+type wsEvent struct {
+	Event   string `json:"event"`
+	Channel string `json:"channel,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RegisterWebSocket registers the WebSocket push API on /ws, it pushes the data the backend of the node publishes
+// to the hub. It is served only by the rest server of okchaind, in the process of the node, which publishes with
+// backend.enable_backend and backend.enable_mkt_compute, the hub rejects the subscriptions otherwise
+func RegisterWebSocket(r *mux.Router, hub *push.Hub) {
+	r.HandleFunc("/ws", webSocketHandler(hub))
+}
+
+func webSocketHandler(hub *push.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		client := push.NewClient(wsSendBuffer)
+		hub.Register(client)
+		go wsWritePump(conn, client)
+		wsReadPump(conn, hub, client)
+	}
+}
+
+// wsReadPump handles the requests of a client until it disconnects or stops answering the pings
+func wsReadPump(conn *websocket.Conn, hub *push.Hub, client *push.Client) {
+	defer hub.Unregister(client)
+
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, bz, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		var req wsRequest
+		if err := json.Unmarshal(bz, &req); err != nil {
+			wsReply(hub, client, wsEvent{Event: "error", Message: "invalid request: " + err.Error()})
+			continue
+		}
+		switch req.Op {
+		case wsOpSubscribe:
+			for _, channel := range req.Args {
+				if err := hub.Subscribe(client, channel); err != nil {
+					wsReply(hub, client, wsEvent{Event: "error", Channel: channel, Message: err.Error()})
+				} else {
+					wsReply(hub, client, wsEvent{Event: wsOpSubscribe, Channel: channel})
+				}
+			}
+		case wsOpUnsubscribe:
+			for _, channel := range req.Args {
+				hub.Unsubscribe(client, channel)
+				wsReply(hub, client, wsEvent{Event: wsOpUnsubscribe, Channel: channel})
+			}
+		case wsOpPing:
+			wsReply(hub, client, wsEvent{Event: "pong"})
+		default:
+			wsReply(hub, client, wsEvent{Event: "error", Message: "unknown op: " + req.Op})
+		}
+	}
+}
+
+func wsReply(hub *push.Hub, client *push.Client, event wsEvent) {
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	hub.Reply(client, bz)
+}
+
+// wsWritePump writes the messages queued for a client and pings it, the connection is closed once the hub
+// unregistered the client
+func wsWritePump(conn *websocket.Conn, client *push.Client) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case bz, ok := <-client.Send:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, bz); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,571 @@
+// Code generated by x/backend/client/v2client/gen. DO NOT EDIT.
+
+// Package v2client is a typed client of okchain backend REST API v2, generated from its OpenAPI specification.
+package v2client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// BookSnapshotV2 is the BookSnapshotV2 schema of the API
+type BookSnapshotV2 struct {
+	Asks         []DepthLevel `json:"asks"`
+	Bids         []DepthLevel `json:"bids"`
+	BlockHeight  int64        `json:"block_height"`
+	InstrumentId string       `json:"instrument_id"`
+	Timestamp    string       `json:"timestamp"`
+}
+
+// Deal is the Deal schema of the API
+type Deal struct {
+	BlockHeight int64  `json:"block_height"`
+	Fee         string `json:"fee"`
+	OrderId     string `json:"order_id"`
+	Price       string `json:"price"`
+	Product     string `json:"product"`
+	Sender      string `json:"sender"`
+	Side        string `json:"side"`
+	Timestamp   int64  `json:"timestamp"`
+	Volume      string `json:"volume"`
+}
+
+// DepthLevel is the DepthLevel schema of the API
+type DepthLevel struct {
+	Price    string `json:"price"`
+	Quantity string `json:"quantity"`
+}
+
+// FeeDetail is the FeeDetail schema of the API
+type FeeDetail struct {
+	Address   string `json:"address"`
+	Fee       string `json:"fee"`
+	FeeType   string `json:"fee_type"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// InstrumentV2 is the InstrumentV2 schema of the API
+type InstrumentV2 struct {
+	BaseCurrency          string        `json:"base_currency"`
+	BaseCurrencyDecimals  int64         `json:"base_currency_decimals"`
+	BaseCurrencyMetadata  TokenMetadata `json:"base_currency_metadata"`
+	InstrumentId          string        `json:"instrument_id"`
+	MinSize               string        `json:"min_size"`
+	QuoteCurrency         string        `json:"quote_currency"`
+	QuoteCurrencyDecimals int64         `json:"quote_currency_decimals"`
+	QuoteCurrencyMetadata TokenMetadata `json:"quote_currency_metadata"`
+	SizeIncrement         string        `json:"size_increment"`
+	TickSize              string        `json:"tick_size"`
+}
+
+// MatchResult is the MatchResult schema of the API
+type MatchResult struct {
+	BlockHeight int64  `json:"block_height"`
+	Price       string `json:"price"`
+	Product     string `json:"product"`
+	Timestamp   int64  `json:"timestamp"`
+	Volume      string `json:"volume"`
+}
+
+// OrderV2 is the OrderV2 schema of the API
+type OrderV2 struct {
+	FilledNotional string `json:"filled_notional"`
+	FilledSize     string `json:"filled_size"`
+	InstrumentId   string `json:"instrument_id"`
+	Notional       string `json:"notional"`
+	OrderId        string `json:"order_id"`
+	OrderType      string `json:"order_type"`
+	Price          string `json:"price"`
+	Side           string `json:"side"`
+	Size           string `json:"size"`
+	State          string `json:"state"`
+	Timestamp      string `json:"timestamp"`
+	Type           string `json:"type"`
+}
+
+// PnLReport is the PnLReport schema of the API
+type PnLReport struct {
+	Address   string       `json:"address"`
+	Method    string       `json:"method"`
+	OrderFees string       `json:"order_fees"`
+	Products  []ProductPnL `json:"products"`
+	Timestamp int64        `json:"timestamp"`
+	Totals    []PnLTotal   `json:"totals"`
+}
+
+// PnLTotal is the PnLTotal schema of the API
+type PnLTotal struct {
+	OrderFees   string `json:"order_fees"`
+	RealisedPnl string `json:"realised_pnl"`
+	Token       string `json:"token"`
+}
+
+// ProductPnL is the ProductPnL schema of the API
+type ProductPnL struct {
+	AvgCost           string `json:"avg_cost"`
+	BoughtQuantity    string `json:"bought_quantity"`
+	CostBasis         string `json:"cost_basis"`
+	DealFees          string `json:"deal_fees"`
+	Position          string `json:"position"`
+	Price             string `json:"price"`
+	Product           string `json:"product"`
+	RealisedPnl       string `json:"realised_pnl"`
+	SoldQuantity      string `json:"sold_quantity"`
+	TransferredIn     string `json:"transferred_in"`
+	TransferredOut    string `json:"transferred_out"`
+	UnmatchedQuantity string `json:"unmatched_quantity"`
+	UnrealisedPnl     string `json:"unrealised_pnl"`
+}
+
+// ResponseErrorV2 is the ResponseErrorV2 schema of the API
+type ResponseErrorV2 struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// TickerListItemV2 is the TickerListItemV2 schema of the API
+type TickerListItemV2 struct {
+	BaseVolume24H  string `json:"BaseVolume24H"`
+	BestAsk        string `json:"BestAsk"`
+	BestBid        string `json:"BestBid"`
+	High24H        string `json:"High24H"`
+	InstrumentId   string `json:"InstrumentId"`
+	Last           string `json:"Last"`
+	Low24H         string `json:"Low24H"`
+	Open24H        string `json:"Open24H"`
+	QuoteVolume24H string `json:"QuoteVolume24H"`
+	Timestamp      string `json:"Timestamp"`
+}
+
+// TickerV2 is the TickerV2 schema of the API
+type TickerV2 struct {
+	BaseVolume24h  string `json:"base_volume_24h"`
+	BestAsk        string `json:"best_ask"`
+	BestBid        string `json:"best_bid"`
+	High24h        string `json:"high_24h"`
+	InstrumentId   string `json:"instrument_id"`
+	Last           string `json:"last"`
+	Low24h         string `json:"low_24h"`
+	Open24h        string `json:"open_24h"`
+	QuoteVolume24h string `json:"quote_volume_24h"`
+	Timestamp      string `json:"timestamp"`
+}
+
+// TokenMetadata is the TokenMetadata schema of the API
+type TokenMetadata struct {
+	LogoHash string `json:"logo_hash"`
+	Uri      string `json:"uri"`
+	Website  string `json:"website"`
+}
+
+// Transaction is the Transaction schema of the API
+type Transaction struct {
+	Side      int64  `json:"Side"`
+	Address   string `json:"address"`
+	Detail    string `json:"detail"`
+	Fee       string `json:"fee"`
+	Quantity  string `json:"quantity"`
+	Symbol    string `json:"symbol"`
+	Timestamp int64  `json:"timestamp"`
+	Txhash    string `json:"txhash"`
+	Type      int64  `json:"type"`
+}
+
+// Client is a client of the API
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client of the API served at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: http.DefaultClient}
+}
+
+// APIError is a response with an error status
+type APIError struct {
+	StatusCode int
+	Body       ResponseErrorV2
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status %d: %+v", e.StatusCode, e.Body)
+}
+
+// get decodes the json body of the response into result, which is left untouched if the body is empty
+func (c *Client) get(ctx context.Context, path string, query url.Values, result interface{}) error {
+	u := strings.TrimRight(c.BaseURL, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(body, &apiErr.Body)
+		return apiErr
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, result)
+}
+
+// GetBlockTxHashes returns the hashes of the txs in a block, errors are returned with status 200 as the routes v1
+func (c *Client) GetBlockTxHashes(ctx context.Context, blockHeight string) ([]string, error) {
+	var result []string
+	err := c.get(ctx, "/block_tx_hashes/"+url.PathEscape(blockHeight), nil, &result)
+	return result, err
+}
+
+// GetBookSnapshotParams is the query parameters of GetBookSnapshot
+type GetBookSnapshotParams struct {
+	// height of the block, the latest if 0
+	Height int64
+	// unix timestamp, the latest if 0
+	Timestamp int64
+}
+
+// GetBookSnapshot returns the latest book snapshot of an instrument at or before a height or a time
+func (c *Client) GetBookSnapshot(ctx context.Context, instrumentId string, params GetBookSnapshotParams) (*BookSnapshotV2, error) {
+	query := url.Values{}
+	if params.Height != 0 {
+		query.Set("height", strconv.FormatInt(params.Height, 10))
+	}
+	if params.Timestamp != 0 {
+		query.Set("timestamp", strconv.FormatInt(params.Timestamp, 10))
+	}
+	var result *BookSnapshotV2
+	err := c.get(ctx, "/instruments/"+url.PathEscape(instrumentId)+"/book_snapshot", query, &result)
+	return result, err
+}
+
+// GetCandlesParams is the query parameters of GetCandles
+type GetCandlesParams struct {
+	// seconds of a candle, 60 by default
+	Granularity int64
+	// number of the candles, 100 by default
+	Size int64
+}
+
+// GetCandles returns the candles of an instrument as [timestamp, open, high, low, close, volume] arrays
+func (c *Client) GetCandles(ctx context.Context, instrumentId string, params GetCandlesParams) ([][]string, error) {
+	query := url.Values{}
+	if params.Granularity != 0 {
+		query.Set("granularity", strconv.FormatInt(params.Granularity, 10))
+	}
+	if params.Size != 0 {
+		query.Set("size", strconv.FormatInt(params.Size, 10))
+	}
+	var result [][]string
+	err := c.get(ctx, "/instruments/"+url.PathEscape(instrumentId)+"/candles", query, &result)
+	return result, err
+}
+
+// GetClosedOrdersParams is the query parameters of GetClosedOrders
+type GetClosedOrdersParams struct {
+	// name of the token pair
+	InstrumentId string
+	// bech32 address of the sender
+	Address string
+	// side of the orders, BUY or SELL
+	Side string
+	// unix timestamp the orders are after
+	After int64
+	// unix timestamp the orders are before
+	Before int64
+	// max number of the results, 100 by default
+	Limit int64
+}
+
+// GetClosedOrders returns the closed orders of an instrument
+func (c *Client) GetClosedOrders(ctx context.Context, params GetClosedOrdersParams) ([]OrderV2, error) {
+	query := url.Values{}
+	query.Set("instrument_id", params.InstrumentId)
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Side != "" {
+		query.Set("side", params.Side)
+	}
+	if params.After != 0 {
+		query.Set("after", strconv.FormatInt(params.After, 10))
+	}
+	if params.Before != 0 {
+		query.Set("before", strconv.FormatInt(params.Before, 10))
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	var result []OrderV2
+	err := c.get(ctx, "/orders/list/closed", query, &result)
+	return result, err
+}
+
+// GetDealsParams is the query parameters of GetDeals
+type GetDealsParams struct {
+	// bech32 address of the account
+	Address string
+	// name of the token pair, all if empty
+	InstrumentId string
+	// side of the deals case insensitively, all if empty
+	Side string
+	// unix timestamp the results are after
+	After int64
+	// unix timestamp the results are before
+	Before int64
+	// max number of the results, 100 by default
+	Limit int64
+}
+
+// GetDeals returns the deals of an account
+func (c *Client) GetDeals(ctx context.Context, params GetDealsParams) ([]Deal, error) {
+	query := url.Values{}
+	query.Set("address", params.Address)
+	if params.InstrumentId != "" {
+		query.Set("instrument_id", params.InstrumentId)
+	}
+	if params.Side != "" {
+		query.Set("side", params.Side)
+	}
+	query.Set("after", strconv.FormatInt(params.After, 10))
+	query.Set("before", strconv.FormatInt(params.Before, 10))
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	var result []Deal
+	err := c.get(ctx, "/deals", query, &result)
+	return result, err
+}
+
+// GetFeesParams is the query parameters of GetFees
+type GetFeesParams struct {
+	// bech32 address of the account
+	Address string
+	// unix timestamp the results are after
+	After int64
+	// unix timestamp the results are before
+	Before int64
+	// max number of the results, 100 by default
+	Limit int64
+}
+
+// GetFees returns the fee details of an account
+func (c *Client) GetFees(ctx context.Context, params GetFeesParams) ([]FeeDetail, error) {
+	query := url.Values{}
+	query.Set("address", params.Address)
+	query.Set("after", strconv.FormatInt(params.After, 10))
+	query.Set("before", strconv.FormatInt(params.Before, 10))
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	var result []FeeDetail
+	err := c.get(ctx, "/fees", query, &result)
+	return result, err
+}
+
+// GetInstruments returns all the instruments
+func (c *Client) GetInstruments(ctx context.Context) ([]InstrumentV2, error) {
+	var result []InstrumentV2
+	err := c.get(ctx, "/instruments", nil, &result)
+	return result, err
+}
+
+// GetMatchesParams is the query parameters of GetMatches
+type GetMatchesParams struct {
+	// unix timestamp the results are after
+	After int64
+	// unix timestamp the results are before
+	Before int64
+	// max number of the results, 100 by default
+	Limit int64
+}
+
+// GetMatches returns the match results of an instrument
+func (c *Client) GetMatches(ctx context.Context, instrumentId string, params GetMatchesParams) ([]MatchResult, error) {
+	query := url.Values{}
+	query.Set("after", strconv.FormatInt(params.After, 10))
+	query.Set("before", strconv.FormatInt(params.Before, 10))
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	var result []MatchResult
+	err := c.get(ctx, "/instruments/"+url.PathEscape(instrumentId)+"/matches", query, &result)
+	return result, err
+}
+
+// GetOpenOrdersParams is the query parameters of GetOpenOrders
+type GetOpenOrdersParams struct {
+	// name of the token pair
+	InstrumentId string
+	// bech32 address of the sender
+	Address string
+	// side of the orders, BUY or SELL
+	Side string
+	// unix timestamp the orders are after
+	After int64
+	// unix timestamp the orders are before
+	Before int64
+	// max number of the results, 100 by default
+	Limit int64
+}
+
+// GetOpenOrders returns the open orders of an instrument
+func (c *Client) GetOpenOrders(ctx context.Context, params GetOpenOrdersParams) ([]OrderV2, error) {
+	query := url.Values{}
+	query.Set("instrument_id", params.InstrumentId)
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Side != "" {
+		query.Set("side", params.Side)
+	}
+	if params.After != 0 {
+		query.Set("after", strconv.FormatInt(params.After, 10))
+	}
+	if params.Before != 0 {
+		query.Set("before", strconv.FormatInt(params.Before, 10))
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	var result []OrderV2
+	err := c.get(ctx, "/orders/list/open", query, &result)
+	return result, err
+}
+
+// GetOrder returns an order
+func (c *Client) GetOrder(ctx context.Context, orderId string) (*OrderV2, error) {
+	var result *OrderV2
+	err := c.get(ctx, "/orders/"+url.PathEscape(orderId), nil, &result)
+	return result, err
+}
+
+// GetPendingOrdersParams is the query parameters of GetPendingOrders
+type GetPendingOrdersParams struct {
+	// name of the token pair
+	InstrumentId string
+	// bech32 address of the sender
+	Address string
+	// side of the orders, BUY or SELL
+	Side string
+	// unix timestamp the orders are after
+	After int64
+	// unix timestamp the orders are before
+	Before int64
+	// max number of the results, 100 by default
+	Limit int64
+}
+
+// GetPendingOrders returns the open orders of an instrument, the same as getOpenOrders
+func (c *Client) GetPendingOrders(ctx context.Context, params GetPendingOrdersParams) ([]OrderV2, error) {
+	query := url.Values{}
+	query.Set("instrument_id", params.InstrumentId)
+	if params.Address != "" {
+		query.Set("address", params.Address)
+	}
+	if params.Side != "" {
+		query.Set("side", params.Side)
+	}
+	if params.After != 0 {
+		query.Set("after", strconv.FormatInt(params.After, 10))
+	}
+	if params.Before != 0 {
+		query.Set("before", strconv.FormatInt(params.Before, 10))
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	var result []OrderV2
+	err := c.get(ctx, "/orders_pending", query, &result)
+	return result, err
+}
+
+// GetPnLParams is the query parameters of GetPnL
+type GetPnLParams struct {
+	// bech32 address of the account
+	Address string
+	// name of the token pair, all if empty
+	InstrumentId string
+	// cost basis method, fifo by default
+	Method string
+}
+
+// GetPnL returns the cost basis and pnl report of an account
+func (c *Client) GetPnL(ctx context.Context, params GetPnLParams) (*PnLReport, error) {
+	query := url.Values{}
+	query.Set("address", params.Address)
+	if params.InstrumentId != "" {
+		query.Set("instrument_id", params.InstrumentId)
+	}
+	if params.Method != "" {
+		query.Set("method", params.Method)
+	}
+	var result *PnLReport
+	err := c.get(ctx, "/pnl", query, &result)
+	return result, err
+}
+
+// GetTicker returns the 24h ticker of an instrument
+func (c *Client) GetTicker(ctx context.Context, instrumentId string) (*TickerV2, error) {
+	var result *TickerV2
+	err := c.get(ctx, "/instruments/"+url.PathEscape(instrumentId)+"/ticker", nil, &result)
+	return result, err
+}
+
+// GetTickerList returns the 24h tickers of all the instruments
+func (c *Client) GetTickerList(ctx context.Context) ([]TickerListItemV2, error) {
+	var result []TickerListItemV2
+	err := c.get(ctx, "/instruments/ticker", nil, &result)
+	return result, err
+}
+
+// GetTransactionsParams is the query parameters of GetTransactions
+type GetTransactionsParams struct {
+	// bech32 address of the account
+	Address string
+	// type of the transactions, all if 0
+	Type int64
+	// unix timestamp the results are after
+	After int64
+	// unix timestamp the results are before
+	Before int64
+	// max number of the results, 100 by default
+	Limit int64
+}
+
+// GetTransactions returns the transactions of an account
+func (c *Client) GetTransactions(ctx context.Context, params GetTransactionsParams) ([]Transaction, error) {
+	query := url.Values{}
+	query.Set("address", params.Address)
+	if params.Type != 0 {
+		query.Set("type", strconv.FormatInt(params.Type, 10))
+	}
+	query.Set("after", strconv.FormatInt(params.After, 10))
+	query.Set("before", strconv.FormatInt(params.Before, 10))
+	if params.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(params.Limit, 10))
+	}
+	var result []Transaction
+	err := c.get(ctx, "/transactions", query, &result)
+	return result, err
+}
@@ -0,0 +1,45 @@
+// Command gen generates the typed client of the backend REST API v2 and its OpenAPI specification in json from
+// the routes v2 registered by the backend REST server
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/okex/okchain/x/backend/client/openapi"
+	"github.com/okex/okchain/x/backend/client/rest"
+)
+
+// Generator is the name of the generator in the header of the generated client
+const Generator = "x/backend/client/v2client/gen"
+
+func main() {
+	clientFile := flag.String("client", "client.go", "file to write the client into")
+	specFile := flag.String("spec", "openapi.json", "file to write the specification into")
+	flag.Parse()
+
+	if err := generate(*clientFile, *specFile); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate(clientFile, specFile string) error {
+	spec := rest.SpecV2()
+	client, err := openapi.GenerateClient(spec, "v2client", Generator)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(clientFile, client, 0644); err != nil {
+		return err
+	}
+
+	specJSON, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(specFile, append(specJSON, '\n'), 0644)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerate checks the generated client and specification are up to date with the routes v2,
+// run go generate in x/backend/client/v2client if it fails
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "v2client")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	clientFile, specFile := filepath.Join(dir, "client.go"), filepath.Join(dir, "openapi.json")
+	require.Nil(t, generate(clientFile, specFile))
+	for generated, committed := range map[string]string{clientFile: "../client.go", specFile: "../openapi.json"} {
+		expected, err := ioutil.ReadFile(generated)
+		require.Nil(t, err)
+		actual, err := ioutil.ReadFile(committed)
+		require.Nil(t, err)
+		require.Equal(t, string(expected), string(actual), "%s is out of date", committed)
+	}
+}
@@ -0,0 +1,3 @@
+package v2client
+
+//go:generate go run ./gen -client client.go -spec openapi.json
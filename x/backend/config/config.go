@@ -5,10 +5,40 @@ import (
 	"encoding/json"
 	okchaincfg "github.com/cosmos/cosmos-sdk/server/config"
 	"os"
+	"path/filepath"
 
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/common"
 )
 
+// the block log options of the backend section of okchaind.toml, next to the ones of BackendConfig
+const (
+	// KeyEnableBlockLog makes the node append the backend data of every block to the block log read by
+	// `okchaind backend indexer`, even with enable_backend = false
+	KeyEnableBlockLog = "backend.enable_block_log"
+	// KeyBlockLogDir is the directory of the block log, data/backend_blocklog under the node home by default
+	KeyBlockLogDir = "backend.block_log_dir"
+
+	// KeyArchivePruned makes the backend write the rows pruned by their kept days in clean_ups_kept_days of
+	// maintain.conf into gzipped json lines files, one per table and pruning
+	KeyArchivePruned = "backend.archive_pruned"
+	// KeyArchiveDir is the directory of the archived rows, data/backend_archive under the node home by default
+	KeyArchiveDir = "backend.archive_dir"
+
+	// KeyDepthSnapshotInterval makes the backend store the top levels of the books of all the products every
+	// that many blocks, and of the matched products at every block, 0 by default to disable the depth snapshots
+	KeyDepthSnapshotInterval = "backend.depth_snapshot_interval"
+	// KeyDepthSnapshotSize is the number of price levels of each side kept in a depth snapshot
+	KeyDepthSnapshotSize = "backend.depth_snapshot_size"
+
+	DefaultDepthSnapshotSize = 20
+
+	// KeyTickerFile is the file the rolling 24h tickers are saved into to be restored at restart,
+	// data/backend_tickers.json under the node home by default
+	KeyTickerFile = "backend.ticker_file"
+)
+
 var (
 	DefaultMaintainConfile = "maintain.conf"
 	DefaultNodeHome        = okchaincfg.DefaultBackendNodeHome
@@ -60,3 +90,51 @@ func SafeLoadMaintainConfig(configDir string) *Config {
 	}
 	return maintainConf
 }
+
+// BlockLogEnabled returns whether the node writes the block log
+func BlockLogEnabled() bool {
+	return viper.GetBool(KeyEnableBlockLog)
+}
+
+// BlockLogDir returns the directory of the block log
+func BlockLogDir() string {
+	if dir := viper.GetString(KeyBlockLogDir); dir != "" {
+		return dir
+	}
+	return filepath.Join(viper.GetString(cli.HomeFlag), "data", "backend_blocklog")
+}
+
+// ArchiveDir returns the directory to archive the pruned rows into, empty if the pruned rows are not archived
+func ArchiveDir() string {
+	if !viper.GetBool(KeyArchivePruned) {
+		return ""
+	}
+	if dir := viper.GetString(KeyArchiveDir); dir != "" {
+		return dir
+	}
+	return filepath.Join(viper.GetString(cli.HomeFlag), "data", "backend_archive")
+}
+
+// DepthSnapshotInterval returns the interval in blocks to take the depth snapshots, 0 if they are disabled
+func DepthSnapshotInterval() int64 {
+	return viper.GetInt64(KeyDepthSnapshotInterval)
+}
+
+// DepthSnapshotSize returns the number of price levels of each side kept in a depth snapshot
+func DepthSnapshotSize() int {
+	if size := viper.GetInt(KeyDepthSnapshotSize); size > 0 {
+		return size
+	}
+	return DefaultDepthSnapshotSize
+}
+
+// TickerFile returns the file to save the rolling 24h tickers into, empty if there's no node home to save them
+func TickerFile() string {
+	if file := viper.GetString(KeyTickerFile); file != "" {
+		return file
+	}
+	if home := viper.GetString(cli.HomeFlag); home != "" {
+		return filepath.Join(home, "data", "backend_tickers.json")
+	}
+	return ""
+}
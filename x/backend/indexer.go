@@ -0,0 +1,96 @@
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/okex/okchain/x/backend/blocklog"
+	"github.com/okex/okchain/x/backend/types"
+)
+
+// Indexer fills the backend database from the block log written by a node, in a process of its own, so that the
+// validators can keep the backend disabled. The last applied height is stored with the data of each block, so the
+// indexer resumes right after it and applies every block exactly once
+type Indexer struct {
+	keeper   Keeper
+	dir      string
+	reader   *blocklog.Reader
+	height   int64
+	pruneLog bool
+}
+
+// NewIndexer creates an indexer applying the block log in dir to the ORM of the keeper. With pruneLog, the segments
+// of the block log are removed once applied
+func NewIndexer(keeper Keeper, dir string, pruneLog bool) *Indexer {
+	height := keeper.Orm.GetIndexerHeight()
+	return &Indexer{
+		keeper:   keeper,
+		dir:      dir,
+		reader:   blocklog.NewReader(dir, height),
+		height:   height,
+		pruneLog: pruneLog,
+	}
+}
+
+// Height returns the height of the last applied block
+func (idx *Indexer) Height() int64 {
+	return idx.height
+}
+
+// Run applies the blocks as the node appends them to the block log, polling it every interval until stop is closed
+func (idx *Indexer) Run(stop <-chan struct{}, interval time.Duration) error {
+	defer idx.reader.Close()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+
+		entry, err := idx.reader.Next()
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			select {
+			case <-stop:
+				return nil
+			case <-time.After(interval):
+			}
+			continue
+		}
+
+		if err := idx.Apply(entry); err != nil {
+			return fmt.Errorf("failed to apply block %d: %s", entry.Height, err.Error())
+		}
+	}
+}
+
+// Apply writes the data of a block to the backend database, unless it was already applied
+func (idx *Indexer) Apply(entry *types.BlockLogEntry) error {
+	if idx.height > 0 && entry.Height > idx.height+1 {
+		idx.keeper.Logger.Error(fmt.Sprintf("[backend] blocks [%d, %d] are missing from the block log, "+
+			"backfill them with `okchaind backend reindex`", idx.height+1, entry.Height-1))
+	}
+
+	applied, err := idx.keeper.Orm.ApplyBlockLogEntry(entry)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return nil
+	}
+
+	idx.height = entry.Height
+	// the kline goroutines of the keeper follow the block time
+	idx.keeper.Orm.MaxBlockTimestamp = entry.Timestamp
+	idx.keeper.Logger.Debug(fmt.Sprintf("[backend] indexed block %d", entry.Height))
+
+	if idx.pruneLog && entry.Height%blocklog.DefaultSegmentBlocks == 0 {
+		if err := blocklog.PruneBefore(idx.dir, entry.Height); err != nil {
+			idx.keeper.Logger.Error(fmt.Sprintf("[backend] failed to prune the block log: %s", err.Error()))
+		}
+	}
+	return nil
+}
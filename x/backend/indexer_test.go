@@ -0,0 +1,78 @@
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okchain/x/backend/orm"
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order"
+	ordertypes "github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestIndexer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blocklog")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// the node writes the block log with the backend disabled
+	mapp, addrKeysSlice := getMockApp(t, 2, false, "")
+	require.Nil(t, mapp.backendKeeper.OpenBlockLog(dir))
+	require.True(t, mapp.backendKeeper.SyncEnabled())
+	defer mapp.backendKeeper.Stop()
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0)}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	feeParams := ordertypes.DefaultParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+	mapp.dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+
+	orders := []*ordertypes.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.5"),
+	}
+	for i := 0; i < 2; i++ {
+		orders[i].Sender = addrKeysSlice[i].Address
+		require.NoError(t, mapp.orderKeeper.PlaceOrder(ctx, orders[i]))
+	}
+	order.EndBlocker(ctx, mapp.orderKeeper)
+	EndBlocker(ctx, mapp.backendKeeper)
+	require.Equal(t, int64(10), mapp.backendKeeper.BlockLog.LastHeight())
+
+	// the indexer applies it to its own database
+	o, dbPath := orm.MockSqlite3ORM()
+	defer orm.DeleteDB(dbPath)
+	keeper := Keeper{Orm: o, Logger: log.NewNopLogger()}
+	indexer := NewIndexer(keeper, dir, false)
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- indexer.Run(stop, 10*time.Millisecond) }()
+	time.Sleep(200 * time.Millisecond)
+	close(stop)
+	require.Nil(t, <-done)
+
+	require.Equal(t, int64(10), indexer.Height())
+	require.Equal(t, int64(10), o.GetIndexerHeight())
+	require.Equal(t, int64(1000), o.MaxBlockTimestamp)
+	for _, ord := range orders {
+		_, total := o.GetOrderList(ord.Sender.String(), types.TestTokenPair, "", true, 0, 10, 0, 1, false)
+		require.Equal(t, 1, total)
+		_, total = o.GetFeeDetails(ord.Sender.String(), 0, 10)
+		require.Equal(t, 1, total)
+	}
+
+	// a restarted indexer resumes after the last applied block
+	indexer = NewIndexer(keeper, dir, false)
+	require.Equal(t, int64(10), indexer.Height())
+	entry, err := indexer.reader.Next()
+	require.Nil(t, err)
+	require.Nil(t, entry)
+}
@@ -2,6 +2,8 @@ package keeper
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,11 +12,15 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/backend/blocklog"
 	"github.com/okex/okchain/x/backend/cache"
 	"github.com/okex/okchain/x/backend/config"
 	"github.com/okex/okchain/x/backend/orm"
+	"github.com/okex/okchain/x/backend/push"
 	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/common/monitor"
 	"github.com/okex/okchain/x/token"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -32,10 +38,23 @@ type Keeper struct {
 
 	// memory cache
 	Cache *cache.Cache
+
+	// BlockLog receives the backend data of every block for the out of process indexer, nil if it's disabled
+	BlockLog *blocklog.Writer
+
+	// DepthSnapshotInterval is the interval in blocks to take the depth snapshots of all the products, besides
+	// the ones of the products matched in every block, 0 if the depth snapshots are disabled
+	DepthSnapshotInterval int64
+	DepthSnapshotSize     int
+
+	// the file the rolling tickers are saved into, empty if they're not saved
+	tickerFile string
+	// the encoded rolling tickers to be saved by saveTickersLoop
+	tickerSaves chan []byte
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, marketKeeper types.MarketKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config) Keeper {
+func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, marketKeeper types.MarketKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config, metrics *monitor.BackendMetrics) Keeper {
 	k := Keeper{
 		OrderKeeper:  orderKeeper,
 		TokenKeeper:  tokenKeeper,
@@ -52,13 +71,17 @@ func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dex
 		if err == nil {
 			k.Orm = orm
 			k.stopChan = make(chan struct{})
+			go PruneTables(k.stopChan, k.Orm, k.Config, config.ArchiveDir(), metrics)
 
 			if k.Config.EnableMktCompute {
 				go generateKline1M(k.stopChan, k.Config, k.Orm, &k.Logger)
-				// init ticker buffer
-				ts := time.Now().Unix()
-
-				k.UpdateTickersBuffer(ts-types.SecondsInADay*14, ts, nil)
+				// the EndBlocker publishes the data of every block to the WebSocket clients
+				push.GetHub().EnablePublisher()
+				// init the rolling tickers
+				k.tickerFile = config.TickerFile()
+				k.InitTickers(k.tickerFile, time.Now().Unix())
+				k.tickerSaves = make(chan []byte, 1)
+				go saveTickersLoop(k.stopChan, k.tickerSaves, k.tickerFile, k.Logger)
 			}
 		}
 	}
@@ -67,11 +90,72 @@ func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dex
 	return k
 }
 
+// OpenBlockLog makes the keeper append the backend data of every block to the block log in dir
+func (k *Keeper) OpenBlockLog(dir string) error {
+	w, err := blocklog.NewWriter(dir, blocklog.DefaultSegmentBlocks)
+	if err != nil {
+		return err
+	}
+	k.BlockLog = w
+	if k.Cache == nil {
+		k.Cache = cache.NewCache()
+	}
+	return nil
+}
+
+// EnableDepthSnapshots makes the keeper store the top size levels of the books every interval blocks and at matches
+func (k *Keeper) EnableDepthSnapshots(interval int64, size int) {
+	k.DepthSnapshotInterval = interval
+	k.DepthSnapshotSize = size
+}
+
+// TakeDepthSnapshots takes the depth snapshots of the products matched in the block, and of all the products
+// every DepthSnapshotInterval blocks
+func (k Keeper) TakeDepthSnapshots(ctx sdk.Context) []*types.DepthSnapshot {
+	if k.DepthSnapshotInterval <= 0 {
+		return nil
+	}
+
+	var products []string
+	blockHeight := ctx.BlockHeight()
+	if blockHeight%k.DepthSnapshotInterval == 0 {
+		for _, tokenPair := range k.dexKeeper.GetTokenPairs(ctx) {
+			products = append(products, tokenPair.Name())
+		}
+	} else if result := k.OrderKeeper.GetBlockMatchResult(); result != nil {
+		for product, matchResult := range result.ResultMap {
+			if matchResult.BlockHeight == blockHeight {
+				products = append(products, product)
+			}
+		}
+		sort.Strings(products)
+	}
+
+	snapshots := make([]*types.DepthSnapshot, 0, len(products))
+	timestamp := ctx.BlockHeader().Time.Unix()
+	for _, product := range products {
+		snapshots = append(snapshots, types.NewDepthSnapshot(product, blockHeight, timestamp,
+			k.OrderKeeper.GetDepthBookCopy(product), k.DepthSnapshotSize))
+	}
+	return snapshots
+}
+
+// SyncEnabled returns whether the txs and the blocks are collected, for the backend database or the block log
+func (k Keeper) SyncEnabled() bool {
+	return (k.Config.EnableBackend && k.Config.EnableMktCompute) || k.BlockLog != nil
+}
+
 func (k Keeper) Stop() {
 	defer types.PrintStackIfPanic()
 	if k.stopChan != nil {
 		close(k.stopChan)
 	}
+	if k.Orm != nil && k.Config.EnableMktCompute {
+		k.saveTickers()
+	}
+	if k.BlockLog != nil {
+		k.BlockLog.Close()
+	}
 	if k.Orm != nil {
 		k.Orm.Close()
 	}
@@ -81,16 +165,22 @@ func (k Keeper) Flush() {
 	defer k.Cache.Flush()
 }
 
-func (k Keeper) SyncTx(ctx sdk.Context, tx *auth.StdTx, txHash string, timestamp int64) {
-	if k.Config.EnableBackend && k.Config.EnableMktCompute {
+func (k Keeper) SyncTx(ctx sdk.Context, tx *auth.StdTx, txHash string, events []abci.Event, timestamp int64) {
+	if k.SyncEnabled() {
 		k.Logger.Debug(fmt.Sprintf("[backend] get new tx, txHash: %s", txHash))
-		txs := types.GenerateTx(tx, txHash, ctx, k.OrderKeeper, k.TokenKeeper, timestamp)
+		txs := k.GenerateTx(ctx, tx, txHash, events, timestamp)
 		for _, tx := range txs {
 			k.Cache.AddTransaction(tx)
 		}
 	}
 }
 
+// GenerateTx returns the transactions of the addresses involved in a tx delivered successfully with the events
+func (k Keeper) GenerateTx(ctx sdk.Context, tx *auth.StdTx, txHash string, events []abci.Event,
+	timestamp int64) []*types.Transaction {
+	return types.GenerateTx(tx, txHash, events, ctx, k.OrderKeeper, k.TokenKeeper, k.dexKeeper, timestamp)
+}
+
 func (k Keeper) MarshalJSON(o interface{}) ([]byte, error) {
 	return k.cdc.MarshalJSON(o)
 }
@@ -206,6 +296,10 @@ func (k Keeper) GetTickers(products []string, count int) []types.Ticker {
 	}
 }
 
+// UpdateTickersBuffer recomputes the tickers of the products from the klines and the match results in the
+// backend database.
+//
+// Deprecated: the tickers are maintained incrementally by UpdateTickers at every block.
 func (k Keeper) UpdateTickersBuffer(startTS, endTS int64, productList []string) {
 
 	defer types.PrintStackIfPanic()
@@ -241,8 +335,8 @@ func (k Keeper) UpdateTickersBuffer(startTS, endTS int64, productList []string)
 				previousTicker.Open = previousTicker.Close
 				previousTicker.High = previousTicker.Close
 				previousTicker.Low = previousTicker.Close
-				previousTicker.Volume = 0
-				previousTicker.Change = 0
+				previousTicker.Volume = sdk.ZeroDec().String()
+				previousTicker.Change = sdk.ZeroDec().String()
 				previousTicker.ChangePercentage = "0.00%"
 			}
 
@@ -281,3 +375,108 @@ func (k Keeper) GetAllTickers() []types.Ticker {
 	}
 	return tickers
 }
+
+// GetPnLReport calculates the cost basis and pnl of the products an address dealt on,
+// the unrealised pnl is valued at the latest ticker price. The base tokens transferred in and out of the address
+// change the positions, and the order fees are deducted from the realised pnl in the totals.
+func (k Keeper) GetPnLReport(ctx sdk.Context, address, product, method string) (types.PnLReport, error) {
+	report := types.PnLReport{Address: address, Method: method, Timestamp: time.Now().Unix(), Products: []types.ProductPnL{}}
+	// all the deals are needed to give the transfers of a base token to the products of the token
+	deals, err := k.Orm.GetDealsInTimeOrder(address, "")
+	if err != nil {
+		return report, err
+	}
+
+	var products []string
+	productDeals := make(map[string][]types.Deal)
+	for _, deal := range deals {
+		if _, ok := productDeals[deal.Product]; !ok {
+			products = append(products, deal.Product)
+		}
+		productDeals[deal.Product] = append(productDeals[deal.Product], deal)
+	}
+	baseTransfers := make(map[string]map[string][]types.PnLTransfer)
+	for _, p := range products {
+		if product != "" && p != product {
+			continue
+		}
+		base := strings.Split(p, "_")[0]
+		if _, ok := baseTransfers[base]; !ok {
+			if baseTransfers[base], err = k.getPnLTransfers(address, base, deals); err != nil {
+				return report, err
+			}
+		}
+		pnl, err := types.CalculateProductPnL(p, method, productDeals[p], baseTransfers[base][p], k.getLatestPrice(ctx, p))
+		if err != nil {
+			return report, err
+		}
+		report.Products = append(report.Products, pnl)
+	}
+
+	feeDetails, err := k.Orm.GetAllFeeDetails(address)
+	if err != nil {
+		return report, err
+	}
+	orderFees, err := types.CalculateOrderFees(feeDetails)
+	if err != nil {
+		return report, err
+	}
+	report.OrderFees = orderFees.String()
+	report.Totals, err = types.CalculatePnLTotals(report.Products, orderFees)
+	return report, err
+}
+
+// getPnLTransfers returns the transfers of a base token in and out of an address by the products of the token.
+// Each transfer is given to exactly one product, the one dealt on last before it or the one dealt on first, so that
+// a token shared by several products is never counted twice. The tokens transferred in are valued at the latest
+// match price of the product when they were received, zero if none
+func (k Keeper) getPnLTransfers(address, base string, deals []types.Deal) (map[string][]types.PnLTransfer, error) {
+	var baseDeals []types.Deal
+	for _, deal := range deals {
+		if strings.Split(deal.Product, "_")[0] == base {
+			baseDeals = append(baseDeals, deal)
+		}
+	}
+	if len(baseDeals) == 0 {
+		return nil, nil
+	}
+	txs, err := k.Orm.GetTransfersInTimeOrder(address, base)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make(map[string][]types.PnLTransfer)
+	product, next := baseDeals[0].Product, 0
+	for _, tx := range txs {
+		for ; next < len(baseDeals) && baseDeals[next].Timestamp <= tx.Timestamp; next++ {
+			product = baseDeals[next].Product
+		}
+		price := sdk.ZeroDec()
+		if tx.Side == types.TxSideTo {
+			matchResult, err := k.Orm.GetLatestMatchResultBefore(product, tx.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			if matchResult != nil {
+				if price, err = sdk.NewDecFromStr(matchResult.Price); err != nil {
+					return nil, err
+				}
+			}
+		}
+		transfer, err := types.NewPnLTransfer(tx, price)
+		if err != nil {
+			return nil, err
+		}
+		transfers[product] = append(transfers[product], transfer)
+	}
+	return transfers, nil
+}
+
+func (k Keeper) getLatestPrice(ctx sdk.Context, product string) sdk.Dec {
+	if ticker, ok := k.Cache.LatestTicker[product]; ok && ticker != nil {
+		if price, err := sdk.NewDecFromStr(ticker.Price); err == nil {
+			return price
+		}
+	}
+	return k.OrderKeeper.GetLastPrice(ctx, product)
+}
@@ -1,12 +1,17 @@
 package keeper
 
 import (
+	"compress/gzip"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/okex/okchain/x/backend/config"
 	"github.com/okex/okchain/x/backend/orm"
 	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/common/monitor"
 
 	"github.com/tendermint/tendermint/libs/log"
 )
@@ -33,7 +38,6 @@ func generateKline1M(stop chan struct{}, conf *config.Config, o *orm.ORM, log *l
 	interval := time.Second * 60
 	ticker := time.NewTicker(interval)
 
-	go CleanUpKlines(stop, o, conf)
 	var klineNotifyChans *map[int]chan struct{} = nil
 	work := func() {
 		if o.MaxBlockTimestamp == 0 {
@@ -158,44 +162,108 @@ func generateKlinesMX(notifyChan chan struct{}, stop chan struct{}, refreshInter
 	}
 }
 
-func CleanUpKlines(stop chan struct{}, o *orm.ORM, conf *config.Config) {
-	o.Debug(fmt.Sprintf("[backend] cleanUpKlines go routine started. MaintainConf: %+v", *conf))
-	interval := time.Duration(60 * int(time.Second))
-	ticker := time.NewTicker(time.Duration(int(60-time.Now().Second()) * int(time.Second)))
+// PruneTables deletes the rows older than the kept days of their tables in conf.CleanUpsKeptDays every day at
+// conf.CleanUpsTime, tables without kept days are never pruned. The pruned rows are archived into archiveDir
+// unless it's empty, and the number of rows of the tables are reported to metrics every hour.
+func PruneTables(stop chan struct{}, o *orm.ORM, conf *config.Config, archiveDir string, metrics *monitor.BackendMetrics) {
+	o.Debug(fmt.Sprintf("[backend] pruneTables go routine started. MaintainConf: %+v, archiveDir: %s", *conf, archiveDir))
+	defer types.PrintStackIfPanic()
+
+	updateTableRows(o, metrics)
+	timer := time.NewTimer(time.Duration(int(60-time.Now().Second()) * int(time.Second)))
+	var ticker <-chan time.Time
 
 	work := func() {
 		now := time.Now()
-		strNow := now.Format("15:04:05")
-		if strNow == conf.CleanUpsTime {
-
-			m := types.GetAllKlineMap()
-			for _, ktype := range m {
-				expiredDays := conf.CleanUpsKeptDays[ktype]
-				if expiredDays != 0 {
-					o.Debug(fmt.Sprintf("[backend] entering cleanUpKlines, "+
-						"fired time: %s(currentTS: %d), kline type: %s", conf.CleanUpsTime, now.Unix(), ktype))
-					//anchorTS := now.Add(-time.Duration(int(time.Second) * 1440 * expiredDays)).Unix()
-					anchorTS := now.Add(-time.Duration(int(time.Second) * types.SecondsInADay * expiredDays)).Unix()
-					kline, err := types.NewKlineFactory(ktype, nil)
-					if err != nil {
-						o.Debug("failed to NewKlineFactory becoz of : " + err.Error())
-						break
-					}
-					o.DeleteKlineBefore(anchorTS, kline)
-				}
-			}
+		if strings.HasPrefix(conf.CleanUpsTime, now.Format("15:04")) {
+			pruneTables(o, conf, now, archiveDir, metrics)
+		} else if now.Minute() == 0 {
+			updateTableRows(o, metrics)
 		}
 	}
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
+			work()
+			ticker = time.NewTicker(time.Minute).C
+		case <-ticker:
 			work()
-			ticker = time.NewTicker(interval)
-
 		case <-stop:
-			break
+			return
+		}
+	}
+}
+
+// CleanUpKlines deletes the klines older than the kept days of their tables in conf.CleanUpsKeptDays every day
+// at conf.CleanUpsTime, the other tables are never pruned.
+//
+// Deprecated: use PruneTables, which prunes all the tables with kept days and archives the pruned rows.
+func CleanUpKlines(stop chan struct{}, o *orm.ORM, conf *config.Config) {
+	klineConf := *conf
+	klineConf.CleanUpsKeptDays = make(map[string]int)
+	for _, ktype := range types.GetAllKlineMap() {
+		if keptDays, ok := conf.CleanUpsKeptDays[ktype]; ok {
+			klineConf.CleanUpsKeptDays[ktype] = keptDays
+		}
+	}
+	PruneTables(stop, o, &klineConf, "", monitor.NopBackendMetrics())
+}
 
+func pruneTables(o *orm.ORM, conf *config.Config, now time.Time, archiveDir string, metrics *monitor.BackendMetrics) {
+	for _, table := range orm.GetPrunableTables() {
+		keptDays := conf.CleanUpsKeptDays[table]
+		if keptDays <= 0 {
+			continue
 		}
+		anchorTS := now.Add(-time.Duration(int(time.Second) * types.SecondsInADay * keptDays)).Unix()
+		o.Debug(fmt.Sprintf("[backend] entering pruneTables, fired time: %s(currentTS: %d), table: %s, "+
+			"kept days: %d", conf.CleanUpsTime, now.Unix(), table, keptDays))
+
+		pruned, err := pruneTable(o, table, anchorTS, archiveDir, now)
+		if err != nil {
+			o.Error(fmt.Sprintf("[backend] failed to prune table %s before %d: %s", table, anchorTS, err.Error()))
+		}
+		metrics.PrunedRows.With("table", table).Add(float64(pruned))
+	}
+	updateTableRows(o, metrics)
+}
+
+func pruneTable(o *orm.ORM, table string, timestamp int64, archiveDir string, now time.Time) (pruned int, err error) {
+	if archiveDir == "" {
+		return o.PruneTable(table, timestamp, nil)
+	}
+
+	if err := os.MkdirAll(archiveDir, os.ModePerm); err != nil {
+		return 0, err
+	}
+	path := filepath.Join(archiveDir, fmt.Sprintf("%s_%s.jsonl.gz", table, now.Format("20060102150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	w := gzip.NewWriter(f)
+	pruned, err = o.PruneTable(table, timestamp, w)
+	if errClose := w.Close(); err == nil {
+		err = errClose
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if pruned == 0 && err == nil {
+		err = os.Remove(path)
+	}
+	return pruned, err
+}
+
+func updateTableRows(o *orm.ORM, metrics *monitor.BackendMetrics) {
+	counts, err := o.GetTableRowCounts()
+	if err != nil {
+		o.Error(fmt.Sprintf("[backend] failed to count the rows of tables: %s", err.Error()))
+		return
+	}
+	for table, cnt := range counts {
+		metrics.TableRows.With("table", table).Set(float64(cnt))
 	}
 }
@@ -97,6 +97,10 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			res, err = queryDealsV2(ctx, path[1:], req, keeper)
 		case types.QueryTxListV2:
 			res, err = queryTxListV2(ctx, path[1:], req, keeper)
+		case types.QueryPnLV2:
+			res, err = queryPnLV2(ctx, path[1:], req, keeper)
+		case types.QueryDepthSnapshotV2:
+			res, err = queryDepthSnapshotV2(ctx, path[1:], req, keeper)
 		default:
 			res, err = nil, sdk.ErrUnknownRequest("unknown backend endpoint")
 		}
@@ -269,15 +273,15 @@ func queryTickerList(ctx sdk.Context, path []string, req abci.RequestQuery, keep
 		if !exists {
 			//tmpPrice := keeper.orderKeeper.GetLastPrice(ctx, p)
 			tmpTicker := types.Ticker{
-				Price:            -1,
+				Price:            "-1",
 				Product:          p,
 				Symbol:           p,
-				Open:             0,
-				Close:            0,
-				High:             0,
-				Low:              0,
-				Volume:           0,
-				Change:           0,
+				Open:             "0",
+				Close:            "0",
+				High:             "0",
+				Low:              "0",
+				Volume:           "0",
+				Change:           "0",
 				ChangePercentage: "0.00%",
 				Timestamp:        time.Now().Unix(),
 			}
@@ -103,12 +103,12 @@ func queryTickerV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	for _, t := range tickers {
 		if params.Product == t.Product {
 			notExist = false
-			result.Last = fmt.Sprintf("%f", t.Price)
-			result.Open24H = fmt.Sprintf("%f", t.Open)
-			result.High24H = fmt.Sprintf("%f", t.High)
-			result.Low24H = fmt.Sprintf("%f", t.Low)
-			result.BaseVolume24H = fmt.Sprintf("%f", t.Volume)
-			result.QuoteVolume24H = fmt.Sprintf("%f", t.Volume)
+			result.Last = t.Price
+			result.Open24H = t.Open
+			result.High24H = t.High
+			result.Low24H = t.Low
+			result.BaseVolume24H = t.Volume
+			result.QuoteVolume24H = t.Volume
 			result.Timestamp = time.Unix(t.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z")
 			break
 		}
@@ -135,12 +135,12 @@ func queryTickerListV2(ctx sdk.Context, path []string, req abci.RequestQuery, ke
 	var tickerList []types.TickerV2
 	for _, t := range tickers {
 		var ticker types.TickerV2
-		ticker.Last = fmt.Sprintf("%f", t.Price)
-		ticker.Open24H = fmt.Sprintf("%f", t.Open)
-		ticker.High24H = fmt.Sprintf("%f", t.High)
-		ticker.Low24H = fmt.Sprintf("%f", t.Low)
-		ticker.BaseVolume24H = fmt.Sprintf("%f", t.Volume)
-		ticker.QuoteVolume24H = fmt.Sprintf("%f", t.Volume)
+		ticker.Last = t.Price
+		ticker.Open24H = t.Open
+		ticker.High24H = t.High
+		ticker.Low24H = t.Low
+		ticker.BaseVolume24H = t.Volume
+		ticker.QuoteVolume24H = t.Volume
 		ticker.Timestamp = time.Unix(t.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z")
 		bestBid, bestAsk := keeper.OrderKeeper.GetBestBidAndAsk(ctx, t.Product)
 		ticker.BestBid = bestBid.String()
@@ -162,7 +162,10 @@ func queryInstrumentsV2(ctx sdk.Context, path []string, req abci.RequestQuery, k
 
 	var result []*types.InstrumentV2
 	for _, t := range tokenPairs {
-		result = append(result, types.ConvertTokenPairToInstrumentV2(t))
+		instrument := types.ConvertTokenPairToInstrumentV2(t)
+		instrument.SetCurrencies(keeper.TokenKeeper.GetTokenInfo(ctx, t.BaseAssetSymbol),
+			keeper.TokenKeeper.GetTokenInfo(ctx, t.QuoteAssetSymbol))
+		result = append(result, instrument)
 	}
 
 	res, err := json.Marshal(result)
@@ -351,3 +354,54 @@ func queryTxListV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 
 	return res, nil
 }
+
+func queryPnLV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryPnLParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if _, err := sdk.AccAddressFromBech32(params.Address); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
+	}
+	if err := types.ValidatePnLMethod(params.Method); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+
+	report, err := keeper.GetPnLReport(ctx, params.Address, params.Product, params.Method)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	res, err := common.JSONMarshalV2(report)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
+
+func queryDepthSnapshotV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryDepthSnapshotParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.Product == "" {
+		return nil, sdk.ErrUnknownRequest("invalid params: product is required")
+	}
+
+	snapshot := keeper.Orm.GetDepthSnapshot(params.Product, params.Height, params.Timestamp)
+	if snapshot == nil {
+		return nil, nil
+	}
+	result, err := types.ConvertDepthSnapshotToBookV2(*snapshot)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	res, err := json.Marshal(result)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
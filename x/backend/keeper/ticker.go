@@ -0,0 +1,172 @@
+package keeper
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/okex/okchain/x/backend/cache"
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// tickerSaveInterval is the interval in seconds of block time to save the rolling tickers, the match results of
+// the blocks after the saved one are replayed from the backend database at restart
+const tickerSaveInterval = 5 * 60
+
+// InitTickers restores the rolling tickers saved in file, and seeds them from the klines and the match results of
+// the last 24 hours if they were not saved or are out of date
+func (k Keeper) InitTickers(file string, timestamp int64) {
+	defer types.PrintStackIfPanic()
+
+	window := k.loadTickerWindow(file, timestamp)
+	if window == nil {
+		window = k.seedTickerWindow(timestamp)
+	}
+	k.Cache.TickerWindow = window
+	for product := range window.Products {
+		k.Cache.LatestTicker[product] = window.Ticker(product)
+	}
+}
+
+func (k Keeper) loadTickerWindow(file string, timestamp int64) *cache.TickerWindow {
+	if file == "" {
+		return nil
+	}
+	window, err := cache.LoadTickerWindow(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			k.Logger.Error(fmt.Sprintf("[backend] failed to load the tickers from %s, error: %s", file, err.Error()))
+		}
+		return nil
+	}
+	if window.Timestamp < timestamp-types.SecondsInADay {
+		return nil
+	}
+
+	results, err := k.Orm.GetMatchResultsAfterHeight(window.BlockHeight)
+	if err != nil {
+		k.Logger.Error(fmt.Sprintf("[backend] failed to replay the tickers after block %d, error: %s", window.BlockHeight, err.Error()))
+		return nil
+	}
+	for _, result := range results {
+		window.Advance(result.Timestamp)
+		window.AddTrade(result.Product, result.Timestamp, types.DecFromString(result.Price), types.DecFromString(result.Quantity))
+		window.BlockHeight = result.BlockHeight
+	}
+	window.Advance(timestamp)
+	return window
+}
+
+func (k Keeper) seedTickerWindow(timestamp int64) *cache.TickerWindow {
+	window := cache.NewTickerWindow()
+	window.Advance(timestamp)
+	products, err := k.Orm.GetAllUpdatedProducts(timestamp-types.SecondsInADay*14, timestamp)
+	if err != nil {
+		k.Logger.Error(fmt.Sprintf("[backend] failed to seed the tickers, error: %s", err.Error()))
+		return window
+	}
+
+	for _, product := range products {
+		// klines and match results are both sorted desc by timestamp, the latest minutes may not be merged into
+		// klines yet
+		var klines []types.KlineM1
+		if err := k.Orm.GetKlinesByTimeRange(product, timestamp-types.SecondsInADay, timestamp, &klines); err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] failed to seed the ticker of %s, error: %s", product, err.Error()))
+			continue
+		}
+		matchStart := timestamp - types.SecondsInADay
+		for i := len(klines) - 1; i >= 0; i-- {
+			kline := klines[i]
+			window.Add(product, kline.Timestamp, types.DecFromString(kline.Open), types.DecFromString(kline.Close),
+				types.DecFromString(kline.High), types.DecFromString(kline.Low), types.DecFromString(kline.Volume))
+			matchStart = kline.Timestamp + 60
+		}
+
+		results, err := k.Orm.GetMatchResultsByTimeRange(product, matchStart, timestamp+1)
+		if err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] failed to seed the ticker of %s, error: %s", product, err.Error()))
+			continue
+		}
+		for i := len(results) - 1; i >= 0; i-- {
+			result := results[i]
+			window.AddTrade(product, result.Timestamp, types.DecFromString(result.Price), types.DecFromString(result.Quantity))
+			if result.BlockHeight > window.BlockHeight {
+				window.BlockHeight = result.BlockHeight
+			}
+		}
+
+		if len(klines) == 0 && len(results) == 0 {
+			if latest, err := k.Orm.GetLatestMatchResults(product, 1); err == nil && len(latest) == 1 {
+				window.SetLastPrice(product, types.DecFromString(latest[0].Price))
+			}
+		}
+	}
+	return window
+}
+
+// UpdateTickers applies the match results of a block to the rolling tickers, only the tickers of the matched
+// products and of the products whose trades leave the 24 hours window are refreshed
+func (k Keeper) UpdateTickers(blockHeight, timestamp int64, results []*types.MatchResult) {
+	defer types.PrintStackIfPanic()
+
+	window := k.Cache.TickerWindow
+	changed := window.Advance(timestamp)
+	for _, result := range results {
+		window.AddTrade(result.Product, result.Timestamp, types.DecFromString(result.Price), types.DecFromString(result.Quantity))
+		changed = append(changed, result.Product)
+	}
+	window.BlockHeight = blockHeight
+
+	for _, product := range changed {
+		k.Cache.LatestTicker[product] = window.Ticker(product)
+	}
+
+	if timestamp-window.SavedAt() >= tickerSaveInterval {
+		k.queueTickerSave()
+	}
+}
+
+// queueTickerSave hands the rolling tickers over to saveTickersLoop, so that the EndBlocker doesn't wait for the
+// file to be written
+func (k Keeper) queueTickerSave() {
+	if k.tickerSaves == nil {
+		return
+	}
+	bytes, err := k.Cache.TickerWindow.Marshal()
+	if err != nil {
+		k.Logger.Error(fmt.Sprintf("[backend] failed to encode the tickers, error: %s", err.Error()))
+		return
+	}
+	// a save still pending is older, it's replaced
+	select {
+	case <-k.tickerSaves:
+	default:
+	}
+	k.tickerSaves <- bytes
+}
+
+// saveTickersLoop writes the rolling tickers queued by the EndBlocker into file until stop is closed. A write
+// finishing after the one of Stop leaves older tickers in the file, the match results after them are replayed
+// at restart
+func saveTickersLoop(stop chan struct{}, saves chan []byte, file string, logger log.Logger) {
+	for {
+		select {
+		case bytes := <-saves:
+			if err := cache.WriteTickerWindow(file, bytes); err != nil {
+				logger.Error(fmt.Sprintf("[backend] failed to save the tickers into %s, error: %s", file, err.Error()))
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// saveTickers saves the rolling tickers to be restored at restart, when the keeper stops
+func (k Keeper) saveTickers() {
+	if k.tickerFile == "" || k.Cache == nil {
+		return
+	}
+	if err := k.Cache.TickerWindow.Save(k.tickerFile); err != nil {
+		k.Logger.Error(fmt.Sprintf("[backend] failed to save the tickers into %s, error: %s", k.tickerFile, err.Error()))
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/okex/okchain/x/backend/types"
 	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/monitor"
 	tokentypes "github.com/okex/okchain/x/token/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -132,7 +133,7 @@ func TestKeeper_Tx(t *testing.T) {
 	require.EqualValues(t, 1, len(getTxs))
 }
 
-func TestKeeper_CleanUpKlines(t *testing.T) {
+func TestKeeper_PruneTables(t *testing.T) {
 	o, _ := orm.MockSqlite3ORM()
 	ch := make(chan struct{}, 1)
 	conf := config.DefaultConfig()
@@ -141,18 +142,37 @@ func TestKeeper_CleanUpKlines(t *testing.T) {
 	strClenaUpTime := cleanUpTime.Format("15:04") + ":00"
 	conf.CleanUpsTime = strClenaUpTime
 	conf.EnableBackend = true
-	go CleanUpKlines(ch, o, conf)
+	conf.CleanUpsKeptDays[orm.TableDeals] = 30
+	go PruneTables(ch, o, conf, "", monitor.NopBackendMetrics())
+	ch <- struct{}{}
 
 	//time.Sleep(121 * time.Second)
 }
 
-func sumKlinesVolume(product string, o *orm.ORM, ikline types.IKline) float64 {
+func TestKeeper_CleanUpKlines(t *testing.T) {
+	o, _ := orm.MockSqlite3ORM()
+	ch := make(chan struct{})
+	conf := config.DefaultConfig()
+	conf.CleanUpsKeptDays[orm.TableDeals] = 30
+
+	done := make(chan struct{})
+	go func() {
+		CleanUpKlines(ch, o, conf)
+		close(done)
+	}()
+	ch <- struct{}{}
+	<-done
+	// the kept days of the other tables are left to PruneTables
+	require.Equal(t, 30, conf.CleanUpsKeptDays[orm.TableDeals])
+}
+
+func sumKlinesVolume(product string, o *orm.ORM, ikline types.IKline) sdk.Dec {
 	klines, _ := types.NewKlinesFactory(ikline.GetTableName())
 	o.GetLatestKlinesByProduct(product, 10000, 0, klines)
 	iklines := types.ToIKlinesArray(klines, time.Now().Unix(), false)
-	volume := 0.0
+	volume := sdk.ZeroDec()
 	for _, i := range iklines {
-		volume += i.GetVolume()
+		volume = volume.Add(i.GetVolume())
 	}
 
 	return volume
@@ -180,8 +200,8 @@ func TestKeeper_FixJira85(t *testing.T) {
 	km360Sum := sumKlinesVolume(product, mapp.backendKeeper.Orm, &types.KlineM360{})
 	km1440Sum := sumKlinesVolume(product, mapp.backendKeeper.Orm, &types.KlineM1440{})
 
-	require.True(t, km1Sum == km3Sum && km3Sum == km5Sum && km5Sum == km15Sum &&
-		km15Sum == km360Sum && km1440Sum == km1Sum && km1Sum == 11.0)
+	require.True(t, km1Sum.Equal(km3Sum) && km3Sum.Equal(km5Sum) && km5Sum.Equal(km15Sum) &&
+		km15Sum.Equal(km360Sum) && km1440Sum.Equal(km1Sum) && km1Sum.Equal(sdk.NewDec(11)))
 
 	// 2. TestTicker
 	tickers := mapp.backendKeeper.GetTickers(nil, 100)
@@ -218,11 +238,11 @@ func TestKeeper_KlineInitialize_RebootTwice(t *testing.T) {
 		}
 
 		products := []string{"btc-235_" + common.NativeToken, "atom-564_" + common.NativeToken, "bch-035_" + common.NativeToken}
-		expectedSum := []float64{11.0, 10.1, 11.7445}
+		expectedSum := []string{"11.0", "10.1", "11.7445"}
 
 		for j := 0; j < len(products); j++ {
 			product := products[j]
-			expSum := expectedSum[j]
+			expSum := sdk.MustNewDecFromStr(expectedSum[j])
 
 			km1Sum := sumKlinesVolume(product, mapp.backendKeeper.Orm, &types.KlineM1{})
 			km3Sum := sumKlinesVolume(product, mapp.backendKeeper.Orm, &types.KlineM3{})
@@ -238,9 +258,9 @@ func TestKeeper_KlineInitialize_RebootTwice(t *testing.T) {
 
 			fmt.Println(fmt.Sprintln("Product: ", product, " Expected sum: ", expSum, " Km1Sum: ", km1Sum, "K15Sum", km15Sum, km30Sum, km60Sum, km120Sum, km240Sum, km360Sum, km720Sum, km1440Sum))
 
-			require.True(t, km1Sum == km3Sum && km3Sum == km5Sum && km5Sum == km15Sum && km15Sum == km360Sum &&
-				km30Sum == km1Sum && km60Sum == km1Sum && km120Sum == km1Sum && km240Sum == km1Sum && km720Sum == km1Sum &&
-				km1440Sum == km1Sum && km1Sum == expSum)
+			require.True(t, km1Sum.Equal(km3Sum) && km3Sum.Equal(km5Sum) && km5Sum.Equal(km15Sum) && km15Sum.Equal(km360Sum) &&
+				km30Sum.Equal(km1Sum) && km60Sum.Equal(km1Sum) && km120Sum.Equal(km1Sum) && km240Sum.Equal(km1Sum) && km720Sum.Equal(km1Sum) &&
+				km1440Sum.Equal(km1Sum) && km1Sum.Equal(expSum))
 
 		}
 	}
@@ -261,11 +281,11 @@ func TestKeeper_KlineInitialize_RebootTwice2(t *testing.T) {
 
 		products := []string{"bch-035_" + common.NativeToken, "btc-235_" + common.NativeToken, "atom-564_" + common.NativeToken,
 			"dash-150_" + common.NativeToken, "eos-5d4_" + common.NativeToken, "ltc-b72_" + common.NativeToken}
-		expectedSum := []float64{12.7445, 11.0, 10.1, 1, 0.45, 2.5099}
+		expectedSum := []string{"12.7445", "11.0", "10.1", "1", "0.45", "2.5099"}
 
 		for j := 0; j < len(products); j++ {
 			product := products[j]
-			expSum := expectedSum[j]
+			expSum := sdk.MustNewDecFromStr(expectedSum[j])
 
 			checkKlinesVolume(t, product, mapp.backendKeeper.Orm, expSum)
 
@@ -273,7 +293,7 @@ func TestKeeper_KlineInitialize_RebootTwice2(t *testing.T) {
 	}
 }
 
-func checkKlinesVolume(t *testing.T, product string, o *orm.ORM, expSum float64) {
+func checkKlinesVolume(t *testing.T, product string, o *orm.ORM, expSum sdk.Dec) {
 
 	km1Sum := sumKlinesVolume(product, o, &types.KlineM1{})
 	km3Sum := sumKlinesVolume(product, o, &types.KlineM3{})
@@ -288,9 +308,9 @@ func checkKlinesVolume(t *testing.T, product string, o *orm.ORM, expSum float64)
 	km1440Sum := sumKlinesVolume(product, o, &types.KlineM1440{})
 
 	fmt.Println(fmt.Sprintln("Product: ", product, " Expected sum: ", expSum, " Km1Sum: ", km1Sum, km3Sum, km5Sum, km15Sum, km30Sum, km60Sum, km120Sum, km240Sum, km360Sum, km720Sum, km1440Sum))
-	require.True(t, km1Sum == km3Sum && km3Sum == km5Sum && km5Sum == km15Sum && km15Sum == km360Sum &&
-		km30Sum == km1Sum && km60Sum == km1Sum && km120Sum == km1Sum && km240Sum == km1Sum && km720Sum == km1Sum &&
-		km1440Sum == km1Sum && km1Sum == expSum)
+	require.True(t, km1Sum.Equal(km3Sum) && km3Sum.Equal(km5Sum) && km5Sum.Equal(km15Sum) && km15Sum.Equal(km360Sum) &&
+		km30Sum.Equal(km1Sum) && km60Sum.Equal(km1Sum) && km120Sum.Equal(km1Sum) && km240Sum.Equal(km1Sum) && km720Sum.Equal(km1Sum) &&
+		km1440Sum.Equal(km1Sum) && km1Sum.Equal(expSum))
 
 }
 
@@ -309,11 +329,11 @@ func TestKeeper_KlineInitialize_RebootTwice3(t *testing.T) {
 
 		products := []string{"bch-035_" + common.NativeToken, "btc-235_" + common.NativeToken, "atom-564_" + common.NativeToken,
 			"dash-150_" + common.NativeToken, "eos-5d4_" + common.NativeToken, "ltc-b72_" + common.NativeToken}
-		expectedSum := []float64{11.7445, 11.0, 10.1, 1, 0.45, 2.0099}
+		expectedSum := []string{"11.7445", "11.0", "10.1", "1", "0.45", "2.0099"}
 
 		for j := 0; j < len(products); j++ {
 			product := products[j]
-			expSum := expectedSum[j]
+			expSum := sdk.MustNewDecFromStr(expectedSum[j])
 			checkKlinesVolume(t, product, mapp.backendKeeper.Orm, expSum)
 		}
 	}
@@ -332,7 +352,7 @@ func TestKeeper_getCandles(t *testing.T) {
 	orm.MockCommitKlines(k0, k1, k2)
 
 	endTs := []int64{timeMap["-24h"], timeMap["-15m"], timeMap["-1m"], timeMap["now"] + 120}
-	expectedCloses := []string{"0.5000", "1.0000", "2.0000", "2.0000"}
+	expectedCloses := []string{"0.50000000", "1.00000000", "2.00000000", "2.00000000"}
 	expectedVolumes := []string{"0.50000000", "1.00000000", "2.00000000", "0.00000000"}
 	expectedKlineCount := []int{1, 1000, 1000, 1000}
 
@@ -20,6 +20,7 @@ import (
 	"github.com/okex/okchain/x/params"
 	tokentypes "github.com/okex/okchain/x/token/types"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -162,13 +163,14 @@ func getMockApp(t *testing.T, numGenAccs int, enableBackend bool, dbDir string)
 		nil,
 		mockApp.Cdc,
 		mockApp.Logger(),
-		cfg)
+		cfg,
+		monitor.NopBackendMetrics())
 
 	mockApp.Router().AddRoute(ordertypes.RouterKey, order.NewOrderHandler(mockApp.orderKeeper))
 	mockApp.QueryRouter().AddRoute(ordertypes.QuerierRoute, keeper.NewQuerier(mockApp.orderKeeper))
 	//mockApp.Router().AddRoute(token.RouterKey, token.NewHandler(mockApp.tokenKeeper))
 	mockApp.Router().AddRoute(token.RouterKey, token.NewTokenHandler(mockApp.tokenKeeper, version.ProtocolVersionV0))
-	mockApp.QueryRouter().AddRoute(token.QuerierRoute, token.NewQuerier(mockApp.tokenKeeper))
+	mockApp.QueryRouter().AddRoute(token.QuerierRoute, token.NewQuerier(mockApp.tokenKeeper, mockApp.AccountKeeper))
 
 	mockApp.SetEndBlocker(getEndBlocker(mockApp.orderKeeper, mockApp.backendKeeper))
 	mockApp.SetInitChainer(getInitChainer(mockApp.App, mockApp.supplyKeeper,
@@ -258,7 +260,8 @@ func mockApplyBlock(app *MockApp, ctx sdk.Context, txs []auth.StdTx) {
 			txBytes, _ := auth.DefaultTxEncoder(app.Cdc)(tx)
 			txHash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
 			app.Logger().Info(fmt.Sprintf("[Sync Tx(%s) to backend module]", txHash))
-			app.backendKeeper.SyncTx(ctx, &txs[i], txHash, ctx.BlockHeader().Time.Unix()) // do not use tx
+			deliverCtx := app.GetState(baseapp.RunTxModeDeliver()).Context()
+			app.backendKeeper.SyncTx(deliverCtx, &txs[i], txHash, response.Events.ToABCIEvents(), ctx.BlockHeader().Time.Unix()) // do not use tx
 		} else {
 			app.Logger().Error(fmt.Sprintf("DeliverTx failed: %v", response))
 		}
@@ -0,0 +1,181 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+	"github.com/okex/okchain/x/backend/client/openapi"
+	"github.com/okex/okchain/x/backend/client/rest"
+	"github.com/okex/okchain/x/backend/client/v2client"
+	"github.com/okex/okchain/x/backend/types"
+	ordertypes "github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// querierNode is a node answering the abci queries with the backend querier, other rpcs are not implemented
+type querierNode struct {
+	rpcclient.Client
+	ctx     sdk.Context
+	querier sdk.Querier
+}
+
+func (n querierNode) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	// custom/backend/<query>
+	res, err := n.querier(n.ctx, strings.Split(path, "/")[2:], abci.RequestQuery{Data: data})
+	if err != nil {
+		return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Code: uint32(err.Code()), Log: err.Error()}}, nil
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: res}}, nil
+}
+
+func (n querierNode) Block(height *int64) (*ctypes.ResultBlock, error) {
+	block := &tmtypes.Block{Header: tmtypes.Header{Height: *height}, Data: tmtypes.Data{Txs: tmtypes.Txs{[]byte("tx")}}}
+	return &ctypes.ResultBlock{Block: block}, nil
+}
+
+// mockRestServerV2 serves the routes v2 with a trade of orders[0] and a book snapshot indexed at height 3001
+func mockRestServerV2(t *testing.T) (*httptest.Server, []*ordertypes.Order) {
+	mapp, ctx, querier, orders := mockQuerier(t)
+	keeper := mapp.backendKeeper
+	height, timestamp, sender := int64(3001), time.Now().Unix(), orders[0].Sender.String()
+	results := []*types.MatchResult{{Timestamp: timestamp, BlockHeight: height, Product: types.TestTokenPair, Price: "10", Quantity: "1"}}
+	_, err := keeper.Orm.AddMatchResults(results)
+	require.Nil(t, err)
+	_, err = keeper.Orm.AddDeals([]*types.Deal{{Timestamp: timestamp, BlockHeight: height, OrderId: "ID0000003001-1",
+		Sender: sender, Product: types.TestTokenPair, Side: types.BuyOrder, Price: "10", Quantity: "1", Fee: ""}})
+	require.Nil(t, err)
+	_, err = keeper.Orm.AddTransactions([]*types.Transaction{{TxHash: "3001", Type: types.TxTypeOrderNew, Address: sender,
+		Symbol: types.TestTokenPair, Side: types.TxSideBuy, Quantity: "1", Fee: "", Timestamp: timestamp, Detail: "{}"}})
+	require.Nil(t, err)
+	keeper.UpdateTickers(height, timestamp, results)
+	(&keeper).EnableDepthSnapshots(1, 20)
+	_, err = keeper.Orm.AddDepthSnapshots(keeper.TakeDepthSnapshots(ctx.WithBlockHeight(height)))
+	require.Nil(t, err)
+
+	cliCtx := clientcontext.NewCLIContext().WithCodec(mapp.Cdc).WithTrustNode(true).
+		WithClient(querierNode{ctx: ctx, querier: querier})
+	router := mux.NewRouter()
+	rest.RegisterRoutesV2(cliCtx, router)
+	return httptest.NewServer(router), orders
+}
+
+func TestRoutesV2_OpenAPI(t *testing.T) {
+	server, orders := mockRestServerV2(t)
+	defer server.Close()
+
+	address := orders[0].Sender.String()
+	after, before := "0", fmt.Sprint(time.Now().Unix()+types.SecondsInADay)
+	// a request with valid parameters of every operation
+	requests := map[string]string{
+		"getBlockTxHashes": "/block_tx_hashes/10",
+		"getInstruments":   "/instruments",
+		"getTickerList":    "/instruments/ticker",
+		"getTicker":        "/instruments/" + types.TestTokenPair + "/ticker",
+		"getPendingOrders": "/orders_pending?instrument_id=" + types.TestTokenPair,
+		"getOpenOrders":    "/orders/list/open?instrument_id=" + types.TestTokenPair + "&address=" + address,
+		"getClosedOrders":  "/orders/list/closed?instrument_id=" + types.TestTokenPair,
+		"getOrder":         "/orders/" + orders[0].OrderID,
+		"getCandles":       "/instruments/" + types.TestTokenPair + "/candles?granularity=60&size=10",
+		"getMatches":       "/instruments/" + types.TestTokenPair + "/matches?after=" + after + "&before=" + before,
+		"getBookSnapshot":  "/instruments/" + types.TestTokenPair + "/book_snapshot",
+		"getFees":          "/fees?address=" + address + "&after=" + after + "&before=" + before,
+		"getDeals":         "/deals?address=" + address + "&side=buy&after=" + after + "&before=" + before,
+		"getTransactions":  "/transactions?address=" + address + "&after=" + after + "&before=" + before,
+		"getPnL":           "/pnl?address=" + address,
+	}
+
+	spec := rest.SpecV2()
+	for _, entry := range spec.Operations() {
+		op := entry.Operation
+		path, ok := requests[op.OperationID]
+		require.True(t, ok, "no request of %s", op.OperationID)
+		delete(requests, op.OperationID)
+
+		status, body := getV2(t, server.URL+path)
+		require.Equal(t, http.StatusOK, status, "%s: %s", path, body)
+		require.Nil(t, spec.Validate(op, status, body), "%s: %s", path, body)
+	}
+	require.Empty(t, requests)
+
+	// errors
+	for _, path := range []string{"/fees?address=" + address, "/deals?address=okchain1", "/orders/list/open"} {
+		status, body := getV2(t, server.URL+path)
+		require.Equal(t, http.StatusBadRequest, status)
+		op := spec.Paths[strings.Split(path, "?")[0]].Get
+		require.Nil(t, spec.Validate(op, status, body), "%s: %s", path, body)
+	}
+
+	// the spec served
+	status, body := getV2(t, server.URL+rest.SpecV2Path)
+	require.Equal(t, http.StatusOK, status)
+	served := &openapi.Spec{}
+	require.Nil(t, json.Unmarshal(body, served))
+	require.Equal(t, len(spec.Operations()), len(served.Operations()))
+}
+
+func TestRoutesV2_Client(t *testing.T) {
+	server, orders := mockRestServerV2(t)
+	defer server.Close()
+	client := v2client.NewClient(server.URL)
+	ctx := context.Background()
+	address := orders[0].Sender.String()
+	before := time.Now().Unix() + types.SecondsInADay
+
+	ticker, err := client.GetTicker(ctx, types.TestTokenPair)
+	require.Nil(t, err)
+	require.Equal(t, types.TestTokenPair, ticker.InstrumentId)
+
+	// the ticker list is encoded with the names of the go fields, without the instrument ids
+	tickers, err := client.GetTickerList(ctx)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(tickers))
+	require.Equal(t, ticker.Last, tickers[0].Last)
+	require.Empty(t, tickers[0].InstrumentId)
+
+	order, err := client.GetOrder(ctx, orders[0].OrderID)
+	require.Nil(t, err)
+	require.Equal(t, orders[0].OrderID, order.OrderId)
+
+	matches, err := client.GetMatches(ctx, types.TestTokenPair, v2client.GetMatchesParams{Before: before})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(matches))
+
+	deals, err := client.GetDeals(ctx, v2client.GetDealsParams{Address: address, Before: before})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(deals))
+	require.Equal(t, types.BuyOrder, deals[0].Side)
+
+	report, err := client.GetPnL(ctx, v2client.GetPnLParams{Address: address})
+	require.Nil(t, err)
+	require.Equal(t, address, report.Address)
+
+	_, err = client.GetFees(ctx, v2client.GetFeesParams{Address: "okchain1"})
+	apiErr, ok := err.(*v2client.APIError)
+	require.True(t, ok, "%v", err)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.NotEmpty(t, apiErr.Body.Code)
+}
+
+func getV2(t *testing.T, url string) (int, []byte) {
+	resp, err := http.Get(url)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.Nil(t, err)
+	return resp.StatusCode, body
+}
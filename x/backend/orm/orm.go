@@ -1,16 +1,19 @@
 package orm
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	okchaincfg "github.com/cosmos/cosmos-sdk/server/config"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
@@ -25,6 +28,9 @@ import (
 const (
 	EngineTypeSqlite = okchaincfg.BackendOrmEngineTypeSqlite
 	EngineTypeMysql  = okchaincfg.BackendOrmEngineTypeMysql
+
+	// the number of rows copied at a time by the migration of the DOUBLE columns
+	migrationBatchSize = 1000
 )
 
 type OrmEngineInfo = okchaincfg.BackendOrmEngineInfo
@@ -75,11 +81,17 @@ func New(enableLog bool, engineInfo *OrmEngineInfo, logger *log.Logger) (m *ORM,
 	orm.bufferLock = new(sync.Mutex)
 	orm.singleEntryLock = new(sync.Mutex)
 	orm.db.LogMode(enableLog)
+	if err = orm.migrateDecimalColumns(); err != nil {
+		return nil, err
+	}
 	orm.db.AutoMigrate(&types.MatchResult{})
 	orm.db.AutoMigrate(&types.Deal{})
 	orm.db.AutoMigrate(&token.FeeDetail{})
 	orm.db.AutoMigrate(&types.Order{})
 	orm.db.AutoMigrate(&types.Transaction{})
+	orm.db.AutoMigrate(&types.ReindexProgress{})
+	orm.db.AutoMigrate(&types.IndexerProgress{})
+	orm.db.AutoMigrate(&types.DepthSnapshot{})
 
 	allKlinesMap := types.GetAllKlineMap()
 	for _, v := range allKlinesMap {
@@ -89,6 +101,207 @@ func New(enableLog bool, engineInfo *OrmEngineInfo, logger *log.Logger) (m *ORM,
 	return &orm, nil
 }
 
+// migrateDecimalColumns converts the prices, quantities and klines created as DOUBLE columns by earlier
+// versions into exact decimal strings, so that the existing databases keep working after upgrading
+func (orm *ORM) migrateDecimalColumns() error {
+	models := []interface{}{&types.MatchResult{}, &types.Deal{}}
+	for _, v := range types.GetAllKlineMap() {
+		models = append(models, types.MustNewKlineFactory(v, nil))
+	}
+
+	for _, model := range models {
+		if err := orm.migrateDoubleTable(model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (orm *ORM) migrateDoubleTable(model interface{}) error {
+	scope := orm.db.NewScope(model)
+	tableName := scope.TableName()
+	oldTableName := tableName + "_double"
+	if orm.db.HasTable(oldTableName) {
+		return orm.resumeDoubleTable(model, tableName, oldTableName)
+	}
+	if !orm.db.HasTable(tableName) {
+		return nil
+	}
+
+	columns, isDouble, err := doubleColumns(orm.db, tableName)
+	if err != nil || len(isDouble) == 0 {
+		return err
+	}
+	orm.Debug(fmt.Sprintf("[backend] migrating DOUBLE columns of %s to decimal strings", tableName))
+
+	// sqlite runs the whole migration atomically, ddl statements commit implicitly in mysql
+	tx := orm.db.Begin()
+	if err = copyDoubleTable(tx, model, tableName, oldTableName, columns, isDouble); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// resumeDoubleTable finishes a migration stopped after the table was moved aside, which only happens in mysql where
+// the rename commits implicitly. The rows copied before the stop are dropped with the new table and copied again
+func (orm *ORM) resumeDoubleTable(model interface{}, tableName, oldTableName string) error {
+	orm.Debug(fmt.Sprintf("[backend] resuming the migration of the DOUBLE columns of %s", tableName))
+	columns, isDouble, err := doubleColumns(orm.db, oldTableName)
+	if err != nil {
+		return err
+	}
+
+	tx := orm.db.Begin()
+	if err = tx.DropTableIfExists(tableName).Error; err == nil {
+		err = fillDoubleTable(tx, model, tableName, oldTableName, columns, isDouble)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// doubleColumns returns the columns of a table and which of them are DOUBLE
+func doubleColumns(db *gorm.DB, tableName string) (columns []string, isDouble map[int]bool, err error) {
+	rows, err := db.Table(tableName).Limit(1).Rows()
+	if err != nil {
+		return nil, nil, err
+	}
+	columnTypes, err := rows.ColumnTypes()
+	rows.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns = make([]string, 0, len(columnTypes))
+	isDouble = map[int]bool{}
+	for i, ct := range columnTypes {
+		columns = append(columns, ct.Name())
+		if strings.ToUpper(ct.DatabaseTypeName()) == "DOUBLE" {
+			isDouble[i] = true
+		}
+	}
+	return columns, isDouble, nil
+}
+
+func copyDoubleTable(tx *gorm.DB, model interface{}, tableName, oldTableName string, columns []string,
+	isDouble map[int]bool) error {
+
+	// 1. Move the old table aside, its indexes would clash with the ones of the new table in sqlite
+	if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tableName, oldTableName)).Error; err != nil {
+		return err
+	}
+	scope := tx.NewScope(model)
+	for _, field := range scope.GetStructFields() {
+		if name, ok := field.TagSettingsGet("INDEX"); ok {
+			for _, name := range strings.Split(name, ",") {
+				if name == "INDEX" || name == "" {
+					name = scope.Dialect().BuildKeyName("idx", tableName, field.DBName)
+				}
+				if err := tx.Table(oldTableName).RemoveIndex(name).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return fillDoubleTable(tx, model, tableName, oldTableName, columns, isDouble)
+}
+
+// fillDoubleTable creates the table of the model and copies the rows of the old table into it, then drops the old one
+func fillDoubleTable(tx *gorm.DB, model interface{}, tableName, oldTableName string, columns []string,
+	isDouble map[int]bool) error {
+
+	if err := tx.AutoMigrate(model).Error; err != nil {
+		return err
+	}
+
+	// 2. Copy rows by batches, formatting doubles as the decimals they were stored from.
+	// A batch is read before being inserted, as mysql can't execute a statement while reading the rows of another one
+	var orders []string
+	for _, field := range tx.NewScope(model).PrimaryFields() {
+		orders = append(orders, field.DBName)
+	}
+	insertSQL := fmt.Sprintf("INSERT INTO %s (%s) VALUES (?%s)",
+		tableName, strings.Join(columns, ","), strings.Repeat(",?", len(columns)-1))
+	for offset := 0; ; offset += migrationBatchSize {
+		query := tx.Table(oldTableName).Select(strings.Join(columns, ",")).Offset(offset).Limit(migrationBatchSize)
+		if len(orders) != 0 {
+			query = query.Order(strings.Join(orders, ","))
+		}
+		batch, err := readDoubleRows(query, tableName, columns, isDouble)
+		if err != nil {
+			return err
+		}
+		for _, values := range batch {
+			if err := tx.Exec(insertSQL, values...).Error; err != nil {
+				return err
+			}
+		}
+		if len(batch) < migrationBatchSize {
+			break
+		}
+	}
+
+	return tx.Exec(fmt.Sprintf("DROP TABLE %s", oldTableName)).Error
+}
+
+// readDoubleRows reads the rows of the query, with the doubles formatted as decimal strings
+func readDoubleRows(query *gorm.DB, tableName string, columns []string, isDouble map[int]bool) (
+	batch [][]interface{}, err error) {
+
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		doubles := make([]sql.NullFloat64, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range columns {
+			if isDouble[i] {
+				dest[i] = &doubles[i]
+			} else {
+				dest[i] = &values[i]
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i := range columns {
+			switch {
+			case isDouble[i] && doubles[i].Valid:
+				dec, err := doubleToDec(doubles[i].Float64)
+				if err != nil {
+					return nil, fmt.Errorf("failed to migrate %s of %s: %s", columns[i], tableName, err.Error())
+				}
+				values[i] = dec.String()
+			case isDouble[i]:
+				values[i] = nil
+			default:
+				// text is scanned as bytes, which sqlite would store as blobs
+				if b, ok := values[i].([]byte); ok {
+					values[i] = string(b)
+				}
+			}
+		}
+		batch = append(batch, values)
+	}
+	return batch, rows.Err()
+}
+
+// doubleToDec converts a double with its shortest decimal representation, which is the decimal it was parsed from,
+// rather than its exact binary value which has noise digits beyond the precision of a double
+func doubleToDec(f float64) (sdk.Dec, error) {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 && len(s)-i-1 > sdk.Precision {
+		s = s[:i+1+sdk.Precision]
+	}
+	return sdk.NewDecFromStr(s)
+}
+
 func (orm *ORM) Debug(msg string) {
 	if orm.logger != nil {
 		(*orm.logger).Debug(msg)
@@ -198,6 +411,13 @@ func (orm *ORM) GetMatchResultsByTimeRange(product string, startTime, endTime in
 	return matchResults, r.Error
 }
 
+// GetMatchResultsAfterHeight returns the match results of the blocks after height, sorted by block height ascending
+func (orm *ORM) GetMatchResultsAfterHeight(height int64) ([]types.MatchResult, error) {
+	var matchResults []types.MatchResult
+	r := orm.db.Where("block_height > ?", height).Order("block_height asc").Find(&matchResults)
+	return matchResults, r.Error
+}
+
 func (orm *ORM) GetLatestMatchResults(product string, limit int) ([]types.MatchResult, error) {
 	var matchResults []types.MatchResult
 	r := orm.db.Where("Product = ?", product).Order("Timestamp desc").Limit(limit).Find(&matchResults)
@@ -208,6 +428,18 @@ func (orm *ORM) GetLatestMatchResults(product string, limit int) ([]types.MatchR
 	return matchResults, r.Error
 }
 
+// GetLatestMatchResultBefore returns the latest match result of a product at or before the timestamp,
+// nil if there is none
+func (orm *ORM) GetLatestMatchResultBefore(product string, timestamp int64) (*types.MatchResult, error) {
+	var matchResults []types.MatchResult
+	r := orm.db.Where("Product = ? and Timestamp <= ?", product, timestamp).Order("Timestamp desc").Limit(1).
+		Find(&matchResults)
+	if r.Error != nil || len(matchResults) == 0 {
+		return nil, r.Error
+	}
+	return &matchResults[0], nil
+}
+
 // Deal
 func (orm *ORM) AddDeals(deals []*types.Deal) (addedCnt int, err error) {
 	orm.singleEntryLock.Lock()
@@ -291,6 +523,28 @@ func (orm *ORM) GetDeals(address, product, side string, startTime, endTime int64
 	return deals, total
 }
 
+// GetDealsInTimeOrder returns all the deals of an address, in the order they were made
+func (orm *ORM) GetDealsInTimeOrder(address, product string) ([]types.Deal, error) {
+	var deals []types.Deal
+	query := orm.db.Model(types.Deal{}).Where("sender = ?", address)
+	if product != "" {
+		query = query.Where("product = ?", product)
+	}
+	r := query.Order("block_height asc, order_id asc").Find(&deals)
+	return deals, r.Error
+}
+
+// GetTransfersInTimeOrder returns the transactions of the transfers of a token in or out of an address,
+// in the order they were made
+func (orm *ORM) GetTransfersInTimeOrder(address, symbol string) ([]types.Transaction, error) {
+	var txs []types.Transaction
+	r := orm.db.Model(types.Transaction{}).Where("address = ? and symbol = ?", address, symbol).
+		Where("type in (?)", []int64{types.TxTypeTransfer, types.TxTypeMultiSend, types.TxTypeTimeLockedSend}).
+		Where("side in (?)", []int64{types.TxSideFrom, types.TxSideTo}).
+		Order("timestamp asc").Find(&txs)
+	return txs, r.Error
+}
+
 func (orm *ORM) GetDealsByTimestampRange(product string, startTS, endTS int64) ([]types.Deal, error) {
 	var deals []types.Deal
 	r := orm.db.Model(types.Deal{}).Where(
@@ -313,15 +567,6 @@ func (orm *ORM) getOpenCloseDeals(startTS, endTS int64, product string) (open *t
 	return nil, nil
 }
 
-func (orm *ORM) getOpenCloseKline(startTS, endTS int64, product string, firstK interface{}, lastK interface{}) error {
-	defer types.PrintStackIfPanic()
-
-	orm.db.Where("Timestamp >= ? and Timestamp < ? and Product = ?", startTS, endTS, product).Order("Timestamp desc").Limit(1).First(lastK)
-	orm.db.Where("Timestamp >= ? and Timestamp < ? and Product = ?", startTS, endTS, product).Order("Timestamp asc").Limit(1).First(firstK)
-
-	return nil
-}
-
 func (orm *ORM) getMinTimestamp(tbName string) int64 {
 
 	sql := fmt.Sprintf("select min(Timestamp) as ts from %s", tbName)
@@ -379,8 +624,7 @@ func (orm *ORM) GetMergeResultMaxTimestamp() int64 {
 
 type IKline1MDataSource interface {
 	GetDataSourceMinTimestamp() int64
-	GetMaxMinSumByGroupSQL(startTS, endTS int64) string
-	GetOpenClosePrice(startTS, endTS int64, product string) (float64, float64)
+	GetTradesByTimeRange(startTS, endTS int64) ([]types.MatchResult, error)
 }
 
 type DealDataSource struct {
@@ -391,15 +635,21 @@ func (dm *DealDataSource) GetDataSourceMinTimestamp() int64 {
 	return dm.orm.GetDealsMinTimestamp()
 }
 
-func (dm *DealDataSource) GetMaxMinSumByGroupSQL(startTS, endTS int64) string {
-	sql := fmt.Sprintf("select product, sum(Quantity) as quantity, max(Price) as high, min(Price) as low, count(price) as cnt from deals "+
-		"where Timestamp >= %d and Timestamp < %d and Side = 'BUY' group by product", startTS, endTS)
-	return sql
-}
+// GetTradesByTimeRange returns the buy side deals in [startTS, endTS) as trades ordered by time
+func (dm *DealDataSource) GetTradesByTimeRange(startTS, endTS int64) ([]types.MatchResult, error) {
+	var deals []types.Deal
+	r := dm.orm.db.Model(types.Deal{}).Where("Timestamp >= ? and Timestamp < ? and Side = ?", startTS, endTS, types.BuyOrder).
+		Order("Timestamp asc, block_height asc").Find(&deals)
+	if r.Error != nil {
+		return nil, r.Error
+	}
 
-func (dm *DealDataSource) GetOpenClosePrice(startTS, endTS int64, product string) (float64, float64) {
-	openDeal, closeDeal := dm.orm.getOpenCloseDeals(startTS, endTS, product)
-	return openDeal.Price, closeDeal.Price
+	trades := make([]types.MatchResult, 0, len(deals))
+	for _, deal := range deals {
+		trades = append(trades, types.MatchResult{Timestamp: deal.Timestamp, BlockHeight: deal.BlockHeight,
+			Product: deal.Product, Price: deal.Price, Quantity: deal.Quantity})
+	}
+	return trades, nil
 }
 
 type MergeResultDataSource struct {
@@ -410,15 +660,39 @@ func (dm *MergeResultDataSource) GetDataSourceMinTimestamp() int64 {
 	return dm.Orm.GetMergeResultMinTimestamp()
 }
 
-func (dm *MergeResultDataSource) GetMaxMinSumByGroupSQL(startTS, endTS int64) string {
-	sql := fmt.Sprintf("select product, sum(Quantity) as quantity, max(Price) as high, min(Price) as low, count(price) as cnt from match_results "+
-		"where Timestamp >= %d and Timestamp < %d group by product", startTS, endTS)
-	return sql
+// GetTradesByTimeRange returns the match results in [startTS, endTS) ordered by time
+func (dm *MergeResultDataSource) GetTradesByTimeRange(startTS, endTS int64) ([]types.MatchResult, error) {
+	var matchResults []types.MatchResult
+	r := dm.Orm.db.Model(types.MatchResult{}).Where("Timestamp >= ? and Timestamp < ?", startTS, endTS).
+		Order("Timestamp asc, block_height asc").Find(&matchResults)
+	return matchResults, r.Error
+}
+
+// klineAccumulator folds time ordered trades or klines of one product into a kline with exact decimal arithmetic
+type klineAccumulator struct {
+	open, close, high, low, volume sdk.Dec
+	cnt                            int
 }
 
-func (dm *MergeResultDataSource) GetOpenClosePrice(startTS, endTS int64, product string) (float64, float64) {
-	openDeal, closeDeal := dm.Orm.getOpenCloseDeals(startTS, endTS, product)
-	return openDeal.Price, closeDeal.Price
+func (acc *klineAccumulator) add(open, close, high, low, volume sdk.Dec) {
+	if acc.cnt == 0 {
+		acc.open, acc.high, acc.low, acc.volume = open, high, low, sdk.ZeroDec()
+	}
+	if high.GT(acc.high) {
+		acc.high = high
+	}
+	if low.LT(acc.low) {
+		acc.low = low
+	}
+	acc.close = close
+	acc.volume = acc.volume.Add(volume)
+	acc.cnt++
+}
+
+func (acc *klineAccumulator) baseKline(product string, timestamp int64) types.BaseKline {
+	return types.BaseKline{
+		Product: product, Timestamp: timestamp, Open: acc.open.String(), Close: acc.close.String(),
+		High: acc.high.String(), Low: acc.low.String(), Volume: acc.volume.String()}
 }
 
 // Rule1. No deals to handle between [startTS, endTS), anchorEndTS <- startTS
@@ -458,36 +732,25 @@ func (orm *ORM) CreateKline1min(startTS, endTS int64, dataSource IKline1MDataSou
 	nextTime := anchorStartTime.Add(time.Minute)
 	nextTimeStamp := nextTime.Unix()
 	for nextTimeStamp <= endTS {
-		sql := dataSource.GetMaxMinSumByGroupSQL(anchorStartTime.Unix(), nextTime.Unix())
-		rows, err := orm.db.Raw(sql).Rows()
-
-		if rows != nil && err == nil {
-			for rows.Next() {
-				var product string
-				var quantity, high, low float64
-				var cnt int
-
-				rows.Scan(&product, &quantity, &high, &low, &cnt)
-				if cnt > 0 {
-
-					openPrice, closePrice := dataSource.GetOpenClosePrice(anchorStartTime.Unix(), nextTime.Unix(), product)
-
-					b := types.BaseKline{
-						Product: product, High: high, Low: low, Volume: quantity,
-						Timestamp: anchorStartTime.Unix(), Open: openPrice, Close: closePrice}
-					k1min := types.NewKlineM1(&b)
-
-					klines := productKlines[product]
-					if klines == nil {
-						klines = []types.KlineM1{*k1min}
-					} else {
-						klines = append(klines, *k1min)
-					}
-					productKlines[product] = klines
-				}
+		trades, err := dataSource.GetTradesByTimeRange(anchorStartTime.Unix(), nextTime.Unix())
+		if err != nil {
+			orm.Error(fmt.Sprintf("failed to GetTradesByTimeRange, error: %s", err.Error()))
+		}
+
+		accs := map[string]*klineAccumulator{}
+		for _, trade := range trades {
+			acc := accs[trade.Product]
+			if acc == nil {
+				acc = &klineAccumulator{}
+				accs[trade.Product] = acc
 			}
+			price := types.DecFromString(trade.Price)
+			acc.add(price, price, price, price, types.DecFromString(trade.Quantity))
+		}
 
-			rows.Close()
+		for product, acc := range accs {
+			b := acc.baseKline(product, anchorStartTime.Unix())
+			productKlines[product] = append(productKlines[product], *types.NewKlineM1(&b))
 		}
 
 		anchorStartTime = nextTime
@@ -692,41 +955,26 @@ func (orm *ORM) MergeKlineM1(startTS, endTS int64, destKline types.IKline) (anch
 	nextTimeStamp := nextTime.Unix()
 	for nextTimeStamp <= anchorEndTime {
 
-		sql := fmt.Sprintf("select %d, product, sum(volume) as volume, max(high) as high, min(low) as low, count(*) as cnt from %s "+
-			"where Timestamp >= %d and Timestamp < %d group by product", anchorStartTime.Unix(), kM1.(types.IKline).GetTableName(), anchorStartTime.Unix(), nextTime.Unix())
-
-		rows, err := orm.db.Raw(sql).Rows()
-
-		if rows != nil && err == nil {
-			for rows.Next() {
-				var product string
-				var quantity, high, low float64
-				var cnt int
-				var ts int64
-
-				rows.Scan(&ts, &product, &quantity, &high, &low, &cnt)
-				if cnt > 0 {
-
-					openKline := types.MustNewKlineFactory(kM1.(types.IKline).GetTableName(), nil)
-					closeKline := types.MustNewKlineFactory(kM1.(types.IKline).GetTableName(), nil)
-					orm.getOpenCloseKline(anchorStartTime.Unix(), nextTime.Unix(), product, openKline, closeKline)
-
-					b := types.BaseKline{
-						Product: product, High: high, Low: low, Volume: quantity, Timestamp: anchorStartTime.Unix(),
-						Open: openKline.(types.IKline).GetOpen(), Close: closeKline.(types.IKline).GetClose()}
-
-					newDestK := types.MustNewKlineFactory(destKline.GetTableName(), &b)
+		klineM1s := []types.KlineM1{}
+		r := orm.db.Where("Timestamp >= ? and Timestamp < ?", anchorStartTime.Unix(), nextTime.Unix()).
+			Order("Timestamp asc").Find(&klineM1s)
+		if r.Error != nil {
+			orm.Error(fmt.Sprintf("failed to get %s, error: %s", kM1.(types.IKline).GetTableName(), r.Error.Error()))
+		}
 
-					klines := productKlines[product]
-					if klines == nil {
-						klines = []interface{}{newDestK}
-					} else {
-						klines = append(klines, newDestK)
-					}
-					productKlines[product] = klines
-				}
+		accs := map[string]*klineAccumulator{}
+		for _, k := range klineM1s {
+			acc := accs[k.Product]
+			if acc == nil {
+				acc = &klineAccumulator{}
+				accs[k.Product] = acc
 			}
-			rows.Close()
+			acc.add(k.GetOpen(), k.GetClose(), k.GetHigh(), k.GetLow(), k.GetVolume())
+		}
+
+		for product, acc := range accs {
+			b := acc.baseKline(product, anchorStartTime.Unix())
+			productKlines[product] = append(productKlines[product], types.MustNewKlineFactory(destKline.GetTableName(), &b))
 		}
 
 		anchorStartTime = nextTime
@@ -753,7 +1001,9 @@ func (orm *ORM) MergeKlineM1(startTS, endTS int64, destKline types.IKline) (anch
 	return anchorEndTS, len(productKlines), nil
 }
 
-// Latest 24H KlineM1 to Ticker
+// RefreshTickers computes the tickers of the last 24 hours from the klines and the deals.
+//
+// Deprecated: the backend keeper maintains the rolling tickers incrementally at every block.
 func (orm *ORM) RefreshTickers(startTS, endTS int64, productList []string) (m map[string]*types.Ticker, err error) {
 
 	orm.Debug(fmt.Sprintf("[backend] entering RefreshTickers, expected TickerTimeRange: [%d, %d)=[%s, %s), expectedProducts: %+v",
@@ -862,36 +1112,16 @@ func (orm *ORM) RefreshTickers(startTS, endTS int64, productList []string) (m ma
 
 		// 3.2 Do iklines sort desc by timestamp.
 
-		allVolume, lowest, highest := 0.0, 0.0, 0.0
+		acc := klineAccumulator{}
 		matchResults := matchResultMap[p]
 
+		// klines and match results are both sorted desc by timestamp, fold them from the oldest
 		if len(iklines) > 0 {
 			sort.Sort(iklines)
-			allVolume, lowest, highest = 0.0, iklines[0].GetLow(), iklines[0].GetHigh()
-			for _, k := range iklines {
+			for i := len(iklines) - 1; i >= 0; i-- {
+				k := iklines[i]
 				orm.Debug(fmt.Sprintf("RefreshTickers, Handled Kline(%s): %s", k.GetTableName(), k.PrettyTimeString()))
-
-				allVolume += k.GetVolume()
-				if k.GetHigh() > highest {
-					highest = k.GetHigh()
-				}
-				if k.GetLow() < lowest {
-					lowest = k.GetLow()
-				}
-			}
-		} else {
-			if len(matchResults) > 0 {
-				allVolume, lowest, highest = 0.0, matchResults[0].Price, matchResults[0].Price
-			}
-		}
-
-		for _, match := range matchResults {
-			allVolume += match.Quantity
-			if match.Price > highest {
-				highest = match.Price
-			}
-			if match.Price < lowest {
-				lowest = match.Price
+				acc.add(k.GetOpen(), k.GetClose(), k.GetHigh(), k.GetLow(), k.GetVolume())
 			}
 		}
 
@@ -901,34 +1131,34 @@ func (orm *ORM) RefreshTickers(startTS, endTS int64, productList []string) (m ma
 				orm.Debug(fmt.Sprintf("failed to GetLatestMatchResults, error: %s", err.Error()))
 			}
 
-			if len(latestMatches) == 1 {
-				matchResults = latestMatches
-				highest = matchResults[0].Price
-				lowest = matchResults[0].Price
-			} else {
+			if len(latestMatches) != 1 {
 				continue
 			}
+			price := types.DecFromString(latestMatches[0].Price)
+			acc.add(price, price, price, price, sdk.ZeroDec())
 		}
 
-		t := types.Ticker{}
-		if len(iklines) > 0 {
-			t.Open = iklines[len(iklines)-1].GetOpen()
-			t.Close = iklines[0].GetClose()
-		} else {
-			t.Open = matchResults[len(matchResults)-1].Price
+		for i := len(matchResults) - 1; i >= 0; i-- {
+			price := types.DecFromString(matchResults[i].Price)
+			acc.add(price, price, price, price, types.DecFromString(matchResults[i].Quantity))
 		}
 
-		if len(matchResults) > 0 {
-			t.Close = matchResults[0].Price
+		change := acc.close.Sub(acc.open)
+		changePercentage := 0.0
+		if !acc.open.IsZero() {
+			changePercentage, _ = strconv.ParseFloat(change.MulInt64(100).Quo(acc.open).String(), 64)
 		}
 
-		t.Volume = allVolume
-		t.High = highest
-		t.Low = lowest
+		t := types.Ticker{}
+		t.Open = acc.open.String()
+		t.Close = acc.close.String()
+		t.Volume = acc.volume.String()
+		t.High = acc.high.String()
+		t.Low = acc.low.String()
 		t.Symbol = p
 		t.Product = p
-		t.Change = t.Close - t.Open
-		t.ChangePercentage = fmt.Sprintf("%.2f", t.Change*100/t.Open) + "%"
+		t.Change = change.String()
+		t.ChangePercentage = fmt.Sprintf("%.2f", changePercentage) + "%"
 		t.Price = t.Close
 		t.Timestamp = endTS
 		tickerMap[p] = &t
@@ -976,6 +1206,13 @@ func (orm *ORM) GetFeeDetails(address string, offset, limit int) ([]token.FeeDet
 	return feeDetails, total
 }
 
+// GetAllFeeDetails returns all the fee details of an address
+func (orm *ORM) GetAllFeeDetails(address string) ([]token.FeeDetail, error) {
+	var feeDetails []token.FeeDetail
+	r := orm.db.Model(token.FeeDetail{}).Where("address = ?", address).Find(&feeDetails)
+	return feeDetails, r.Error
+}
+
 // Order
 func (orm *ORM) AddOrders(orders []*types.Order) (addedCnt int, err error) {
 	orm.singleEntryLock.Lock()
@@ -1108,6 +1345,62 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 	trx := orm.db.Begin()
 	defer orm.deferRollbackTx(trx, err)
 
+	resultMap, err = batchInsertOrUpdate(trx, newOrders, updatedOrders, deals, mrs, feeDetails, trxs)
+	if err != nil {
+		trx.Rollback()
+		return resultMap, err
+	}
+	trx.Commit()
+
+	return resultMap, nil
+}
+
+// ApplyBlockLogEntry writes the data of a block of the block log together with the indexer progress, it returns false
+// if the block was already applied
+func (orm *ORM) ApplyBlockLogEntry(entry *types.BlockLogEntry) (applied bool, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	trx := orm.db.Begin()
+	progress := types.IndexerProgress{Name: types.IndexerProgressName}
+	if r := trx.Where(&progress).First(&progress); r.Error != nil && !r.RecordNotFound() {
+		trx.Rollback()
+		return false, r.Error
+	}
+	if entry.Height <= progress.Height {
+		trx.Rollback()
+		return false, nil
+	}
+
+	if _, err = batchInsertOrUpdate(trx, entry.NewOrders, entry.UpdatedOrders, entry.Deals, entry.MatchResults,
+		entry.FeeDetails, entry.Transactions); err != nil {
+		trx.Rollback()
+		return false, err
+	}
+	for _, snapshot := range entry.DepthSnapshots {
+		if r := trx.Create(snapshot); r.Error != nil {
+			trx.Rollback()
+			return false, r.Error
+		}
+	}
+	progress.Height = entry.Height
+	if r := trx.Save(&progress); r.Error != nil {
+		trx.Rollback()
+		return false, r.Error
+	}
+	return true, trx.Commit().Error
+}
+
+// GetIndexerHeight returns the height of the last block applied by the indexer, 0 if it hasn't applied any block
+func (orm *ORM) GetIndexerHeight() int64 {
+	progress := types.IndexerProgress{Name: types.IndexerProgressName}
+	if r := orm.db.Where(&progress).First(&progress); r.Error != nil {
+		return 0
+	}
+	return progress.Height
+}
+
+func batchInsertOrUpdate(trx *gorm.DB, newOrders []*types.Order, updatedOrders []*types.Order, deals []*types.Deal, mrs []*types.MatchResult, feeDetails []*token.FeeDetail, trxs []*types.Transaction) (resultMap map[string]int, err error) {
 	resultMap = map[string]int{}
 	resultMap["newOrders"] = 0
 	resultMap["updatedOrders"] = 0
@@ -1159,7 +1452,7 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 	// 2. Batch Insert Deals
 	dealVItems := []string{}
 	for _, d := range deals {
-		vItem := fmt.Sprintf("('%d','%d','%s','%s','%s','%s','%f','%f','%s')",
+		vItem := fmt.Sprintf("('%d','%d','%s','%s','%s','%s','%s','%s','%s')",
 			d.Timestamp, d.BlockHeight, d.OrderId, d.Sender, d.Product, d.Side, d.Price, d.Quantity, d.Fee)
 		dealVItems = append(dealVItems, vItem)
 	}
@@ -1209,11 +1502,93 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 		}
 	}
 
-	trx.Commit()
-
 	return resultMap, nil
 }
 
+// GetReindexProgress returns the progress of the reindex of [fromHeight, toHeight], or nil if it has not started yet
+func (orm *ORM) GetReindexProgress(fromHeight, toHeight int64) *types.ReindexProgress {
+	var progress types.ReindexProgress
+	if r := orm.db.Where("from_height = ? and to_height = ?", fromHeight, toHeight).First(&progress); r.Error != nil {
+		return nil
+	}
+	return &progress
+}
+
+func (orm *ORM) SaveReindexProgress(progress *types.ReindexProgress) error {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	return orm.db.Save(progress).Error
+}
+
+// DeleteBlockRange deletes the orders, deals, match results, fee details and transactions of the blocks
+// [fromHeight, toHeight], whose block times lie in [startTS, endTS]
+func (orm *ORM) DeleteBlockRange(fromHeight, toHeight, startTS, endTS int64) error {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	for _, model := range []interface{}{&types.MatchResult{}, &types.Deal{}, &types.DepthSnapshot{}} {
+		if r := tx.Delete(model, "block_height >= ? and block_height <= ?", fromHeight, toHeight); r.Error != nil {
+			tx.Rollback()
+			return r.Error
+		}
+	}
+	// orders, fee details and transactions only carry the block time
+	for _, model := range []interface{}{&types.Order{}, &token.FeeDetail{}, &types.Transaction{}} {
+		if r := tx.Delete(model, "timestamp >= ? and timestamp <= ?", startTS, endTS); r.Error != nil {
+			tx.Rollback()
+			return r.Error
+		}
+	}
+	return tx.Commit().Error
+}
+
+// RebuildKlines regenerates the klines of every frequency from the match results since startTS, up to the last
+// complete minute before endTS
+func (orm *ORM) RebuildKlines(startTS, endTS int64) error {
+	freqs := make([]int, 0, len(types.GetAllKlineMap()))
+	for freq := range types.GetAllKlineMap() {
+		freqs = append(freqs, freq)
+	}
+	sort.Ints(freqs)
+
+	klines := make([]types.IKline, 0, len(freqs))
+	for _, freq := range freqs {
+		k := types.MustNewKlineFactory(types.GetKlineTableNameByFreq(freq), nil).(types.IKline)
+		if err := orm.deleteKlinesSince(k.GetAnchorTimeTS(startTS), k); err != nil {
+			return err
+		}
+		klines = append(klines, k)
+	}
+
+	// kline_m1 comes first, the others are merged from it
+	for _, k := range klines {
+		anchorTS := k.GetAnchorTimeTS(startTS)
+		if endTS <= anchorTS {
+			continue
+		}
+
+		var err error
+		if k.GetFreqInSecond() == 60 {
+			_, _, err = orm.CreateKline1min(anchorTS, endTS, &MergeResultDataSource{Orm: orm})
+		} else {
+			_, _, err = orm.MergeKlineM1(anchorTS, endTS, k)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (orm *ORM) deleteKlinesSince(unixTS int64, kline interface{}) error {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	return orm.db.Delete(kline, " Timestamp >= ? ", unixTS).Error
+}
+
 func (orm *ORM) GetOrderListV2(instrumentId string, address string, side string, open bool, after string, before string, limit int) []types.Order {
 	var orders []types.Order
 
@@ -1335,3 +1710,38 @@ func (orm *ORM) GetTransactionListV2(address string, txType int, after string, b
 	query.Order("timestamp desc").Limit(limit).Find(&txs)
 	return txs
 }
+
+// DepthSnapshot
+func (orm *ORM) AddDepthSnapshots(snapshots []*types.DepthSnapshot) (addedCnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	cnt := 0
+	tx := orm.db.Begin()
+	defer func() { orm.deferRollbackTx(tx, err) }()
+
+	for _, snapshot := range snapshots {
+		if r := tx.Create(snapshot); r.Error != nil {
+			return cnt, r.Error
+		}
+		cnt++
+	}
+	return cnt, tx.Commit().Error
+}
+
+// GetDepthSnapshot returns the latest depth snapshot of the product taken at or before the height and the timestamp,
+// the ones equal to 0 are ignored. It returns nil if there is no such snapshot.
+func (orm *ORM) GetDepthSnapshot(product string, height, timestamp int64) *types.DepthSnapshot {
+	var snapshot types.DepthSnapshot
+	query := orm.db.Model(types.DepthSnapshot{}).Where("product = ?", product)
+	if height > 0 {
+		query = query.Where("block_height <= ?", height)
+	}
+	if timestamp > 0 {
+		query = query.Where("timestamp <= ?", timestamp)
+	}
+	if r := query.Order("block_height desc").First(&snapshot); r.Error != nil {
+		return nil
+	}
+	return &snapshot
+}
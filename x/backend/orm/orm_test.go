@@ -1,11 +1,11 @@
 package orm
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"runtime/debug"
-	"strconv"
 	"testing"
 	"time"
 
@@ -41,13 +41,13 @@ func TestGorm(t *testing.T) {
 	p := sdk.NewDecWithPrec(1, 2)
 	p.String()
 
-	fp, _ := strconv.ParseFloat(p.String(), 64)
+	fp := p.String()
 
 	d1 := types.Deal{
-		BlockHeight: 1, OrderId: "order0", Product: "abc_bcd", Price: fp, Quantity: 100,
+		BlockHeight: 1, OrderId: "order0", Product: "abc_bcd", Price: fp, Quantity: "100",
 		Sender: "asdlfkjsd", Side: types.SellOrder, Timestamp: time.Now().Unix()}
 	d2 := types.Deal{
-		BlockHeight: 2, OrderId: "order1", Product: "abc_bcd", Price: fp, Quantity: 200,
+		BlockHeight: 2, OrderId: "order1", Product: "abc_bcd", Price: fp, Quantity: "200",
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: time.Now().Unix()}
 
 	db.AutoMigrate(&types.Deal{})
@@ -149,31 +149,31 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 	assert.True(t, err == nil)
 
 	p := sdk.NewDecWithPrec(1, 2)
-	fp, _ := strconv.ParseFloat(p.String(), 64)
-	highPrice, _ := strconv.ParseFloat("100", 64)
-	lowPrice, _ := strconv.ParseFloat("0.0001", 64)
+	fp := p.String()
+	highPrice := sdk.NewDec(100).String()
+	lowPrice := sdk.NewDecWithPrec(1, 4).String()
 
 	product := "abc_bcd"
 	adr1 := "asdlfkjsd"
 
 	ts := time.Now().Unix()
 	d1 := types.Deal{
-		BlockHeight: 1, OrderId: "order0", Product: product, Price: fp, Quantity: 100,
+		BlockHeight: 1, OrderId: "order0", Product: product, Price: fp, Quantity: "100",
 		Sender: adr1, Side: types.BuyOrder, Timestamp: ts - 60*30}
 	d2 := types.Deal{
-		BlockHeight: 2, OrderId: "order1", Product: product, Price: fp + 0.1, Quantity: 200,
+		BlockHeight: 2, OrderId: "order1", Product: product, Price: p.Add(sdk.NewDecWithPrec(1, 1)).String(), Quantity: "200",
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: ts - 60*15}
 	d3 := types.Deal{
-		BlockHeight: 3, OrderId: "order1", Product: product, Price: fp, Quantity: 300,
+		BlockHeight: 3, OrderId: "order1", Product: product, Price: fp, Quantity: "300",
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: ts - 60*5}
 	d4 := types.Deal{
-		BlockHeight: 4, OrderId: "order1", Product: product, Price: fp + 0.2, Quantity: 400,
+		BlockHeight: 4, OrderId: "order1", Product: product, Price: p.Add(sdk.NewDecWithPrec(2, 1)).String(), Quantity: "400",
 		Sender: "asdlfkjsd", Side: types.BuyOrder, Timestamp: ts - 60*3 - 1}
 
 	matches := []*types.MatchResult{
-		{BlockHeight: 3, Product: product, Price: fp, Quantity: 300, Timestamp: ts - 60*5},
-		{BlockHeight: 4, Product: product, Price: highPrice, Quantity: 200, Timestamp: ts - 60},
-		{BlockHeight: 5, Product: product, Price: lowPrice, Quantity: 200, Timestamp: ts - 60},
+		{BlockHeight: 3, Product: product, Price: fp, Quantity: "300", Timestamp: ts - 60*5},
+		{BlockHeight: 4, Product: product, Price: highPrice, Quantity: "200", Timestamp: ts - 60},
+		{BlockHeight: 5, Product: product, Price: lowPrice, Quantity: "200", Timestamp: ts - 60},
 	}
 	addCnt, err := orm.AddMatchResults(matches)
 	assert.Equal(t, len(matches), addCnt)
@@ -188,10 +188,10 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 
 	deals, err = orm.GetLatestDeals(product, 100)
 	assert.True(t, len(deals) == len(all_deals) && deals != nil)
-	var allDealVolume, allKM1Volume, allKM3Volume float64
+	allDealVolume, allKM1Volume, allKM3Volume := sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()
 	for _, d := range deals {
 		fmt.Printf("%+v\n", d)
-		allDealVolume += d.Quantity
+		allDealVolume = allDealVolume.Add(types.DecFromString(d.Quantity))
 	}
 
 	deals, err = orm.GetDealsByTimestampRange(product, 0, time.Now().Unix())
@@ -224,7 +224,7 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 	fmt.Printf("NOW : %s\n", types.TimeString(ts))
 	for _, v := range *r {
 		//fmt.Printf("%d, %+v\n", v.GetTimestamp(), v.PrettyTimeString())
-		allKM1Volume += v.Volume
+		allKM1Volume = allKM1Volume.Add(v.GetVolume())
 	}
 
 	kM3, e := types.NewKlineFactory("kline_m3", nil)
@@ -250,12 +250,12 @@ func testORMAllInOne(t *testing.T, orm *ORM) {
 
 	for _, v := range kM3List {
 		//fmt.Printf("%d, %+v\n", v.GetTimestamp(), v.PrettyTimeString())
-		allKM3Volume += v.Volume
+		allKM3Volume = allKM3Volume.Add(v.GetVolume())
 	}
 	orm.GetLatestKlinesByProduct(product, 100, -1, &kM3List)
 	assert.True(t, kM3List != nil && len(kM3List) > 0)
 
-	assert.True(t, int64(allDealVolume) == int64(allKM1Volume) && int64(allKM3Volume) == int64(allKM1Volume))
+	assert.True(t, allDealVolume.Equal(allKM1Volume) && allKM3Volume.Equal(allKM1Volume))
 
 	TestORM_KlineM1ToTicker(t)
 }
@@ -430,10 +430,10 @@ func TestCandles_FromLocalDB(t *testing.T) {
 func testORMDeals(t *testing.T, orm *ORM) {
 
 	addDeals := []*types.Deal{
-		{100, 1, "ID1", "addr1", types.TestTokenPair, types.BuyOrder, 10.0, 1.0, "0"},
-		{300, 3, "ID2", "addr1", "btc_" + common.NativeToken, types.BuyOrder, 10.0, 1.0, "0"},
-		{200, 2, "ID3", "addr1", types.TestTokenPair, types.BuyOrder, 10.0, 1.0, "0"},
-		{400, 1, "ID4", "addr2", types.TestTokenPair, types.BuyOrder, 10.0, 1.0, "0"},
+		{100, 1, "ID1", "addr1", types.TestTokenPair, types.BuyOrder, "10", "1", "0"},
+		{300, 3, "ID2", "addr1", "btc_" + common.NativeToken, types.BuyOrder, "10", "1", "0"},
+		{200, 2, "ID3", "addr1", types.TestTokenPair, types.BuyOrder, "10", "1", "0"},
+		{400, 1, "ID4", "addr2", types.TestTokenPair, types.BuyOrder, "10", "1", "0"},
 	}
 	// Test AddDeals
 	cnt, err := orm.AddDeals(addDeals)
@@ -470,9 +470,15 @@ func testORMDeals(t *testing.T, orm *ORM) {
 	require.EqualValues(t, addDeals[2], &dealsV2[0])
 
 	mrds := MergeResultDataSource{orm}
-	oPrice, cPrice := mrds.GetOpenClosePrice(0, time.Now().Unix(), types.TestTokenPair)
-	require.EqualValues(t, 10, oPrice)
-	require.EqualValues(t, 10, cPrice)
+	trades, err := mrds.GetTradesByTimeRange(0, time.Now().Unix())
+	require.Nil(t, err)
+	require.Empty(t, trades)
+	dds := DealDataSource{orm}
+	trades, err = dds.GetTradesByTimeRange(0, time.Now().Unix())
+	require.Nil(t, err)
+	require.EqualValues(t, 4, len(trades))
+	require.EqualValues(t, "10", trades[0].Price)
+	require.EqualValues(t, "10", trades[len(trades)-1].Price)
 }
 
 // Matches
@@ -481,10 +487,10 @@ func TestORMMatches(t *testing.T) {
 	defer DeleteDB(dbPath)
 
 	addMatches := []*types.MatchResult{
-		{100, 1, types.TestTokenPair, 10.0, 1.0},
-		{100, 1, "btc_" + common.NativeToken, 11.0, 2.0},
-		{200, 2, types.TestTokenPair, 12.0, 3.0},
-		{300, 3, types.TestTokenPair, 13.0, 4.0},
+		{100, 1, types.TestTokenPair, "10", "1"},
+		{100, 1, "btc_" + common.NativeToken, "11", "2"},
+		{200, 2, types.TestTokenPair, "12", "3"},
+		{300, 3, types.TestTokenPair, "13", "4"},
 	}
 	// Test AddMatchResults
 	cnt, err := orm.AddMatchResults(addMatches)
@@ -496,8 +502,8 @@ func TestORMMatches(t *testing.T) {
 	matches, total := orm.GetMatchResults(types.TestTokenPair, 0, 0, 1, 2)
 	require.EqualValues(t, 3, total)
 	require.EqualValues(t, 2, len(matches))
-	require.EqualValues(t, 3, matches[0].Quantity)
-	require.EqualValues(t, 1, matches[1].Quantity)
+	require.EqualValues(t, "3", matches[0].Quantity)
+	require.EqualValues(t, "1", matches[1].Quantity)
 
 	// filtered by address & start end time
 	matches, total = orm.GetMatchResults("", 100, 200, 0, 3)
@@ -527,8 +533,11 @@ func TestORMMatches(t *testing.T) {
 	//
 	mrds := MergeResultDataSource{orm}
 	require.EqualValues(t, 100, mrds.GetDataSourceMinTimestamp())
-	sql := `select product, sum(Quantity) as quantity, max(Price) as high, min(Price) as low, count(price) as cnt from match_results where Timestamp >= 0 and Timestamp < 1574406957 group by product`
-	require.EqualValues(t, sql, mrds.GetMaxMinSumByGroupSQL(0, 1574406957))
+	trades, err := mrds.GetTradesByTimeRange(0, 250)
+	require.Nil(t, err)
+	require.EqualValues(t, 3, len(trades))
+	require.EqualValues(t, "10", trades[0].Price)
+	require.EqualValues(t, "12", trades[2].Price)
 
 }
 
@@ -663,10 +672,10 @@ func testORMTransactions(t *testing.T, orm *ORM) {
 	defer DeleteDB(dbPath)
 
 	txs := []*types.Transaction{
-		{"hash1", types.TxTypeTransfer, "addr1", common.TestToken, types.TxSideFrom, "10.0", "0.1" + common.NativeToken, 100},
-		{"hash2", types.TxTypeOrderNew, "addr1", types.TestTokenPair, types.TxSideBuy, "10.0", "0.1" + common.NativeToken, 300},
-		{"hash3", types.TxTypeOrderCancel, "addr1", types.TestTokenPair, types.TxSideSell, "10.0", "0.1" + common.NativeToken, 200},
-		{"hash4", types.TxTypeTransfer, "addr2", common.TestToken, types.TxSideTo, "10.0", "0.1" + common.NativeToken, 100},
+		{"hash1", types.TxTypeTransfer, "addr1", common.TestToken, types.TxSideFrom, "10.0", "0.1" + common.NativeToken, 100, ""},
+		{"hash2", types.TxTypeOrderNew, "addr1", types.TestTokenPair, types.TxSideBuy, "10.0", "0.1" + common.NativeToken, 300, ""},
+		{"hash3", types.TxTypeOrderCancel, "addr1", types.TestTokenPair, types.TxSideSell, "10.0", "0.1" + common.NativeToken, 200, ""},
+		{"hash4", types.TxTypeTransfer, "addr2", common.TestToken, types.TxSideTo, "10.0", "0.1" + common.NativeToken, 100, ""},
 	}
 	// Test AddTransactions
 	cnt, err := orm.AddTransactions(txs)
@@ -749,18 +758,18 @@ func testORMBatchInsert(t *testing.T, orm *ORM) {
 	}
 
 	txs := []*types.Transaction{
-		{"FAKEIDHash-1", types.TxTypeTransfer, "addr1", common.TestToken, types.TxSideFrom, "10.0", "0.1" + common.NativeToken, 100},
+		{"FAKEIDHash-1", types.TxTypeTransfer, "addr1", common.TestToken, types.TxSideFrom, "10.0", "0.1" + common.NativeToken, 100, ""},
 	}
 
 	addDeals := []*types.Deal{
-		{100, 1, "FAKEID-0001", "addr1", types.TestTokenPair, types.BuyOrder, 10.0, 1.0, "0"},
-		{300, 3, "FAKEID-0002", "addr1", "btc_" + common.NativeToken, types.BuyOrder, 10.0, 1.0, "0"},
-		{200, 2, "FAKEID-0003", "addr1", types.TestTokenPair, types.BuyOrder, 10.0, 1.0, "0"},
-		{400, 1, "FAKEID-0004", "addr2", types.TestTokenPair, types.BuyOrder, 10.0, 1.0, "0"},
+		{100, 1, "FAKEID-0001", "addr1", types.TestTokenPair, types.BuyOrder, "10", "1", "0"},
+		{300, 3, "FAKEID-0002", "addr1", "btc_" + common.NativeToken, types.BuyOrder, "10", "1", "0"},
+		{200, 2, "FAKEID-0003", "addr1", types.TestTokenPair, types.BuyOrder, "10", "1", "0"},
+		{400, 1, "FAKEID-0004", "addr2", types.TestTokenPair, types.BuyOrder, "10", "1", "0"},
 	}
 
 	mrs := []*types.MatchResult{
-		{100, 1, types.TestTokenPair, 10.0, 1.0},
+		{100, 1, types.TestTokenPair, "10", "1"},
 	}
 
 	feeDetails := []*token.FeeDetail{
@@ -830,13 +839,13 @@ func TestORM_CloseDB(t *testing.T) {
 
 	// insert after close DB
 	cnt, err := closeORM.AddMatchResults([]*types.MatchResult{
-		{100, 1, types.TestTokenPair, 10.0, 1.0},
+		{100, 1, types.TestTokenPair, "10", "1"},
 	})
 	require.Error(t, err)
 	require.Equal(t, 0, cnt)
 
 	cnt, err = closeORM.AddDeals([]*types.Deal{
-		{100, 1, "FAKEID-0001", "addr1", types.TestTokenPair, types.BuyOrder, 10.0, 1.0, "0"},
+		{100, 1, "FAKEID-0001", "addr1", types.TestTokenPair, types.BuyOrder, "10", "1", "0"},
 	})
 	require.Error(t, err)
 	require.Equal(t, 0, cnt)
@@ -854,7 +863,7 @@ func TestORM_CloseDB(t *testing.T) {
 	require.Equal(t, 0, cnt)
 
 	cnt, err = closeORM.AddTransactions([]*types.Transaction{
-		{"hash1", types.TxTypeTransfer, "addr1", common.TestToken, types.TxSideFrom, "10.0", "0.1" + common.NativeToken, 100},
+		{"hash1", types.TxTypeTransfer, "addr1", common.TestToken, types.TxSideFrom, "10.0", "0.1" + common.NativeToken, 100, ""},
 	})
 	require.Error(t, err)
 	require.Equal(t, 0, cnt)
@@ -876,3 +885,314 @@ func TestORM_DeferRollbackTx(t *testing.T) {
 	panic("orm deferRollbackTx recover will catch the panic")
 
 }
+
+func TestORM_MigrateDecimalColumns(t *testing.T) {
+	dbPath := "/tmp/test_migrate_decimal.db"
+	DeleteDB(dbPath)
+	defer DeleteDB(dbPath)
+
+	// tables created by the versions storing prices and quantities as DOUBLE
+	db, err := gorm.Open(EngineTypeSqlite, dbPath)
+	require.Nil(t, err)
+	for _, sql := range []string{
+		`CREATE TABLE "deals" ("timestamp" bigint,"block_height" bigint,"order_id" varchar(30),"sender" varchar(80),` +
+			`"product" varchar(20),"side" varchar(10),"price" DOUBLE,"quantity" DOUBLE,"fee" varchar(20), PRIMARY KEY ("block_height","order_id"))`,
+		`CREATE INDEX idx_deals_sender ON "deals"("sender")`,
+		`INSERT INTO deals VALUES (100, 1, 'ID1', 'addr1', 'abc_bcd', 'BUY', 0.0001, 123456.12345678, '0')`,
+		`INSERT INTO deals VALUES (200, 2, 'ID2', 'addr1', 'abc_bcd', 'BUY', 1e-08, 10, '0')`,
+		`CREATE TABLE "kline_m1" ("product" varchar(20),"timestamp" bigint,"open" DOUBLE,"close" DOUBLE,"high" DOUBLE,` +
+			`"low" DOUBLE,"volume" DOUBLE, PRIMARY KEY ("product","timestamp"))`,
+		`INSERT INTO kline_m1 VALUES ('abc_bcd', 60, 0.01, 0.02, 0.03, 0.005, 100.5)`,
+		// the exact binary values have noise digits within the precision of sdk.Dec
+		`INSERT INTO kline_m1 VALUES ('abc_bcd', 120, 12345678901.12345678, 0.1 + 0.2, 1e-9, NULL, 98765432.1)`,
+		// more rows than a batch of the migration
+		`WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < 1500) ` +
+			`INSERT INTO kline_m1 SELECT 'xyz_bcd', n * 60, n * 0.1, 1, 1, 1, 1 FROM seq`,
+	} {
+		require.Nil(t, db.Exec(sql).Error)
+	}
+	require.Nil(t, db.Close())
+
+	orm, err := NewSqlite3ORM(false, "/tmp", "test_migrate_decimal.db", nil)
+	require.Nil(t, err)
+	defer orm.Close()
+
+	deals, total := orm.GetDeals("addr1", "", "", 0, 300, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, "0.00000001", deals[0].Price)
+	require.Equal(t, "10.00000000", deals[0].Quantity)
+	require.Equal(t, "0.00010000", deals[1].Price)
+	require.Equal(t, "123456.12345678", deals[1].Quantity)
+
+	klines, err := orm.GetLatestKlineM1ByProduct("abc_bcd", 10)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(*klines))
+	require.Equal(t, "0.00500000", (*klines)[1].Low)
+	require.Equal(t, "100.50000000", (*klines)[1].Volume)
+	require.Equal(t, "12345678901.12345700", (*klines)[0].Open)
+	require.Equal(t, "0.30000000", (*klines)[0].Close)
+	require.Equal(t, "0.00000000", (*klines)[0].High)
+	require.Equal(t, "", (*klines)[0].Low)
+	require.Equal(t, "98765432.10000000", (*klines)[0].Volume)
+
+	var count int
+	require.Nil(t, orm.db.Table("kline_m1").Where("product = ?", "xyz_bcd").Count(&count).Error)
+	require.Equal(t, 1500, count)
+	klines, err = orm.GetLatestKlineM1ByProduct("xyz_bcd", 1)
+	require.Nil(t, err)
+	require.Equal(t, "150.00000000", (*klines)[0].Open)
+
+	require.False(t, orm.db.HasTable("deals_double"))
+	require.True(t, orm.db.Model(&types.Deal{}).Dialect().HasIndex("deals", "idx_deals_sender"))
+
+	// migrating twice is a no-op
+	orm2, err := NewSqlite3ORM(false, "/tmp", "test_migrate_decimal.db", nil)
+	require.Nil(t, err)
+	defer orm2.Close()
+	deals, total = orm2.GetDeals("addr1", "", "", 0, 300, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, "0.00000001", deals[0].Price)
+}
+
+func TestORM_ResumeMigrateDecimalColumns(t *testing.T) {
+	dbPath := "/tmp/test_resume_migrate_decimal.db"
+	DeleteDB(dbPath)
+	defer DeleteDB(dbPath)
+
+	// a migration stopped in mysql after the table was moved aside and some rows were copied
+	db, err := gorm.Open(EngineTypeSqlite, dbPath)
+	require.Nil(t, err)
+	for _, sql := range []string{
+		`CREATE TABLE "deals_double" ("timestamp" bigint,"block_height" bigint,"order_id" varchar(30),"sender" varchar(80),` +
+			`"product" varchar(20),"side" varchar(10),"price" DOUBLE,"quantity" DOUBLE,"fee" varchar(20), PRIMARY KEY ("block_height","order_id"))`,
+		`INSERT INTO deals_double VALUES (100, 1, 'ID1', 'addr1', 'abc_bcd', 'BUY', 0.0001, 123456.12345678, '0')`,
+		`INSERT INTO deals_double VALUES (200, 2, 'ID2', 'addr1', 'abc_bcd', 'BUY', 1e-08, 10, '0')`,
+	} {
+		require.Nil(t, db.Exec(sql).Error)
+	}
+	require.Nil(t, db.AutoMigrate(&types.Deal{}).Error)
+	require.Nil(t, db.Create(&types.Deal{Timestamp: 100, BlockHeight: 1, OrderId: "ID1", Sender: "addr1",
+		Product: "abc_bcd", Side: "BUY", Price: "0.00010000", Quantity: "123456.12345678", Fee: "0"}).Error)
+	require.Nil(t, db.Close())
+
+	orm, err := NewSqlite3ORM(false, "/tmp", "test_resume_migrate_decimal.db", nil)
+	require.Nil(t, err)
+	defer orm.Close()
+
+	deals, total := orm.GetDeals("addr1", "", "", 0, 300, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, "0.00000001", deals[0].Price)
+	require.Equal(t, "0.00010000", deals[1].Price)
+	require.Equal(t, "123456.12345678", deals[1].Quantity)
+	require.False(t, orm.db.HasTable("deals_double"))
+}
+
+func TestORM_Reindex(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	require.Nil(t, orm.GetReindexProgress(1, 10))
+	require.Nil(t, orm.SaveReindexProgress(&types.ReindexProgress{FromHeight: 1, ToHeight: 10, Height: 3}))
+	require.Nil(t, orm.SaveReindexProgress(&types.ReindexProgress{FromHeight: 1, ToHeight: 10, Height: 5}))
+	require.Nil(t, orm.SaveReindexProgress(&types.ReindexProgress{FromHeight: 2, ToHeight: 10, Height: 2}))
+	progress := orm.GetReindexProgress(1, 10)
+	require.NotNil(t, progress)
+	require.Equal(t, int64(5), progress.Height)
+
+	// block 1 at 1860s, blocks 2 and 3 at 1920s, block 4 at 2000s
+	mrs := []*types.MatchResult{
+		{Timestamp: 1860, BlockHeight: 1, Product: types.TestTokenPair, Price: "1", Quantity: "2"},
+		{Timestamp: 1920, BlockHeight: 2, Product: types.TestTokenPair, Price: "3", Quantity: "1"},
+		{Timestamp: 1920, BlockHeight: 3, Product: types.TestTokenPair, Price: "2", Quantity: "1"},
+		{Timestamp: 2000, BlockHeight: 4, Product: types.TestTokenPair, Price: "4", Quantity: "1"},
+	}
+	deals := []*types.Deal{
+		{Timestamp: 1860, BlockHeight: 1, OrderId: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "1", Quantity: "2", Fee: "0"},
+		{Timestamp: 1920, BlockHeight: 2, OrderId: "ID2", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "3", Quantity: "1", Fee: "0"},
+	}
+	orders := []*types.Order{
+		{OrderId: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "1", Quantity: "2", Status: 1, Timestamp: 1860},
+		{OrderId: "ID2", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "3", Quantity: "1", Status: 1, Timestamp: 1920},
+	}
+	feeDetails := []*token.FeeDetail{
+		{Address: "addr1", Fee: "0.1" + common.NativeToken, FeeType: types.FeeTypeOrderNew, Timestamp: 1860},
+		{Address: "addr1", Fee: "0.1" + common.NativeToken, FeeType: types.FeeTypeOrderNew, Timestamp: 1920},
+	}
+	txs := []*types.Transaction{
+		{TxHash: "hash1", Type: types.TxTypeOrderNew, Address: "addr1", Symbol: types.TestTokenPair, Side: types.TxSideBuy, Quantity: "2", Fee: "0.1", Timestamp: 1860},
+		{TxHash: "hash2", Type: types.TxTypeOrderNew, Address: "addr1", Symbol: types.TestTokenPair, Side: types.TxSideBuy, Quantity: "1", Fee: "0.1", Timestamp: 1920},
+	}
+	_, err := orm.BatchInsertOrUpdate(orders, nil, deals, mrs, feeDetails, txs)
+	require.Nil(t, err)
+
+	// klines are rebuilt from the match results, up to the last complete minute, latest first
+	require.Nil(t, orm.RebuildKlines(1860, 2000))
+	klines := []types.KlineM1{}
+	require.Nil(t, orm.GetKlinesByTimeRange(types.TestTokenPair, 0, 3000, &klines))
+	require.Equal(t, 2, len(klines))
+	require.Equal(t, "3.00000000", klines[0].Open)
+	require.Equal(t, "2.00000000", klines[0].Close)
+	require.Equal(t, "2.00000000", klines[0].Volume)
+	klinesM3 := []types.KlineM3{}
+	require.Nil(t, orm.GetKlinesByTimeRange(types.TestTokenPair, 0, 3000, &klinesM3))
+	require.Equal(t, 1, len(klinesM3))
+	require.Equal(t, "4.00000000", klinesM3[0].Volume)
+
+	// rebuilding again does not duplicate klines
+	require.Nil(t, orm.RebuildKlines(1860, 2000))
+	klines = []types.KlineM1{}
+	require.Nil(t, orm.GetKlinesByTimeRange(types.TestTokenPair, 0, 3000, &klines))
+	require.Equal(t, 2, len(klines))
+
+	// blocks [2, 3] are deleted, block 1 and block 4 are kept
+	require.Nil(t, orm.DeleteBlockRange(2, 3, 1920, 1920))
+	results, err := orm.GetMatchResultsByTimeRange(types.TestTokenPair, 0, 3000)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(results))
+	_, total := orm.GetDeals("addr1", "", "", 0, 3000, 0, 10)
+	require.Equal(t, 1, total)
+	_, total = orm.GetOrderList("addr1", "", "", false, 0, 10, 0, 3000, false)
+	require.Equal(t, 1, total)
+	_, total = orm.GetFeeDetails("addr1", 0, 10)
+	require.Equal(t, 1, total)
+	_, total = orm.GetTransactionList("addr1", 0, 0, 3000, 0, 10)
+	require.Equal(t, 1, total)
+}
+
+func TestORM_ApplyBlockLogEntry(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	require.Equal(t, int64(0), orm.GetIndexerHeight())
+	entry := &types.BlockLogEntry{
+		Height:    5,
+		Timestamp: 1860,
+		NewOrders: []*types.Order{
+			{OrderId: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "1", Quantity: "2", Status: 1, Timestamp: 1860},
+		},
+		Deals: []*types.Deal{
+			{Timestamp: 1860, BlockHeight: 5, OrderId: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "1", Quantity: "2", Fee: "0"},
+		},
+		MatchResults: []*types.MatchResult{
+			{Timestamp: 1860, BlockHeight: 5, Product: types.TestTokenPair, Price: "1", Quantity: "2"},
+		},
+	}
+	applied, err := orm.ApplyBlockLogEntry(entry)
+	require.Nil(t, err)
+	require.True(t, applied)
+	require.Equal(t, int64(5), orm.GetIndexerHeight())
+
+	// the same block is not applied twice
+	applied, err = orm.ApplyBlockLogEntry(entry)
+	require.Nil(t, err)
+	require.False(t, applied)
+	_, total := orm.GetDeals("addr1", "", "", 0, 3000, 0, 10)
+	require.Equal(t, 1, total)
+
+	// a failed block is rolled back together with the progress
+	failed := &types.BlockLogEntry{Height: 6, Timestamp: 1920, NewOrders: entry.NewOrders}
+	_, err = orm.ApplyBlockLogEntry(failed)
+	require.NotNil(t, err)
+	require.Equal(t, int64(5), orm.GetIndexerHeight())
+
+	updated := *entry.NewOrders[0]
+	updated.Status = 2
+	applied, err = orm.ApplyBlockLogEntry(&types.BlockLogEntry{Height: 6, Timestamp: 1920, UpdatedOrders: []*types.Order{&updated}})
+	require.Nil(t, err)
+	require.True(t, applied)
+	require.Equal(t, int64(6), orm.GetIndexerHeight())
+	require.Equal(t, int64(2), orm.GetOrderById("ID1").Status)
+}
+
+func TestORM_PruneTable(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	deals := []*types.Deal{
+		{Timestamp: 1860, BlockHeight: 1, OrderId: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "1", Quantity: "2", Fee: "0"},
+		{Timestamp: 1920, BlockHeight: 2, OrderId: "ID2", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "3", Quantity: "1", Fee: "0"},
+		{Timestamp: 2000, BlockHeight: 3, OrderId: "ID3", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "2", Quantity: "1", Fee: "0"},
+	}
+	orders := []*types.Order{
+		{OrderId: "ID1", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "1", Quantity: "2", Status: 1, Timestamp: 1860},
+		{OrderId: "ID2", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "3", Quantity: "1", Status: 0, Timestamp: 1920},
+		{OrderId: "ID3", Sender: "addr1", Product: types.TestTokenPair, Side: types.BuyOrder, Price: "2", Quantity: "1", Status: 6, Timestamp: 1920},
+	}
+	_, err := orm.BatchInsertOrUpdate(orders, nil, deals, nil, nil, nil)
+	require.Nil(t, err)
+
+	// the pruned deals are archived as json lines
+	archive := bytes.Buffer{}
+	pruned, err := orm.PruneTable(TableDeals, 2000, &archive)
+	require.Nil(t, err)
+	require.Equal(t, 2, pruned)
+	decoder := json.NewDecoder(&archive)
+	for _, deal := range deals[:2] {
+		archived := types.Deal{}
+		require.Nil(t, decoder.Decode(&archived))
+		require.Equal(t, *deal, archived)
+	}
+	require.False(t, decoder.More())
+	_, total := orm.GetDeals("addr1", "", "", 0, 3000, 0, 10)
+	require.Equal(t, 1, total)
+
+	// open and partially filled orders are never pruned
+	pruned, err = orm.PruneTable(TableOrders, 3000, nil)
+	require.Nil(t, err)
+	require.Equal(t, 1, pruned)
+	_, total = orm.GetOrderList("addr1", "", "", true, 0, 10, 0, 3000, false)
+	require.Equal(t, 1, total)
+
+	pruned, err = orm.PruneTable(TableDeals, 2000, nil)
+	require.Nil(t, err)
+	require.Equal(t, 0, pruned)
+	_, err = orm.PruneTable("not_exists", 2000, nil)
+	require.NotNil(t, err)
+
+	counts, err := orm.GetTableRowCounts()
+	require.Nil(t, err)
+	require.Equal(t, len(GetPrunableTables()), len(counts))
+	require.Equal(t, 1, counts[TableDeals])
+	require.Equal(t, 2, counts[TableOrders])
+	require.Equal(t, 0, counts[TableTransactions])
+}
+
+func TestORM_DepthSnapshots(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	require.Nil(t, orm.GetDepthSnapshot(types.TestTokenPair, 0, 0))
+	snapshots := []*types.DepthSnapshot{
+		{BlockHeight: 10, Product: types.TestTokenPair, Timestamp: 1860, Asks: "[]", Bids: "[]"},
+		{BlockHeight: 20, Product: types.TestTokenPair, Timestamp: 1920, Asks: "[]", Bids: "[]"},
+		{BlockHeight: 20, Product: "btc_" + common.NativeToken, Timestamp: 1920, Asks: "[]", Bids: "[]"},
+	}
+	cnt, err := orm.AddDepthSnapshots(snapshots)
+	require.Nil(t, err)
+	require.Equal(t, 3, cnt)
+	_, err = orm.AddDepthSnapshots(snapshots[:1])
+	require.NotNil(t, err)
+
+	// the latest snapshot at or before the height and the time
+	require.Equal(t, int64(20), orm.GetDepthSnapshot(types.TestTokenPair, 0, 0).BlockHeight)
+	require.Equal(t, int64(10), orm.GetDepthSnapshot(types.TestTokenPair, 19, 0).BlockHeight)
+	require.Equal(t, int64(10), orm.GetDepthSnapshot(types.TestTokenPair, 0, 1919).BlockHeight)
+	require.Equal(t, int64(20), orm.GetDepthSnapshot(types.TestTokenPair, 25, 2000).BlockHeight)
+	require.Nil(t, orm.GetDepthSnapshot(types.TestTokenPair, 9, 0))
+
+	// the snapshots of the blocks are removed for reindexing
+	require.Nil(t, orm.DeleteBlockRange(20, 20, 1920, 1920))
+	require.Equal(t, int64(10), orm.GetDepthSnapshot(types.TestTokenPair, 0, 0).BlockHeight)
+	require.Nil(t, orm.GetDepthSnapshot("btc_"+common.NativeToken, 0, 0))
+
+	// the snapshots of the block log are applied with the block
+	applied, err := orm.ApplyBlockLogEntry(&types.BlockLogEntry{Height: 30, Timestamp: 2000,
+		DepthSnapshots: []*types.DepthSnapshot{{BlockHeight: 30, Product: types.TestTokenPair, Timestamp: 2000}}})
+	require.Nil(t, err)
+	require.True(t, applied)
+	require.Equal(t, int64(30), orm.GetDepthSnapshot(types.TestTokenPair, 0, 0).BlockHeight)
+
+	pruned, err := orm.PruneTable(TableDepthSnapshots, 2000, nil)
+	require.Nil(t, err)
+	require.Equal(t, 1, pruned)
+}
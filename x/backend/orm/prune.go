@@ -0,0 +1,132 @@
+package orm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/token"
+)
+
+// the tables pruned by their kept days in the clean ups config, besides the klines
+const (
+	TableMatchResults   = "match_results"
+	TableDeals          = "deals"
+	TableFeeDetails     = "fee_details"
+	TableOrders         = "orders"
+	TableTransactions   = "transactions"
+	TableDepthSnapshots = "depth_snapshots"
+
+	pruneBatchSize = 1000
+)
+
+// prunableTable describes how the rows of a table expire
+type prunableTable struct {
+	model interface{}
+	// condition on the rows besides the timestamp, e.g. open orders are never pruned
+	condition string
+}
+
+func getPrunableTables() map[string]prunableTable {
+	tables := map[string]prunableTable{
+		TableMatchResults:   {model: &types.MatchResult{}},
+		TableDeals:          {model: &types.Deal{}},
+		TableFeeDetails:     {model: &token.FeeDetail{}},
+		TableOrders:         {model: &types.Order{}, condition: "status in (1, 2, 3, 4, 5)"},
+		TableTransactions:   {model: &types.Transaction{}},
+		TableDepthSnapshots: {model: &types.DepthSnapshot{}},
+	}
+	for _, kline := range types.GetAllKlineMap() {
+		tables[kline] = prunableTable{model: types.MustNewKlineFactory(kline, nil)}
+	}
+	return tables
+}
+
+// GetPrunableTables returns the names of the tables which can be pruned, sorted by name
+func GetPrunableTables() []string {
+	var names []string
+	for name := range getPrunableTables() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PruneTable deletes the rows of the table older than timestamp in batches. The pruned rows are written
+// into archive as json lines before deleted if archive is not nil. It returns the number of pruned rows.
+func (orm *ORM) PruneTable(table string, timestamp int64, archive io.Writer) (pruned int, err error) {
+	t, ok := getPrunableTables()[table]
+	if !ok {
+		return 0, fmt.Errorf("table %s can't be pruned", table)
+	}
+
+	for {
+		cnt, err := orm.pruneBatch(t, timestamp, archive)
+		pruned += cnt
+		if err != nil || cnt == 0 {
+			return pruned, err
+		}
+	}
+}
+
+// pruneBatch prunes the oldest rows of the table, at least the first batch and all the rows with the same
+// timestamp as the last row of the batch, so that rows are never archived twice
+func (orm *ORM) pruneBatch(t prunableTable, timestamp int64, archive io.Writer) (cnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	defer func() { orm.deferRollbackTx(tx, err) }()
+
+	where := func(lastTimestamp int64) *gorm.DB {
+		query := tx.Model(t.model).Where("timestamp < ?", lastTimestamp)
+		if t.condition != "" {
+			query = query.Where(t.condition)
+		}
+		return query
+	}
+	var lastTimestamps []int64
+	if r := where(timestamp).Order("timestamp asc").Limit(pruneBatchSize).Pluck("timestamp", &lastTimestamps); r.Error != nil {
+		return 0, r.Error
+	}
+	if len(lastTimestamps) == 0 {
+		return 0, tx.Commit().Error
+	}
+	lastTimestamp := lastTimestamps[len(lastTimestamps)-1] + 1
+
+	if archive != nil {
+		rows := reflect.New(reflect.SliceOf(reflect.TypeOf(t.model).Elem()))
+		if r := where(lastTimestamp).Find(rows.Interface()); r.Error != nil {
+			return 0, r.Error
+		}
+		encoder := json.NewEncoder(archive)
+		for i := 0; i < rows.Elem().Len(); i++ {
+			if err := encoder.Encode(rows.Elem().Index(i).Interface()); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	r := where(lastTimestamp).Delete(t.model)
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	return int(r.RowsAffected), tx.Commit().Error
+}
+
+// GetTableRowCounts returns the number of rows of every prunable table
+func (orm *ORM) GetTableRowCounts() (map[string]int, error) {
+	counts := make(map[string]int)
+	for name, t := range getPrunableTables() {
+		var cnt int
+		if r := orm.db.Model(t.model).Count(&cnt); r.Error != nil {
+			return nil, r.Error
+		}
+		counts[name] = cnt
+	}
+	return counts, nil
+}
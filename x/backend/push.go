@@ -0,0 +1,214 @@
+package backend
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/backend/push"
+	"github.com/okex/okchain/x/backend/types"
+)
+
+// pushBlock publishes the data of the block to the WebSocket subscribers of the REST server, nothing is computed
+// for the channels without subscribers
+func pushBlock(ctx sdk.Context, keeper Keeper, hub *push.Hub) {
+	defer types.PrintStackIfPanic()
+	if hub.Idle() {
+		return
+	}
+
+	newOrders, err := GetNewOrdersAtEndBlock(ctx, keeper.OrderKeeper)
+	if err != nil {
+		keeper.Logger.Error(fmt.Sprintf("[backend] failed to push block %d, error: %s", ctx.BlockHeight(), err.Error()))
+		return
+	}
+	orders := append(newOrders, GetUpdatedOrdersAtEndBlock(ctx, keeper.OrderKeeper)...)
+	deals, results, err := GetNewDealsAndMatchResultsAtEndBlock(ctx, keeper.OrderKeeper)
+	if err != nil {
+		keeper.Logger.Error(fmt.Sprintf("[backend] failed to push block %d, error: %s", ctx.BlockHeight(), err.Error()))
+		return
+	}
+
+	pushTickers(keeper, hub)
+	pushMatches(hub, results)
+	pushCandles(keeper, hub, results, ctx.BlockHeader().Time.Unix())
+	pushDepths(keeper, hub, orders)
+	pushOrders(hub, orders)
+	pushBalances(ctx, keeper, hub, orders, deals)
+}
+
+func pushTickers(keeper Keeper, hub *push.Hub) {
+	for _, product := range hub.Filters(push.TableTicker) {
+		ticker := keeper.Cache.LatestTicker[product]
+		if ticker == nil {
+			continue
+		}
+		channel := push.Channel(push.TableTicker, product)
+		if latest, ok := hub.Latest(channel).(types.Ticker); !ok || latest != *ticker {
+			hub.Publish(channel, *ticker)
+		}
+	}
+}
+
+func pushMatches(hub *push.Hub, results []*types.MatchResult) {
+	for _, result := range results {
+		channel := push.Channel(push.TableMatches, result.Product)
+		if hub.Subscribed(channel) {
+			hub.Publish(channel, []*types.MatchResult{result})
+		}
+	}
+}
+
+// pushCandles publishes the current candles of the products matched in the block. The first candle of a channel is
+// seeded from the klines and the match results of the backend database, then updated from the match results
+func pushCandles(keeper Keeper, hub *push.Hub, results []*types.MatchResult, timestamp int64) {
+	matched := make(map[string]*types.MatchResult, len(results))
+	for _, result := range results {
+		matched[result.Product] = result
+	}
+
+	for freq := range types.GetAllKlineMap() {
+		table := push.CandleTable(freq)
+		for _, product := range hub.Filters(table) {
+			channel := push.Channel(table, product)
+			anchorTS := timestamp / int64(freq) * int64(freq)
+
+			var candle *types.BaseKline
+			if latest, ok := hub.Latest(channel).(*types.BaseKline); ok {
+				candle = latest
+				if result := matched[product]; result != nil {
+					candle = mergeCandle(candle, anchorTS, result)
+				} else {
+					continue
+				}
+			} else {
+				candle = seedCandle(keeper, product, anchorTS, timestamp)
+			}
+
+			if candle != nil {
+				hub.Publish(channel, candle)
+			}
+		}
+	}
+}
+
+func mergeCandle(candle *types.BaseKline, anchorTS int64, result *types.MatchResult) *types.BaseKline {
+	price, quantity := types.DecFromString(result.Price), types.DecFromString(result.Quantity)
+	if candle.Timestamp != anchorTS {
+		return &types.BaseKline{Product: result.Product, Timestamp: anchorTS, Open: price.String(),
+			Close: price.String(), High: price.String(), Low: price.String(), Volume: quantity.String()}
+	}
+
+	merged := *candle
+	merged.Close = price.String()
+	if price.GT(candle.GetHigh()) {
+		merged.High = price.String()
+	}
+	if price.LT(candle.GetLow()) {
+		merged.Low = price.String()
+	}
+	merged.Volume = candle.GetVolume().Add(quantity).String()
+	return &merged
+}
+
+// seedCandle merges the 1 minute klines of the period and the match results after the last of them
+func seedCandle(keeper Keeper, product string, anchorTS, timestamp int64) *types.BaseKline {
+	var klines []types.KlineM1
+	if err := keeper.Orm.GetKlinesByTimeRange(product, anchorTS, timestamp+1, &klines); err != nil {
+		return nil
+	}
+
+	var candle *types.BaseKline
+	startTS := anchorTS
+	// the klines are returned latest first
+	for i := len(klines) - 1; i >= 0; i-- {
+		k := klines[i]
+		if candle == nil {
+			candle = &types.BaseKline{Product: product, Timestamp: anchorTS, Open: k.Open, Close: k.Close,
+				High: k.High, Low: k.Low, Volume: k.Volume}
+		} else {
+			if k.GetHigh().GT(candle.GetHigh()) {
+				candle.High = k.High
+			}
+			if k.GetLow().LT(candle.GetLow()) {
+				candle.Low = k.Low
+			}
+			candle.Close = k.Close
+			candle.Volume = candle.GetVolume().Add(k.GetVolume()).String()
+		}
+		startTS = k.Timestamp + 60
+	}
+
+	results, err := keeper.Orm.GetMatchResultsByTimeRange(product, startTS, timestamp+1)
+	if err != nil {
+		return nil
+	}
+	for i := len(results) - 1; i >= 0; i-- {
+		if candle == nil {
+			// a new candle is started from the first match result
+			candle = &types.BaseKline{Timestamp: -1}
+		}
+		candle = mergeCandle(candle, anchorTS, &results[i])
+	}
+	return candle
+}
+
+func pushDepths(keeper Keeper, hub *push.Hub, orders []*types.Order) {
+	products := make(map[string]struct{})
+	for _, order := range orders {
+		if hub.Subscribed(push.Channel(push.TableDepth, order.Product)) {
+			products[order.Product] = struct{}{}
+		}
+	}
+	for _, product := range hub.PendingFilters(push.TableDepth) {
+		products[product] = struct{}{}
+	}
+
+	for product := range products {
+		hub.PublishDepth(product, keeper.OrderKeeper.GetDepthBookCopy(product))
+	}
+}
+
+func pushOrders(hub *push.Hub, orders []*types.Order) {
+	bySender := make(map[string][]*types.Order)
+	for _, order := range orders {
+		if hub.Subscribed(push.Channel(push.TableOrder, order.Sender)) {
+			bySender[order.Sender] = append(bySender[order.Sender], order)
+		}
+	}
+	for sender, orders := range bySender {
+		hub.Publish(push.Channel(push.TableOrder, sender), orders)
+	}
+}
+
+// pushBalances publishes the balances of the addresses of the orders, the deals, the fees and the txs of the block
+func pushBalances(ctx sdk.Context, keeper Keeper, hub *push.Hub, orders []*types.Order, deals []*types.Deal) {
+	addrs := make(map[string]struct{})
+	touch := func(addr string) {
+		if hub.Subscribed(push.Channel(push.TableBalance, addr)) {
+			addrs[addr] = struct{}{}
+		}
+	}
+	for _, order := range orders {
+		touch(order.Sender)
+	}
+	for _, deal := range deals {
+		touch(deal.Sender)
+	}
+	for _, fee := range keeper.TokenKeeper.GetFeeDetailList() {
+		touch(fee.Address)
+	}
+	for _, tx := range keeper.Cache.GetTransactions() {
+		touch(tx.Address)
+	}
+	for _, addr := range hub.PendingFilters(push.TableBalance) {
+		addrs[addr] = struct{}{}
+	}
+
+	for addr := range addrs {
+		accAddr, err := sdk.AccAddressFromBech32(addr)
+		if err != nil {
+			continue
+		}
+		hub.Publish(push.Channel(push.TableBalance, addr), keeper.TokenKeeper.GetCoinsInfo(ctx, accAddr))
+	}
+}
@@ -0,0 +1,118 @@
+package push
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ordertypes "github.com/okex/okchain/x/order/types"
+)
+
+// DepthItem is a price level of a book, the levels removed by an update have a zero quantity
+type DepthItem struct {
+	Price    string `json:"price"`
+	Quantity string `json:"quantity"`
+}
+
+// Depth is the data of the depth channel, the asks by ascending price and the bids by descending price
+type Depth struct {
+	Asks []DepthItem `json:"asks"`
+	Bids []DepthItem `json:"bids"`
+}
+
+// DepthBook is the quantity of each price level of a book
+type DepthBook struct {
+	Asks map[string]sdk.Dec
+	Bids map[string]sdk.Dec
+}
+
+// NewDepthBook converts the depth book of the order module
+func NewDepthBook(book *ordertypes.DepthBook) *DepthBook {
+	b := &DepthBook{Asks: make(map[string]sdk.Dec), Bids: make(map[string]sdk.Dec)}
+	for _, item := range book.Items {
+		if item.SellQuantity.IsPositive() {
+			b.Asks[item.Price.String()] = item.SellQuantity
+		}
+		if item.BuyQuantity.IsPositive() {
+			b.Bids[item.Price.String()] = item.BuyQuantity
+		}
+	}
+	return b
+}
+
+// Depth returns the whole book
+func (b *DepthBook) Depth() Depth {
+	return Depth{
+		Asks: depthItems(b.Asks, nil, true),
+		Bids: depthItems(b.Bids, nil, false),
+	}
+}
+
+// Update returns the levels changed since the previous book
+func (b *DepthBook) Update(previous *DepthBook) Depth {
+	return Depth{
+		Asks: depthItems(b.Asks, previous.Asks, true),
+		Bids: depthItems(b.Bids, previous.Bids, false),
+	}
+}
+
+func depthItems(levels, previous map[string]sdk.Dec, ascending bool) []DepthItem {
+	changed := make(map[string]sdk.Dec)
+	for price, quantity := range levels {
+		if prev, ok := previous[price]; !ok || !prev.Equal(quantity) {
+			changed[price] = quantity
+		}
+	}
+	for price := range previous {
+		if _, ok := levels[price]; !ok {
+			changed[price] = sdk.ZeroDec()
+		}
+	}
+
+	prices := make([]sdk.Dec, 0, len(changed))
+	for price := range changed {
+		prices = append(prices, sdk.MustNewDecFromStr(price))
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		if ascending {
+			return prices[i].LT(prices[j])
+		}
+		return prices[i].GT(prices[j])
+	})
+
+	items := make([]DepthItem, 0, len(prices))
+	for _, price := range prices {
+		items = append(items, DepthItem{Price: price.String(), Quantity: changed[price.String()].String()})
+	}
+	return items
+}
+
+// PublishDepth sends the changes of the book of a product to the subscribers of its depth channel, and the whole
+// book to the ones waiting for their partial
+func (h *Hub) PublishDepth(product string, book *ordertypes.DepthBook) {
+	channel := Channel(TableDepth, product)
+	current := NewDepthBook(book)
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if len(h.subs[channel]) == 0 {
+		return
+	}
+	previous := h.books[product]
+	h.books[product] = current
+
+	partial := &Message{Table: TableDepth, Action: ActionPartial, Filter: product, Data: current.Depth()}
+	var update *Message
+	if previous != nil {
+		if depth := current.Update(previous); len(depth.Asks) > 0 || len(depth.Bids) > 0 {
+			update = &Message{Table: TableDepth, Action: ActionUpdate, Filter: product, Data: depth}
+		}
+	}
+	for c := range h.subs[channel] {
+		if _, ok := c.pending[channel]; ok || previous == nil {
+			delete(c.pending, channel)
+			h.send(c, partial)
+		} else if update != nil {
+			h.send(c, update)
+		}
+	}
+}
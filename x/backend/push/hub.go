@@ -0,0 +1,338 @@
+// Package push fans the backend data of every block out to the WebSocket clients of the REST server. The backend
+// EndBlocker publishes to the hub of the node, only for the channels that have subscribers. It publishes only when
+// the node runs with backend.enable_backend and backend.enable_mkt_compute, a node appending the blocks to the block
+// log of an indexer doesn't publish, and the hub rejects the subscriptions when nothing publishes to it.
+//
+// A channel is "<table>:<filter>": ticker:<product>, depth:<product>, matches:<product>, candle<freq>s:<product>
+// (freq in seconds, e.g. candle60s:xxb_okt), order:<address> and balance:<address>.
+package push
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/backend/types"
+)
+
+// tables of the channels
+const (
+	TableTicker  = "ticker"
+	TableDepth   = "depth"
+	TableMatches = "matches"
+	TableOrder   = "order"
+	TableBalance = "balance"
+
+	candleTablePrefix = "candle"
+)
+
+// actions of the pushed data: a partial is the whole data of the channel, an update only what changed
+const (
+	ActionPartial = "partial"
+	ActionUpdate  = "update"
+)
+
+// CandleTable returns the table of the candles of a frequency in seconds
+func CandleTable(freq int) string {
+	return fmt.Sprintf("%s%ds", candleTablePrefix, freq)
+}
+
+// Channel returns the channel of a table filtered on a product or an address
+func Channel(table, filter string) string {
+	return table + ":" + filter
+}
+
+// ParseChannel checks a channel subscribed by a client
+func ParseChannel(channel string) (table, filter string, err error) {
+	parts := strings.SplitN(channel, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid channel %s, expected <table>:<filter>", channel)
+	}
+	table, filter = parts[0], parts[1]
+
+	switch table {
+	case TableTicker, TableDepth, TableMatches:
+	case TableOrder, TableBalance:
+		if _, err := sdk.AccAddressFromBech32(filter); err != nil {
+			return "", "", fmt.Errorf("invalid address %s in channel %s", filter, channel)
+		}
+	default:
+		freq, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(table, candleTablePrefix), "s"))
+		if err != nil || CandleTable(freq) != table || types.GetAllKlineMap()[freq] == "" {
+			return "", "", fmt.Errorf("unknown table %s in channel %s", table, channel)
+		}
+	}
+	return table, filter, nil
+}
+
+// Message is the data pushed on a channel
+type Message struct {
+	Table  string      `json:"table"`
+	Action string      `json:"action"`
+	Filter string      `json:"filter"`
+	Data   interface{} `json:"data"`
+}
+
+// Client is a connection subscribing to channels, the hub queues the messages on Send
+type Client struct {
+	Send     chan []byte
+	channels map[string]struct{}
+	// channels waiting for their partial
+	pending map[string]struct{}
+	closed  bool
+}
+
+// NewClient creates a client queueing at most bufferSize messages
+func NewClient(bufferSize int) *Client {
+	return &Client{
+		Send:     make(chan []byte, bufferSize),
+		channels: make(map[string]struct{}),
+		pending:  make(map[string]struct{}),
+	}
+}
+
+// Hub keeps the subscriptions of the clients and the last data of the channels
+type Hub struct {
+	mtx     sync.RWMutex
+	clients map[*Client]struct{}
+	subs    map[string]map[*Client]struct{}
+	// the last message of the ticker and candle channels, sent to their new subscribers
+	latest map[string]*Message
+	// the books of the subscribed products, the depth updates are computed against them
+	books map[string]*DepthBook
+	// whether the backend of the node publishes to the hub
+	publishing bool
+}
+
+var (
+	hub     *Hub
+	hubOnce sync.Once
+)
+
+// GetHub returns the hub of the node
+func GetHub() *Hub {
+	hubOnce.Do(func() {
+		hub = NewHub()
+	})
+	return hub
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{
+		clients: make(map[*Client]struct{}),
+		subs:    make(map[string]map[*Client]struct{}),
+		latest:  make(map[string]*Message),
+		books:   make(map[string]*DepthBook),
+	}
+}
+
+// EnablePublisher marks the hub as published to by the backend of the node, the subscriptions are rejected until then
+func (h *Hub) EnablePublisher() {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.publishing = true
+}
+
+// Register adds a client to the hub
+func (h *Hub) Register(c *Client) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.clients[c] = struct{}{}
+}
+
+// Unregister removes a client and its subscriptions from the hub and closes its Send channel
+func (h *Hub) Unregister(c *Client) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.unregister(c)
+}
+
+func (h *Hub) unregister(c *Client) {
+	if c.closed {
+		return
+	}
+	for channel := range c.channels {
+		h.unsubscribe(c, channel)
+	}
+	delete(h.clients, c)
+	c.closed = true
+	close(c.Send)
+}
+
+// Subscribe subscribes a client to a channel
+func (h *Hub) Subscribe(c *Client, channel string) error {
+	table, _, err := ParseChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if !h.publishing {
+		return fmt.Errorf("nothing is pushed on channel %s, the node must run with backend.enable_backend and "+
+			"backend.enable_mkt_compute", channel)
+	}
+	if c.closed {
+		return nil
+	}
+	if h.subs[channel] == nil {
+		h.subs[channel] = make(map[*Client]struct{})
+	}
+	h.subs[channel][c] = struct{}{}
+	c.channels[channel] = struct{}{}
+
+	switch table {
+	case TableDepth, TableBalance:
+		// the publisher sends the partial with the next block
+		c.pending[channel] = struct{}{}
+	case TableOrder, TableMatches:
+	default:
+		if msg := h.latest[channel]; msg != nil {
+			partial := *msg
+			partial.Action = ActionPartial
+			h.send(c, &partial)
+		}
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes a client from a channel
+func (h *Hub) Unsubscribe(c *Client, channel string) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.unsubscribe(c, channel)
+}
+
+func (h *Hub) unsubscribe(c *Client, channel string) {
+	delete(c.channels, channel)
+	delete(c.pending, channel)
+	if subs := h.subs[channel]; subs != nil {
+		delete(subs, c)
+		if len(subs) == 0 {
+			delete(h.subs, channel)
+			delete(h.latest, channel)
+			table, filter, _ := ParseChannel(channel)
+			if table == TableDepth {
+				delete(h.books, filter)
+			}
+		}
+	}
+}
+
+// Idle returns whether no client is subscribed to any channel
+func (h *Hub) Idle() bool {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	return len(h.subs) == 0
+}
+
+// Subscribed returns whether a channel has subscribers
+func (h *Hub) Subscribed(channel string) bool {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	return len(h.subs[channel]) > 0
+}
+
+// Filters returns the products or the addresses of the subscribed channels of a table
+func (h *Hub) Filters(table string) []string {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	var filters []string
+	prefix := table + ":"
+	for channel := range h.subs {
+		if strings.HasPrefix(channel, prefix) {
+			filters = append(filters, strings.TrimPrefix(channel, prefix))
+		}
+	}
+	return filters
+}
+
+// PendingFilters returns the products or the addresses of the channels of a table with clients waiting for their
+// partial
+func (h *Hub) PendingFilters(table string) []string {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	var filters []string
+	prefix := table + ":"
+	for channel, subs := range h.subs {
+		if !strings.HasPrefix(channel, prefix) {
+			continue
+		}
+		for c := range subs {
+			if _, ok := c.pending[channel]; ok {
+				filters = append(filters, strings.TrimPrefix(channel, prefix))
+				break
+			}
+		}
+	}
+	return filters
+}
+
+// Latest returns the data of the last message published on a channel, nil if there's none
+func (h *Hub) Latest(channel string) interface{} {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	if msg := h.latest[channel]; msg != nil {
+		return msg.Data
+	}
+	return nil
+}
+
+// Publish sends an update to the subscribers of a channel, the partials waited for are sent the whole data
+func (h *Hub) Publish(channel string, data interface{}) {
+	table, filter, err := ParseChannel(channel)
+	if err != nil {
+		return
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	msg := &Message{Table: table, Action: ActionUpdate, Filter: filter, Data: data}
+	if table == TableTicker || strings.HasPrefix(table, candleTablePrefix) {
+		h.latest[channel] = msg
+	}
+
+	partial := *msg
+	partial.Action = ActionPartial
+	for c := range h.subs[channel] {
+		if _, ok := c.pending[channel]; ok {
+			delete(c.pending, channel)
+			h.send(c, &partial)
+		} else {
+			h.send(c, msg)
+		}
+	}
+}
+
+// Reply queues the answer to a request of a client
+func (h *Hub) Reply(c *Client, bz []byte) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.Send <- bz:
+	default:
+		h.unregister(c)
+	}
+}
+
+// send queues a message without blocking the publisher, a client too slow to keep up is disconnected
+func (h *Hub) send(c *Client, msg *Message) {
+	bz, err := json.Marshal(msg)
+	if err != nil || c.closed {
+		return
+	}
+	select {
+	case c.Send <- bz:
+	default:
+		h.unregister(c)
+	}
+}
@@ -0,0 +1,134 @@
+package push
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ordertypes "github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func readMessage(t *testing.T, c *Client) *Message {
+	select {
+	case bz := <-c.Send:
+		var msg Message
+		require.Nil(t, json.Unmarshal(bz, &msg))
+		return &msg
+	default:
+		return nil
+	}
+}
+
+func TestParseChannel(t *testing.T) {
+	table, filter, err := ParseChannel("candle60s:xxb_okt")
+	require.Nil(t, err)
+	require.Equal(t, CandleTable(60), table)
+	require.Equal(t, "xxb_okt", filter)
+
+	for _, channel := range []string{"ticker", "ticker:", "candle61s:xxb_okt", "candle:xxb_okt", "unknown:xxb_okt",
+		"order:not_an_address"} {
+		_, _, err := ParseChannel(channel)
+		require.NotNil(t, err, channel)
+	}
+}
+
+func TestHub_SubscribeWithoutPublisher(t *testing.T) {
+	hub := NewHub()
+	c := NewClient(10)
+	hub.Register(c)
+	require.NotNil(t, hub.Subscribe(c, "ticker:xxb_okt"))
+	require.True(t, hub.Idle())
+
+	hub.EnablePublisher()
+	require.Nil(t, hub.Subscribe(c, "ticker:xxb_okt"))
+	require.False(t, hub.Idle())
+}
+
+func TestHub_Publish(t *testing.T) {
+	hub := NewHub()
+	hub.EnablePublisher()
+	require.True(t, hub.Idle())
+
+	c1, c2 := NewClient(10), NewClient(10)
+	hub.Register(c1)
+	hub.Register(c2)
+	require.NotNil(t, hub.Subscribe(c1, "unknown:xxb_okt"))
+	require.Nil(t, hub.Subscribe(c1, "ticker:xxb_okt"))
+	require.False(t, hub.Idle())
+	require.Equal(t, []string{"xxb_okt"}, hub.Filters(TableTicker))
+
+	hub.Publish("ticker:xxb_okt", "1")
+	msg := readMessage(t, c1)
+	require.Equal(t, ActionUpdate, msg.Action)
+	require.Equal(t, "1", msg.Data)
+	require.Nil(t, readMessage(t, c2))
+
+	// a new subscriber of a ticker gets the last one as partial
+	require.Nil(t, hub.Subscribe(c2, "ticker:xxb_okt"))
+	msg = readMessage(t, c2)
+	require.Equal(t, ActionPartial, msg.Action)
+	require.Equal(t, "1", msg.Data)
+
+	hub.Unsubscribe(c1, "ticker:xxb_okt")
+	hub.Publish("ticker:xxb_okt", "2")
+	require.Nil(t, readMessage(t, c1))
+	require.Equal(t, "2", readMessage(t, c2).Data)
+
+	// a client too slow to keep up is disconnected
+	slow := NewClient(1)
+	hub.Register(slow)
+	require.Nil(t, hub.Subscribe(slow, "matches:xxb_okt"))
+	hub.Publish("matches:xxb_okt", "1")
+	hub.Publish("matches:xxb_okt", "2")
+	require.False(t, hub.Subscribed("matches:xxb_okt"))
+	<-slow.Send
+	_, ok := <-slow.Send
+	require.False(t, ok)
+
+	hub.Unregister(c2)
+	require.True(t, hub.Idle())
+	_, ok = <-c2.Send
+	require.False(t, ok)
+}
+
+func TestHub_PublishDepth(t *testing.T) {
+	hub := NewHub()
+	hub.EnablePublisher()
+	c1, c2 := NewClient(10), NewClient(10)
+	hub.Register(c1)
+	hub.Register(c2)
+	require.Nil(t, hub.Subscribe(c1, "depth:xxb_okt"))
+	require.Equal(t, []string{"xxb_okt"}, hub.PendingFilters(TableDepth))
+
+	book := &ordertypes.DepthBook{Items: []ordertypes.DepthBookItem{
+		{Price: sdk.MustNewDecFromStr("11"), SellQuantity: sdk.MustNewDecFromStr("1"), BuyQuantity: sdk.ZeroDec()},
+		{Price: sdk.MustNewDecFromStr("10"), SellQuantity: sdk.ZeroDec(), BuyQuantity: sdk.MustNewDecFromStr("2")},
+	}}
+	hub.PublishDepth("xxb_okt", book)
+	msg := readMessage(t, c1)
+	require.Equal(t, ActionPartial, msg.Action)
+	require.Empty(t, hub.PendingFilters(TableDepth))
+
+	// the update holds the changed and the removed levels
+	book = &ordertypes.DepthBook{Items: []ordertypes.DepthBookItem{
+		{Price: sdk.MustNewDecFromStr("11"), SellQuantity: sdk.MustNewDecFromStr("3"), BuyQuantity: sdk.ZeroDec()},
+	}}
+	require.Nil(t, hub.Subscribe(c2, "depth:xxb_okt"))
+	hub.PublishDepth("xxb_okt", book)
+	msg = readMessage(t, c1)
+	require.Equal(t, ActionUpdate, msg.Action)
+	bz, err := json.Marshal(msg.Data)
+	require.Nil(t, err)
+	var depth Depth
+	require.Nil(t, json.Unmarshal(bz, &depth))
+	require.Equal(t, []DepthItem{{Price: "11.00000000", Quantity: "3.00000000"}}, depth.Asks)
+	require.Equal(t, []DepthItem{{Price: "10.00000000", Quantity: "0.00000000"}}, depth.Bids)
+
+	msg = readMessage(t, c2)
+	require.Equal(t, ActionPartial, msg.Action)
+
+	// nothing is sent when the book didn't change
+	hub.PublishDepth("xxb_okt", book)
+	require.Nil(t, readMessage(t, c1))
+}
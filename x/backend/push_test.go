@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okchain/x/backend/push"
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order"
+	ordertypes "github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestPushBlock(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2, true, "")
+	defer mapp.backendKeeper.Stop()
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(1000, 0)}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	feeParams := ordertypes.DefaultParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+	mapp.dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+
+	sender := addrKeysSlice[0].Address
+	hub := push.NewHub()
+	hub.EnablePublisher()
+	client := push.NewClient(10)
+	hub.Register(client)
+	require.Nil(t, hub.Subscribe(client, push.Channel(push.TableDepth, types.TestTokenPair)))
+	require.Nil(t, hub.Subscribe(client, push.Channel(push.TableOrder, sender.String())))
+	require.Nil(t, hub.Subscribe(client, push.Channel(push.TableBalance, addrKeysSlice[1].Address.String())))
+
+	ord := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	ord.Sender = sender
+	require.NoError(t, mapp.orderKeeper.PlaceOrder(ctx, ord))
+	order.EndBlocker(ctx, mapp.orderKeeper)
+	pushBlock(ctx, mapp.backendKeeper, hub)
+
+	received := make(map[string]*push.Message)
+	for len(client.Send) > 0 {
+		var msg push.Message
+		require.Nil(t, json.Unmarshal(<-client.Send, &msg))
+		received[msg.Table] = &msg
+	}
+	require.Len(t, received, 3)
+	require.Equal(t, push.ActionPartial, received[push.TableDepth].Action)
+	require.Equal(t, push.ActionUpdate, received[push.TableOrder].Action)
+	// the balance of an address without activity is sent once, as partial
+	require.Equal(t, push.ActionPartial, received[push.TableBalance].Action)
+	require.Equal(t, addrKeysSlice[1].Address.String(), received[push.TableBalance].Filter)
+
+	// nothing is pushed for a block without activity
+	ctx = ctx.WithBlockHeight(11)
+	order.EndBlocker(ctx, mapp.orderKeeper)
+	pushBlock(ctx, mapp.backendKeeper, hub)
+	require.Len(t, client.Send, 0)
+}
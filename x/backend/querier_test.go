@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -180,3 +181,150 @@ func TestQuerier_QueryTxList(t *testing.T) {
 	}
 
 }
+
+func TestQuerier_QueryPnL(t *testing.T) {
+	mapp, ctx, querier, orders := mockQuerier(t)
+	path := []string{types.QueryPnLV2}
+	request := abci.RequestQuery{}
+
+	// 1. Invalid address & method
+	request.Data, _ = mapp.backendKeeper.MarshalJSON(types.NewQueryPnLParams("NotExists", "", ""))
+	_, err := querier(ctx, path, request)
+	require.NotNil(t, err)
+	request.Data, _ = mapp.backendKeeper.MarshalJSON(types.NewQueryPnLParams(orders[0].Sender.String(), "", "lifo"))
+	_, err = querier(ctx, path, request)
+	require.NotNil(t, err)
+
+	// 2. Both methods
+	sender := orders[0].Sender.String()
+	_, errDeals := mapp.backendKeeper.Orm.AddDeals([]*types.Deal{
+		{Timestamp: 100, BlockHeight: 100, OrderId: "ID0000000100-1", Sender: sender, Product: types.TestTokenPair,
+			Side: types.BuyOrder, Price: "1", Quantity: "10", Fee: ""},
+		{Timestamp: 101, BlockHeight: 101, OrderId: "ID0000000101-1", Sender: sender, Product: types.TestTokenPair,
+			Side: types.SellOrder, Price: "2", Quantity: "4", Fee: ""},
+	})
+	require.Nil(t, errDeals)
+	// 2 tokens received after the deals at the price of the match before, and 1 sent out
+	_, errMatches := mapp.backendKeeper.Orm.AddMatchResults([]*types.MatchResult{
+		{Timestamp: 99, BlockHeight: 99, Product: types.TestTokenPair, Price: "3", Quantity: "1"}})
+	require.Nil(t, errMatches)
+	base := strings.Split(types.TestTokenPair, "_")[0]
+	_, errTxs := mapp.backendKeeper.Orm.AddTransactions([]*types.Transaction{
+		{TxHash: "102", Type: types.TxTypeTransfer, Address: sender, Symbol: base, Side: types.TxSideTo,
+			Quantity: "2", Timestamp: 102},
+		{TxHash: "103", Type: types.TxTypeMultiSend, Address: sender, Symbol: base, Side: types.TxSideFrom,
+			Quantity: "1", Timestamp: 103},
+		{TxHash: "104", Type: types.TxTypeTokenMint, Address: sender, Symbol: base, Side: types.TxSideTo,
+			Quantity: "100", Timestamp: 104},
+	})
+	require.Nil(t, errTxs)
+	for _, method := range []string{types.PnLMethodFIFO, types.PnLMethodWeightedAverage} {
+		params := types.NewQueryPnLParams(orders[0].Sender.String(), types.TestTokenPair, method)
+		request.Data, _ = mapp.backendKeeper.MarshalJSON(params)
+		bytesBuffer, err := querier(ctx, path, request)
+		require.Nil(t, err)
+
+		var report types.PnLReport
+		require.Nil(t, common.JSONUnmarshalV2(bytesBuffer, &report))
+		fmt.Println(fmt.Sprintf("report: %s", bytesBuffer))
+		require.Equal(t, method, report.Method)
+		require.Equal(t, 1, len(report.Products))
+		require.Equal(t, types.TestTokenPair, report.Products[0].Product)
+		require.Equal(t, "7.00000000", report.Products[0].Position)
+		require.Equal(t, "2.00000000", report.Products[0].TransferredIn)
+		require.Equal(t, "1.00000000", report.Products[0].TransferredOut)
+		require.Equal(t, "4.00000000", report.Products[0].RealisedPnL)
+
+		// the order fees are deducted from the realised pnl of the quote token
+		orderFees, errFees := sdk.ParseDecCoins(report.OrderFees)
+		require.Nil(t, errFees)
+		quote := strings.Split(types.TestTokenPair, "_")[1]
+		require.Equal(t, quote, report.Totals[0].Token)
+		require.Equal(t, sdk.NewDec(4).Sub(orderFees.AmountOf(quote)).String(), report.Totals[0].RealisedPnL)
+	}
+	// the fifo cost basis of the position: 5 bought at 1 and 2 received at 3
+	params := types.NewQueryPnLParams(sender, types.TestTokenPair, types.PnLMethodFIFO)
+	report, errReport := mapp.backendKeeper.GetPnLReport(ctx, params.Address, params.Product, params.Method)
+	require.Nil(t, errReport)
+	require.Equal(t, "11.00000000", report.Products[0].CostBasis)
+}
+
+func TestQuerier_QueryPnLSharedBaseToken(t *testing.T) {
+	mapp, ctx, querier, orders := mockQuerier(t)
+	path := []string{types.QueryPnLV2}
+	request := abci.RequestQuery{}
+
+	// two products of the same base token, each transfer is given to the product dealt on last before it
+	sender := orders[0].Sender.String()
+	base := strings.Split(types.TestTokenPair, "_")[0]
+	otherPair := base + "_usdk"
+	_, errDeals := mapp.backendKeeper.Orm.AddDeals([]*types.Deal{
+		{Timestamp: 100, BlockHeight: 100, OrderId: "ID0000000100-1", Sender: sender, Product: types.TestTokenPair,
+			Side: types.BuyOrder, Price: "1", Quantity: "10", Fee: ""},
+		{Timestamp: 105, BlockHeight: 105, OrderId: "ID0000000105-1", Sender: sender, Product: otherPair,
+			Side: types.BuyOrder, Price: "2", Quantity: "10", Fee: ""},
+	})
+	require.Nil(t, errDeals)
+	_, errTxs := mapp.backendKeeper.Orm.AddTransactions([]*types.Transaction{
+		{TxHash: "102", Type: types.TxTypeTransfer, Address: sender, Symbol: base, Side: types.TxSideTo,
+			Quantity: "2", Timestamp: 102},
+		{TxHash: "106", Type: types.TxTypeTransfer, Address: sender, Symbol: base, Side: types.TxSideFrom,
+			Quantity: "3", Timestamp: 106},
+	})
+	require.Nil(t, errTxs)
+
+	request.Data, _ = mapp.backendKeeper.MarshalJSON(types.NewQueryPnLParams(sender, "", types.PnLMethodFIFO))
+	bytesBuffer, err := querier(ctx, path, request)
+	require.Nil(t, err)
+	var report types.PnLReport
+	require.Nil(t, common.JSONUnmarshalV2(bytesBuffer, &report))
+	require.Equal(t, 2, len(report.Products))
+	require.Equal(t, types.TestTokenPair, report.Products[0].Product)
+	require.Equal(t, "12.00000000", report.Products[0].Position)
+	require.Equal(t, "2.00000000", report.Products[0].TransferredIn)
+	require.Equal(t, "0.00000000", report.Products[0].TransferredOut)
+	require.Equal(t, otherPair, report.Products[1].Product)
+	require.Equal(t, "7.00000000", report.Products[1].Position)
+	require.Equal(t, "0.00000000", report.Products[1].TransferredIn)
+	require.Equal(t, "3.00000000", report.Products[1].TransferredOut)
+
+	// filtering by a product gives it the same transfers
+	report, errReport := mapp.backendKeeper.GetPnLReport(ctx, sender, otherPair, types.PnLMethodFIFO)
+	require.Nil(t, errReport)
+	require.Equal(t, 1, len(report.Products))
+	require.Equal(t, "7.00000000", report.Products[0].Position)
+}
+
+func TestQuerier_QueryDepthSnapshot(t *testing.T) {
+	mapp, ctx, querier, _ := mockQuerier(t)
+	path := []string{types.QueryDepthSnapshotV2}
+	request := abci.RequestQuery{}
+
+	// 1. Invalid product
+	request.Data, _ = mapp.backendKeeper.MarshalJSON(types.QueryDepthSnapshotParams{})
+	_, err := querier(ctx, path, request)
+	require.NotNil(t, err)
+
+	// 2. No snapshot taken
+	request.Data, _ = mapp.backendKeeper.MarshalJSON(types.QueryDepthSnapshotParams{Product: types.TestTokenPair})
+	bytesBuffer, err := querier(ctx, path, request)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(bytesBuffer))
+
+	// 3. Snapshots of all the products are taken every interval blocks
+	keeper := mapp.backendKeeper
+	require.Equal(t, 0, len(keeper.TakeDepthSnapshots(ctx)))
+	(&keeper).EnableDepthSnapshots(5, 20)
+	snapshots := keeper.TakeDepthSnapshots(ctx.WithBlockHeight(10))
+	require.NotEqual(t, 0, len(snapshots))
+	_, errSnapshots := keeper.Orm.AddDepthSnapshots(snapshots)
+	require.Nil(t, errSnapshots)
+
+	request.Data, _ = mapp.backendKeeper.MarshalJSON(types.QueryDepthSnapshotParams{Product: snapshots[0].Product, Height: 12})
+	bytesBuffer, err = querier(ctx, path, request)
+	require.Nil(t, err)
+	var book types.BookSnapshotV2
+	require.Nil(t, json.Unmarshal(bytesBuffer, &book))
+	require.Equal(t, snapshots[0].Product, book.InstrumentId)
+	require.Equal(t, int64(10), book.BlockHeight)
+}
@@ -0,0 +1,76 @@
+package backend
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/okex/okchain/x/backend/types"
+	"github.com/okex/okchain/x/token"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Reindexer collects the backend data of the blocks replayed by `okchaind backend reindex`, the same way the
+// EndBlocker does for live blocks, and writes them block by block through orm.BatchInsertOrUpdate
+type Reindexer struct {
+	keeper Keeper
+	err    error
+
+	newOrders     []*types.Order
+	updatedOrders []*types.Order
+	deals         []*types.Deal
+	matchResults  []*types.MatchResult
+	feeDetails    []*token.FeeDetail
+	txs           []*types.Transaction
+	snapshots     []*types.DepthSnapshot
+}
+
+// NewReindexer creates a new reindexer writing to the ORM of the keeper
+func NewReindexer(keeper Keeper) *Reindexer {
+	return &Reindexer{keeper: keeper}
+}
+
+// SyncTx collects the transactions of a tx delivered successfully with the events
+func (r *Reindexer) SyncTx(ctx sdk.Context, tx *auth.StdTx, txHash string, events []abci.Event) {
+	txs := r.keeper.GenerateTx(ctx, tx, txHash, events, ctx.BlockHeader().Time.Unix())
+	r.txs = append(r.txs, txs...)
+}
+
+// EndBlock collects the orders, deals, match results and fee details of the block once all the EndBlockers ran
+func (r *Reindexer) EndBlock(ctx sdk.Context) {
+	newOrders, err := GetNewOrdersAtEndBlock(ctx, r.keeper.OrderKeeper)
+	if err != nil {
+		r.err = err
+		return
+	}
+	deals, matchResults, err := GetNewDealsAndMatchResultsAtEndBlock(ctx, r.keeper.OrderKeeper)
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	r.newOrders = newOrders
+	r.updatedOrders = GetUpdatedOrdersAtEndBlock(ctx, r.keeper.OrderKeeper)
+	r.deals = deals
+	r.matchResults = matchResults
+	r.feeDetails = r.keeper.TokenKeeper.GetFeeDetailList()
+	r.snapshots = r.keeper.TakeDepthSnapshots(ctx)
+}
+
+// Commit writes the data collected for the block and resets the reindexer for the next one
+func (r *Reindexer) Commit() error {
+	defer r.reset()
+
+	if r.err != nil {
+		return r.err
+	}
+	if _, err := r.keeper.Orm.BatchInsertOrUpdate(r.newOrders, r.updatedOrders, r.deals, r.matchResults, r.feeDetails, r.txs); err != nil {
+		return err
+	}
+	_, err := r.keeper.Orm.AddDepthSnapshots(r.snapshots)
+	return err
+}
+
+func (r *Reindexer) reset() {
+	r.err = nil
+	r.newOrders, r.updatedOrders, r.deals, r.matchResults, r.feeDetails, r.txs = nil, nil, nil, nil, nil, nil
+	r.snapshots = nil
+}
@@ -2,11 +2,19 @@ package backend
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okchain/x/backend/cache"
 	"github.com/okex/okchain/x/backend/orm"
 	"github.com/okex/okchain/x/backend/types"
 	"github.com/okex/okchain/x/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
 	"testing"
 	"time"
 )
@@ -23,12 +31,12 @@ func prepareKlineMx(product string, refreshInterval int, open, close, low, high
 
 		b := types.BaseKline{
 			Product:   product,
-			High:      high,
-			Low:       low,
-			Volume:    volumes[i],
+			High:      decString(high),
+			Low:       decString(low),
+			Volume:    decString(volumes[i]),
 			Timestamp: ts,
-			Open:      open,
-			Close:     close,
+			Open:      decString(open),
+			Close:     decString(close),
 		}
 
 		newDestK, _ := types.NewKlineFactory(destIKline.GetTableName(), &b)
@@ -46,8 +54,8 @@ func prepareMatches(product string, prices []float64, quantities []float64, endT
 			Timestamp:   endTS - int64(len(prices)) + int64(i),
 			BlockHeight: endTS + int64(i),
 			Product:     product,
-			Price:       prices[i],
-			Quantity:    quantities[i],
+			Price:       decString(prices[i]),
+			Quantity:    decString(quantities[i]),
 		}
 
 		matchResults = append(matchResults, match)
@@ -60,17 +68,22 @@ func aTicker(product string, open, close, high, low, price, volume float64) *typ
 	t := types.Ticker{
 		Timestamp: time.Now().Unix(),
 		Product:   product,
-		Open:      open,
-		Close:     close,
-		High:      high,
-		Low:       low,
-		Price:     price,
-		Volume:    volume,
+		Open:      decString(open),
+		Close:     decString(close),
+		High:      decString(high),
+		Low:       decString(low),
+		Price:     decString(price),
+		Volume:    decString(volume),
 		Symbol:    product,
 	}
 	return &t
 }
 
+// decString converts a float literal of the test cases into the decimal string stored by the backend
+func decString(f float64) string {
+	return sdk.MustNewDecFromStr(strconv.FormatFloat(f, 'f', -1, 64)).String()
+}
+
 func GetTimes() map[string]int64 {
 
 	timeMap := map[string]int64{}
@@ -468,12 +481,12 @@ func TestTicker_C1(t *testing.T) {
 	assert.True(t, err == nil)
 
 	oldTicker := latestTickers["not_exist"]
-	assert.True(t, oldTicker.Open == 230.0)
-	assert.True(t, oldTicker.Close == 230.0)
-	assert.True(t, oldTicker.High == 230.0)
-	assert.True(t, oldTicker.Low == 230.0)
-	assert.True(t, oldTicker.Price == 230.0)
-	assert.True(t, oldTicker.Volume == 0)
+	assert.True(t, oldTicker.Open == decString(230.0))
+	assert.True(t, oldTicker.Close == decString(230.0))
+	assert.True(t, oldTicker.High == decString(230.0))
+	assert.True(t, oldTicker.Low == decString(230.0))
+	assert.True(t, oldTicker.Price == decString(230.0))
+	assert.True(t, oldTicker.Volume == decString(0))
 }
 
 //func TestTicker_C2(t *testing.T) {
@@ -601,3 +614,64 @@ func TestTicker_C4(t *testing.T) {
 
 	fmt.Println(dbPath)
 }
+
+func TestKeeper_RollingTickers(t *testing.T) {
+	product := "rtk_" + common.NativeToken
+	timeMap := GetTimes()
+	o, dbPath := orm.MockSqlite3ORM()
+	defer orm.DeleteDB(dbPath)
+
+	// klines of the minutes merged already, and the match results of the latest minute
+	kline1s := prepareKlineMx(product, 60, 100.0, 110.0, 90.0, 120.0, []float64{1.0, 2.0}, timeMap["-24h"], timeMap["-2m"])
+	matches := prepareMatches(product, []float64{105.0, 108.0}, []float64{3.0, 4.0}, timeMap["now"])
+	kline15s := prepareKlineMx(product, 15*60, 100.0, 110.0, 90.0, 120.0, []float64{3.0}, timeMap["-24h"], timeMap["-15m"])
+	o.MockCommitKlines(kline15s, kline1s)
+	_, err := o.AddMatchResults(matches)
+	require.Nil(t, err)
+
+	keeper := Keeper{Orm: o, Cache: cache.NewCache(), Logger: log.NewNopLogger()}
+	keeper.InitTickers("", timeMap["now"])
+	ticker := keeper.Cache.LatestTicker[product]
+	require.NotNil(t, ticker)
+	require.Equal(t, decString(100.0), ticker.Open)
+	require.Equal(t, decString(108.0), ticker.Close)
+	require.Equal(t, decString(120.0), ticker.High)
+	require.Equal(t, decString(90.0), ticker.Low)
+	require.Equal(t, decString(10.0), ticker.Volume)
+
+	// the tickers are updated by the match results of the blocks
+	result := &types.MatchResult{Timestamp: timeMap["now"] + 30, BlockHeight: timeMap["now"] + 30, Product: product,
+		Price: decString(130.0), Quantity: decString(5.0)}
+	keeper.UpdateTickers(result.BlockHeight, result.Timestamp, []*types.MatchResult{result})
+	ticker = keeper.Cache.LatestTicker[product]
+	require.Equal(t, decString(130.0), ticker.Close)
+	require.Equal(t, decString(130.0), ticker.High)
+	require.Equal(t, decString(15.0), ticker.Volume)
+
+	// the saved tickers are restored with the match results of the blocks after them
+	dir, err := ioutil.TempDir("", "tickers")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "tickers.json")
+	require.Nil(t, keeper.Cache.TickerWindow.Save(file))
+
+	next := &types.MatchResult{Timestamp: timeMap["now"] + 60, BlockHeight: timeMap["now"] + 60, Product: product,
+		Price: decString(80.0), Quantity: decString(1.0)}
+	_, err = o.AddMatchResults([]*types.MatchResult{result, next})
+	require.Nil(t, err)
+
+	restored := Keeper{Orm: o, Cache: cache.NewCache(), Logger: log.NewNopLogger()}
+	restored.InitTickers(file, timeMap["now"]+90)
+	ticker = restored.Cache.LatestTicker[product]
+	require.Equal(t, decString(80.0), ticker.Close)
+	require.Equal(t, decString(80.0), ticker.Low)
+	require.Equal(t, decString(16.0), ticker.Volume)
+	require.Equal(t, next.BlockHeight, restored.Cache.TickerWindow.BlockHeight)
+
+	// the tickers are seeded again if the saved ones are out of date
+	seeded := Keeper{Orm: o, Cache: cache.NewCache(), Logger: log.NewNopLogger()}
+	seeded.InitTickers(file, timeMap["now"]+types.SecondsInADay*2)
+	ticker = seeded.Cache.LatestTicker[product]
+	require.Equal(t, decString(80.0), ticker.Open)
+	require.Equal(t, decString(0), ticker.Volume)
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	orderTypes "github.com/okex/okchain/x/order/types"
+)
+
+// DepthLevel is a price level of a book
+type DepthLevel struct {
+	Price    string `json:"price" v2:"price"`
+	Quantity string `json:"quantity" v2:"quantity"`
+}
+
+// DepthSnapshot is the top price levels of the book of a product at the end of a block.
+// The levels are stored in json, the asks by ascending price and the bids by descending price.
+type DepthSnapshot struct {
+	BlockHeight int64  `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
+	Product     string `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product" v2:"product"`
+	Timestamp   int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+	Asks        string `gorm:"type:text" json:"asks" v2:"asks"`
+	Bids        string `gorm:"type:text" json:"bids" v2:"bids"`
+}
+
+// NewDepthSnapshot takes a snapshot of the top size price levels of each side of the book
+func NewDepthSnapshot(product string, blockHeight, timestamp int64, book *orderTypes.DepthBook, size int) *DepthSnapshot {
+	asks, bids := make([]DepthLevel, 0, size), make([]DepthLevel, 0, size)
+	// items in depth book are sorted by price desc
+	for i := len(book.Items) - 1; i >= 0 && len(asks) < size; i-- {
+		if item := book.Items[i]; item.SellQuantity.IsPositive() {
+			asks = append(asks, DepthLevel{Price: item.Price.String(), Quantity: item.SellQuantity.String()})
+		}
+	}
+	for i := 0; i < len(book.Items) && len(bids) < size; i++ {
+		if item := book.Items[i]; item.BuyQuantity.IsPositive() {
+			bids = append(bids, DepthLevel{Price: item.Price.String(), Quantity: item.BuyQuantity.String()})
+		}
+	}
+
+	asksJSON, _ := json.Marshal(asks)
+	bidsJSON, _ := json.Marshal(bids)
+	return &DepthSnapshot{
+		BlockHeight: blockHeight,
+		Product:     product,
+		Timestamp:   timestamp,
+		Asks:        string(asksJSON),
+		Bids:        string(bidsJSON),
+	}
+}
+
+// BookSnapshotV2 is the book of a product at a past block
+type BookSnapshotV2 struct {
+	InstrumentId string       `json:"instrument_id"`
+	BlockHeight  int64        `json:"block_height"`
+	Timestamp    string       `json:"timestamp"`
+	Asks         []DepthLevel `json:"asks"`
+	Bids         []DepthLevel `json:"bids"`
+}
+
+// ConvertDepthSnapshotToBookV2 converts the depth snapshot stored by the backend to BookSnapshotV2
+func ConvertDepthSnapshotToBookV2(snapshot DepthSnapshot) (BookSnapshotV2, error) {
+	book := BookSnapshotV2{
+		InstrumentId: snapshot.Product,
+		BlockHeight:  snapshot.BlockHeight,
+		Timestamp:    time.Unix(snapshot.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z"),
+	}
+	if err := json.Unmarshal([]byte(snapshot.Asks), &book.Asks); err != nil {
+		return book, err
+	}
+	err := json.Unmarshal([]byte(snapshot.Bids), &book.Bids)
+	return book, err
+}
+
+// QueryDepthSnapshotParams is the params of the query for the book of a product at a height or a time,
+// the latest snapshot taken at or before them is returned, and the latest one if both are 0
+type QueryDepthSnapshotParams struct {
+	Product   string
+	Height    int64
+	Timestamp int64
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	orderTypes "github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDepthSnapshot(t *testing.T) {
+	book := &orderTypes.DepthBook{}
+	for _, item := range []struct{ price, buy, sell string }{
+		{"13", "0", "1"}, {"12", "0", "2"}, {"11", "0", "3"}, {"10", "4", "0"}, {"9", "5", "0"}, {"8", "6", "0"},
+	} {
+		book.Items = append(book.Items, orderTypes.DepthBookItem{
+			Price:        sdk.MustNewDecFromStr(item.price),
+			BuyQuantity:  sdk.MustNewDecFromStr(item.buy),
+			SellQuantity: sdk.MustNewDecFromStr(item.sell),
+		})
+	}
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC).Unix()
+
+	snapshot := NewDepthSnapshot(TestTokenPair, 10, ts, book, 2)
+	require.Equal(t, int64(10), snapshot.BlockHeight)
+	require.Equal(t, TestTokenPair, snapshot.Product)
+
+	result, err := ConvertDepthSnapshotToBookV2(*snapshot)
+	require.Nil(t, err)
+	require.Equal(t, "2020-01-02T03:04:05.000Z", result.Timestamp)
+	require.Equal(t, []DepthLevel{{"11.00000000", "3.00000000"}, {"12.00000000", "2.00000000"}}, result.Asks)
+	require.Equal(t, []DepthLevel{{"10.00000000", "4.00000000"}, {"9.00000000", "5.00000000"}}, result.Bids)
+
+	// an empty book
+	result, err = ConvertDepthSnapshotToBookV2(*NewDepthSnapshot(TestTokenPair, 10, ts, &orderTypes.DepthBook{}, 2))
+	require.Nil(t, err)
+	require.Equal(t, 0, len(result.Asks))
+	require.Equal(t, 0, len(result.Bids))
+
+	snapshot.Bids = "invalid"
+	_, err = ConvertDepthSnapshotToBookV2(*snapshot)
+	require.NotNil(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/okex/okchain/x/order"
 	ordertypes "github.com/okex/okchain/x/order/types"
 	"github.com/okex/okchain/x/token"
+	tokentypes "github.com/okex/okchain/x/token/types"
 )
 
 // expected order keeper
@@ -16,16 +17,20 @@ type OrderKeeper interface {
 	GetBlockMatchResult() *ordertypes.BlockMatchResult
 	GetLastPrice(ctx sdk.Context, product string) sdk.Dec
 	GetBestBidAndAsk(ctx sdk.Context, product string) (sdk.Dec, sdk.Dec)
+	GetDepthBookCopy(product string) *ordertypes.DepthBook
 }
 
 // expected token keeper
 type TokenKeeper interface {
 	GetFeeDetailList() []*token.FeeDetail
 	GetParams(ctx sdk.Context) (params token.Params)
+	GetTokenInfo(ctx sdk.Context, symbol string) tokentypes.Token
+	GetCoinsInfo(ctx sdk.Context, addr sdk.AccAddress) tokentypes.CoinsInfo
 }
 
 type DexKeeper interface {
 	GetTokenPairs(ctx sdk.Context) []*dextypes.TokenPair
+	GetParams(ctx sdk.Context) (params dextypes.Params)
 }
 
 // expected market keeper which would get data from pulsar & redis
@@ -18,16 +18,18 @@ const (
 	QueryTickerList   = "tickers"
 
 	// v2
-	QueryTickerListV2   = "tickerListV2"
-	QueryTickerV2       = "tickerV2"
-	QueryInstrumentsV2  = "instrumentsV2"
-	QueryOrderListV2    = "orderListV2"
-	QueryOrderV2        = "orderV2"
-	QueryCandleListV2   = "candlesV2"
-	QueryMatchResultsV2 = "matchesV2"
-	QueryFeeDetailsV2   = "feesV2"
-	QueryDealListV2     = "dealsV2"
-	QueryTxListV2       = "txsV2"
+	QueryTickerListV2    = "tickerListV2"
+	QueryTickerV2        = "tickerV2"
+	QueryInstrumentsV2   = "instrumentsV2"
+	QueryOrderListV2     = "orderListV2"
+	QueryOrderV2         = "orderV2"
+	QueryCandleListV2    = "candlesV2"
+	QueryMatchResultsV2  = "matchesV2"
+	QueryFeeDetailsV2    = "feesV2"
+	QueryDealListV2      = "dealsV2"
+	QueryTxListV2        = "txsV2"
+	QueryPnLV2           = "pnlV2"
+	QueryDepthSnapshotV2 = "depthSnapshotV2"
 
 	// kline const
 	Kline1GoRoutineWaitInSecond = 5
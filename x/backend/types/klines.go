@@ -6,6 +6,7 @@ import (
 	"sort"
 	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 )
 
@@ -15,11 +16,11 @@ type IKline interface {
 	GetTableName() string
 	GetProduct() string
 	GetTimestamp() int64
-	GetOpen() float64
-	GetClose() float64
-	GetHigh() float64
-	GetLow() float64
-	GetVolume() float64
+	GetOpen() sdk.Dec
+	GetClose() sdk.Dec
+	GetHigh() sdk.Dec
+	GetLow() sdk.Dec
+	GetVolume() sdk.Dec
 	PrettyTimeString() string
 	GetBrifeInfo() []string
 }
@@ -53,13 +54,13 @@ func (klines IKlinesDsc) Less(i, j int) bool {
 }
 
 type BaseKline struct {
-	Product   string  `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product"`
-	Timestamp int64   `gorm:"PRIMARY_KEY;type:bigint;" json:"timestamp"`
-	Open      float64 `gorm:"type:DOUBLE" json:"open"`
-	Close     float64 `gorm:"type:DOUBLE" json:"close"`
-	High      float64 `gorm:"type:DOUBLE" json:"high"`
-	Low       float64 `gorm:"type:DOUBLE" json:"low"`
-	Volume    float64 `gorm:"type:DOUBLE" json:"volume"`
+	Product   string `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product"`
+	Timestamp int64  `gorm:"PRIMARY_KEY;type:bigint;" json:"timestamp"`
+	Open      string `gorm:"type:varchar(40)" json:"open"`
+	Close     string `gorm:"type:varchar(40)" json:"close"`
+	High      string `gorm:"type:varchar(40)" json:"high"`
+	Low       string `gorm:"type:varchar(40)" json:"low"`
+	Volume    string `gorm:"type:varchar(40)" json:"volume"`
 	impl      IKline
 }
 
@@ -92,34 +93,34 @@ func (b *BaseKline) GetTimestamp() int64 {
 	return b.Timestamp
 }
 
-func (b *BaseKline) GetOpen() float64 {
-	return b.Open
+func (b *BaseKline) GetOpen() sdk.Dec {
+	return DecFromString(b.Open)
 }
 
-func (b *BaseKline) GetClose() float64 {
-	return b.Close
+func (b *BaseKline) GetClose() sdk.Dec {
+	return DecFromString(b.Close)
 }
 
-func (b *BaseKline) GetHigh() float64 {
-	return b.High
+func (b *BaseKline) GetHigh() sdk.Dec {
+	return DecFromString(b.High)
 }
 
-func (b *BaseKline) GetLow() float64 {
-	return b.Low
+func (b *BaseKline) GetLow() sdk.Dec {
+	return DecFromString(b.Low)
 }
 
-func (b *BaseKline) GetVolume() float64 {
-	return b.Volume
+func (b *BaseKline) GetVolume() sdk.Dec {
+	return DecFromString(b.Volume)
 }
 
 func (b *BaseKline) GetBrifeInfo() []string {
 	m := []string{
 		time.Unix(b.GetTimestamp(), 0).UTC().Format("2006-01-02T15:04:05.000Z"),
-		fmt.Sprintf("%.4f", b.GetOpen()),
-		fmt.Sprintf("%.4f", b.GetHigh()),
-		fmt.Sprintf("%.4f", b.GetLow()),
-		fmt.Sprintf("%.4f", b.GetClose()),
-		fmt.Sprintf("%.8f", b.GetVolume()),
+		b.GetOpen().String(),
+		b.GetHigh().String(),
+		b.GetLow().String(),
+		b.GetClose().String(),
+		b.GetVolume().String(),
 	}
 	return m
 }
@@ -129,7 +130,7 @@ func TimeString(ts int64) string {
 }
 
 func (b *BaseKline) PrettyTimeString() string {
-	return fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%.4f, %.4f, %.4f, %.4f, %.4f)",
+	return fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%s, %s, %s, %s, %s)",
 		b.Product, b.GetFreqInSecond(), TimeString(b.Timestamp), b.Open, b.Close, b.High, b.Low, b.Volume)
 }
 
@@ -514,11 +515,11 @@ func ToIKlinesArray(klines interface{}, endTS int64, doPadding bool) []IKline {
 		baseKline := BaseKline{
 			Product:   lastKline.GetProduct(),
 			Timestamp: anchorTS,
-			Open:      lastKline.GetClose(),
-			Close:     lastKline.GetClose(),
-			High:      lastKline.GetClose(),
-			Low:       lastKline.GetClose(),
-			Volume:    0,
+			Open:      lastKline.GetClose().String(),
+			Close:     lastKline.GetClose().String(),
+			High:      lastKline.GetClose().String(),
+			Low:       lastKline.GetClose().String(),
+			Volume:    sdk.ZeroDec().String(),
 		}
 		newKline := MustNewKlineFactory(lastKline.GetTableName(), &baseKline)
 		newKlines := []IKline{newKline.(IKline)}
@@ -537,11 +538,11 @@ func ToIKlinesArray(klines interface{}, endTS int64, doPadding bool) []IKline {
 			baseKline := BaseKline{
 				Product:   crrIKline.GetProduct(),
 				Timestamp: expectNextTime,
-				Open:      crrIKline.GetClose(),
-				Close:     crrIKline.GetClose(),
-				High:      crrIKline.GetClose(),
-				Low:       crrIKline.GetClose(),
-				Volume:    0,
+				Open:      crrIKline.GetClose().String(),
+				Close:     crrIKline.GetClose().String(),
+				High:      crrIKline.GetClose().String(),
+				Low:       crrIKline.GetClose().String(),
+				Volume:    sdk.ZeroDec().String(),
 			}
 
 			newKline := MustNewKlineFactory(crrIKline.GetTableName(), &baseKline)
@@ -94,23 +94,23 @@ func TestBaseKLine(t *testing.T) {
 	bk := BaseKline{
 		"flt_" + common.NativeToken,
 		time.Now().Unix(),
-		100,
-		101,
-		103,
-		99,
-		400,
+		"100",
+		"101",
+		"103",
+		"99",
+		"400",
 		nil,
 	}
 
 	bi := bk.GetBrifeInfo()
-	assert.True(t, bi[1] == "100.0000")
-	assert.True(t, bi[2] == "103.0000")
-	assert.True(t, bi[3] == "99.0000")
-	assert.True(t, bi[4] == "101.0000")
+	assert.True(t, bi[1] == "100.00000000")
+	assert.True(t, bi[2] == "103.00000000")
+	assert.True(t, bi[3] == "99.00000000")
+	assert.True(t, bi[4] == "101.00000000")
 	assert.True(t, bi[5] == "400.00000000")
 
 	require.Equal(t, bk.Product, bk.GetProduct())
-	str := fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%.4f, %.4f, %.4f, %.4f, %.4f)",
+	str := fmt.Sprintf("Product: %s, Freq: %d, Time: %s, OCHLV(%s, %s, %s, %s, %s)",
 		bk.Product, bk.GetFreqInSecond(), TimeString(bk.Timestamp), bk.Open, bk.Close, bk.High, bk.Low, bk.Volume)
 	require.Equal(t, str, bk.PrettyTimeString())
 	require.Equal(t, -1, bk.GetFreqInSecond())
@@ -137,11 +137,11 @@ func TestToIKlinesArray(t *testing.T) {
 	bk := &BaseKline{
 		"flt_" + common.NativeToken,
 		time.Now().Unix(),
-		100,
-		101,
-		103,
-		99,
-		400,
+		"100",
+		"101",
+		"103",
+		"99",
+		"400",
 		nil,
 	}
 
@@ -0,0 +1,337 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	orderTypes "github.com/okex/okchain/x/order/types"
+	"github.com/okex/okchain/x/token"
+)
+
+const (
+	// PnLMethodFIFO matches every sell against the earliest bought lots
+	PnLMethodFIFO = "fifo"
+	// PnLMethodWeightedAverage matches every sell against the average cost of the position
+	PnLMethodWeightedAverage = "avg"
+)
+
+// QueryPnLParams is the params of the pnl report query
+type QueryPnLParams struct {
+	Address string
+	Product string
+	Method  string
+}
+
+// NewQueryPnLParams creates a new instance of QueryPnLParams, the method defaults to fifo
+func NewQueryPnLParams(address, product, method string) QueryPnLParams {
+	if method == "" {
+		method = PnLMethodFIFO
+	}
+	return QueryPnLParams{
+		Address: address,
+		Product: product,
+		Method:  strings.ToLower(method),
+	}
+}
+
+// ValidatePnLMethod checks whether the cost basis method is supported
+func ValidatePnLMethod(method string) error {
+	if method != PnLMethodFIFO && method != PnLMethodWeightedAverage {
+		return fmt.Errorf("unsupported pnl method %q, only %s and %s are supported",
+			method, PnLMethodFIFO, PnLMethodWeightedAverage)
+	}
+	return nil
+}
+
+// ProductPnL is the cost basis and pnl of an address on one product.
+// Quantities are in the base token and values in the quote token of the product.
+type ProductPnL struct {
+	Product           string `json:"product" v2:"product"`
+	BoughtQuantity    string `json:"bought_quantity" v2:"bought_quantity"`
+	SoldQuantity      string `json:"sold_quantity" v2:"sold_quantity"`
+	TransferredIn     string `json:"transferred_in" v2:"transferred_in"`
+	TransferredOut    string `json:"transferred_out" v2:"transferred_out"`
+	Position          string `json:"position" v2:"position"`
+	AvgCost           string `json:"avg_cost" v2:"avg_cost"`
+	CostBasis         string `json:"cost_basis" v2:"cost_basis"`
+	Price             string `json:"price" v2:"price"`
+	DealFees          string `json:"deal_fees" v2:"deal_fees"`
+	RealisedPnL       string `json:"realised_pnl" v2:"realised_pnl"`
+	UnrealisedPnL     string `json:"unrealised_pnl" v2:"unrealised_pnl"`
+	UnmatchedQuantity string `json:"unmatched_quantity" v2:"unmatched_quantity"` // sold quantity without known cost, e.g. tokens minted
+}
+
+// PnLTotal is the realised pnl of an address in one token, summed up over the products quoted in the token
+// and net of the order fees charged in it
+type PnLTotal struct {
+	Token       string `json:"token" v2:"token"`
+	RealisedPnL string `json:"realised_pnl" v2:"realised_pnl"`
+	OrderFees   string `json:"order_fees" v2:"order_fees"`
+}
+
+// PnLReport is the pnl report of an address
+type PnLReport struct {
+	Address   string       `json:"address" v2:"address"`
+	Method    string       `json:"method" v2:"method"`
+	Timestamp int64        `json:"timestamp" v2:"timestamp"`
+	Products  []ProductPnL `json:"products" v2:"products"`
+	OrderFees string       `json:"order_fees" v2:"order_fees"` // net fees charged for placing, cancelling and expiring orders
+	Totals    []PnLTotal   `json:"totals" v2:"totals"`
+}
+
+// PnLTransfer is a transfer of the base token of a product in or out of an address, which changes the position
+// without a deal. The tokens transferred in cost the price of the product when they were received.
+type PnLTransfer struct {
+	Timestamp int64
+	Quantity  sdk.Dec // positive for the transfers in and negative for the transfers out
+	Price     sdk.Dec
+}
+
+// NewPnLTransfer creates a PnLTransfer from a transaction of a transfer, valued at price
+func NewPnLTransfer(tx Transaction, price sdk.Dec) (PnLTransfer, error) {
+	quantity, err := sdk.NewDecFromStr(tx.Quantity)
+	if err != nil {
+		return PnLTransfer{}, fmt.Errorf("invalid quantity of transaction %s: %s", tx.TxHash, err.Error())
+	}
+	switch tx.Side {
+	case TxSideTo:
+	case TxSideFrom:
+		quantity = quantity.Neg()
+	default:
+		return PnLTransfer{}, fmt.Errorf("transaction %s is not a transfer in or out", tx.TxHash)
+	}
+	return PnLTransfer{Timestamp: tx.Timestamp, Quantity: quantity, Price: price}, nil
+}
+
+// pnlLot is a bought quantity with its cost not sold yet
+type pnlLot struct {
+	quantity sdk.Dec
+	cost     sdk.Dec
+}
+
+// pnlLots are the lots of a position, merged into one by the weighted-average method
+type pnlLots struct {
+	method string
+	lots   []pnlLot
+}
+
+func (l *pnlLots) add(lot pnlLot) {
+	if l.method == PnLMethodWeightedAverage && len(l.lots) > 0 {
+		l.lots[0].quantity = l.lots[0].quantity.Add(lot.quantity)
+		l.lots[0].cost = l.lots[0].cost.Add(lot.cost)
+		return
+	}
+	l.lots = append(l.lots, lot)
+}
+
+// take removes up to quantity from the earliest lots, and returns the quantity removed with its cost
+func (l *pnlLots) take(quantity sdk.Dec) (taken, cost sdk.Dec) {
+	taken, cost = sdk.ZeroDec(), sdk.ZeroDec()
+	for len(l.lots) > 0 && quantity.IsPositive() {
+		lot := &l.lots[0]
+		if lot.quantity.GT(quantity) {
+			partCost := lot.cost.Mul(quantity).Quo(lot.quantity)
+			lot.quantity = lot.quantity.Sub(quantity)
+			lot.cost = lot.cost.Sub(partCost)
+			return taken.Add(quantity), cost.Add(partCost)
+		}
+		taken = taken.Add(lot.quantity)
+		cost = cost.Add(lot.cost)
+		quantity = quantity.Sub(lot.quantity)
+		l.lots = l.lots[1:]
+	}
+	return taken, cost
+}
+
+// CalculateProductPnL calculates the pnl of the deals and the transfers of the base token of one product, which
+// must be sorted by time ascending. The transfers are applied before the deals made at the same time.
+// The deal fee of a buy is charged in the base token and reduces the bought position, the deal fee of a sell
+// is charged in the quote token and reduces the proceeds, so that the realised pnl is net of deal fees.
+// The tokens transferred out leave the position at their cost and realise no pnl.
+func CalculateProductPnL(product, method string, deals []Deal, transfers []PnLTransfer, price sdk.Dec) (ProductPnL, error) {
+	if err := ValidatePnLMethod(method); err != nil {
+		return ProductPnL{}, err
+	}
+	base, quote := splitProduct(product)
+
+	bought, sold, unmatched := sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()
+	transferredIn, transferredOut := sdk.ZeroDec(), sdk.ZeroDec()
+	fees, realised := sdk.ZeroDec(), sdk.ZeroDec()
+	lots := &pnlLots{method: method}
+	applyTransfers := func(before int64) {
+		for len(transfers) > 0 && transfers[0].Timestamp <= before {
+			transfer := transfers[0]
+			transfers = transfers[1:]
+			if transfer.Quantity.IsNegative() {
+				transferredOut = transferredOut.Sub(transfer.Quantity)
+				lots.take(transfer.Quantity.Neg())
+				continue
+			}
+			transferredIn = transferredIn.Add(transfer.Quantity)
+			lots.add(pnlLot{quantity: transfer.Quantity, cost: transfer.Quantity.Mul(transfer.Price)})
+		}
+	}
+
+	for _, deal := range deals {
+		applyTransfers(deal.Timestamp)
+		dealPrice, err := sdk.NewDecFromStr(deal.Price)
+		if err != nil {
+			return ProductPnL{}, fmt.Errorf("invalid price of deal %s: %s", deal.OrderId, err.Error())
+		}
+		quantity, err := sdk.NewDecFromStr(deal.Quantity)
+		if err != nil {
+			return ProductPnL{}, fmt.Errorf("invalid quantity of deal %s: %s", deal.OrderId, err.Error())
+		}
+		baseFee, quoteFee, errFee := splitDealFee(deal.Fee, base, quote)
+		if errFee != nil {
+			return ProductPnL{}, fmt.Errorf("invalid fee of deal %s: %s", deal.OrderId, errFee.Error())
+		}
+		fees = fees.Add(baseFee.Mul(dealPrice)).Add(quoteFee)
+
+		if deal.Side == BuyOrder {
+			bought = bought.Add(quantity)
+			lots.add(pnlLot{quantity: quantity.Sub(baseFee), cost: quantity.Mul(dealPrice).Add(quoteFee)})
+			continue
+		}
+
+		sold = sold.Add(quantity)
+		matchedQuantity, matchedCost := lots.take(quantity)
+		unmatched = unmatched.Add(quantity.Sub(matchedQuantity))
+
+		// only the matched part of a sell realises pnl, with its share of the deal fee
+		if matchedQuantity.IsPositive() {
+			fee := baseFee.Mul(dealPrice).Add(quoteFee).Mul(matchedQuantity).Quo(quantity)
+			realised = realised.Add(matchedQuantity.Mul(dealPrice).Sub(fee).Sub(matchedCost))
+		}
+	}
+	if len(transfers) > 0 {
+		applyTransfers(transfers[len(transfers)-1].Timestamp)
+	}
+
+	position, costBasis := sdk.ZeroDec(), sdk.ZeroDec()
+	for _, lot := range lots.lots {
+		position = position.Add(lot.quantity)
+		costBasis = costBasis.Add(lot.cost)
+	}
+	avgCost := sdk.ZeroDec()
+	if position.IsPositive() {
+		avgCost = costBasis.Quo(position)
+	}
+	unrealised := sdk.ZeroDec()
+	if position.IsPositive() && price.IsPositive() {
+		unrealised = position.Mul(price).Sub(costBasis)
+	}
+
+	return ProductPnL{
+		Product:           product,
+		BoughtQuantity:    bought.String(),
+		SoldQuantity:      sold.String(),
+		TransferredIn:     transferredIn.String(),
+		TransferredOut:    transferredOut.String(),
+		Position:          position.String(),
+		AvgCost:           avgCost.String(),
+		CostBasis:         costBasis.String(),
+		Price:             price.String(),
+		DealFees:          fees.String(),
+		RealisedPnL:       realised.String(),
+		UnrealisedPnL:     unrealised.String(),
+		UnmatchedQuantity: unmatched.String(),
+	}, nil
+}
+
+// CalculatePnLTotals sums up the realised pnl of the products by their quote tokens, and deducts the order fees
+// charged in the same tokens, so that the realised pnl is net of both the deal fees and the order fees.
+// The totals are sorted by token.
+func CalculatePnLTotals(products []ProductPnL, orderFees sdk.DecCoins) ([]PnLTotal, error) {
+	realised := make(map[string]sdk.Dec)
+	for _, p := range products {
+		pnl, err := sdk.NewDecFromStr(p.RealisedPnL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid realised pnl of product %s: %s", p.Product, err.Error())
+		}
+		_, quote := splitProduct(p.Product)
+		if total, ok := realised[quote]; ok {
+			pnl = pnl.Add(total)
+		}
+		realised[quote] = pnl
+	}
+	for _, fee := range orderFees {
+		if _, ok := realised[fee.Denom]; !ok {
+			realised[fee.Denom] = sdk.ZeroDec()
+		}
+	}
+
+	var tokens []string
+	for token := range realised {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	totals := make([]PnLTotal, 0, len(tokens))
+	for _, token := range tokens {
+		fee := orderFees.AmountOf(token)
+		totals = append(totals, PnLTotal{
+			Token:       token,
+			RealisedPnL: realised[token].Sub(fee).String(),
+			OrderFees:   fee.String(),
+		})
+	}
+	return totals, nil
+}
+
+// CalculateOrderFees sums up the fees charged for orders in the fee details of an address.
+// The fee locked by a new order is partly returned when the order quits, deal fees are counted in the deals.
+func CalculateOrderFees(feeDetails []token.FeeDetail) (sdk.DecCoins, error) {
+	charged, received := sdk.DecCoins{}, sdk.DecCoins{}
+	for _, detail := range feeDetails {
+		if detail.FeeType == orderTypes.FeeTypeOrderDeal || detail.Fee == "" {
+			continue
+		}
+		fee, err := sdk.ParseDecCoins(detail.Fee)
+		if err != nil {
+			return nil, err
+		}
+		switch detail.FeeType {
+		case orderTypes.FeeTypeOrderNew, orderTypes.FeeTypeOrderCancel, orderTypes.FeeTypeOrderExpire:
+			charged = charged.Add(fee)
+		case orderTypes.FeeTypeOrderReceive:
+			received = received.Add(fee)
+		}
+	}
+	// the new fee of an order may be pruned before the fee returned to it
+	if net, hasNeg := charged.SafeSub(received); !hasNeg {
+		return net, nil
+	}
+	return charged, nil
+}
+
+// CSVRecords returns the report as csv records with a header line
+func (r PnLReport) CSVRecords() [][]string {
+	records := [][]string{{"address", "method", "product", "bought_quantity", "sold_quantity", "transferred_in",
+		"transferred_out", "position", "avg_cost", "cost_basis", "price", "deal_fees", "realised_pnl",
+		"unrealised_pnl", "unmatched_quantity"}}
+	for _, p := range r.Products {
+		records = append(records, []string{r.Address, r.Method, p.Product, p.BoughtQuantity, p.SoldQuantity,
+			p.TransferredIn, p.TransferredOut, p.Position, p.AvgCost, p.CostBasis, p.Price, p.DealFees,
+			p.RealisedPnL, p.UnrealisedPnL, p.UnmatchedQuantity})
+	}
+	return records
+}
+
+func splitProduct(product string) (base, quote string) {
+	symbols := strings.SplitN(product, "_", 2)
+	if len(symbols) != 2 {
+		return product, ""
+	}
+	return symbols[0], symbols[1]
+}
+
+// splitDealFee returns the amount of the deal fee in the base and the quote token
+func splitDealFee(fee, base, quote string) (sdk.Dec, sdk.Dec, error) {
+	coins, err := sdk.ParseDecCoins(fee)
+	if err != nil {
+		return sdk.ZeroDec(), sdk.ZeroDec(), err
+	}
+	return coins.AmountOf(base), coins.AmountOf(quote), nil
+}
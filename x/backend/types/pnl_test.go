@@ -0,0 +1,170 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	orderTypes "github.com/okex/okchain/x/order/types"
+	"github.com/okex/okchain/x/token"
+	tokenTypes "github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mockPnLDeals() []Deal {
+	return []Deal{
+		{BlockHeight: 1, OrderId: "ID0000000001-1", Product: "xxb_okt", Side: BuyOrder, Price: "1", Quantity: "10", Fee: "0.01000000xxb"},
+		{BlockHeight: 2, OrderId: "ID0000000002-1", Product: "xxb_okt", Side: BuyOrder, Price: "2", Quantity: "10", Fee: ""},
+		{BlockHeight: 3, OrderId: "ID0000000003-1", Product: "xxb_okt", Side: SellOrder, Price: "3", Quantity: "15", Fee: "0.04500000okt"},
+	}
+}
+
+func TestCalculateProductPnL(t *testing.T) {
+	price := sdk.NewDec(4)
+
+	fifo, err := CalculateProductPnL("xxb_okt", PnLMethodFIFO, mockPnLDeals(), nil, price)
+	require.Nil(t, err)
+	require.Equal(t, "20.00000000", fifo.BoughtQuantity)
+	require.Equal(t, "15.00000000", fifo.SoldQuantity)
+	require.Equal(t, "4.99000000", fifo.Position)
+	require.Equal(t, "9.98000000", fifo.CostBasis)
+	require.Equal(t, "2.00000000", fifo.AvgCost)
+	require.Equal(t, "0.05500000", fifo.DealFees)
+	require.Equal(t, "24.93500000", fifo.RealisedPnL)
+	require.Equal(t, "9.98000000", fifo.UnrealisedPnL)
+	require.Equal(t, "0.00000000", fifo.UnmatchedQuantity)
+
+	avg, err := CalculateProductPnL("xxb_okt", PnLMethodWeightedAverage, mockPnLDeals(), nil, price)
+	require.Nil(t, err)
+	require.Equal(t, "4.99000000", avg.Position)
+	require.Equal(t, "7.48874437", avg.CostBasis)
+	require.Equal(t, "22.44374437", avg.RealisedPnL)
+	require.Equal(t, "12.47125563", avg.UnrealisedPnL)
+
+	// the total pnl doesn't depend on the method
+	total := func(pnl ProductPnL) sdk.Dec {
+		return sdk.MustNewDecFromStr(pnl.RealisedPnL).Add(sdk.MustNewDecFromStr(pnl.UnrealisedPnL))
+	}
+	require.Equal(t, total(fifo), total(avg))
+}
+
+func TestCalculateProductPnL_Unmatched(t *testing.T) {
+	deals := []Deal{
+		{BlockHeight: 1, OrderId: "ID0000000001-1", Product: "xxb_okt", Side: BuyOrder, Price: "1", Quantity: "1"},
+		{BlockHeight: 2, OrderId: "ID0000000002-1", Product: "xxb_okt", Side: SellOrder, Price: "2", Quantity: "3"},
+	}
+	pnl, err := CalculateProductPnL("xxb_okt", PnLMethodFIFO, deals, nil, sdk.ZeroDec())
+	require.Nil(t, err)
+	require.Equal(t, "0.00000000", pnl.Position)
+	require.Equal(t, "2.00000000", pnl.UnmatchedQuantity)
+	require.Equal(t, "1.00000000", pnl.RealisedPnL)
+	require.Equal(t, "0.00000000", pnl.UnrealisedPnL)
+
+	_, err = CalculateProductPnL("xxb_okt", "lifo", deals, nil, sdk.ZeroDec())
+	require.NotNil(t, err)
+
+	deals[0].Price = "abc"
+	_, err = CalculateProductPnL("xxb_okt", PnLMethodFIFO, deals, nil, sdk.ZeroDec())
+	require.NotNil(t, err)
+}
+
+func TestCalculateProductPnL_Transfers(t *testing.T) {
+	deals := []Deal{
+		{Timestamp: 1, OrderId: "ID0000000001-1", Product: "xxb_okt", Side: BuyOrder, Price: "1", Quantity: "10"},
+		{Timestamp: 3, OrderId: "ID0000000003-1", Product: "xxb_okt", Side: SellOrder, Price: "3", Quantity: "12"},
+		{Timestamp: 5, OrderId: "ID0000000005-1", Product: "xxb_okt", Side: SellOrder, Price: "4", Quantity: "2"},
+	}
+	txs := []Transaction{
+		{TxHash: "2", Timestamp: 2, Side: TxSideTo, Quantity: "10"},
+		{TxHash: "3", Timestamp: 3, Side: TxSideFrom, Quantity: "3"},
+		{TxHash: "6", Timestamp: 6, Side: TxSideFrom, Quantity: "1"},
+	}
+	// the tokens transferred in cost the price when they were received
+	transfers := make([]PnLTransfer, len(txs))
+	for i, tx := range txs {
+		transfer, err := NewPnLTransfer(tx, sdk.NewDec(2))
+		require.Nil(t, err)
+		transfers[i] = transfer
+	}
+	require.Equal(t, sdk.NewDec(-3), transfers[1].Quantity)
+
+	// 10 bought at 1, 10 received at 2, 3 of the bought sent out at the time of the sell of 7 bought and 5 received,
+	// 2 received sold at 4 and the last one sent out
+	fifo, err := CalculateProductPnL("xxb_okt", PnLMethodFIFO, deals, transfers, sdk.NewDec(5))
+	require.Nil(t, err)
+	require.Equal(t, "10.00000000", fifo.TransferredIn)
+	require.Equal(t, "4.00000000", fifo.TransferredOut)
+	require.Equal(t, "2.00000000", fifo.Position)
+	require.Equal(t, "4.00000000", fifo.CostBasis)
+	require.Equal(t, "0.00000000", fifo.UnmatchedQuantity)
+	require.Equal(t, "23.00000000", fifo.RealisedPnL)
+	require.Equal(t, "6.00000000", fifo.UnrealisedPnL)
+
+	avg, err := CalculateProductPnL("xxb_okt", PnLMethodWeightedAverage, deals, transfers, sdk.NewDec(5))
+	require.Nil(t, err)
+	require.Equal(t, "2.00000000", avg.Position)
+	require.Equal(t, "3.00000000", avg.CostBasis)
+	require.Equal(t, "23.00000000", avg.RealisedPnL)
+	require.Equal(t, "7.00000000", avg.UnrealisedPnL)
+
+	// without the transfers, the sells are not matched
+	pnl, err := CalculateProductPnL("xxb_okt", PnLMethodFIFO, deals, nil, sdk.NewDec(5))
+	require.Nil(t, err)
+	require.Equal(t, "4.00000000", pnl.UnmatchedQuantity)
+
+	_, err = NewPnLTransfer(Transaction{Side: TxSideBuy, Quantity: "1"}, sdk.ZeroDec())
+	require.NotNil(t, err)
+	_, err = NewPnLTransfer(Transaction{Side: TxSideTo, Quantity: "abc"}, sdk.ZeroDec())
+	require.NotNil(t, err)
+}
+
+func TestCalculatePnLTotals(t *testing.T) {
+	products := []ProductPnL{
+		{Product: "xxb_okt", RealisedPnL: "10.00000000"},
+		{Product: "yyb_okt", RealisedPnL: "-2.00000000"},
+		{Product: "xxb_usdk", RealisedPnL: "3.00000000"},
+	}
+	orderFees := sdk.DecCoins{sdk.NewDecCoinFromDec("okt", sdk.MustNewDecFromStr("0.5")),
+		sdk.NewDecCoinFromDec("zzb", sdk.NewDec(1))}
+	totals, err := CalculatePnLTotals(products, orderFees)
+	require.Nil(t, err)
+	require.Equal(t, []PnLTotal{
+		{Token: "okt", RealisedPnL: "7.50000000", OrderFees: "0.50000000"},
+		{Token: "usdk", RealisedPnL: "3.00000000", OrderFees: "0.00000000"},
+		{Token: "zzb", RealisedPnL: "-1.00000000", OrderFees: "1.00000000"},
+	}, totals)
+
+	products[0].RealisedPnL = "abc"
+	_, err = CalculatePnLTotals(products, orderFees)
+	require.NotNil(t, err)
+}
+
+func TestCalculateOrderFees(t *testing.T) {
+	feeDetails := []token.FeeDetail{
+		{Fee: "0.25920000okt", FeeType: orderTypes.FeeTypeOrderNew},
+		{Fee: "0.25900000okt", FeeType: orderTypes.FeeTypeOrderReceive},
+		{Fee: "0.01000000xxb", FeeType: orderTypes.FeeTypeOrderDeal},
+		{Fee: "0.01250000okt", FeeType: tokenTypes.FeeTypeTransfer},
+	}
+	fees, err := CalculateOrderFees(feeDetails)
+	require.Nil(t, err)
+	require.Equal(t, "0.00020000okt", fees.String())
+
+	feeDetails = append(feeDetails, token.FeeDetail{Fee: "okt", FeeType: orderTypes.FeeTypeOrderNew})
+	_, err = CalculateOrderFees(feeDetails)
+	require.NotNil(t, err)
+}
+
+func TestPnLReport_CSVRecords(t *testing.T) {
+	pnl, err := CalculateProductPnL("xxb_okt", PnLMethodFIFO, mockPnLDeals(), nil, sdk.NewDec(4))
+	require.Nil(t, err)
+	report := PnLReport{Address: "addr", Method: PnLMethodFIFO, Products: []ProductPnL{pnl}}
+
+	records := report.CSVRecords()
+	require.Equal(t, 2, len(records))
+	require.Equal(t, len(records[0]), len(records[1]))
+	require.Equal(t, []string{"addr", PnLMethodFIFO, "xxb_okt"}, records[1][:3])
+
+	params := NewQueryPnLParams("addr", "", "AVG")
+	require.Equal(t, PnLMethodWeightedAverage, params.Method)
+	require.Equal(t, PnLMethodFIFO, NewQueryPnLParams("addr", "", "").Method)
+}
@@ -1,103 +1,377 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	ammswapTypes "github.com/okex/okchain/x/ammswap/types"
 	"github.com/okex/okchain/x/common"
+	dexTypes "github.com/okex/okchain/x/dex/types"
+	distrTypes "github.com/okex/okchain/x/distribution/types"
+	farmTypes "github.com/okex/okchain/x/farm/types"
+	govTypes "github.com/okex/okchain/x/gov/types"
+	htlcTypes "github.com/okex/okchain/x/htlc/types"
+	orderKeeper "github.com/okex/okchain/x/order/keeper"
 	orderTypes "github.com/okex/okchain/x/order/types"
+	stakingTypes "github.com/okex/okchain/x/staking/types"
 	tokenTypes "github.com/okex/okchain/x/token/types"
+	upgradeTypes "github.com/okex/okchain/x/upgrade/types"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
-func GenerateTx(tx *auth.StdTx, txHash string, ctx sdk.Context, orderKeeper OrderKeeper, tokenKeeper TokenKeeper,
-	timestamp int64) []*Transaction {
-	txs := make([]*Transaction, 0, 2)
-	for _, msg := range tx.GetMsgs() {
-		switch msg.Type() {
-		case "send": // token/send
-			txFrom, txTo := buildTransactionsTransfer(msg.(tokenTypes.MsgSend), txHash, ctx, tokenKeeper,
-				timestamp)
-			txs = append(txs, txFrom, txTo)
-		case "new": // order/new
-			transaction := buildTransactionNew(msg.(orderTypes.MsgNewOrders), txHash, ctx, timestamp)
-			txs = append(txs, transaction)
-		case "cancel": // order/cancel
-			transaction := buildTransactionCancel(msg.(orderTypes.MsgCancelOrders), txHash, ctx, orderKeeper,
-				timestamp)
-			if transaction != nil {
-				txs = append(txs, transaction)
+// GenerateTx converts the messages of a tx delivered successfully, with the events of its delivery, into the
+// transactions of the addresses they involve. The fees paid are recorded on the transactions of the paying address:
+// the system fee on its first one, the fee charged by a handler on the first one of the message
+func GenerateTx(tx *auth.StdTx, txHash string, events []abci.Event, ctx sdk.Context, orderKeeper OrderKeeper,
+	tokenKeeper TokenKeeper, dexKeeper DexKeeper, timestamp int64) []*Transaction {
+	b := &txBuilder{
+		ctx:           ctx,
+		txHash:        txHash,
+		timestamp:     timestamp,
+		orderKeeper:   orderKeeper,
+		tokenKeeper:   tokenKeeper,
+		dexKeeper:     dexKeeper,
+		issuedSymbols: issuedSymbols(events),
+	}
+	msgs := tx.GetMsgs()
+	for _, msg := range msgs {
+		b.addMsg(msg)
+	}
+
+	if fee := systemFee(ctx, msgs); len(fee) > 0 && len(b.txs) > 0 {
+		payer := tx.GetSigners()[0].String()
+		i := 0
+		for j, transaction := range b.txs {
+			if transaction.Address == payer {
+				i = j
+				break
 			}
-		default: // In other cases, do nothing
-			continue
 		}
+		b.fees[i] = b.fees[i].Add(fee)
 	}
-	return txs
+
+	for i, transaction := range b.txs {
+		transaction.Fee = feeString(b.fees[i])
+	}
+	return b.txs
 }
 
-func buildTransactionsTransfer(msg tokenTypes.MsgSend, txHash string, ctx sdk.Context, tokenKeeper TokenKeeper,
-	timestamp int64) (*Transaction, *Transaction) {
-	decCoins := msg.Amount
-
-	txFrom := &Transaction{
-		TxHash:    txHash,
-		Address:   msg.FromAddress.String(),
-		Type:      TxTypeTransfer,
-		Side:      TxSideFrom,
-		Symbol:    decCoins[0].Denom,
-		Quantity:  decCoins[0].Amount.String(),
-		Fee:       tokenKeeper.GetParams(ctx).FeeBase.String(), // TODO: get fee from params
-		Timestamp: timestamp,
-	}
-	txTo := &Transaction{
-		TxHash:    txHash,
-		Address:   msg.ToAddress.String(),
-		Type:      TxTypeTransfer,
-		Side:      TxSideTo,
-		Symbol:    decCoins[0].Denom,
-		Quantity:  decCoins[0].Amount.String(),
-		Fee:       sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String(),
-		Timestamp: timestamp,
-	}
-	return txFrom, txTo
+// systemFee returns the fee charged by the ante handler to the first signer, the txs of orders only are free
+func systemFee(ctx sdk.Context, msgs []sdk.Msg) sdk.DecCoins {
+	if orderTypes.IsSystemFree(ctx, msgs) {
+		return nil
+	}
+	return sdk.GetSystemFee().ToCoins()
 }
 
-func buildTransactionNew(msg orderTypes.MsgNewOrders, txHash string, ctx sdk.Context, timestamp int64) *Transaction {
-	side := TxSideBuy
-	if msg.OrderItems[0].Side == orderTypes.SellOrder {
-		side = TxSideSell
-	}
-	return &Transaction{
-		TxHash:    txHash,
-		Address:   msg.Sender.String(),
-		Type:      TxTypeOrderNew,
-		Side:      int64(side),
-		Symbol:    msg.OrderItems[0].Product,
-		Quantity:  msg.OrderItems[0].Quantity.String(),
-		Fee:       sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String(), // TODO: get fee from params
-		Timestamp: timestamp,
+func feeString(fee sdk.DecCoins) string {
+	if fee.IsZero() {
+		return sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String()
 	}
+	return fee.String()
 }
 
-func buildTransactionCancel(msg orderTypes.MsgCancelOrders, txHash string, ctx sdk.Context, orderKeeper OrderKeeper, timestamp int64) *Transaction {
-	order := orderKeeper.GetOrder(ctx, msg.OrderIDs[0])
-	if order == nil {
-		return nil
+type txBuilder struct {
+	ctx         sdk.Context
+	txHash      string
+	timestamp   int64
+	orderKeeper OrderKeeper
+	tokenKeeper TokenKeeper
+	dexKeeper   DexKeeper
+
+	txs  []*Transaction
+	fees []sdk.DecCoins
+	// the orders placed by the tx are all added with its first MsgNewOrders
+	ordersAdded bool
+	// the symbols of the tokens issued by the tx, not taken by its messages yet
+	issuedSymbols []string
+}
+
+func (b *txBuilder) add(addr sdk.AccAddress, txType, side int64, symbol, quantity string, fee sdk.DecCoins,
+	detail string) {
+	b.txs = append(b.txs, &Transaction{
+		TxHash:    b.txHash,
+		Address:   addr.String(),
+		Type:      txType,
+		Side:      side,
+		Symbol:    symbol,
+		Quantity:  quantity,
+		Timestamp: b.timestamp,
+		Detail:    detail,
+	})
+	b.fees = append(b.fees, fee)
+}
+
+// addCoins adds a transaction for each of the coins, the fee is recorded on the first one
+func (b *txBuilder) addCoins(addr sdk.AccAddress, txType, side int64, coins sdk.DecCoins, fee sdk.DecCoins,
+	detail string) {
+	if len(coins) == 0 {
+		b.add(addr, txType, side, "", "", fee, detail)
+		return
+	}
+	for i, coin := range coins {
+		if i > 0 {
+			fee = nil
+		}
+		b.add(addr, txType, side, coin.Denom, coin.Amount.String(), fee, detail)
 	}
-	side := TxSideBuy
+}
+
+func (b *txBuilder) tokenParams() tokenTypes.Params {
+	return b.tokenKeeper.GetParams(b.ctx)
+}
+
+func (b *txBuilder) addMsg(msg sdk.Msg) {
+	detail := string(msg.GetSignBytes())
+	switch msg := msg.(type) {
+	// order
+	case orderTypes.MsgNewOrders:
+		b.addNewOrders()
+	case orderTypes.MsgCancelOrders:
+		b.addCancelOrders(msg)
+
+	// token
+	case tokenTypes.MsgSend:
+		fee := b.tokenParams().MultiCoinsFee(len(msg.Amount))
+		b.addCoins(msg.FromAddress, TxTypeTransfer, TxSideFrom, msg.Amount, fee, detail)
+		b.addCoins(msg.ToAddress, TxTypeTransfer, TxSideTo, msg.Amount, nil, detail)
+	case tokenTypes.MsgMultiSend:
+		var coinNum int
+		for _, unit := range msg.Transfers {
+			coinNum += len(unit.Coins)
+		}
+		fee := b.tokenParams().MultiCoinsFee(coinNum)
+		for _, unit := range msg.Transfers {
+			b.addCoins(msg.From, TxTypeMultiSend, TxSideFrom, unit.Coins, fee, detail)
+			b.addCoins(unit.To, TxTypeMultiSend, TxSideTo, unit.Coins, nil, detail)
+			fee = nil
+		}
+	case tokenTypes.MsgTimeLockedSend:
+		fee := b.tokenParams().MultiCoinsFee(len(msg.Amount))
+		b.addCoins(msg.FromAddress, TxTypeTimeLockedSend, TxSideFrom, msg.Amount, fee, detail)
+		b.addCoins(msg.ToAddress, TxTypeTimeLockedSend, TxSideTo, msg.Amount, nil, detail)
+	case tokenTypes.MsgTokenIssue:
+		b.add(msg.Owner, TxTypeTokenIssue, TxSideTo, b.issuedSymbol(msg), msg.TotalSupply,
+			b.tokenParams().FeeIssue.ToCoins(), detail)
+	case tokenTypes.MsgTokenMint:
+		b.add(msg.Owner, TxTypeTokenMint, TxSideTo, msg.Amount.Denom, msg.Amount.Amount.String(),
+			b.tokenParams().FeeMint.ToCoins(), detail)
+	case tokenTypes.MsgTokenBurn:
+		b.add(msg.Owner, TxTypeTokenBurn, TxSideFrom, msg.Amount.Denom, msg.Amount.Amount.String(),
+			b.tokenParams().FeeBurn.ToCoins(), detail)
+	case tokenTypes.MsgTokenModify:
+		b.add(msg.Owner, TxTypeTokenModify, TxSideNone, msg.Symbol, "", b.tokenParams().FeeModify.ToCoins(), detail)
+	case tokenTypes.MsgFreeze:
+		b.add(msg.Owner, TxTypeTokenFreeze, TxSideNone, msg.Symbol, "", nil, detail)
+		if !msg.Address.Equals(msg.Owner) {
+			b.add(msg.Address, TxTypeTokenFreeze, TxSideNone, msg.Symbol, "", nil, detail)
+		}
+	case tokenTypes.MsgUnfreeze:
+		b.add(msg.Owner, TxTypeTokenUnfreeze, TxSideNone, msg.Symbol, "", nil, detail)
+		if !msg.Address.Equals(msg.Owner) {
+			b.add(msg.Address, TxTypeTokenUnfreeze, TxSideNone, msg.Symbol, "", nil, detail)
+		}
+	case tokenTypes.MsgTransferOwnership:
+		b.add(msg.FromAddress, TxTypeTokenOwnership, TxSideFrom, msg.Symbol, "", b.tokenParams().FeeChown.ToCoins(),
+			detail)
+		b.add(msg.ToAddress, TxTypeTokenOwnership, TxSideTo, msg.Symbol, "", nil, detail)
+	case tokenTypes.MsgProposeOwnership:
+		b.add(msg.FromAddress, TxTypeTokenOwnership, TxSideFrom, msg.Symbol, "", b.tokenParams().FeeChown.ToCoins(),
+			detail)
+		b.add(msg.ToAddress, TxTypeTokenOwnership, TxSideTo, msg.Symbol, "", nil, detail)
+	case tokenTypes.MsgConfirmOwnership:
+		b.add(msg.Address, TxTypeTokenOwnership, TxSideTo, msg.Symbol, "", nil, detail)
+	case tokenTypes.MsgCreateMultisig:
+		b.add(msg.Creator, TxTypeTokenMultisig, TxSideNone, "", "", nil, detail)
+	case tokenTypes.MsgMultisigSubmit:
+		b.add(msg.Member, TxTypeTokenMultisig, TxSideNone, "", "", nil, detail)
+	case tokenTypes.MsgMultisigApprove:
+		b.add(msg.Member, TxTypeTokenMultisig, TxSideNone, "", "", nil, detail)
+	case tokenTypes.MsgDistribute:
+		b.addCoins(msg.Sender, TxTypeTokenDistribute, TxSideFrom, msg.Amount, b.tokenParams().FeeDistribute.ToCoins(),
+			detail)
+
+	// dex
+	case dexTypes.MsgList:
+		product := fmt.Sprintf("%s_%s", msg.ListAsset, msg.QuoteAsset)
+		b.add(msg.Owner, TxTypeDexList, TxSideNone, product, "", b.dexKeeper.GetParams(b.ctx).ListFee.ToCoins(), detail)
+	case dexTypes.MsgDeposit:
+		b.add(msg.Depositor, TxTypeDexDeposit, TxSideFrom, msg.Amount.Denom, msg.Amount.Amount.String(), nil, detail)
+	case dexTypes.MsgWithdraw:
+		b.add(msg.Depositor, TxTypeDexWithdraw, TxSideTo, msg.Amount.Denom, msg.Amount.Amount.String(), nil, detail)
+	case dexTypes.MsgCancelWithdraw:
+		b.add(msg.Depositor, TxTypeDexCancelWithdraw, TxSideFrom, msg.Amount.Denom, msg.Amount.Amount.String(), nil,
+			detail)
+	case dexTypes.MsgTransferOwnership:
+		b.add(msg.FromAddress, TxTypeDexOwnership, TxSideFrom, msg.Product, "",
+			b.dexKeeper.GetParams(b.ctx).TransferOwnershipFee.ToCoins(), detail)
+		b.add(msg.ToAddress, TxTypeDexOwnership, TxSideTo, msg.Product, "", nil, detail)
+	case dexTypes.MsgRegisterOperator:
+		b.add(msg.Owner, TxTypeDexOperator, TxSideNone, "", "", nil, detail)
+
+	// staking
+	case stakingTypes.MsgCreateValidator:
+		b.add(msg.DelegatorAddress, TxTypeStakingValidator, TxSideFrom, msg.MinSelfDelegation.Denom,
+			msg.MinSelfDelegation.Amount.String(), nil, detail)
+	case stakingTypes.MsgEditValidator:
+		b.add(sdk.AccAddress(msg.ValidatorAddress), TxTypeStakingValidator, TxSideNone, "", "", nil, detail)
+	case stakingTypes.MsgDestroyValidator:
+		b.add(msg.DelAddr, TxTypeStakingValidator, TxSideNone, "", "", nil, detail)
+	case stakingTypes.MsgDelegate:
+		b.add(msg.DelegatorAddress, TxTypeStakingDelegate, TxSideFrom, msg.Amount.Denom, msg.Amount.Amount.String(),
+			nil, detail)
+	case stakingTypes.MsgUndelegate:
+		b.add(msg.DelegatorAddress, TxTypeStakingUndelegate, TxSideTo, msg.Amount.Denom, msg.Amount.Amount.String(),
+			nil, detail)
+	case stakingTypes.MsgVote:
+		b.add(msg.DelAddr, TxTypeStakingVote, TxSideNone, "", "", nil, detail)
+	case stakingTypes.MsgRegProxy:
+		b.add(msg.ProxyAddress, TxTypeStakingProxy, TxSideNone, "", "", nil, detail)
+	case stakingTypes.MsgBindProxy:
+		b.add(msg.DelAddr, TxTypeStakingProxy, TxSideNone, "", "", nil, detail)
+	case stakingTypes.MsgUnbindProxy:
+		b.add(msg.DelAddr, TxTypeStakingProxy, TxSideNone, "", "", nil, detail)
+
+	// gov
+	case govTypes.MsgSubmitProposal:
+		b.addCoins(msg.Proposer, TxTypeGovProposal, TxSideFrom, msg.InitialDeposit, nil, detail)
+	case govTypes.MsgDeposit:
+		b.addCoins(msg.Depositor, TxTypeGovDeposit, TxSideFrom, msg.Amount, nil, detail)
+	case govTypes.MsgVote:
+		b.add(msg.Voter, TxTypeGovVote, TxSideNone, "", "", nil, detail)
+
+	// distribution
+	case distrTypes.MsgWithdrawValidatorCommission:
+		b.add(sdk.AccAddress(msg.ValidatorAddress), TxTypeDistrWithdraw, TxSideTo, "", "", nil, detail)
+	case distrTypes.MsgSetWithdrawAddress:
+		b.add(msg.DelegatorAddress, TxTypeDistrSetWithdrawAddress, TxSideNone, "", "", nil, detail)
+
+	// farm
+	case farmTypes.MsgSetFarmPool:
+		b.add(msg.Owner, TxTypeFarmSetPool, TxSideNone, msg.Product, "", nil, detail)
+	case farmTypes.MsgFundFarmPool:
+		b.add(msg.Owner, TxTypeFarmFundPool, TxSideFrom, msg.Amount.Denom, msg.Amount.Amount.String(), nil, detail)
+	case farmTypes.MsgWithdrawFarmPool:
+		b.add(msg.Owner, TxTypeFarmWithdrawPool, TxSideTo, msg.Amount.Denom, msg.Amount.Amount.String(), nil, detail)
+	case farmTypes.MsgClaimReward:
+		b.add(msg.Address, TxTypeFarmClaimReward, TxSideTo, "", "", nil, detail)
+
+	// ammswap
+	case ammswapTypes.MsgAddLiquidity:
+		b.addCoins(msg.Sender, TxTypeSwapAddLiquidity, TxSideFrom,
+			sdk.DecCoins{msg.MaxBaseAmount, msg.QuoteAmount}, nil, detail)
+	case ammswapTypes.MsgRemoveLiquidity:
+		b.add(msg.Sender, TxTypeSwapRemoveLiquidity, TxSideTo, "", msg.Liquidity.String(), nil, detail)
+	case ammswapTypes.MsgSwap:
+		b.add(msg.Sender, TxTypeSwap, TxSideFrom, msg.SoldTokenAmount.Denom, msg.SoldTokenAmount.Amount.String(),
+			nil, detail)
+	case ammswapTypes.MsgRouteSwap:
+		b.add(msg.Sender, TxTypeSwap, TxSideFrom, msg.SoldTokenAmount.Denom, msg.SoldTokenAmount.Amount.String(),
+			nil, detail)
+
+	// htlc
+	case htlcTypes.MsgCreateHTLC:
+		b.addCoins(msg.Sender, TxTypeHTLCCreate, TxSideFrom, msg.Amount, nil, detail)
+	case htlcTypes.MsgClaimHTLC:
+		b.add(msg.Sender, TxTypeHTLCClaim, TxSideTo, "", "", nil, detail)
+	case htlcTypes.MsgRefundHTLC:
+		b.add(msg.Sender, TxTypeHTLCRefund, TxSideTo, "", "", nil, detail)
+
+	case upgradeTypes.MsgUpgradeConfig:
+		b.add(msg.Owner, TxTypeUpgrade, TxSideNone, "", "", nil, detail)
+
+	default:
+		for _, signer := range msg.GetSigners() {
+			b.add(signer, TxTypeOther, TxSideNone, "", "", nil, detail)
+		}
+	}
+}
+
+// addNewOrders adds the orders placed by the tx, they are the last ones placed in the block
+func (b *txBuilder) addNewOrders() {
+	if b.ordersAdded {
+		return
+	}
+	b.ordersAdded = true
+
+	height := b.ctx.BlockHeight()
+	var orders []*orderTypes.Order
+	for num := b.orderKeeper.GetBlockOrderNum(b.ctx, height); num > 0; num-- {
+		order := b.orderKeeper.GetOrder(b.ctx, orderTypes.FormatOrderID(height, num))
+		if order == nil || order.TxHash != b.txHash {
+			break
+		}
+		orders = append(orders, order)
+	}
+
+	for i := len(orders) - 1; i >= 0; i-- {
+		order := orders[i]
+		fee, err := sdk.ParseDecCoins(order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyNewFee))
+		if err != nil {
+			fee = nil
+		}
+		b.add(order.Sender, TxTypeOrderNew, orderSide(order), order.Product, order.Quantity.String(), fee,
+			orderDetail(order))
+	}
+}
+
+// addCancelOrders adds the orders canceled by the message, charged the fee of the blocks they were open
+func (b *txBuilder) addCancelOrders(msg orderTypes.MsgCancelOrders) {
+	updated := make(map[string]struct{})
+	for _, orderID := range b.orderKeeper.GetUpdatedOrderIDs() {
+		updated[orderID] = struct{}{}
+	}
+	for _, orderID := range msg.OrderIDs {
+		order := b.orderKeeper.GetOrder(b.ctx, orderID)
+		if _, ok := updated[orderID]; !ok || order == nil || order.Status != orderTypes.OrderStatusCancelled {
+			// the order wasn't canceled by the message
+			continue
+		}
+		fee := orderKeeper.GetOrderCostFee(order, b.ctx)
+		b.add(msg.Sender, TxTypeOrderCancel, orderSide(order), order.Product, order.Quantity.String(), fee,
+			orderDetail(order))
+	}
+}
+
+func orderSide(order *orderTypes.Order) int64 {
 	if order.Side == orderTypes.SellOrder {
-		side = TxSideSell
-	}
-	cancelFeeStr := order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyCancelFee)
-	if cancelFeeStr == "" {
-		cancelFeeStr = sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String()
-	}
-	return &Transaction{
-		TxHash:    txHash,
-		Address:   msg.Sender.String(),
-		Type:      TxTypeOrderCancel,
-		Side:      int64(side),
-		Symbol:    order.Product,
-		Quantity:  order.Quantity.String(),
-		Fee:       cancelFeeStr,
-		Timestamp: timestamp,
+		return TxSideSell
+	}
+	return TxSideBuy
+}
+
+func orderDetail(order *orderTypes.Order) string {
+	bz, err := json.Marshal(order)
+	if err != nil {
+		return ""
+	}
+	return string(bz)
+}
+
+// issuedSymbol returns the symbol of the token issued by the message, as suffixed by the handler. The issue events
+// of the tx are in the order of its messages
+func (b *txBuilder) issuedSymbol(msg tokenTypes.MsgTokenIssue) string {
+	if len(b.issuedSymbols) == 0 {
+		return msg.OriginalSymbol
+	}
+	symbol := b.issuedSymbols[0]
+	b.issuedSymbols = b.issuedSymbols[1:]
+	return symbol
+}
+
+// issuedSymbols returns the symbols of the issue events of a tx
+func issuedSymbols(events []abci.Event) (symbols []string) {
+	for _, event := range events {
+		if event.Type != tokenTypes.EventTypeIssue {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == tokenTypes.AttributeKeySymbol {
+				symbols = append(symbols, string(attr.Value))
+			}
+		}
 	}
+	return symbols
 }
@@ -4,84 +4,131 @@ import (
 	"fmt"
 	"sort"
 	"testing"
-	"time"
 
 	"github.com/okex/okchain/x/common"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	dexTypes "github.com/okex/okchain/x/dex/types"
 	"github.com/okex/okchain/x/order"
 	orderKeeper "github.com/okex/okchain/x/order/keeper"
 	orderTypes "github.com/okex/okchain/x/order/types"
-	tokenKeeper "github.com/okex/okchain/x/token"
 	token "github.com/okex/okchain/x/token/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
-func TestGenerateTx(t *testing.T) {
+func buildStdTx(t *testing.T, priKey secp256k1.PrivKeySecp256k1, msgs ...sdk.Msg) auth.StdTx {
 	txbldr := auth.NewTxBuilder(auth.DefaultTxEncoder(auth.ModuleCdc), 1, 2, 3, 4, false, "okchain", "memo", nil, nil)
+	txSigMsg, err := txbldr.BuildSignMsg(msgs)
+	require.Nil(t, err)
+	sig, err := priKey.Sign(txSigMsg.Bytes())
+	require.Nil(t, err)
+	return auth.NewStdTx(txSigMsg.Msgs, txSigMsg.Fee, []auth.StdSignature{{PubKey: priKey.PubKey(), Signature: sig}}, "")
+}
 
+func TestGenerateTx(t *testing.T) {
 	priKeyFrom := secp256k1.GenPrivKey()
-	pubKeyFrom := priKeyFrom.PubKey()
-	accFrom := sdk.AccAddress(pubKeyFrom.Address())
+	accFrom := sdk.AccAddress(priKeyFrom.PubKey().Address())
+	accTo := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
 
-	priKeyTo := secp256k1.GenPrivKey()
-	pubKeyTo := priKeyTo.PubKey()
-	accTo := sdk.AccAddress(pubKeyTo.Address())
-
-	// send
-	decCoins, err := sdk.ParseDecCoins("100" + common.NativeToken)
-	require.Nil(t, err)
-	sendMsg := token.NewMsgTokenSend(accFrom, accTo, decCoins)
-
-	sendMsgSig, _ := priKeyFrom.Sign(sendMsg.GetSignBytes())
-	sigs := []auth.StdSignature{
-		{
-			PubKey:    pubKeyFrom,
-			Signature: sendMsgSig,
-		},
+	testInput := orderKeeper.CreateTestInput(t)
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	testInput.TokenKeeper.SetParams(ctx, token.DefaultParams())
+	testInput.DexKeeper.SetParams(ctx, *dexTypes.DefaultParams())
+	generateWithEvents := func(tx auth.StdTx, txHash string, events sdk.Events) []*Transaction {
+		return GenerateTx(&tx, txHash, events.ToABCIEvents(), ctx, testInput.OrderKeeper, testInput.TokenKeeper,
+			testInput.DexKeeper, 100)
 	}
-	txSigMsg, _ := txbldr.BuildSignMsg([]sdk.Msg{sendMsg})
-	tx := auth.NewStdTx(txSigMsg.Msgs, txSigMsg.Fee, sigs, "")
-	ctx0, keeper0, _, _ := tokenKeeper.CreateParam(t, false)
-	GenerateTx(&tx, "", ctx0, nil, keeper0, time.Now().Unix())
-
-	// order/new
-	orderNewMsg := order.NewMsgNewOrder(accFrom, "btc_"+common.NativeToken, SellOrder, "23.76", "289")
-	orderNewMsgSig, _ := priKeyFrom.Sign(orderNewMsg.GetSignBytes())
-	sigs = []auth.StdSignature{
-		{
-			PubKey:    pubKeyFrom,
-			Signature: orderNewMsgSig,
-		},
+	generate := func(tx auth.StdTx, txHash string) []*Transaction {
+		return generateWithEvents(tx, txHash, nil)
 	}
-	txSigMsg, _ = txbldr.BuildSignMsg([]sdk.Msg{orderNewMsg})
-	tx = auth.NewStdTx(txSigMsg.Msgs, txSigMsg.Fee, sigs, "")
-	GenerateTx(&tx, "", sdk.Context{}, nil, nil, time.Now().Unix())
-
-	// order/cancel
-	orderCancelMsg := order.NewMsgCancelOrder(accFrom, "ORDER-123")
-	orderCancelMsgSig, _ := priKeyFrom.Sign(orderCancelMsg.GetSignBytes())
-	sigs = []auth.StdSignature{
-		{
-			PubKey:    pubKeyFrom,
-			Signature: orderCancelMsgSig,
-		},
+
+	// send: a transaction per coin on each side, the system fee and the multi coins fee paid by the sender
+	decCoins, err := sdk.ParseDecCoins("100" + common.NativeToken + ",10" + common.TestToken)
+	require.Nil(t, err)
+	txs := generate(buildStdTx(t, priKeyFrom, token.NewMsgTokenSend(accFrom, accTo, decCoins)), "send")
+	require.Len(t, txs, 4)
+	for i, tx := range txs {
+		require.Equal(t, int64(TxTypeTransfer), tx.Type)
+		require.Equal(t, "send", tx.TxHash)
+		require.Equal(t, int64(100), tx.Timestamp)
+		require.NotEmpty(t, tx.Detail)
+		if i < 2 {
+			require.Equal(t, accFrom.String(), tx.Address)
+			require.Equal(t, int64(TxSideFrom), tx.Side)
+		} else {
+			require.Equal(t, accTo.String(), tx.Address)
+			require.Equal(t, int64(TxSideTo), tx.Side)
+		}
+		require.Equal(t, decCoins[i%2].Denom, tx.Symbol)
 	}
-	txSigMsg, _ = txbldr.BuildSignMsg([]sdk.Msg{orderCancelMsg})
-	tx = auth.NewStdTx(txSigMsg.Msgs, txSigMsg.Fee, sigs, "")
+	require.Equal(t, "0.02000000"+common.NativeToken, txs[0].Fee)
+	require.Equal(t, "0.00000000"+common.NativeToken, txs[1].Fee)
+	require.Equal(t, "0.00000000"+common.NativeToken, txs[2].Fee)
 
-	testInput := orderKeeper.CreateTestInput(t)
-	keeper := testInput.OrderKeeper
-	ctx := testInput.Ctx.WithBlockHeight(10)
-	or := &order.Order{
-		OrderID: orderCancelMsg.OrderIDs[0],
-		Side:    SellOrder,
+	// order/new: the orders placed by the tx, with their new fee and without system fee
+	orderNewMsg := order.NewMsgNewOrder(accFrom, TestTokenPair, SellOrder, "23.76", "289")
+	params := testInput.OrderKeeper.GetParams(ctx)
+	newOrder := orderTypes.NewOrder("neworder", testInput.TestAddrs[0], TestTokenPair, SellOrder,
+		sdk.MustNewDecFromStr("23.76"), sdk.MustNewDecFromStr("1"), 100, params.OrderExpireBlocks, params.FeePerBlock)
+	require.Nil(t, testInput.OrderKeeper.PlaceOrder(ctx, newOrder))
+	txs = generate(buildStdTx(t, priKeyFrom, orderNewMsg), "neworder")
+	require.Len(t, txs, 1)
+	require.Equal(t, int64(TxTypeOrderNew), txs[0].Type)
+	require.Equal(t, int64(TxSideSell), txs[0].Side)
+	require.Equal(t, testInput.TestAddrs[0].String(), txs[0].Address)
+	require.Equal(t, orderKeeper.GetOrderNewFee(newOrder).String(), txs[0].Fee)
+	require.Contains(t, txs[0].Detail, newOrder.OrderID)
+	require.Len(t, generate(buildStdTx(t, priKeyFrom, orderNewMsg), "other"), 0)
+
+	// order/cancel: the orders canceled, charged the fee of the blocks they were open
+	orderCancelMsg := order.NewMsgCancelOrder(testInput.TestAddrs[0], newOrder.OrderID)
+	require.Len(t, generate(buildStdTx(t, priKeyFrom, orderCancelMsg), "cancel"), 0)
+	ctx = ctx.WithBlockHeight(12)
+	testInput.OrderKeeper.CancelOrder(ctx, newOrder, ctx.Logger())
+	txs = generate(buildStdTx(t, priKeyFrom, orderCancelMsg), "cancel")
+	require.Len(t, txs, 1)
+	require.Equal(t, int64(TxTypeOrderCancel), txs[0].Type)
+	require.Equal(t, int64(TxSideSell), txs[0].Side)
+	require.Equal(t, newOrder.FeePerBlock.Amount.MulInt64(2).String()+newOrder.FeePerBlock.Denom, txs[0].Fee)
+
+	// token/mint and dex/list: the fee charged by the handler
+	mintMsg := token.NewMsgTokenMint(sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(5)), accFrom)
+	listMsg := dexTypes.NewMsgList(accFrom, common.TestToken, common.NativeToken, sdk.OneDec())
+	txs = generate(buildStdTx(t, priKeyFrom, mintMsg, listMsg), "mint")
+	require.Len(t, txs, 2)
+	require.Equal(t, int64(TxTypeTokenMint), txs[0].Type)
+	require.Equal(t, int64(TxSideTo), txs[0].Side)
+	require.Equal(t, "5.00000000", txs[0].Quantity)
+	require.Equal(t, token.DefaultParams().FeeMint.ToCoins().Add(sdk.GetSystemFee().ToCoins()).String(), txs[0].Fee)
+	require.Equal(t, int64(TxTypeDexList), txs[1].Type)
+	require.Equal(t, dexTypes.DefaultParams().ListFee.String(), txs[1].Fee)
+
+	// token/issue: the symbols suffixed by the handler are read from the issue events, in the order of the messages
+	issueMsg1 := token.NewMsgTokenIssue("", "abc", "abc", "abc", "100", accFrom, true)
+	issueMsg2 := token.NewMsgTokenIssue("", "def", "def", "def", "200", accFrom, true)
+	events := sdk.Events{
+		sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(token.AttributeKeySymbol, "abc-1a2")),
+		sdk.NewEvent(token.EventTypeIssue, sdk.NewAttribute(token.AttributeKeySymbol, "abc-1a2")),
+		sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(token.AttributeKeySymbol, "def-3b4")),
+		sdk.NewEvent(token.EventTypeIssue, sdk.NewAttribute(token.AttributeKeySymbol, "def-3b4")),
 	}
-	keeper.SetOrder(ctx, or.OrderID, or)
-	or.SetExtraInfoWithKeyValue(orderTypes.OrderExtraInfoKeyCancelFee, "1"+common.NativeToken)
-	GenerateTx(&tx, "", ctx, keeper, nil, time.Now().Unix())
+	txs = generateWithEvents(buildStdTx(t, priKeyFrom, issueMsg1, issueMsg2), "issue", events)
+	require.Len(t, txs, 2)
+	require.Equal(t, int64(TxTypeTokenIssue), txs[0].Type)
+	require.Equal(t, "abc-1a2", txs[0].Symbol)
+	require.Equal(t, "100", txs[0].Quantity)
+	require.Equal(t, "def-3b4", txs[1].Symbol)
+	txs = generate(buildStdTx(t, priKeyFrom, issueMsg1), "issue")
+	require.Equal(t, "abc", txs[0].Symbol)
+
+	// other messages are recorded for each of their signers
+	txs = generate(buildStdTx(t, priKeyFrom, sdk.NewTestMsg(accFrom, accTo)), "other")
+	require.Len(t, txs, 2)
+	require.Equal(t, int64(TxTypeOther), txs[1].Type)
+	require.Equal(t, accTo.String(), txs[1].Address)
+	require.Equal(t, sdk.GetSystemFee().String(), txs[0].Fee)
 }
 
 func TestTicker(t *testing.T) {
@@ -89,31 +136,31 @@ func TestTicker(t *testing.T) {
 		Symbol:           "btc",
 		Product:          "btc_" + common.NativeToken,
 		Timestamp:        0,
-		Open:             10.5,
-		Close:            53.5,
-		High:             100,
-		Low:              6.66,
-		Price:            2.46,
-		Volume:           3000,
-		Change:           43,
+		Open:             "10.5",
+		Close:            "53.5",
+		High:             "100",
+		Low:              "6.66",
+		Price:            "2.46",
+		Volume:           "3000",
+		Change:           "43",
 		ChangePercentage: "409.52%",
 	}
 	tiker2 := Ticker{
 		Symbol:           "eth",
 		Product:          "eth_" + common.NativeToken,
 		Timestamp:        0,
-		Open:             3.8,
-		Close:            15.9,
-		High:             200,
-		Low:              2,
-		Price:            9.6,
-		Volume:           110,
-		Change:           12.1,
+		Open:             "3.8",
+		Close:            "15.9",
+		High:             "200",
+		Low:              "2",
+		Price:            "9.6",
+		Volume:           "110",
+		Change:           "12.1",
 		ChangePercentage: "318.42%",
 	}
 
 	tikerStr := tiker1.PrettyString()
-	str := fmt.Sprintf("[Ticker] Symbol: %s, Price: %f, TStr: %s, Timestamp: %d, OCHLV(%f, %f, %f, %f, %f) [%f, %s])",
+	str := fmt.Sprintf("[Ticker] Symbol: %s, Price: %s, TStr: %s, Timestamp: %d, OCHLV(%s, %s, %s, %s, %s) [%s, %s])",
 		tiker1.Symbol, tiker1.Price, TimeString(tiker1.Timestamp), tiker1.Timestamp, tiker1.Open, tiker1.Close, tiker1.High, tiker1.Low, tiker1.Volume, tiker1.Change, tiker1.ChangePercentage)
 
 	require.Equal(t, str, tikerStr)
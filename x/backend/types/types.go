@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	orderTypes "github.com/okex/okchain/x/order/types"
+	"github.com/okex/okchain/x/token"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -14,10 +15,68 @@ const (
 	TxTypeOrderNew    = 2
 	TxTypeOrderCancel = 3
 
+	// token
+	TxTypeMultiSend       = 4
+	TxTypeTimeLockedSend  = 5
+	TxTypeTokenIssue      = 6
+	TxTypeTokenMint       = 7
+	TxTypeTokenBurn       = 8
+	TxTypeTokenModify     = 9
+	TxTypeTokenFreeze     = 10
+	TxTypeTokenUnfreeze   = 11
+	TxTypeTokenOwnership  = 12 // transfer, propose and confirm the ownership of a token
+	TxTypeTokenMultisig   = 13 // create a multisig account, submit and approve its proposals
+	TxTypeTokenDistribute = 14
+
+	// dex
+	TxTypeDexList           = 20
+	TxTypeDexDeposit        = 21
+	TxTypeDexWithdraw       = 22
+	TxTypeDexCancelWithdraw = 23
+	TxTypeDexOwnership      = 24
+	TxTypeDexOperator       = 25
+
+	// staking
+	TxTypeStakingValidator  = 30 // create, edit and destroy a validator
+	TxTypeStakingDelegate   = 31
+	TxTypeStakingUndelegate = 32
+	TxTypeStakingVote       = 33
+	TxTypeStakingProxy      = 34 // register, bind and unbind a proxy
+
+	// gov
+	TxTypeGovProposal = 40
+	TxTypeGovDeposit  = 41
+	TxTypeGovVote     = 42
+
+	// distribution
+	TxTypeDistrWithdraw           = 50
+	TxTypeDistrSetWithdrawAddress = 51
+
+	// farm
+	TxTypeFarmSetPool      = 60
+	TxTypeFarmFundPool     = 61
+	TxTypeFarmClaimReward  = 62
+	TxTypeFarmWithdrawPool = 63
+
+	// ammswap
+	TxTypeSwapAddLiquidity    = 70
+	TxTypeSwapRemoveLiquidity = 71
+	TxTypeSwap                = 72
+
+	// htlc
+	TxTypeHTLCCreate = 80
+	TxTypeHTLCClaim  = 81
+	TxTypeHTLCRefund = 82
+
+	TxTypeUpgrade = 90
+	// the messages of the other modules, recorded for each of their signers
+	TxTypeOther = 99
+
+	TxSideNone = 0 // the message doesn't move coins of the address
 	TxSideBuy  = 1
 	TxSideSell = 2
-	TxSideFrom = 3
-	TxSideTo   = 4
+	TxSideFrom = 3 // coins leave the address
+	TxSideTo   = 4 // coins come to the address
 
 	BuyOrder      = orderTypes.BuyOrder
 	SellOrder     = orderTypes.SellOrder
@@ -44,21 +103,21 @@ type TxEvent struct {
 }
 
 type Ticker struct {
-	Symbol           string  `json:"symbol"`
-	Product          string  `json:"product"`
-	Timestamp        int64   `json:"timestamp"`
-	Open             float64 `json:"open"`  // Open In 24h
-	Close            float64 `json:"close"` // Close in 24h
-	High             float64 `json:"high"`  // High in 24h
-	Low              float64 `json:"low"`   // Low in 24h
-	Price            float64 `json:"price"`
-	Volume           float64 `json:"volume"`            // Volume in 24h
-	Change           float64 `json:"change"`            // (Close - Open)
-	ChangePercentage string  `json:"change_percentage"` // Change / Open * 100%
+	Symbol           string `json:"symbol"`
+	Product          string `json:"product"`
+	Timestamp        int64  `json:"timestamp"`
+	Open             string `json:"open"`  // Open In 24h
+	Close            string `json:"close"` // Close in 24h
+	High             string `json:"high"`  // High in 24h
+	Low              string `json:"low"`   // Low in 24h
+	Price            string `json:"price"`
+	Volume           string `json:"volume"`            // Volume in 24h
+	Change           string `json:"change"`            // (Close - Open)
+	ChangePercentage string `json:"change_percentage"` // Change / Open * 100%
 }
 
 func (t *Ticker) PrettyString() string {
-	return fmt.Sprintf("[Ticker] Symbol: %s, Price: %f, TStr: %s, Timestamp: %d, OCHLV(%f, %f, %f, %f, %f) [%f, %s])",
+	return fmt.Sprintf("[Ticker] Symbol: %s, Price: %s, TStr: %s, Timestamp: %d, OCHLV(%s, %s, %s, %s, %s) [%s, %s])",
 		t.Symbol, t.Price, TimeString(t.Timestamp), t.Timestamp, t.Open, t.Close, t.High, t.Low, t.Volume, t.Change, t.ChangePercentage)
 }
 
@@ -73,7 +132,16 @@ func (c Tickers) Swap(i, j int) {
 }
 
 func (tickers Tickers) Less(i, j int) bool {
-	return tickers[i].Change < tickers[j].Change
+	return DecFromString(tickers[i].Change).LT(DecFromString(tickers[j].Change))
+}
+
+// DecFromString parses a decimal string stored by the backend, treating an empty or malformed value as zero
+func DecFromString(s string) sdk.Dec {
+	d, err := sdk.NewDecFromStr(s)
+	if err != nil {
+		return sdk.ZeroDec()
+	}
+	return d
 }
 
 type KlineSnapShot struct {
@@ -81,6 +149,39 @@ type KlineSnapShot struct {
 	LastSyncedTime  int64 `json:"last_synced_time"`
 }
 
+// ReindexProgress records the last block written by `okchaind backend reindex --from FromHeight --to ToHeight`,
+// so that an interrupted reindex resumes right after it
+type ReindexProgress struct {
+	FromHeight int64 `gorm:"PRIMARY_KEY;AUTO_INCREMENT:false" json:"from_height"`
+	ToHeight   int64 `gorm:"PRIMARY_KEY;AUTO_INCREMENT:false" json:"to_height"`
+	Height     int64 `json:"height"`
+}
+
+// IndexerProgressName is the name of the progress row of the indexer
+const IndexerProgressName = "indexer"
+
+// IndexerProgress records the last block applied by `okchaind backend indexer`, it is saved in the same database
+// transaction as the data of the block so that every block of the block log is applied exactly once
+type IndexerProgress struct {
+	Name   string `gorm:"PRIMARY_KEY;type:varchar(20)" json:"name"`
+	Height int64  `json:"height"`
+}
+
+// BlockLogEntry is the backend data of a committed block, appended to the block log by the node and applied to the
+// backend database by the indexer
+type BlockLogEntry struct {
+	Height        int64              `json:"height"`
+	Timestamp     int64              `json:"timestamp"`
+	NewOrders     []*Order           `json:"new_orders,omitempty"`
+	UpdatedOrders []*Order           `json:"updated_orders,omitempty"`
+	Deals         []*Deal            `json:"deals,omitempty"`
+	MatchResults  []*MatchResult     `json:"match_results,omitempty"`
+	FeeDetails    []*token.FeeDetail `json:"fee_details,omitempty"`
+	Transactions  []*Transaction     `json:"transactions,omitempty"`
+	// DepthSnapshots are only taken if the depth snapshots are enabled on the node
+	DepthSnapshots []*DepthSnapshot `json:"depth_snapshots,omitempty"`
+}
+
 type Order struct {
 	TxHash         string `gorm:"type:varchar(80)" json:"txhash" v2:"txhash"`
 	OrderId        string `gorm:"PRIMARY_KEY;type:varchar(30)" json:"order_id" v2:"order_id"`
@@ -97,11 +198,12 @@ type Order struct {
 
 type Transaction struct {
 	TxHash    string `gorm:"type:varchar(80)" json:"txhash" v2:"txhash"`
-	Type      int64  `gorm:"index;" json:"type" v2:"type"` // 1:Transfer, 2:NewOrder, 3:CancelOrder
+	Type      int64  `gorm:"index;" json:"type" v2:"type"` // see TxTypeXXX, e.g. 1:Transfer, 2:NewOrder, 3:CancelOrder
 	Address   string `gorm:"index;type:varchar(80)" json:"address" v2:"address"`
 	Symbol    string `gorm:"type:varchar(20)" json:"symbol" v2:"symbol"`
-	Side      int64  `gorm:"" json:"side"` // 1:buy, 2:sell, 3:from, 4:to
+	Side      int64  `gorm:"" json:"side"` // 0:none, 1:buy, 2:sell, 3:from, 4:to
 	Quantity  string `gorm:"type:varchar(40)" json:"quantity" v2:"quantity"`
 	Fee       string `gorm:"type:varchar(40)" json:"fee" v2:"fee"`
 	Timestamp int64  `gorm:"index" json:"timestamp" v2:"timestamp"`
+	Detail    string `gorm:"type:text" json:"detail" v2:"detail"` // the message in json
 }
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"github.com/okex/okchain/x/dex"
+	tokenTypes "github.com/okex/okchain/x/token/types"
 	"math"
 	"strconv"
 	"strings"
@@ -12,23 +13,23 @@ import (
 )
 
 type MatchResult struct {
-	Timestamp   int64   `gorm:"index;" json:"timestamp" v2:"timestamp"`
-	BlockHeight int64   `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
-	Product     string  `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product" v2:"product"`
-	Price       float64 `gorm:"type:DOUBLE" json:"price" v2:"price"`
-	Quantity    float64 `gorm:"type:DOUBLE" json:"volume" v2:"volume"`
+	Timestamp   int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+	BlockHeight int64  `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
+	Product     string `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product" v2:"product"`
+	Price       string `gorm:"type:varchar(40)" json:"price" v2:"price"`
+	Quantity    string `gorm:"type:varchar(40)" json:"volume" v2:"volume"`
 }
 
 type Deal struct {
-	Timestamp   int64   `gorm:"index;" json:"timestamp" v2:"timestamp"`
-	BlockHeight int64   `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
-	OrderId     string  `gorm:"PRIMARY_KEY;type:varchar(30)" json:"order_id" v2:"order_id"`
-	Sender      string  `gorm:"index;type:varchar(80)" json:"sender" v2:"sender"`
-	Product     string  `gorm:"index;type:varchar(20)" json:"product" v2:"product"`
-	Side        string  `gorm:"type:varchar(10)" json:"side" v2:"side"`
-	Price       float64 `gorm:"type:DOUBLE" json:"price" v2:"price"`
-	Quantity    float64 `gorm:"type:DOUBLE" json:"volume" v2:"volume"`
-	Fee         string  `gorm:"type:varchar(20)" json:"fee" v2:"fee"`
+	Timestamp   int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+	BlockHeight int64  `gorm:"PRIMARY_KEY;type:bigint" json:"block_height" v2:"block_height"`
+	OrderId     string `gorm:"PRIMARY_KEY;type:varchar(30)" json:"order_id" v2:"order_id"`
+	Sender      string `gorm:"index;type:varchar(80)" json:"sender" v2:"sender"`
+	Product     string `gorm:"index;type:varchar(20)" json:"product" v2:"product"`
+	Side        string `gorm:"type:varchar(10)" json:"side" v2:"side"`
+	Price       string `gorm:"type:varchar(40)" json:"price" v2:"price"`
+	Quantity    string `gorm:"type:varchar(40)" json:"volume" v2:"volume"`
+	Fee         string `gorm:"type:varchar(20)" json:"fee" v2:"fee"`
 }
 
 type TickerV2 struct {
@@ -44,6 +45,10 @@ type TickerV2 struct {
 	Timestamp      string `json:"timestamp"`
 }
 
+// TickerListItemV2 is a ticker of the ticker list v2, which is encoded by common.JSONMarshalV2.
+// TickerV2 has no v2 tags, so that the fields are named as in go, e.g. InstrumentId.
+type TickerListItemV2 TickerV2
+
 // DefaultTickerV2 returns default DefaultTickerV2
 func DefaultTickerV2(instrumentId string) TickerV2 {
 	return TickerV2{
@@ -67,6 +72,19 @@ type InstrumentV2 struct {
 	MinSize       string `json:"min_size"`
 	SizeIncrement string `json:"size_increment"`
 	TickSize      string `json:"tick_size"`
+
+	BaseCurrencyDecimals  int64                    `json:"base_currency_decimals"`
+	QuoteCurrencyDecimals int64                    `json:"quote_currency_decimals"`
+	BaseCurrencyMetadata  tokenTypes.TokenMetadata `json:"base_currency_metadata"`
+	QuoteCurrencyMetadata tokenTypes.TokenMetadata `json:"quote_currency_metadata"`
+}
+
+// SetCurrencies fills the display information of the base and quote currencies
+func (instrument *InstrumentV2) SetCurrencies(base, quote tokenTypes.Token) {
+	instrument.BaseCurrencyDecimals = base.Decimals
+	instrument.BaseCurrencyMetadata = base.Metadata
+	instrument.QuoteCurrencyDecimals = quote.Decimals
+	instrument.QuoteCurrencyMetadata = quote.Metadata
 }
 
 func ConvertTokenPairToInstrumentV2(tokenPair *dex.TokenPair) *InstrumentV2 {
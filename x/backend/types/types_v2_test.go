@@ -4,6 +4,7 @@ import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okchain/x/dex"
+	tokenTypes "github.com/okex/okchain/x/token/types"
 	"github.com/stretchr/testify/require"
 	"math"
 	"strings"
@@ -52,3 +53,15 @@ func TestConvertTokenPairToInstrumentV2(t *testing.T) {
 	tickSize := strings.TrimRight(fmt.Sprintf("%.10f", fTickSize), "0")
 	require.Equal(t, tickSize, instrumentV2.TickSize)
 }
+
+func TestInstrumentV2SetCurrencies(t *testing.T) {
+	instrumentV2 := ConvertTokenPairToInstrumentV2(dex.GetBuiltInTokenPair())
+	base := tokenTypes.Token{Decimals: 4, Metadata: tokenTypes.NewTokenMetadata("", "https://www.okex.com", "")}
+	quote := tokenTypes.Token{Decimals: 8}
+	instrumentV2.SetCurrencies(base, quote)
+
+	require.Equal(t, int64(4), instrumentV2.BaseCurrencyDecimals)
+	require.Equal(t, "https://www.okex.com", instrumentV2.BaseCurrencyMetadata.Website)
+	require.Equal(t, int64(8), instrumentV2.QuoteCurrencyDecimals)
+	require.Equal(t, tokenTypes.TokenMetadata{}, instrumentV2.QuoteCurrencyMetadata)
+}
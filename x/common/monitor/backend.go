@@ -0,0 +1,55 @@
+package monitor
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// BackendMetrics is the struct of metric in backend module
+type BackendMetrics struct {
+	// TableRows and PrunedRows are labeled by "table"
+	TableRows  metrics.Gauge
+	PrunedRows metrics.Counter
+}
+
+// DefaultBackendMetrics returns Metrics build using Prometheus client library if Prometheus is enabled
+// Otherwise, it returns no-op Metrics
+func DefaultBackendMetrics(config *prometheusConfig) *BackendMetrics {
+	if config.Prometheus {
+		return NewBackendMetrics()
+	}
+	return NopBackendMetrics()
+}
+
+// NewBackendMetrics returns a pointer of a new BackendMetrics object
+func NewBackendMetrics(labelsAndValues ...string) *BackendMetrics {
+	var labels []string
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	labels = append(labels, "table")
+	return &BackendMetrics{
+		TableRows: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: backendSubSystem,
+			Name:      "table_rows",
+			Help:      "the number of rows of the backend table",
+		}, labels).With(labelsAndValues...),
+		PrunedRows: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: xNameSpace,
+			Subsystem: backendSubSystem,
+			Name:      "pruned_rows",
+			Help:      "the number of rows pruned from the backend table",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopBackendMetrics returns a pointer of no-op Metrics
+func NopBackendMetrics() *BackendMetrics {
+	return &BackendMetrics{
+		TableRows:  discard.NewGauge(),
+		PrunedRows: discard.NewCounter(),
+	}
+}
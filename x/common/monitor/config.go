@@ -6,6 +6,7 @@ const (
 	orderSubSystem   = "order"
 	stakingSubSystem = "staking"
 	streamSubSystem  = "stream"
+	backendSubSystem = "backend"
 )
 
 type prometheusConfig struct {
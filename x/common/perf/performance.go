@@ -23,6 +23,9 @@ const (
 	stakingModule      = "staking"
 	govModule          = "gov"
 	distributionModule = "distribution"
+	ammswapModule      = "ammswap"
+	farmModule         = "farm"
+	htlcModule         = "htlc"
 	summaryFormat      = "BlockHeight<%d>, " +
 		"Abci<%dms>, " +
 		"Tx<%d>, " +
@@ -146,6 +149,9 @@ func newPerf() *performance {
 	p.moduleInfoMap[govModule] = newHanlderMetrics()
 	p.moduleInfoMap[distributionModule] = newHanlderMetrics()
 	p.moduleInfoMap[stakingModule] = newHanlderMetrics()
+	p.moduleInfoMap[ammswapModule] = newHanlderMetrics()
+	p.moduleInfoMap[farmModule] = newHanlderMetrics()
+	p.moduleInfoMap[htlcModule] = newHanlderMetrics()
 	return p
 }
 
@@ -311,6 +317,9 @@ func (p *performance) OnCommitExit(height int64, seq uint64, logger log.Logger)
 	p.moduleInfoMap[govModule] = newHanlderMetrics()
 	p.moduleInfoMap[distributionModule] = newHanlderMetrics()
 	p.moduleInfoMap[stakingModule] = newHanlderMetrics()
+	p.moduleInfoMap[ammswapModule] = newHanlderMetrics()
+	p.moduleInfoMap[farmModule] = newHanlderMetrics()
+	p.moduleInfoMap[htlcModule] = newHanlderMetrics()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////
@@ -87,14 +87,15 @@ func (code errorCodeV2) Message() string {
 	return defaultErrorMessageV2(code)
 }
 
-type responseErrorV2 struct {
+// ResponseErrorV2 is the body of the error responses with V2 standard
+type ResponseErrorV2 struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
 // HandleErrorResponseV2 is the handler of error response with V2 standard
 func HandleErrorResponseV2(w http.ResponseWriter, statusCode int, errCode errorCodeV2) {
-	response, err := json.Marshal(responseErrorV2{
+	response, err := json.Marshal(ResponseErrorV2{
 		Code:    errCode.Code(),
 		Message: errCode.Message(),
 	})
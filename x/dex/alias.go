@@ -41,13 +41,17 @@ type (
 	MsgDelist            = types.MsgDelist
 	MsgDeposit           = types.MsgDeposit
 	MsgWithdraw          = types.MsgWithdraw
+	MsgCancelWithdraw    = types.MsgCancelWithdraw
 	MsgTransferOwnership = types.MsgTransferOwnership
+	MsgRegisterOperator  = types.MsgRegisterOperator
 
 	//
 	TokenPair     = types.TokenPair
 	Params        = types.Params
 	WithdrawInfo  = types.WithdrawInfo
 	WithdrawInfos = types.WithdrawInfos
+	DEXOperator   = types.DEXOperator
+	DEXOperators  = types.DEXOperators
 )
 
 var (
@@ -65,7 +69,14 @@ var (
 	NewMsgDeposit  = types.NewMsgDeposit
 	NewMsgWithdraw = types.NewMsgWithdraw
 
+	NewMsgCancelWithdraw = types.NewMsgCancelWithdraw
+
+	NewMsgRegisterOperator = types.NewMsgRegisterOperator
+
 	ErrInvalidProduct      = types.ErrInvalidProduct
 	ErrTokenPairNotFound   = types.ErrTokenPairNotFound
 	ErrDelistOwnerNotMatch = types.ErrDelistOwnerNotMatch
+	ErrUnknownOperator     = types.ErrUnknownOperator
+	ErrRepeatedOperator    = types.ErrRepeatedOperator
+	ErrWithdrawNotFound    = types.ErrWithdrawNotFound
 )
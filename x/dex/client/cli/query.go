@@ -27,6 +27,9 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryMatchOrder(queryRoute, cdc),
 		GetCmdQueryParams(queryRoute, cdc),
 		GetCmdQueryProductsUnderDelisting(queryRoute, cdc),
+		GetCmdQueryOperator(queryRoute, cdc),
+		GetCmdQueryOperators(queryRoute, cdc),
+		GetCmdQueryWithdraws(queryRoute, cdc),
 	)...)
 
 	return queryCmd
@@ -181,6 +184,114 @@ $ okchaincli query dex products-delisting`),
 	}
 }
 
+// GetCmdQueryOperator queries the dex operator and its products
+func GetCmdQueryOperator(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "operator [operator-addr]",
+		Short: "Query the dex operator",
+		Long: strings.TrimSpace(`Query the dex operator and the products it owns:
+
+$ okchaincli query dex operator okchain10q0rk5qnyag7wfvvt7rtphlw589m7frsmyq4ya`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			queryParams, err := types.NewQueryDexInfoParams(args[0], types.DefaultPage, types.DefaultPerPage)
+			if err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(queryParams)
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryOperator), bz)
+			if err != nil {
+				return err
+			}
+
+			var operatorInfo types.DEXOperatorInfo
+			if err := cdc.UnmarshalJSON(res, &operatorInfo); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(operatorInfo)
+		},
+	}
+}
+
+// GetCmdQueryOperators queries all the dex operators and their products
+func GetCmdQueryOperators(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operators",
+		Short: "Query the list of dex operators",
+		Long: strings.TrimSpace(`Query all the dex operators and the products they own:
+
+$ okchaincli query dex operators`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			page := viper.GetInt("page-number")
+			perPage := viper.GetInt("items-per-page")
+			queryParams, err := types.NewQueryDexInfoParams("", page, perPage)
+			if err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(queryParams)
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryOperators), bz)
+			if err != nil {
+				return err
+			}
+
+			var operatorInfos types.DEXOperatorInfos
+			if err := cdc.UnmarshalJSON(res, &operatorInfos); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(operatorInfos)
+		},
+	}
+	cmd.Flags().IntP("page-number", "p", types.DefaultPage, "page num")
+	cmd.Flags().IntP("items-per-page", "i", types.DefaultPerPage, "items per page")
+	return cmd
+}
+
+// GetCmdQueryWithdraws queries the pending withdrawals of deposits
+func GetCmdQueryWithdraws(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraws",
+		Short: "Query the list of pending withdrawals of deposits",
+		Long: strings.TrimSpace(`Query the pending withdrawals of deposits, which complete after the withdraw period:
+
+$ okchaincli query dex withdraws
+$ okchaincli query dex withdraws --owner okchain1x3jszzymcqh3yqgqd8jqzgymz9x2ms6t4dxw2k`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ownerAddress := viper.GetString("owner")
+			page := viper.GetInt("page-number")
+			perPage := viper.GetInt("items-per-page")
+			queryParams, err := types.NewQueryDexInfoParams(ownerAddress, page, perPage)
+			if err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(queryParams)
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryWithdraws), bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().StringP("owner", "", "", "address of the depositor")
+	cmd.Flags().IntP("page-number", "p", types.DefaultPage, "page num")
+	cmd.Flags().IntP("items-per-page", "i", types.DefaultPerPage, "items per page")
+	return cmd
+}
+
 // just for the object of []string could be inputted into cliCtx.PrintOutput(...)
 type Strings []string
 
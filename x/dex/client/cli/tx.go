@@ -32,6 +32,11 @@ const (
 	FlagProduct    = "product"
 	FlagFrom       = "from"
 	FlagTo         = "to"
+
+	FlagName               = "name"
+	FlagWebsite            = "website"
+	FlagHandlingFeeAddress = "handling-fee-address"
+	FlagContact            = "contact"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -46,8 +51,10 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		//GetCmdDelist(cdc),
 		GetCmdDeposit(cdc),
 		GetCmdWithdraw(cdc),
+		GetCmdCancelWithdraw(cdc),
 		GetCmdTransferOwnership(cdc),
 		GetMultiSignsCmd(cdc),
+		GetCmdRegisterOperator(cdc),
 	)...)
 
 	return txCmd
@@ -206,6 +213,44 @@ The 'product' is a trading pair in full name of the tokens: ${base-asset-symbol}
 	}
 }
 
+// GetCmdCancelWithdraw is the CLI command for cancelling a pending withdrawal
+func GetCmdCancelWithdraw(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel-withdraw [product] [amount]",
+		Args:  cobra.ExactArgs(2),
+		Short: "cancel an amount of the pending withdrawal and put it back into a product",
+		Long: strings.TrimSpace(`Cancel an amount of the pending withdrawal and put it back into the deposits of a product:
+
+$ okchaincli tx dex cancel-withdraw mytoken_okt 1000okt --from mykey
+
+The 'product' is a trading pair in full name of the tokens: ${base-asset-symbol}_${quote-asset-symbol}, for example 'mytoken_okt'.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			product := args[0]
+
+			// Get depositor address
+			from := cliCtx.GetFromAddress()
+
+			// Get amount of coins
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelWithdraw(product, amount, from)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 // GetCmdTransferOwnership is the CLI command for transfer ownership of product
 func GetCmdTransferOwnership(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -300,6 +345,67 @@ func GetMultiSignsCmd(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdRegisterOperator implements registering the sender as a dex operator
+func GetCmdRegisterOperator(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-operator",
+		Short: "register the sender as a dex operator",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Register the sender as a dex operator, which is required before listing a trading pair:
+
+$ okchaincli tx dex register-operator --name myexchange --website https://myexchange.com --handling-fee-address okchain1... --contact support@myexchange.com --from mykey
+
+If --handling-fee-address is not set, the address of the sender will be used.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			name, err := flags.GetString(FlagName)
+			if err != nil {
+				return err
+			}
+			website, err := flags.GetString(FlagWebsite)
+			if err != nil {
+				return err
+			}
+			contact, err := flags.GetString(FlagContact)
+			if err != nil {
+				return err
+			}
+			strFeeAddr, err := flags.GetString(FlagHandlingFeeAddress)
+			if err != nil {
+				return err
+			}
+			var feeAddr sdk.AccAddress
+			if strFeeAddr != "" {
+				feeAddr, err = sdk.AccAddressFromBech32(strFeeAddr)
+				if err != nil {
+					return fmt.Errorf("invalid handling fee address:%s", strFeeAddr)
+				}
+			}
+
+			msg := types.NewMsgRegisterOperator(cliCtx.GetFromAddress(), name, website, feeAddr, contact)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagName, "", "name of the dex operator")
+	cmd.Flags().String(FlagWebsite, "", "website of the dex operator, which must start with http:// or https://")
+	cmd.Flags().String(FlagHandlingFeeAddress, "", "address to receive the handling fee of the dex operator")
+	cmd.Flags().String(FlagContact, "", "contact of the dex operator")
+
+	return cmd
+}
+
 //GetCmdSubmitDelistProposal implememts a command handler for submitting a dex delist proposal transaction
 func GetCmdSubmitDelistProposal(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
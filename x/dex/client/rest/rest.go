@@ -21,6 +21,9 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/products", productsHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/deposits", depositsHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/match_order", matchOrderHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/operators", operatorsHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/operators/{address}", operatorHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/withdraws", withdrawsHandler(cliCtx)).Methods("GET")
 }
 
 func productsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
@@ -137,6 +140,108 @@ func matchOrderHandler(cliContext context.CLIContext) func(http.ResponseWriter,
 
 }
 
+func operatorsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pageStr := r.URL.Query().Get("page")
+		perPageStr := r.URL.Query().Get("per_page")
+
+		var params = &types.QueryDexInfoParams{}
+		err := params.SetPageAndPerPage("", pageStr, perPageStr)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		bz, err := cliContext.Codec.MarshalJSON(&params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryOperators), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		result := common.GetBaseResponse("hello")
+		result2, err2 := json.Marshal(result)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliContext, err2.Error())
+			return
+		}
+		result2 = []byte(strings.Replace(string(result2), "\"hello\"", string(res), 1))
+		rest.PostProcessResponse(w, cliContext, result2)
+	}
+}
+
+func operatorHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := mux.Vars(r)["address"]
+
+		var params = &types.QueryDexInfoParams{}
+		err := params.SetPageAndPerPage(address, "", "")
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		bz, err := cliContext.Codec.MarshalJSON(&params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryOperator), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		result := common.GetBaseResponse("hello")
+		result2, err2 := json.Marshal(result)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliContext, err2.Error())
+			return
+		}
+		result2 = []byte(strings.Replace(string(result2), "\"hello\"", string(res), 1))
+		rest.PostProcessResponse(w, cliContext, result2)
+	}
+}
+
+func withdrawsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		pageStr := r.URL.Query().Get("page")
+		perPageStr := r.URL.Query().Get("per_page")
+
+		var params = &types.QueryDexInfoParams{}
+		err := params.SetPageAndPerPage(address, pageStr, perPageStr)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return
+		}
+		bz, err := cliContext.Codec.MarshalJSON(&params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryWithdraws), bz)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+
+		result := common.GetBaseResponse("hello")
+		result2, err2 := json.Marshal(result)
+		if err2 != nil {
+			common.HandleErrorMsg(w, cliContext, err2.Error())
+			return
+		}
+		result2 = []byte(strings.Replace(string(result2), "\"hello\"", string(res), 1))
+		rest.PostProcessResponse(w, cliContext, result2)
+	}
+}
+
 // TODO: finish the rest handler of Delist
 func DelistProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
 	return govRest.ProposalRESTHandler{}
@@ -11,6 +11,7 @@ type GenesisState struct {
 	TokenPairs    []*TokenPair              `json:"token_pairs"`
 	WithdrawInfos WithdrawInfos             `json:"withdraw_infos"`
 	ProductLocks  ordertypes.ProductLockMap `json:"product_locks"`
+	Operators     DEXOperators              `json:"operators"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -21,6 +22,7 @@ func DefaultGenesisState() GenesisState {
 		TokenPairs:    nil,
 		WithdrawInfos: nil,
 		ProductLocks:  *ordertypes.NewProductLockMap(),
+		Operators:     nil,
 	}
 }
 
@@ -51,6 +53,10 @@ func InitGenesis(ctx sdk.Context, keeper IKeeper, data GenesisState) {
 	for k, v := range data.ProductLocks.Data {
 		keeper.LockTokenPair(ctx, k, v)
 	}
+
+	for _, operator := range data.Operators {
+		keeper.SetOperator(ctx, operator)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -64,10 +70,16 @@ func ExportGenesis(ctx sdk.Context, keeper IKeeper) (data GenesisState) {
 		withdrawInfos = append(withdrawInfos, withdrawInfo)
 		return false
 	})
+	var operators DEXOperators
+	keeper.IterateOperators(ctx, func(operator DEXOperator) (stop bool) {
+		operators = append(operators, operator)
+		return false
+	})
 	return GenesisState{
 		Params:        params,
 		TokenPairs:    tokenPairs,
 		WithdrawInfos: withdrawInfos,
 		ProductLocks:  *keeper.LoadProductLocks(ctx),
+		Operators:     operators,
 	}
 }
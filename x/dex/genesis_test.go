@@ -40,11 +40,22 @@ func TestInitGenesis(t *testing.T) {
 	product := fmt.Sprintf("%s_%s", tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol)
 	lockMap.Data[product] = lock
 
+	operators := DEXOperators{
+		{
+			Address:            tokenPair.Owner,
+			Name:               "operator",
+			Website:            "https://www.okex.com",
+			HandlingFeeAddress: tokenPair.Owner,
+			InitHeight:         1,
+		},
+	}
+
 	initGenesis := GenesisState{
 		Params:        params,
 		TokenPairs:    tokenPairs,
 		WithdrawInfos: withdrawInfos,
 		ProductLocks:  *lockMap,
+		Operators:     operators,
 	}
 
 	InitGenesis(ctx, keeper, initGenesis)
@@ -74,6 +85,7 @@ func TestInitGenesis(t *testing.T) {
 	require.Equal(t, initGenesis.TokenPairs, exportGenesis.TokenPairs)
 	require.True(t, initGenesis.WithdrawInfos.Equal(exportGenesis.WithdrawInfos))
 	require.Equal(t, initGenesis.ProductLocks, exportGenesis.ProductLocks)
+	require.Equal(t, initGenesis.Operators, exportGenesis.Operators)
 
 	exportGenesis.Params.WithdrawPeriod = 55555
 	exportGenesis.TokenPairs[0].ID = 66666
@@ -38,11 +38,21 @@ func NewHandler(k IKeeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgWithDraw(ctx, k, msg, logger)
 			}
+		case MsgCancelWithdraw:
+			name = "handleMsgCancelWithdraw"
+			handlerFun = func() sdk.Result {
+				return handleMsgCancelWithdraw(ctx, k, msg, logger)
+			}
 		case MsgTransferOwnership:
 			name = "handleMsgTransferOwnership"
 			handlerFun = func() sdk.Result {
 				return handleMsgTransferOwnership(ctx, k, msg, logger)
 			}
+		case MsgRegisterOperator:
+			name = "handleMsgRegisterOperator"
+			handlerFun = func() sdk.Result {
+				return handleMsgRegisterOperator(ctx, k, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("unrecognized dex message type: %T", msg)
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -56,6 +66,10 @@ func NewHandler(k IKeeper) sdk.Handler {
 
 func handleMsgList(ctx sdk.Context, keeper IKeeper, msg MsgList, logger log.Logger) sdk.Result {
 
+	if _, isExist := keeper.GetOperator(ctx, msg.Owner); !isExist {
+		return ErrUnknownOperator(msg.Owner).Result()
+	}
+
 	if !keeper.GetTokenKeeper().TokenExist(ctx, msg.ListAsset) ||
 		!keeper.GetTokenKeeper().TokenExist(ctx, msg.QuoteAsset) {
 		return sdk.ErrInvalidCoins(
@@ -190,8 +204,30 @@ func handleMsgWithDraw(ctx sdk.Context, keeper IKeeper, msg MsgWithdraw, logger
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
+func handleMsgCancelWithdraw(ctx sdk.Context, keeper IKeeper, msg MsgCancelWithdraw, logger log.Logger) sdk.Result {
+	if sdkErr := keeper.CancelWithdraw(ctx, msg.Product, msg.Depositor, msg.Amount); sdkErr != nil {
+		return sdkErr.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgCancelWithdraw: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
 func handleMsgTransferOwnership(ctx sdk.Context, keeper IKeeper, msg MsgTransferOwnership,
 	logger log.Logger) sdk.Result {
+	if _, isExist := keeper.GetOperator(ctx, msg.ToAddress); !isExist {
+		return ErrUnknownOperator(msg.ToAddress).Result()
+	}
+
 	if sdkErr := keeper.TransferOwnership(ctx, msg.Product, msg.FromAddress, msg.ToAddress); sdkErr != nil {
 		return sdkErr.Result()
 	}
@@ -216,3 +252,34 @@ func handleMsgTransferOwnership(ctx sdk.Context, keeper IKeeper, msg MsgTransfer
 	)
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
+
+func handleMsgRegisterOperator(ctx sdk.Context, keeper IKeeper, msg MsgRegisterOperator, logger log.Logger) sdk.Result {
+
+	if _, isExist := keeper.GetOperator(ctx, msg.Owner); isExist {
+		return ErrRepeatedOperator(msg.Owner).Result()
+	}
+
+	operator := DEXOperator{
+		Address:            msg.Owner,
+		Name:               msg.Name,
+		Website:            msg.Website,
+		HandlingFeeAddress: msg.HandlingFeeAddress,
+		Contact:            msg.Contact,
+		InitHeight:         ctx.BlockHeight(),
+	}
+	keeper.SetOperator(ctx, operator)
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgRegisterOperator: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+			sdk.NewAttribute("operator", operator.Address.String()),
+			sdk.NewAttribute("name", operator.Name),
+			sdk.NewAttribute("handling-fee-address", operator.HandlingFeeAddress.String()),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -31,9 +31,15 @@ func TestHandler_HandleMsgList(t *testing.T) {
 
 	handlerFunctor := NewHandler(mApp.dexKeeper)
 
+	// fail case : failed to list because the owner is not a registered operator
+	badResult := handlerFunctor(ctx, listMsg)
+	require.Equal(t, types.CodeUnknownOperator, badResult.Code)
+	registerMsg := NewMsgRegisterOperator(address, "operator", "https://www.okex.com", nil, "")
+	require.True(t, handlerFunctor(ctx, registerMsg).Code.IsOK())
+
 	// fail case : failed to list because token is invalid
 	tkKeeper.exist = false
-	badResult := handlerFunctor(ctx, listMsg)
+	badResult = handlerFunctor(ctx, listMsg)
 	require.True(t, badResult.Code != sdk.CodeOK)
 
 	// fail case : failed to list because tokenpair has been exist
@@ -153,6 +159,27 @@ func TestHandler_HandleMsgWithdraw(t *testing.T) {
 	require.True(t, good1.Events != nil)
 }
 
+func TestHandler_HandleMsgCancelWithdraw(t *testing.T) {
+	mApp, _, _, mDexKeeper, ctx := getMockTestCaseEvn(t)
+	builtInTP := GetBuiltInTokenPair()
+	err := mDexKeeper.SaveTokenPair(ctx, builtInTP)
+	require.Nil(t, err)
+	amount := sdk.NewDecCoin(builtInTP.QuoteAssetSymbol, sdk.NewInt(100))
+	cancelWithdrawMsg := NewMsgCancelWithdraw(builtInTP.Name(), amount, builtInTP.Owner)
+
+	handlerFunctor := NewHandler(mApp.dexKeeper)
+
+	// Case1: failed to cancel because of no pending withdrawal
+	bad1 := handlerFunctor(ctx, cancelWithdrawMsg)
+	require.Equal(t, types.CodeWithdrawNotFound, bad1.Code)
+
+	// Case2: success to cancel
+	mDexKeeper.SetWithdrawInfo(ctx, WithdrawInfo{Owner: builtInTP.Owner, Deposits: amount, CompleteTime: ctx.BlockTime()})
+	good1 := handlerFunctor(ctx, cancelWithdrawMsg)
+	require.True(t, good1.Code == sdk.CodeOK)
+	require.True(t, good1.Events != nil)
+}
+
 func TestHandler_HandleMsgBad(t *testing.T) {
 	mApp, _, _, _, ctx := getMockTestCaseEvn(t)
 	handlerFunctor := NewHandler(mApp.dexKeeper)
@@ -185,3 +212,25 @@ func TestHandler_handleMsgTransferOwnership(t *testing.T) {
 	spKeeper.behaveEvil = true
 	handlerFunctor(ctx, msgFailedTransferOwnership)
 }
+
+func TestHandler_HandleMsgRegisterOperator(t *testing.T) {
+	mApp, _, _, mDexKeeper, ctx := getMockTestCaseEvn(t)
+	address := mApp.GenesisAccounts[0].GetAddress()
+	feeAddress := mApp.GenesisAccounts[1].GetAddress()
+	handlerFunctor := NewHandler(mApp.dexKeeper)
+
+	// successful case
+	msg := NewMsgRegisterOperator(address, "operator", "https://www.okex.com", feeAddress, "contact@okex.com")
+	goodResult := handlerFunctor(ctx, msg)
+	require.True(t, goodResult.Code.IsOK())
+	require.True(t, goodResult.Events != nil)
+
+	operator, isExist := mDexKeeper.GetOperator(ctx, address)
+	require.True(t, isExist)
+	require.Equal(t, "operator", operator.Name)
+	require.Equal(t, feeAddress, operator.HandlingFeeAddress)
+
+	// fail case : failed to register an operator which has been registered before
+	badResult := handlerFunctor(ctx, msg)
+	require.Equal(t, types.CodeRepeatedOperator, badResult.Code)
+}
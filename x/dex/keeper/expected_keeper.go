@@ -37,6 +37,7 @@ type IKeeper interface {
 	DeleteTokenPairByName(ctx sdk.Context, owner sdk.AccAddress, tokenPairName string)
 	Deposit(ctx sdk.Context, product string, from sdk.AccAddress, amount sdk.DecCoin) sdk.Error
 	Withdraw(ctx sdk.Context, product string, to sdk.AccAddress, amount sdk.DecCoin) sdk.Error
+	CancelWithdraw(ctx sdk.Context, product string, addr sdk.AccAddress, amount sdk.DecCoin) sdk.Error
 	GetSupplyKeeper() SupplyKeeper
 	GetTokenKeeper() TokenKeeper
 	GetParamSubspace() params.Subspace
@@ -47,12 +48,17 @@ type IKeeper interface {
 	TransferOwnership(ctx sdk.Context, product string, from sdk.AccAddress, to sdk.AccAddress) sdk.Error
 	LockTokenPair(ctx sdk.Context, product string, lock *ordertypes.ProductLock)
 	LoadProductLocks(ctx sdk.Context) *ordertypes.ProductLockMap
+	GetWithdrawInfo(ctx sdk.Context, addr sdk.AccAddress) (withdrawInfo types.WithdrawInfo, ok bool)
 	SetWithdrawInfo(ctx sdk.Context, withdrawInfo types.WithdrawInfo)
 	SetWithdrawCompleteTimeAddress(ctx sdk.Context, completeTime time.Time, addr sdk.AccAddress)
 	IterateWithdrawAddress(ctx sdk.Context, currentTime time.Time, fn func(index int64, key []byte) (stop bool))
 	CompleteWithdraw(ctx sdk.Context, addr sdk.AccAddress) error
 	IterateWithdrawInfo(ctx sdk.Context, fn func(index int64, withdrawInfo types.WithdrawInfo) (stop bool))
 	DeleteWithdrawCompleteTimeAddress(ctx sdk.Context, timestamp time.Time, delAddr sdk.AccAddress)
+	SetOperator(ctx sdk.Context, operator types.DEXOperator)
+	GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator types.DEXOperator, isExist bool)
+	IterateOperators(ctx sdk.Context, fn func(operator types.DEXOperator) (stop bool))
+	GetOperatorInfo(ctx sdk.Context, operator types.DEXOperator) types.DEXOperatorInfo
 }
 
 // StakingKeeper defines the expected staking Keeper (noalias)
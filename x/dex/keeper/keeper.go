@@ -306,6 +306,45 @@ func (k Keeper) Withdraw(ctx sdk.Context, product string, to sdk.AccAddress, amo
 	return nil
 }
 
+// CancelWithdraw cancels withdrawing amount of the pending withdrawal of addr and puts it back into the
+// deposits of the product
+func (k Keeper) CancelWithdraw(ctx sdk.Context, product string, addr sdk.AccAddress, amount sdk.DecCoin) sdk.Error {
+	tokenPair := k.GetTokenPair(ctx, product)
+	if tokenPair == nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("failed to cancel withdraw beacuse non-exist product: %s", product))
+	}
+
+	if !tokenPair.Owner.Equals(addr) {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("failed to cancel withdraw beacuse %s is not the owner of product:%s", addr.String(), product))
+	}
+
+	if amount.Denom != sdk.DefaultBondDenom {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("failed to cancel withdraw beacuse deposits only support %s token", sdk.DefaultBondDenom))
+	}
+
+	withdrawInfo, ok := k.GetWithdrawInfo(ctx, addr)
+	if !ok {
+		return types.ErrWithdrawNotFound(addr)
+	}
+
+	if withdrawInfo.Deposits.IsLT(amount) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("failed to cancel withdraw beacuse withdrawing:%s is less than %s", withdrawInfo.Deposits.String(), amount.String()))
+	}
+
+	// the coins of withdrawing deposits are kept in the dex module account until the withdrawal completes
+	withdrawInfo.Deposits = withdrawInfo.Deposits.Sub(amount)
+	if withdrawInfo.Deposits.IsZero() {
+		k.deleteWithdrawInfo(ctx, addr)
+		k.DeleteWithdrawCompleteTimeAddress(ctx, withdrawInfo.CompleteTime, addr)
+	} else {
+		k.SetWithdrawInfo(ctx, withdrawInfo)
+	}
+
+	tokenPair.Deposits = tokenPair.Deposits.Add(amount)
+	k.UpdateTokenPair(ctx, product, tokenPair)
+	return nil
+}
+
 // GetTokenPairsOrdered returns token pairs ordered by product
 func (k Keeper) GetTokenPairsOrdered(ctx sdk.Context) types.TokenPairs {
 	var result types.TokenPairs
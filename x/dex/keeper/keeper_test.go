@@ -199,6 +199,77 @@ func TestWithdraw(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestCancelWithdraw(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 2, 10000)
+	ctx := testInput.Ctx
+	keeper := testInput.DexKeeper
+	tokenPair := getTestTokenPair()
+	owner := testInput.TestAddrs[0]
+	tokenPair.Owner = owner
+	initDeposit := tokenPair.Deposits
+	keeper.SetParams(ctx, *types.DefaultParams())
+
+	err := keeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	product := tokenPair.Name()
+	depositAmount, err := sdk.ParseDecCoin("30" + sdk.DefaultBondDenom)
+	require.Nil(t, err)
+	err = keeper.Deposit(ctx, product, owner, depositAmount)
+	require.Nil(t, err)
+
+	cancelAmount, err := sdk.ParseDecCoin("10" + sdk.DefaultBondDenom)
+	require.Nil(t, err)
+
+	// CancelWithdraw failed because of no pending withdrawal
+	sdkErr := keeper.CancelWithdraw(ctx, product, owner, cancelAmount)
+	require.NotNil(t, sdkErr)
+	require.Equal(t, types.CodeWithdrawNotFound, sdkErr.Code())
+
+	err = keeper.Withdraw(ctx, product, owner, depositAmount)
+	require.Nil(t, err)
+	withdrawInfo, ok := keeper.GetWithdrawInfo(ctx, owner)
+	require.True(t, ok)
+
+	// CancelWithdraw failed because of product not exist
+	err = keeper.CancelWithdraw(ctx, TestProductNotExist, owner, cancelAmount)
+	require.NotNil(t, err)
+
+	// CancelWithdraw failed because of owner
+	err = keeper.CancelWithdraw(ctx, product, testInput.TestAddrs[1], cancelAmount)
+	require.NotNil(t, err)
+
+	// CancelWithdraw failed because of withdrawing not enough
+	err = keeper.CancelWithdraw(ctx, product, owner, depositAmount.Add(cancelAmount))
+	require.NotNil(t, err)
+
+	// CancelWithdraw failed because of the denom
+	sdkErr = keeper.CancelWithdraw(ctx, product, owner, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(10)))
+	require.NotNil(t, sdkErr)
+	require.Equal(t, sdk.CodeUnknownRequest, sdkErr.Code())
+
+	// CancelWithdraw successful, and the rest is still withdrawing
+	err = keeper.CancelWithdraw(ctx, product, owner, cancelAmount)
+	require.Nil(t, err)
+	require.Equal(t, initDeposit.Add(cancelAmount), keeper.GetTokenPair(ctx, product).Deposits)
+	gotWithdrawInfo, ok := keeper.GetWithdrawInfo(ctx, owner)
+	require.True(t, ok)
+	require.Equal(t, depositAmount.Sub(cancelAmount), gotWithdrawInfo.Deposits)
+	require.True(t, withdrawInfo.CompleteTime.Equal(gotWithdrawInfo.CompleteTime))
+
+	// CancelWithdraw successful, and the withdrawal is removed from the time queue
+	err = keeper.CancelWithdraw(ctx, product, owner, depositAmount.Sub(cancelAmount))
+	require.Nil(t, err)
+	require.Equal(t, initDeposit.Add(depositAmount), keeper.GetTokenPair(ctx, product).Deposits)
+	_, ok = keeper.GetWithdrawInfo(ctx, owner)
+	require.False(t, ok)
+	count := 0
+	keeper.IterateWithdrawAddress(ctx, withdrawInfo.CompleteTime, func(_ int64, _ []byte) (stop bool) {
+		count++
+		return false
+	})
+	require.Equal(t, 0, count)
+}
+
 func TestGetTokenPairsOrdered(t *testing.T) {
 	testInput := CreateTestInputWithBalance(t, 1, 10000)
 	ctx := testInput.Ctx
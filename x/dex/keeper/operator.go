@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/dex/types"
+)
+
+// SetOperator save the dex operator to store
+func (k Keeper) SetOperator(ctx sdk.Context, operator types.DEXOperator) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetOperatorAddressKey(operator.Address)
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(operator))
+}
+
+// GetOperator returns the dex operator binding the addr
+func (k Keeper) GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator types.DEXOperator, isExist bool) {
+	bytes := ctx.KVStore(k.storeKey).Get(types.GetOperatorAddressKey(addr))
+	if bytes == nil {
+		return
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bytes, &operator)
+	return operator, true
+}
+
+// IterateOperators iterates dex operators
+func (k Keeper) IterateOperators(ctx sdk.Context, fn func(operator types.DEXOperator) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.PrefixOperatorKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var operator types.DEXOperator
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &operator)
+		if stop := fn(operator); stop {
+			break
+		}
+	}
+}
+
+// GetOperatorInfo returns the dex operator with the names of its token pairs
+func (k Keeper) GetOperatorInfo(ctx sdk.Context, operator types.DEXOperator) types.DEXOperatorInfo {
+	products := []string{}
+	for _, tokenPair := range k.GetUserTokenPairs(ctx, operator.Address) {
+		if tokenPair != nil {
+			products = append(products, tokenPair.Name())
+		}
+	}
+	return types.DEXOperatorInfo{
+		DEXOperator: operator,
+		Products:    products,
+	}
+}
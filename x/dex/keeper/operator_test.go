@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/okex/okchain/x/dex/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeeper_Operator(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 2, 30)
+	ctx := testInput.Ctx
+	keeper := testInput.DexKeeper
+
+	// operator not exist
+	_, isExist := keeper.GetOperator(ctx, testInput.TestAddrs[0])
+	require.False(t, isExist)
+
+	operator0 := types.DEXOperator{
+		Address:            testInput.TestAddrs[0],
+		Name:               "operator0",
+		Website:            "https://www.okex.com",
+		HandlingFeeAddress: testInput.TestAddrs[1],
+		InitHeight:         10,
+	}
+	operator1 := types.DEXOperator{
+		Address:            testInput.TestAddrs[1],
+		Name:               "operator1",
+		HandlingFeeAddress: testInput.TestAddrs[1],
+		InitHeight:         11,
+	}
+	keeper.SetOperator(ctx, operator0)
+	keeper.SetOperator(ctx, operator1)
+
+	getOperator, isExist := keeper.GetOperator(ctx, testInput.TestAddrs[0])
+	require.True(t, isExist)
+	require.Equal(t, operator0, getOperator)
+
+	var operators types.DEXOperators
+	keeper.IterateOperators(ctx, func(operator types.DEXOperator) (stop bool) {
+		operators = append(operators, operator)
+		return false
+	})
+	require.Equal(t, 2, len(operators))
+
+	// operator info with products
+	tokenPair := getTestTokenPair()
+	tokenPair.Owner = testInput.TestAddrs[0]
+	err := keeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	operatorInfo := keeper.GetOperatorInfo(ctx, operator0)
+	require.Equal(t, []string{tokenPair.Name()}, operatorInfo.Products)
+	operatorInfo = keeper.GetOperatorInfo(ctx, operator1)
+	require.Equal(t, 0, len(operatorInfo.Products))
+}
@@ -26,6 +26,12 @@ func NewQuerier(keeper IKeeper) sdk.Querier {
 			return queryParams(ctx, req, keeper)
 		case types.QueryProductsDelisting:
 			return queryProductsDelisting(ctx, keeper)
+		case types.QueryOperator:
+			return queryOperator(ctx, req, keeper)
+		case types.QueryOperators:
+			return queryOperators(ctx, req, keeper)
+		case types.QueryWithdraws:
+			return queryWithdraws(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown dex query endpoint")
 		}
@@ -191,3 +197,107 @@ func queryProductsDelisting(ctx sdk.Context, keeper IKeeper) (res []byte, err sd
 	return res, nil
 
 }
+
+func queryOperator(ctx sdk.Context, req abci.RequestQuery, keeper IKeeper) (res []byte, err sdk.Error) {
+
+	var params types.QueryDexInfoParams
+	errUnmarshal := keeper.GetCDC().UnmarshalJSON(req.Data, &params)
+	if errUnmarshal != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errUnmarshal.Error()))
+	}
+
+	operatorAddr, errAddr := sdk.AccAddressFromBech32(params.Owner)
+	if errAddr != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", params.Owner))
+	}
+
+	operator, isExist := keeper.GetOperator(ctx, operatorAddr)
+	if !isExist {
+		return nil, types.ErrUnknownOperator(operatorAddr)
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), keeper.GetOperatorInfo(ctx, operator))
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to  marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+func queryOperators(ctx sdk.Context, req abci.RequestQuery, keeper IKeeper) (res []byte, err sdk.Error) {
+
+	var params types.QueryDexInfoParams
+	errUnmarshal := keeper.GetCDC().UnmarshalJSON(req.Data, &params)
+	if errUnmarshal != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errUnmarshal.Error()))
+	}
+
+	var operators types.DEXOperators
+	keeper.IterateOperators(ctx, func(operator types.DEXOperator) (stop bool) {
+		operators = append(operators, operator)
+		return false
+	})
+
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+
+	if len(operators) < offset {
+		operators = operators[0:0]
+	} else if len(operators) < offset+limit {
+		operators = operators[offset:]
+	} else {
+		operators = operators[offset : offset+limit]
+	}
+
+	operatorInfos := types.DEXOperatorInfos{}
+	for _, operator := range operators {
+		operatorInfos = append(operatorInfos, keeper.GetOperatorInfo(ctx, operator))
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), operatorInfos)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to  marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+// queryWithdraws returns the pending withdrawal of the owner, or all the pending withdrawals if no owner is given
+func queryWithdraws(ctx sdk.Context, req abci.RequestQuery, keeper IKeeper) (res []byte, err sdk.Error) {
+
+	var params types.QueryDexInfoParams
+	errUnmarshal := keeper.GetCDC().UnmarshalJSON(req.Data, &params)
+	if errUnmarshal != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errUnmarshal.Error()))
+	}
+
+	withdrawInfos := types.WithdrawInfos{}
+	if params.Owner != "" {
+		ownerAddr, errAddr := sdk.AccAddressFromBech32(params.Owner)
+		if errAddr != nil {
+			return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", params.Owner))
+		}
+
+		if withdrawInfo, ok := keeper.GetWithdrawInfo(ctx, ownerAddr); ok {
+			withdrawInfos = append(withdrawInfos, withdrawInfo)
+		}
+	} else {
+		keeper.IterateWithdrawInfo(ctx, func(_ int64, withdrawInfo types.WithdrawInfo) (stop bool) {
+			withdrawInfos = append(withdrawInfos, withdrawInfo)
+			return false
+		})
+	}
+
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+
+	if len(withdrawInfos) < offset {
+		withdrawInfos = withdrawInfos[0:0]
+	} else if len(withdrawInfos) < offset+limit {
+		withdrawInfos = withdrawInfos[offset:]
+	} else {
+		withdrawInfos = withdrawInfos[offset : offset+limit]
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), withdrawInfos)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to  marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
@@ -123,3 +123,105 @@ func TestQuerier_QueryProductsDelisting(t *testing.T) {
 	require.Nil(t, err)
 
 }
+
+func TestQuerier_QueryOperators(t *testing.T) {
+
+	testInput := CreateTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+	querier := NewQuerier(testInput.DexKeeper)
+
+	addr, err := sdk.AccAddressFromBech32(types.TestTokenPairOwner)
+	require.Nil(t, err)
+	queryParams, err := types.NewQueryDexInfoParams(types.TestTokenPairOwner, 1, 50)
+	require.Nil(t, err)
+	bz, err := amino.MarshalJSON(queryParams)
+	require.Nil(t, err)
+
+	// error case : failed to query an operator which is not registered
+	_, err = querier(ctx, []string{types.QueryOperator}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+
+	operator := types.DEXOperator{
+		Address:            addr,
+		Name:               "operator",
+		HandlingFeeAddress: addr,
+	}
+	testInput.DexKeeper.SetOperator(ctx, operator)
+	tokenPair := &types.TokenPair{
+		BaseAssetSymbol:  "bToken0",
+		QuoteAssetSymbol: common.NativeToken,
+		Owner:            addr,
+		Deposits:         types.DefaultTokenPairDeposit,
+	}
+	err = testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// successful case : query the operator
+	res, err := querier(ctx, []string{types.QueryOperator}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var operatorInfo types.DEXOperatorInfo
+	testInput.Cdc.MustUnmarshalJSON(res, &operatorInfo)
+	require.Equal(t, operator, operatorInfo.DEXOperator)
+	require.Equal(t, []string{tokenPair.Name()}, operatorInfo.Products)
+
+	// successful case : query all the operators
+	res, err = querier(ctx, []string{types.QueryOperators}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var operatorInfos types.DEXOperatorInfos
+	testInput.Cdc.MustUnmarshalJSON(res, &operatorInfos)
+	require.Equal(t, 1, len(operatorInfos))
+
+	// error case : failed to query data because param is nil
+	_, err = querier(ctx, []string{types.QueryOperators}, abci.RequestQuery{Data: nil})
+	require.NotNil(t, err)
+}
+
+func TestQuerier_QueryWithdraws(t *testing.T) {
+
+	testInput := CreateTestInputWithBalance(t, 2, 10000)
+	ctx := testInput.Ctx
+	querier := NewQuerier(testInput.DexKeeper)
+
+	withdrawInfo := types.WithdrawInfo{
+		Owner:        testInput.TestAddrs[0],
+		Deposits:     sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(10)),
+		CompleteTime: time.Now().UTC(),
+	}
+	testInput.DexKeeper.SetWithdrawInfo(ctx, withdrawInfo)
+
+	// successful case : query the withdrawal of the owner
+	queryParams, err := types.NewQueryDexInfoParams(testInput.TestAddrs[0].String(), 1, 50)
+	require.Nil(t, err)
+	bz, err := amino.MarshalJSON(queryParams)
+	require.Nil(t, err)
+	res, err := querier(ctx, []string{types.QueryWithdraws}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var withdrawInfos types.WithdrawInfos
+	testInput.Cdc.MustUnmarshalJSON(res, &withdrawInfos)
+	require.True(t, withdrawInfos.Equal(types.WithdrawInfos{withdrawInfo}))
+
+	// successful case : the owner has no pending withdrawal
+	queryParams, err = types.NewQueryDexInfoParams(testInput.TestAddrs[1].String(), 1, 50)
+	require.Nil(t, err)
+	bz, err = amino.MarshalJSON(queryParams)
+	require.Nil(t, err)
+	res, err = querier(ctx, []string{types.QueryWithdraws}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	withdrawInfos = nil
+	testInput.Cdc.MustUnmarshalJSON(res, &withdrawInfos)
+	require.Equal(t, 0, len(withdrawInfos))
+
+	// successful case : query all the withdrawals
+	queryParams, err = types.NewQueryDexInfoParams("", 1, 50)
+	require.Nil(t, err)
+	bz, err = amino.MarshalJSON(queryParams)
+	require.Nil(t, err)
+	res, err = querier(ctx, []string{types.QueryWithdraws}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	testInput.Cdc.MustUnmarshalJSON(res, &withdrawInfos)
+	require.Equal(t, 1, len(withdrawInfos))
+
+	// error case : failed to query data because param is nil
+	_, err = querier(ctx, []string{types.QueryWithdraws}, abci.RequestQuery{Data: nil})
+	require.NotNil(t, err)
+}
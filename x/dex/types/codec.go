@@ -8,8 +8,10 @@ func RegisterCodec(cdc *codec.Codec) {
 	//cdc.RegisterConcrete(MsgDelist{}, "okchain/dex/MsgDelist", nil)
 	cdc.RegisterConcrete(MsgDeposit{}, "okchain/dex/MsgDeposit", nil)
 	cdc.RegisterConcrete(MsgWithdraw{}, "okchain/dex/MsgWithdraw", nil)
+	cdc.RegisterConcrete(MsgCancelWithdraw{}, "okchain/dex/MsgCancelWithdraw", nil)
 	cdc.RegisterConcrete(MsgTransferOwnership{}, "okchain/dex/MsgTransferTradingPairOwnership", nil)
 	cdc.RegisterConcrete(DelistProposal{}, "okchain/dex/DelistProposal", nil)
+	cdc.RegisterConcrete(MsgRegisterOperator{}, "okchain/dex/MsgRegisterOperator", nil)
 
 }
 
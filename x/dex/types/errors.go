@@ -16,6 +16,10 @@ const (
 	CodeInvalidHeight           sdk.CodeType = 5
 	CodeInvalidAsset            sdk.CodeType = 6
 	CodeInvalidCommon           sdk.CodeType = 7
+	CodeInvalidOperator         sdk.CodeType = 8
+	CodeUnknownOperator         sdk.CodeType = 9
+	CodeRepeatedOperator        sdk.CodeType = 10
+	CodeWithdrawNotFound        sdk.CodeType = 11
 )
 
 // CodeType to Message
@@ -27,6 +31,14 @@ func CodeToDefaultMsg(code sdk.CodeType) string {
 		return "tokenpair not found"
 	case CodeDelistOwnerNotMatch:
 		return "tokenpair delistor should be it's owner "
+	case CodeInvalidOperator:
+		return "invalid dex operator"
+	case CodeUnknownOperator:
+		return "unknown dex operator"
+	case CodeRepeatedOperator:
+		return "dex operator already exists"
+	case CodeWithdrawNotFound:
+		return "pending withdrawal not found"
 	default:
 		return fmt.Sprintf("unknown code %d", code)
 	}
@@ -47,6 +59,22 @@ func ErrDelistOwnerNotMatch(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeDelistOwnerNotMatch, CodeToDefaultMsg(CodeDelistOwnerNotMatch)+": %s", msg)
 }
 
+func ErrInvalidOperator(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidOperator, CodeToDefaultMsg(CodeInvalidOperator)+": %s", msg)
+}
+
+func ErrUnknownOperator(addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeUnknownOperator, CodeToDefaultMsg(CodeUnknownOperator)+": %s", addr.String())
+}
+
+func ErrRepeatedOperator(addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeRepeatedOperator, CodeToDefaultMsg(CodeRepeatedOperator)+": %s", addr.String())
+}
+
+func ErrWithdrawNotFound(addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeWithdrawNotFound, CodeToDefaultMsg(CodeWithdrawNotFound)+": %s", addr.String())
+}
+
 func ErrInvalidBalanceNotEnough(codespace sdk.CodespaceType, message string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidBalanceNotEnough, message)
 }
@@ -29,6 +29,9 @@ const (
 	QueryDeposits   = "deposits"
 	QueryMatchOrder = "match-order"
 	QueryParameters = "params"
+	QueryOperator   = "operator"
+	QueryOperators  = "operators"
+	QueryWithdraws  = "withdraws"
 )
 
 var (
@@ -40,6 +43,7 @@ var (
 	PrefixWithdrawAddressKey = []byte{0x53}
 	PrefixWithdrawTimeKey    = []byte{0x54}
 	PrefixUserTokenPairKey   = []byte{0x06}
+	PrefixOperatorKey        = []byte{0x55}
 )
 
 func GetUserTokenPairAddressPrefix(Owner sdk.AccAddress) []byte {
@@ -60,6 +64,11 @@ func GetWithdrawAddressKey(addr sdk.AccAddress) []byte {
 	return append(PrefixWithdrawAddressKey, addr.Bytes()...)
 }
 
+// GetOperatorAddressKey returns key of operator address
+func GetOperatorAddressKey(addr sdk.AccAddress) []byte {
+	return append(PrefixOperatorKey, addr.Bytes()...)
+}
+
 // GetWithdrawTimeKey returns key of withdraw time
 func GetWithdrawTimeKey(completeTime time.Time) []byte {
 	bz := sdk.FormatTimeBytes(completeTime)
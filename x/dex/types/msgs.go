@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"net/url"
+
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -9,7 +12,9 @@ import (
 const (
 	TypeMsgDeposit           = "deposit"
 	TypeMsgWithdraw          = "withdraw"
+	TypeMsgCancelWithdraw    = "cancelWithdraw"
 	TypeMsgTransferOwnership = "transferOwnership"
+	TypeMsgRegisterOperator  = "registerOperator"
 )
 
 type MsgList struct {
@@ -159,6 +164,45 @@ func (msg MsgWithdraw) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Depositor}
 }
 
+// MsgCancelWithdraw cancels the pending withdrawal and puts the amount back into the deposits of the product
+type MsgCancelWithdraw struct {
+	Product   string         `json:"product"`   // product for trading pair in full name of the tokens
+	Amount    sdk.DecCoin    `json:"amount"`    // Coins to put back into the deposit
+	Depositor sdk.AccAddress `json:"depositor"` // Address of the depositor
+}
+
+func NewMsgCancelWithdraw(product string, amount sdk.DecCoin, depositor sdk.AccAddress) MsgCancelWithdraw {
+	return MsgCancelWithdraw{product, amount, depositor}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgCancelWithdraw) Route() string { return RouterKey }
+func (msg MsgCancelWithdraw) Type() string  { return TypeMsgCancelWithdraw }
+
+// Implements Msg.
+func (msg MsgCancelWithdraw) ValidateBasic() sdk.Error {
+	if msg.Depositor.Empty() {
+		return sdk.ErrInvalidAddress(msg.Depositor.String())
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgCancelWithdraw) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// Implements Msg.
+func (msg MsgCancelWithdraw) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Depositor}
+}
+
 // MsgTransferOwnership - high level transaction of the coin module
 type MsgTransferOwnership struct {
 	FromAddress sdk.AccAddress    `json:"from_address"`
@@ -229,3 +273,73 @@ func (msg MsgTransferOwnership) checkMultiSign() bool {
 	toValid := toSignature.VerifyBytes(msg.GetSignBytes(), toSignature.Signature)
 	return toValid
 }
+
+// MsgRegisterOperator registers the sender as a dex operator
+type MsgRegisterOperator struct {
+	Owner              sdk.AccAddress `json:"owner"`
+	Name               string         `json:"name"`
+	Website            string         `json:"website"`
+	HandlingFeeAddress sdk.AccAddress `json:"handling_fee_address"`
+	Contact            string         `json:"contact"`
+}
+
+func NewMsgRegisterOperator(owner sdk.AccAddress, name, website string, handlingFeeAddress sdk.AccAddress,
+	contact string) MsgRegisterOperator {
+	if handlingFeeAddress.Empty() {
+		handlingFeeAddress = owner
+	}
+	return MsgRegisterOperator{
+		Owner:              owner,
+		Name:               name,
+		Website:            website,
+		HandlingFeeAddress: handlingFeeAddress,
+		Contact:            contact,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgRegisterOperator) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRegisterOperator) Type() string { return TypeMsgRegisterOperator }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRegisterOperator) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress("missing owner address")
+	}
+
+	if msg.HandlingFeeAddress.Empty() {
+		return sdk.ErrInvalidAddress("missing handling fee address")
+	}
+
+	if len(msg.Name) == 0 || len(msg.Name) > MaxOperatorNameLength {
+		return ErrInvalidOperator(fmt.Sprintf("name should not be empty or longer than %d", MaxOperatorNameLength))
+	}
+
+	if len(msg.Website) > MaxOperatorWebsiteLength {
+		return ErrInvalidOperator(fmt.Sprintf("website should not be longer than %d", MaxOperatorWebsiteLength))
+	}
+	if len(msg.Website) > 0 {
+		u, err := url.Parse(msg.Website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidOperator(fmt.Sprintf("invalid website: %s", msg.Website))
+		}
+	}
+
+	if len(msg.Contact) > MaxOperatorContactLength {
+		return ErrInvalidOperator(fmt.Sprintf("contact should not be longer than %d", MaxOperatorContactLength))
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRegisterOperator) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRegisterOperator) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	MaxOperatorNameLength    = 64
+	MaxOperatorWebsiteLength = 1024
+	MaxOperatorContactLength = 256
+)
+
+// DEXOperator represents the operator who runs a dex on okchain and lists token pairs
+type DEXOperator struct {
+	Address            sdk.AccAddress `json:"address"`
+	Name               string         `json:"name"`
+	Website            string         `json:"website"`
+	HandlingFeeAddress sdk.AccAddress `json:"handling_fee_address"`
+	Contact            string         `json:"contact"`
+	InitHeight         int64          `json:"init_height"`
+}
+
+// String implements fmt.Stringer
+func (o DEXOperator) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`DEXOperator:
+  Address:              %s
+  Name:                 %s
+  Website:              %s
+  HandlingFeeAddress:   %s
+  Contact:              %s
+  InitHeight:           %d`,
+		o.Address, o.Name, o.Website, o.HandlingFeeAddress, o.Contact, o.InitHeight))
+}
+
+type DEXOperators []DEXOperator
+
+// DEXOperatorInfo is the result of operator queries, containing the products owned by the operator
+type DEXOperatorInfo struct {
+	DEXOperator
+	Products []string `json:"products"`
+}
+
+// String implements fmt.Stringer
+func (info DEXOperatorInfo) String() string {
+	return fmt.Sprintf("%s\n  Products:             %s", info.DEXOperator.String(), strings.Join(info.Products, ","))
+}
+
+type DEXOperatorInfos []DEXOperatorInfo
+
+// String implements fmt.Stringer
+func (infos DEXOperatorInfos) String() string {
+	strs := make([]string, 0, len(infos))
+	for _, info := range infos {
+		strs = append(strs, info.String())
+	}
+	return strings.Join(strs, "\n")
+}
@@ -0,0 +1,64 @@
+// nolint
+// aliases generated for the following subdirectories:
+// ALIASGEN: github.com/okex/okchain/x/farm/keeper
+// ALIASGEN: github.com/okex/okchain/x/farm/types
+package farm
+
+import (
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/farm/keeper"
+	"github.com/okex/okchain/x/farm/types"
+)
+
+const (
+	ModuleName        = types.ModuleName
+	DefaultCodespace  = types.DefaultCodespace
+	DefaultParamspace = types.DefaultParamspace
+	QuerierRoute      = types.QuerierRoute
+	RouterKey         = types.RouterKey
+	StoreKey          = types.StoreKey
+)
+
+type (
+	// Keepers
+	Keeper              = keeper.Keeper
+	SupplyKeeper        = keeper.SupplyKeeper
+	DexKeeper           = keeper.DexKeeper
+	OrderKeeper         = keeper.OrderKeeper
+	ProtocolVersionType = version.ProtocolVersionType
+
+	// Messages
+	MsgSetFarmPool      = types.MsgSetFarmPool
+	MsgFundFarmPool     = types.MsgFundFarmPool
+	MsgWithdrawFarmPool = types.MsgWithdrawFarmPool
+	MsgClaimReward      = types.MsgClaimReward
+
+	//
+	Params    = types.Params
+	FarmPool  = types.FarmPool
+	FarmPools = types.FarmPools
+	Reward    = types.Reward
+	Rewards   = types.Rewards
+)
+
+var (
+	ModuleCdc = types.ModuleCdc
+
+	RegisterCodec = types.RegisterCodec
+	NewQuerier    = keeper.NewQuerier
+	NewKeeper     = keeper.NewKeeper
+	DefaultParams = types.DefaultParams
+
+	NewMsgSetFarmPool      = types.NewMsgSetFarmPool
+	NewMsgFundFarmPool     = types.NewMsgFundFarmPool
+	NewMsgWithdrawFarmPool = types.NewMsgWithdrawFarmPool
+	NewMsgClaimReward      = types.NewMsgClaimReward
+	NewFarmPool            = types.NewFarmPool
+
+	ErrFarmPoolNotExist   = types.ErrFarmPoolNotExist
+	ErrTokenPairNotListed = types.ErrTokenPairNotListed
+	ErrNotTokenPairOwner  = types.ErrNotTokenPairOwner
+	ErrInvalidRewardDenom = types.ErrInvalidRewardDenom
+	ErrNoReward           = types.ErrNoReward
+	ErrNotFarmPoolOwner   = types.ErrNotFarmPoolOwner
+)
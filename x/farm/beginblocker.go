@@ -0,0 +1,13 @@
+package farm
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common/perf"
+)
+
+// BeginBlocker called every block, distributes the block rewards of the farm pools to the resting orders.
+func BeginBlocker(ctx sdk.Context, keeper Keeper) {
+	seq := perf.GetPerf().OnBeginBlockEnter(ctx, ModuleName)
+	defer perf.GetPerf().OnBeginBlockExit(ctx, ModuleName, seq)
+	keeper.DistributeRewards(ctx)
+}
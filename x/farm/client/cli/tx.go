@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/okchain/x/farm/types"
+	"github.com/spf13/cobra"
+)
+
+// farm flags
+const (
+	FlagRewardPerBlock = "reward-per-block"
+	FlagMaxSpread      = "max-spread"
+)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   "farm",
+		Short: "Liquidity mining subcommands",
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		GetCmdSetFarmPool(cdc),
+		GetCmdFundFarmPool(cdc),
+		GetCmdWithdrawFarmPool(cdc),
+		GetCmdClaimReward(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdSetFarmPool implements the set farm pool command handler
+func GetCmdSetFarmPool(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-pool [product]",
+		Short: "create or update the incentive program of a token pair",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(`Create or update the incentive program of a token pair owned by the sender:
+
+$ okchaincli tx farm set-pool mytoken_okt --reward-per-block 10okt --max-spread 0.02 --from mykey
+
+In every block, the rewards are distributed to the makers of the orders resting within the max spread of the
+mid price, weighted by the remaining quantities and the time the orders rest in the book.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			rewardPerBlockStr, err := flags.GetString(FlagRewardPerBlock)
+			if err != nil {
+				return err
+			}
+			rewardPerBlock, err := sdk.ParseDecCoin(rewardPerBlockStr)
+			if err != nil {
+				return err
+			}
+			maxSpreadStr, err := flags.GetString(FlagMaxSpread)
+			if err != nil {
+				return err
+			}
+			maxSpread, err := sdk.NewDecFromStr(maxSpreadStr)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetFarmPool(args[0], rewardPerBlock, maxSpread, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagRewardPerBlock, "", "rewards distributed in each block")
+	cmd.Flags().String(FlagMaxSpread, "0.01", "max distance of the rewarded orders from the mid price, as a ratio of it")
+
+	return cmd
+}
+
+// GetCmdFundFarmPool implements the fund farm pool command handler
+func GetCmdFundFarmPool(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fund [product] [amount]",
+		Short: "add rewards into the farm pool of a token pair",
+		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(`Add rewards into the farm pool of a token pair owned by the sender:
+
+$ okchaincli tx farm fund mytoken_okt 1000okt --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgFundFarmPool(args[0], amount, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdWithdrawFarmPool implements the withdraw farm pool command handler
+func GetCmdWithdrawFarmPool(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw [product] [amount]",
+		Short: "take back rewards left in the farm pool of a token pair",
+		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(`Take back rewards left in the farm pool owned by the sender:
+
+$ okchaincli tx farm withdraw mytoken_okt 100okt --from mykey
+
+The balance of the pool of a delisted token pair is refunded to its owner automatically.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgWithdrawFarmPool(args[0], amount, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdClaimReward implements the claim reward command handler
+func GetCmdClaimReward(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim",
+		Short: "claim all the rewards accrued by the sender",
+		Args:  cobra.NoArgs,
+		Long: strings.TrimSpace(`Claim all the rewards accrued by the sender:
+
+$ okchaincli tx farm claim --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimReward(cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/farm/types"
+)
+
+// RegisterRoutes - Central function to define routes that get registered by the main application
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc("/farm/pools", queryHandler(cliCtx, types.QueryFarmPools)).Methods("GET")
+	r.HandleFunc("/farm/pool/{product}", queryWithPathHandler(cliCtx, types.QueryFarmPool, "product")).Methods("GET")
+	r.HandleFunc("/farm/rewards/{address}", queryWithPathHandler(cliCtx, types.QueryRewards, "address")).Methods("GET")
+	r.HandleFunc("/farm/params", queryHandler(cliCtx, types.QueryParameters)).Methods("GET")
+}
+
+func queryWithPathHandler(cliContext context.CLIContext, endpoint, pathVar string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliContext.QueryWithData(
+			fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, endpoint, mux.Vars(r)[pathVar]), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		postProcessResponse(w, cliContext, res)
+	}
+}
+
+func queryHandler(cliContext context.CLIContext, endpoint string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliContext.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, endpoint), nil)
+		if err != nil {
+			common.HandleErrorMsg(w, cliContext, err.Error())
+			return
+		}
+		postProcessResponse(w, cliContext, res)
+	}
+}
+
+func postProcessResponse(w http.ResponseWriter, cliContext context.CLIContext, res []byte) {
+	result := common.GetBaseResponse("hello")
+	result2, err := json.Marshal(result)
+	if err != nil {
+		common.HandleErrorMsg(w, cliContext, err.Error())
+		return
+	}
+	result2 = []byte(strings.Replace(string(result2), "\"hello\"", string(res), 1))
+	rest.PostProcessResponse(w, cliContext, result2)
+}
@@ -0,0 +1,70 @@
+package farm
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all farm state that must be provided at genesis
+type GenesisState struct {
+	Params    Params    `json:"params"`
+	FarmPools FarmPools `json:"farm_pools"`
+	Rewards   Rewards   `json:"rewards"`
+}
+
+// DefaultGenesisState - default GenesisState used by Cosmos Hub
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:    DefaultParams(),
+		FarmPools: nil,
+		Rewards:   nil,
+	}
+}
+
+// ValidateGenesis validates the farm genesis parameters
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	products := make(map[string]struct{}, len(data.FarmPools))
+	for _, pool := range data.FarmPools {
+		if _, ok := products[pool.Product]; ok {
+			return fmt.Errorf("duplicated farm pool %s", pool.Product)
+		}
+		products[pool.Product] = struct{}{}
+		if pool.Balance.IsNegative() || pool.Balance.Denom != pool.RewardPerBlock.Denom {
+			return fmt.Errorf("invalid balance of farm pool %s", pool.Product)
+		}
+	}
+
+	for _, reward := range data.Rewards {
+		if reward.Address.Empty() || !reward.Coins.IsValid() {
+			return fmt.Errorf("invalid rewards of %s", reward.Address)
+		}
+	}
+	return nil
+}
+
+// InitGenesis initialize default parameters, the farm pools and the accrued rewards
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+	for _, pool := range data.FarmPools {
+		keeper.SetFarmPool(ctx, pool)
+	}
+	for _, reward := range data.Rewards {
+		keeper.SetReward(ctx, reward.Address, reward.Coins)
+	}
+}
+
+// ExportGenesis writes the current store values
+// to a genesis file, which can be imported again
+// with InitGenesis
+func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
+	return GenesisState{
+		Params:    keeper.GetParams(ctx),
+		FarmPools: keeper.GetFarmPools(ctx),
+		Rewards:   keeper.GetRewards(ctx),
+	}
+}
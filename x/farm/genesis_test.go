@@ -0,0 +1,35 @@
+package farm
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/farm/keeper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesis(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.FarmKeeper
+
+	pool := NewFarmPool(keeper.TestProduct, testInput.TestAddrs[0],
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)), sdk.MustNewDecFromStr("0.1"))
+	pool.Balance.Amount = sdk.NewDec(100)
+	genesisState := DefaultGenesisState()
+	genesisState.Params.TimeWeightPeriod = 3600
+	genesisState.FarmPools = FarmPools{pool}
+	genesisState.Rewards = Rewards{{Address: testInput.TestAddrs[1],
+		Coins: sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1))}}}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	InitGenesis(ctx, k, genesisState)
+	require.Equal(t, genesisState, ExportGenesis(ctx, k))
+
+	// invalid genesis
+	genesisState.FarmPools = append(genesisState.FarmPools, pool)
+	require.NotNil(t, ValidateGenesis(genesisState))
+	genesisState = DefaultGenesisState()
+	genesisState.Params.TimeWeightPeriod = -1
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
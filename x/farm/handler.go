@@ -0,0 +1,164 @@
+package farm
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common/perf"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// NewHandler handles all "farm" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		logger := ctx.Logger().With("module", ModuleName)
+
+		var handlerFun func() sdk.Result
+		var name string
+		switch msg := msg.(type) {
+		case MsgSetFarmPool:
+			name = "handleMsgSetFarmPool"
+			handlerFun = func() sdk.Result {
+				return handleMsgSetFarmPool(ctx, k, msg, logger)
+			}
+		case MsgFundFarmPool:
+			name = "handleMsgFundFarmPool"
+			handlerFun = func() sdk.Result {
+				return handleMsgFundFarmPool(ctx, k, msg, logger)
+			}
+		case MsgWithdrawFarmPool:
+			name = "handleMsgWithdrawFarmPool"
+			handlerFun = func() sdk.Result {
+				return handleMsgWithdrawFarmPool(ctx, k, msg, logger)
+			}
+		case MsgClaimReward:
+			name = "handleMsgClaimReward"
+			handlerFun = func() sdk.Result {
+				return handleMsgClaimReward(ctx, k, msg, logger)
+			}
+		default:
+			errMsg := fmt.Sprintf("unrecognized farm message type: %T", msg)
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+
+		seq := perf.GetPerf().OnDeliverTxEnter(ctx, ModuleName, name)
+		defer perf.GetPerf().OnDeliverTxExit(ctx, ModuleName, name, seq)
+		return handlerFun()
+	}
+}
+
+func handleMsgSetFarmPool(ctx sdk.Context, keeper Keeper, msg MsgSetFarmPool, logger log.Logger) sdk.Result {
+	tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return ErrTokenPairNotListed(msg.Product).Result()
+	}
+	if !tokenPair.Owner.Equals(msg.Owner) {
+		return ErrNotTokenPairOwner(msg.Owner, msg.Product).Result()
+	}
+
+	pool, isExist := keeper.GetFarmPool(ctx, msg.Product)
+	if !isExist {
+		pool = NewFarmPool(msg.Product, msg.Owner, msg.RewardPerBlock, msg.MaxSpread)
+	} else {
+		// the token pair has been transferred, the balance left is refunded to the previous owner of the pool
+		if !pool.Owner.Equals(msg.Owner) {
+			var err sdk.Error
+			if pool, err = keeper.HandOverFarmPool(ctx, pool, msg.Owner); err != nil {
+				return err.Result()
+			}
+		}
+		// the reward denom can be changed only after the balance is used up
+		if pool.Balance.Denom != msg.RewardPerBlock.Denom {
+			if pool.Balance.IsPositive() {
+				return ErrInvalidRewardDenom(fmt.Sprintf("%s is left in the pool", pool.Balance)).Result()
+			}
+			pool.Balance = sdk.NewDecCoinFromDec(msg.RewardPerBlock.Denom, sdk.ZeroDec())
+		}
+		pool.RewardPerBlock = msg.RewardPerBlock
+		pool.MaxSpread = msg.MaxSpread
+	}
+	keeper.SetFarmPool(ctx, pool)
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgSetFarmPool: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+			sdk.NewAttribute("product", msg.Product),
+			sdk.NewAttribute("reward_per_block", msg.RewardPerBlock.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgFundFarmPool(ctx sdk.Context, keeper Keeper, msg MsgFundFarmPool, logger log.Logger) sdk.Result {
+	tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return ErrTokenPairNotListed(msg.Product).Result()
+	}
+	if !tokenPair.Owner.Equals(msg.Owner) {
+		return ErrNotTokenPairOwner(msg.Owner, msg.Product).Result()
+	}
+
+	if err := keeper.FundFarmPool(ctx, msg.Product, msg.Amount, msg.Owner); err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgFundFarmPool: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+			sdk.NewAttribute("product", msg.Product),
+			sdk.NewAttribute("amount", msg.Amount.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgWithdrawFarmPool(ctx sdk.Context, keeper Keeper, msg MsgWithdrawFarmPool, logger log.Logger) sdk.Result {
+	if err := keeper.WithdrawFarmPool(ctx, msg.Product, msg.Amount, msg.Owner); err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgWithdrawFarmPool: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+			sdk.NewAttribute("product", msg.Product),
+			sdk.NewAttribute("amount", msg.Amount.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgClaimReward(ctx sdk.Context, keeper Keeper, msg MsgClaimReward, logger log.Logger) sdk.Result {
+	coins, err := keeper.ClaimReward(ctx, msg.Address)
+	if err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgClaimReward: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+			sdk.NewAttribute("rewards", coins.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -0,0 +1,157 @@
+package farm
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/farm/keeper"
+	"github.com/okex/okchain/x/farm/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_HandleMsgSetFarmPool(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.FarmKeeper
+	handler := NewHandler(k)
+	owner := testInput.TestAddrs[0]
+	rewardPerBlock := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10))
+	maxSpread := sdk.MustNewDecFromStr("0.1")
+
+	// fail case : the token pair is not listed
+	msg := NewMsgSetFarmPool("btc_okt", rewardPerBlock, maxSpread, owner)
+	require.Equal(t, types.CodeTokenPairNotListed, handler(ctx, msg).Code)
+
+	// fail case : the sender is not the owner of the token pair
+	msg = NewMsgSetFarmPool(keeper.TestProduct, rewardPerBlock, maxSpread, testInput.TestAddrs[1])
+	require.Equal(t, types.CodeNotTokenPairOwner, handler(ctx, msg).Code)
+
+	// successful case : create the pool
+	msg = NewMsgSetFarmPool(keeper.TestProduct, rewardPerBlock, maxSpread, owner)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	pool, isExist := k.GetFarmPool(ctx, keeper.TestProduct)
+	require.True(t, isExist)
+	require.Equal(t, NewFarmPool(keeper.TestProduct, owner, rewardPerBlock, maxSpread), pool)
+
+	// fail case : the reward denom is changed with balance left
+	fundMsg := NewMsgFundFarmPool(keeper.TestProduct, rewardPerBlock, owner)
+	require.True(t, handler(ctx, fundMsg).Code.IsOK())
+	msg = NewMsgSetFarmPool(keeper.TestProduct, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1)), maxSpread, owner)
+	require.Equal(t, types.CodeInvalidRewardDenom, handler(ctx, msg).Code)
+
+	// successful case : update the pool
+	newRewardPerBlock := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1))
+	msg = NewMsgSetFarmPool(keeper.TestProduct, newRewardPerBlock, maxSpread, owner)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	pool, _ = k.GetFarmPool(ctx, keeper.TestProduct)
+	require.Equal(t, newRewardPerBlock, pool.RewardPerBlock)
+	require.Equal(t, rewardPerBlock, pool.Balance)
+}
+
+func TestHandler_HandleMsgFundFarmPoolAndClaimReward(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.FarmKeeper
+	handler := NewHandler(k)
+	owner := testInput.TestAddrs[0]
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10))
+
+	// fail case : the sender is not the owner of the token pair
+	fundMsg := NewMsgFundFarmPool(keeper.TestProduct, amount, testInput.TestAddrs[1])
+	require.Equal(t, types.CodeNotTokenPairOwner, handler(ctx, fundMsg).Code)
+
+	// fail case : the pool not exist
+	fundMsg = NewMsgFundFarmPool(keeper.TestProduct, amount, owner)
+	require.Equal(t, types.CodeFarmPoolNotExist, handler(ctx, fundMsg).Code)
+
+	setMsg := NewMsgSetFarmPool(keeper.TestProduct, amount, sdk.MustNewDecFromStr("0.1"), owner)
+	require.True(t, handler(ctx, setMsg).Code.IsOK())
+	require.True(t, handler(ctx, fundMsg).Code.IsOK())
+
+	// fail case : no reward to claim
+	claimMsg := NewMsgClaimReward(testInput.TestAddrs[1])
+	require.Equal(t, types.CodeNoReward, handler(ctx, claimMsg).Code)
+
+	// successful case
+	k.SetReward(ctx, testInput.TestAddrs[1], sdk.DecCoins{amount})
+	require.True(t, handler(ctx, claimMsg).Code.IsOK())
+	require.Equal(t, sdk.NewDec(1010), testInput.TokenKeeper.GetCoins(ctx, testInput.TestAddrs[1]).AmountOf(common.NativeToken))
+}
+
+func TestHandler_HandleMsgFundFarmPoolFrozen(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.FarmKeeper
+	handler := NewHandler(k)
+	owner := testInput.TestAddrs[0]
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10))
+	require.True(t, handler(ctx, NewMsgSetFarmPool(keeper.TestProduct, amount, sdk.MustNewDecFromStr("0.1"), owner)).Code.IsOK())
+
+	// the frozen balance can't fund the pool
+	testInput.TokenKeeper.FreezeAccount(ctx, common.NativeToken, owner)
+	res := handler(ctx, NewMsgFundFarmPool(keeper.TestProduct, amount, owner))
+	require.False(t, res.Code.IsOK())
+	require.Contains(t, res.Log, "frozen")
+	pool, _ := k.GetFarmPool(ctx, keeper.TestProduct)
+	require.True(t, pool.Balance.Amount.IsZero())
+
+	testInput.TokenKeeper.UnfreezeAccount(ctx, common.NativeToken, owner)
+	require.True(t, handler(ctx, NewMsgFundFarmPool(keeper.TestProduct, amount, owner)).Code.IsOK())
+	pool, _ = k.GetFarmPool(ctx, keeper.TestProduct)
+	require.Equal(t, amount, pool.Balance)
+}
+
+func TestHandler_HandleMsgWithdrawFarmPool(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.FarmKeeper
+	handler := NewHandler(k)
+	owner := testInput.TestAddrs[0]
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10))
+	require.True(t, handler(ctx, NewMsgSetFarmPool(keeper.TestProduct, amount, sdk.MustNewDecFromStr("0.1"), owner)).Code.IsOK())
+	require.True(t, handler(ctx, NewMsgFundFarmPool(keeper.TestProduct, amount, owner)).Code.IsOK())
+
+	// fail case : not the owner of the pool
+	withdrawMsg := NewMsgWithdrawFarmPool(keeper.TestProduct, amount, testInput.TestAddrs[1])
+	require.Equal(t, types.CodeNotFarmPoolOwner, handler(ctx, withdrawMsg).Code)
+
+	// successful case
+	withdrawMsg = NewMsgWithdrawFarmPool(keeper.TestProduct, amount, owner)
+	require.True(t, handler(ctx, withdrawMsg).Code.IsOK())
+	pool, _ := k.GetFarmPool(ctx, keeper.TestProduct)
+	require.True(t, pool.Balance.Amount.IsZero())
+	require.Equal(t, sdk.NewDec(1000), testInput.TokenKeeper.GetCoins(ctx, owner).AmountOf(common.NativeToken))
+
+	// fail case : nothing left
+	require.Equal(t, sdk.CodeInsufficientCoins, handler(ctx, withdrawMsg).Code)
+}
+
+func TestHandler_HandleMsgSetFarmPoolAfterOwnershipTransfer(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.FarmKeeper
+	handler := NewHandler(k)
+	oldOwner, newOwner := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10))
+	maxSpread := sdk.MustNewDecFromStr("0.1")
+	require.True(t, handler(ctx, NewMsgSetFarmPool(keeper.TestProduct, amount, maxSpread, oldOwner)).Code.IsOK())
+	require.True(t, handler(ctx, NewMsgFundFarmPool(keeper.TestProduct, amount, oldOwner)).Code.IsOK())
+
+	// the token pair is transferred to the new owner
+	tokenPair := testInput.DexKeeper.GetTokenPair(ctx, keeper.TestProduct)
+	tokenPair.Owner = newOwner
+	testInput.DexKeeper.UpdateTokenPair(ctx, keeper.TestProduct, tokenPair)
+
+	// fail case : the new owner funds the pool before taking it over
+	require.Equal(t, types.CodeNotFarmPoolOwner, handler(ctx, NewMsgFundFarmPool(keeper.TestProduct, amount, newOwner)).Code)
+
+	// successful case : the balance is refunded to the old owner when the new owner takes the pool over
+	rewardPerBlock := sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1))
+	require.True(t, handler(ctx, NewMsgSetFarmPool(keeper.TestProduct, rewardPerBlock, maxSpread, newOwner)).Code.IsOK())
+	pool, _ := k.GetFarmPool(ctx, keeper.TestProduct)
+	require.Equal(t, newOwner, pool.Owner)
+	require.Equal(t, sdk.NewDecCoinFromDec(common.TestToken, sdk.ZeroDec()), pool.Balance)
+	require.Equal(t, sdk.NewDec(1000), testInput.TokenKeeper.GetCoins(ctx, oldOwner).AmountOf(common.NativeToken))
+
+	// fail case : the new owner can't withdraw what the old owner funded
+	withdrawMsg := NewMsgWithdrawFarmPool(keeper.TestProduct, rewardPerBlock, newOwner)
+	require.Equal(t, sdk.CodeInsufficientCoins, handler(ctx, withdrawMsg).Code)
+	withdrawMsg = NewMsgWithdrawFarmPool(keeper.TestProduct, rewardPerBlock, oldOwner)
+	require.Equal(t, types.CodeNotFarmPoolOwner, handler(ctx, withdrawMsg).Code)
+}
@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	dex "github.com/okex/okchain/x/dex/types"
+	order "github.com/okex/okchain/x/order/types"
+)
+
+type SupplyKeeper interface {
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress,
+		recipientModule string, amt sdk.Coins) sdk.Error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string,
+		recipientAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	GetModuleAddress(moduleName string) sdk.AccAddress
+}
+
+type DexKeeper interface {
+	GetTokenPair(ctx sdk.Context, product string) *dex.TokenPair
+}
+
+// expected order keeper, which provides the resting orders to be rewarded
+type OrderKeeper interface {
+	GetOrder(ctx sdk.Context, orderID string) *order.Order
+	GetDepthBookFromDB(ctx sdk.Context, product string) *order.DepthBook
+	GetProductPriceOrderIDsFromDB(ctx sdk.Context, key string) []string
+}
@@ -0,0 +1,206 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/farm/types"
+	order "github.com/okex/okchain/x/order/types"
+)
+
+// FundFarmPool sends amount of addr into the farm pool of the product
+func (k Keeper) FundFarmPool(ctx sdk.Context, product string, amount sdk.DecCoin, addr sdk.AccAddress) sdk.Error {
+	pool, isExist := k.GetFarmPool(ctx, product)
+	if !isExist {
+		return types.ErrFarmPoolNotExist(product)
+	}
+	// a new owner of the token pair takes the pool over with MsgSetFarmPool before funding it
+	if !pool.Owner.Equals(addr) {
+		return types.ErrNotFarmPoolOwner(addr, product)
+	}
+	if amount.Denom != pool.Balance.Denom {
+		return types.ErrInvalidRewardDenom(fmt.Sprintf("%s is not the reward of %s", amount.Denom, product))
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, sdk.DecCoins{amount}); err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s): %s", amount, err.Error()))
+	}
+	pool.Balance = pool.Balance.Add(amount)
+	k.SetFarmPool(ctx, pool)
+	return nil
+}
+
+// WithdrawFarmPool sends amount of the balance of the farm pool of the product back to its owner
+func (k Keeper) WithdrawFarmPool(ctx sdk.Context, product string, amount sdk.DecCoin, addr sdk.AccAddress) sdk.Error {
+	pool, isExist := k.GetFarmPool(ctx, product)
+	if !isExist {
+		return types.ErrFarmPoolNotExist(product)
+	}
+	// the owner of the pool can still withdraw after the token pair is delisted
+	if !pool.Owner.Equals(addr) {
+		return types.ErrNotFarmPoolOwner(addr, product)
+	}
+	if amount.Denom != pool.Balance.Denom {
+		return types.ErrInvalidRewardDenom(fmt.Sprintf("%s is not the reward of %s", amount.Denom, product))
+	}
+	if pool.Balance.IsLT(amount) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("only %s is left in the farm pool of %s", pool.Balance, product))
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, sdk.DecCoins{amount}); err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("failed to withdraw %s: %s", amount, err.Error()))
+	}
+	pool.Balance = pool.Balance.Sub(amount)
+	k.SetFarmPool(ctx, pool)
+	return nil
+}
+
+// HandOverFarmPool refunds the balance of the farm pool to its owner and makes owner the new owner of the pool.
+// It's used when the token pair has been transferred, so that the new owner can't withdraw what the previous one funded
+func (k Keeper) HandOverFarmPool(ctx sdk.Context, pool types.FarmPool, owner sdk.AccAddress) (types.FarmPool, sdk.Error) {
+	if pool.Balance.IsPositive() {
+		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, pool.Owner, sdk.DecCoins{pool.Balance})
+		if err != nil {
+			return pool, sdk.ErrInternal(fmt.Sprintf("failed to refund %s of the farm pool of %s to %s: %s",
+				pool.Balance, pool.Product, pool.Owner, err.Error()))
+		}
+		pool.Balance = sdk.NewDecCoinFromDec(pool.Balance.Denom, sdk.ZeroDec())
+	}
+	pool.Owner = owner
+	k.SetFarmPool(ctx, pool)
+	return pool, nil
+}
+
+// ClaimReward sends all the rewards accrued by addr to it
+func (k Keeper) ClaimReward(ctx sdk.Context, addr sdk.AccAddress) (sdk.DecCoins, sdk.Error) {
+	coins := k.GetReward(ctx, addr)
+	if coins.IsZero() {
+		return nil, types.ErrNoReward(addr)
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to send rewards %s: %s", coins, err.Error()))
+	}
+	k.SetReward(ctx, addr, nil)
+	return coins, nil
+}
+
+// DistributeRewards distributes the block rewards of every farm pool to the makers of the orders resting within
+// the max spread of the mid price, weighted by the remaining quantities and the time they rest in the book.
+// The pools of the delisted token pairs are closed, and their balances are refunded to their owners
+func (k Keeper) DistributeRewards(ctx sdk.Context) {
+	timeWeightPeriod := k.GetParams(ctx).TimeWeightPeriod
+	for _, pool := range k.GetFarmPools(ctx) {
+		if k.dexKeeper.GetTokenPair(ctx, pool.Product) == nil {
+			k.closeFarmPool(ctx, pool)
+			continue
+		}
+		blockReward := pool.BlockReward()
+		if !blockReward.IsPositive() {
+			continue
+		}
+
+		weights, totalWeight := k.getMakerWeights(ctx, pool, timeWeightPeriod)
+		if !totalWeight.IsPositive() {
+			continue
+		}
+
+		distributed := sdk.ZeroDec()
+		for _, weight := range weights {
+			amount := blockReward.Amount.MulTruncate(weight.weight).QuoTruncate(totalWeight)
+			if !amount.IsPositive() {
+				continue
+			}
+			reward := sdk.DecCoins{sdk.NewDecCoinFromDec(blockReward.Denom, amount)}
+			k.SetReward(ctx, weight.addr, k.GetReward(ctx, weight.addr).Add(reward))
+			distributed = distributed.Add(amount)
+		}
+
+		pool.Balance.Amount = pool.Balance.Amount.Sub(distributed)
+		k.SetFarmPool(ctx, pool)
+	}
+}
+
+// closeFarmPool refunds the balance of the farm pool to its owner and deletes it
+func (k Keeper) closeFarmPool(ctx sdk.Context, pool types.FarmPool) {
+	if pool.Balance.IsPositive() {
+		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, pool.Owner, sdk.DecCoins{pool.Balance})
+		if err != nil {
+			// the pool is kept to be withdrawn by its owner
+			ctx.Logger().Error(fmt.Sprintf("failed to refund %s of the farm pool of %s to %s: %s",
+				pool.Balance, pool.Product, pool.Owner, err.Error()))
+			return
+		}
+	}
+	k.DeleteFarmPool(ctx, pool.Product)
+}
+
+type makerWeight struct {
+	addr   sdk.AccAddress
+	weight sdk.Dec
+}
+
+// getMakerWeights returns the weights of the makers with orders rewarded by the pool, sorted by address
+func (k Keeper) getMakerWeights(ctx sdk.Context, pool types.FarmPool, timeWeightPeriod int64) (
+	weights []makerWeight, totalWeight sdk.Dec) {
+
+	totalWeight = sdk.ZeroDec()
+	depthBook := k.orderKeeper.GetDepthBookFromDB(ctx, pool.Product)
+	midPrice, ok := getMidPrice(depthBook)
+	if !ok {
+		return nil, totalWeight
+	}
+
+	now := ctx.BlockHeader().Time.Unix()
+	makers := make(map[string]sdk.Dec)
+	for _, item := range depthBook.Items {
+		if !pool.IsInSpread(item.Price, midPrice) {
+			continue
+		}
+		for _, side := range []string{order.BuyOrder, order.SellOrder} {
+			key := order.FormatOrderIDsKey(pool.Product, item.Price, side)
+			for _, orderID := range k.orderKeeper.GetProductPriceOrderIDsFromDB(ctx, key) {
+				o := k.orderKeeper.GetOrder(ctx, orderID)
+				if o == nil || o.Status != order.OrderStatusOpen {
+					continue
+				}
+				weight := types.CalculateOrderWeight(o.RemainQuantity, now-o.Timestamp, timeWeightPeriod)
+				if old, ok := makers[o.Sender.String()]; ok {
+					weight = weight.Add(old)
+				}
+				makers[o.Sender.String()] = weight
+			}
+		}
+	}
+
+	for addr, weight := range makers {
+		accAddr, err := sdk.AccAddressFromBech32(addr)
+		if err != nil {
+			continue
+		}
+		weights = append(weights, makerWeight{accAddr, weight})
+		totalWeight = totalWeight.Add(weight)
+	}
+	sort.Slice(weights, func(i, j int) bool {
+		return weights[i].addr.String() < weights[j].addr.String()
+	})
+	return weights, totalWeight
+}
+
+// getMidPrice returns the middle of the best bid and the best ask of the depth book sorted by price desc
+func getMidPrice(depthBook *order.DepthBook) (sdk.Dec, bool) {
+	var bestBid, bestAsk sdk.Dec
+	for _, item := range depthBook.Items {
+		if item.SellQuantity.IsPositive() {
+			bestAsk = item.Price
+		}
+		if item.BuyQuantity.IsPositive() && bestBid.IsNil() {
+			bestBid = item.Price
+		}
+	}
+	if bestBid.IsNil() || bestAsk.IsNil() {
+		return sdk.Dec{}, false
+	}
+	return bestBid.Add(bestAsk).QuoTruncate(sdk.NewDec(2)), true
+}
@@ -0,0 +1,159 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/farm/types"
+	order "github.com/okex/okchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func initFarmPool(t *testing.T, testInput TestInput, rewardPerBlock, balance sdk.DecCoin) {
+	ctx, keeper := testInput.Ctx, testInput.FarmKeeper
+	keeper.SetFarmPool(ctx, types.NewFarmPool(TestProduct, testInput.TestAddrs[0], rewardPerBlock,
+		sdk.MustNewDecFromStr("0.1")))
+	require.Nil(t, keeper.FundFarmPool(ctx, TestProduct, balance, testInput.TestAddrs[0]))
+}
+
+func newTestOrder(id string, sender sdk.AccAddress, side, price, quantity string, timestamp int64) *order.Order {
+	o := order.NewOrder(id, sender, TestProduct, side, sdk.MustNewDecFromStr(price),
+		sdk.MustNewDecFromStr(quantity), timestamp, 0, sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec()))
+	o.OrderID = id
+	return o
+}
+
+func TestKeeper_FundFarmPool(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.FarmKeeper
+	addr := testInput.TestAddrs[0]
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100))
+
+	// fail case : the pool not exist
+	err := keeper.FundFarmPool(ctx, TestProduct, amount, addr)
+	require.Equal(t, types.CodeFarmPoolNotExist, err.Code())
+
+	keeper.SetFarmPool(ctx, types.NewFarmPool(TestProduct, addr, sdk.NewDecCoinFromDec(common.NativeToken,
+		sdk.OneDec()), sdk.MustNewDecFromStr("0.1")))
+
+	// fail case : the denom is not the reward of the pool
+	err = keeper.FundFarmPool(ctx, TestProduct, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(100)), addr)
+	require.Equal(t, types.CodeInvalidRewardDenom, err.Code())
+
+	// fail case : insufficient coins
+	err = keeper.FundFarmPool(ctx, TestProduct, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10000)), addr)
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+
+	// successful case
+	require.Nil(t, keeper.FundFarmPool(ctx, TestProduct, amount, addr))
+	pool, isExist := keeper.GetFarmPool(ctx, TestProduct)
+	require.True(t, isExist)
+	require.Equal(t, amount, pool.Balance)
+	require.Equal(t, types.FarmPools{pool}, keeper.GetFarmPools(ctx))
+	require.Equal(t, sdk.NewDec(900), testInput.TokenKeeper.GetCoins(ctx, addr).AmountOf(common.NativeToken))
+}
+
+func TestKeeper_DistributeRewards(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.FarmKeeper
+	addrs := testInput.TestAddrs
+	now := time.Now()
+	ctx = ctx.WithBlockTime(now)
+
+	initFarmPool(t, testInput, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(15)))
+
+	// no reward without the mid price
+	testInput.OrderKeeper.InsertOrder(newTestOrder("ID1", addrs[0], order.BuyOrder, "9.8", "1", now.Unix()))
+	keeper.DistributeRewards(ctx)
+	require.True(t, keeper.GetReward(ctx, addrs[0]).IsZero())
+
+	// the mid price is 10, and the orders out of [9, 11] are not rewarded
+	testInput.OrderKeeper.InsertOrder(newTestOrder("ID2", addrs[1], order.SellOrder, "10.2", "2", now.Unix()))
+	testInput.OrderKeeper.InsertOrder(newTestOrder("ID3", addrs[0], order.SellOrder, "11.5", "100", now.Unix()))
+	testInput.OrderKeeper.InsertOrder(newTestOrder("ID4", addrs[1], order.BuyOrder, "8", "100", now.Unix()))
+	keeper.DistributeRewards(ctx)
+	require.Equal(t, sdk.MustNewDecFromStr("3.33333333"), keeper.GetReward(ctx, addrs[0]).AmountOf(common.NativeToken))
+	require.Equal(t, sdk.MustNewDecFromStr("6.66666666"), keeper.GetReward(ctx, addrs[1]).AmountOf(common.NativeToken))
+	pool, _ := keeper.GetFarmPool(ctx, TestProduct)
+	require.Equal(t, sdk.MustNewDecFromStr("5.00000001"), pool.Balance.Amount)
+
+	// the order resting for the time weight period gets double weight, and the rest of the balance is distributed
+	ctx = ctx.WithBlockTime(now.Add(time.Duration(types.DefaultTimeWeightPeriod) * time.Second))
+	testInput.OrderKeeper.InsertOrder(newTestOrder("ID5", addrs[0], order.BuyOrder, "9.9", "1",
+		ctx.BlockHeader().Time.Unix()))
+	keeper.DistributeRewards(ctx)
+	pool, _ = keeper.GetFarmPool(ctx, TestProduct)
+	require.True(t, pool.Balance.Amount.LT(sdk.MustNewDecFromStr("0.00000002")))
+	// addrs[0]: 1*2 + 1*1 = 3, addrs[1]: 2*2 = 4
+	require.Equal(t, sdk.MustNewDecFromStr("5.47619047"), keeper.GetReward(ctx, addrs[0]).AmountOf(common.NativeToken))
+
+	// claim all the rewards
+	coins, err := keeper.ClaimReward(ctx, addrs[1])
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("9.52380952"), coins.AmountOf(common.NativeToken))
+	require.True(t, keeper.GetReward(ctx, addrs[1]).IsZero())
+	require.Equal(t, sdk.NewDec(1000).Add(coins.AmountOf(common.NativeToken)),
+		testInput.TokenKeeper.GetCoins(ctx, addrs[1]).AmountOf(common.NativeToken))
+	_, err = keeper.ClaimReward(ctx, addrs[1])
+	require.Equal(t, types.CodeNoReward, err.Code())
+}
+
+func TestKeeper_WithdrawFarmPool(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.FarmKeeper
+	owner := testInput.TestAddrs[0]
+	amount := sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(40))
+
+	// fail case : the pool not exist
+	err := keeper.WithdrawFarmPool(ctx, TestProduct, amount, owner)
+	require.Equal(t, types.CodeFarmPoolNotExist, err.Code())
+
+	initFarmPool(t, testInput, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)))
+
+	// fail case : not the owner of the pool
+	err = keeper.WithdrawFarmPool(ctx, TestProduct, amount, testInput.TestAddrs[1])
+	require.Equal(t, types.CodeNotFarmPoolOwner, err.Code())
+
+	// fail case : the denom is not the reward of the pool
+	err = keeper.WithdrawFarmPool(ctx, TestProduct, sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1)), owner)
+	require.Equal(t, types.CodeInvalidRewardDenom, err.Code())
+
+	// fail case : more than the balance
+	err = keeper.WithdrawFarmPool(ctx, TestProduct, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(101)), owner)
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+
+	// successful case
+	require.Nil(t, keeper.WithdrawFarmPool(ctx, TestProduct, amount, owner))
+	pool, _ := keeper.GetFarmPool(ctx, TestProduct)
+	require.Equal(t, sdk.NewDec(60), pool.Balance.Amount)
+	require.Equal(t, sdk.NewDec(940), testInput.TokenKeeper.GetCoins(ctx, owner).AmountOf(common.NativeToken))
+
+	// the owner can withdraw the rest after the token pair is delisted
+	testInput.DexKeeper.DeleteTokenPairByName(ctx, owner, TestProduct)
+	require.Nil(t, keeper.WithdrawFarmPool(ctx, TestProduct, pool.Balance, owner))
+	pool, _ = keeper.GetFarmPool(ctx, TestProduct)
+	require.True(t, pool.Balance.Amount.IsZero())
+	require.Equal(t, sdk.NewDec(1000), testInput.TokenKeeper.GetCoins(ctx, owner).AmountOf(common.NativeToken))
+}
+
+func TestKeeper_DistributeRewardsDelisted(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.FarmKeeper
+	owner := testInput.TestAddrs[0]
+	initFarmPool(t, testInput, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100)))
+	require.Equal(t, sdk.NewDec(900), testInput.TokenKeeper.GetCoins(ctx, owner).AmountOf(common.NativeToken))
+
+	// the balance of the pool of the delisted token pair is refunded to the owner, and the pool is closed
+	testInput.DexKeeper.DeleteTokenPairByName(ctx, owner, TestProduct)
+	keeper.DistributeRewards(ctx)
+	_, isExist := keeper.GetFarmPool(ctx, TestProduct)
+	require.False(t, isExist)
+	require.Nil(t, keeper.GetFarmPools(ctx))
+	require.Equal(t, sdk.NewDec(1000), testInput.TokenKeeper.GetCoins(ctx, owner).AmountOf(common.NativeToken))
+	require.True(t, testInput.SupplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins().IsZero())
+}
@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/farm/types"
+	"github.com/okex/okchain/x/params"
+)
+
+// Keeper maintains the link to data storage and exposes getter/setter methods for the various parts of the state machine
+type Keeper struct {
+	supplyKeeper  SupplyKeeper
+	dexKeeper     DexKeeper
+	orderKeeper   OrderKeeper
+	storeKey      sdk.StoreKey
+	paramSubspace params.Subspace // The reference to the Paramstore to get and set gov modifiable params
+	cdc           *codec.Codec    // The wire codec for binary encoding/decoding.
+}
+
+// NewKeeper creates new instances of the farm Keeper
+func NewKeeper(supplyKeeper SupplyKeeper, dexKeeper DexKeeper, orderKeeper OrderKeeper, storeKey sdk.StoreKey,
+	paramSubspace params.Subspace, cdc *codec.Codec) Keeper {
+	return Keeper{
+		supplyKeeper:  supplyKeeper,
+		dexKeeper:     dexKeeper,
+		orderKeeper:   orderKeeper,
+		storeKey:      storeKey,
+		paramSubspace: paramSubspace.WithKeyTable(types.ParamKeyTable()),
+		cdc:           cdc,
+	}
+}
+
+func (k Keeper) GetSupplyKeeper() SupplyKeeper {
+	return k.supplyKeeper
+}
+
+func (k Keeper) GetDexKeeper() DexKeeper {
+	return k.dexKeeper
+}
+
+func (k Keeper) GetOrderKeeper() OrderKeeper {
+	return k.orderKeeper
+}
+
+func (k Keeper) GetCDC() *codec.Codec {
+	return k.cdc
+}
+
+// GetFarmPool returns the farm pool of the product
+func (k Keeper) GetFarmPool(ctx sdk.Context, product string) (pool types.FarmPool, isExist bool) {
+	bytes := ctx.KVStore(k.storeKey).Get(types.GetFarmPoolKey(product))
+	if bytes == nil {
+		return
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bytes, &pool)
+	return pool, true
+}
+
+// SetFarmPool saves the farm pool of the product to store
+func (k Keeper) SetFarmPool(ctx sdk.Context, pool types.FarmPool) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetFarmPoolKey(pool.Product), k.cdc.MustMarshalBinaryLengthPrefixed(pool))
+}
+
+// DeleteFarmPool deletes the farm pool of the product from store
+func (k Keeper) DeleteFarmPool(ctx sdk.Context, product string) {
+	ctx.KVStore(k.storeKey).Delete(types.GetFarmPoolKey(product))
+}
+
+// GetFarmPools returns all the farm pools ordered by product
+func (k Keeper) GetFarmPools(ctx sdk.Context) (pools types.FarmPools) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.FarmPoolPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var pool types.FarmPool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &pool)
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
+// GetReward returns the rewards accrued by addr
+func (k Keeper) GetReward(ctx sdk.Context, addr sdk.AccAddress) sdk.DecCoins {
+	bytes := ctx.KVStore(k.storeKey).Get(types.GetRewardKey(addr))
+	if bytes == nil {
+		return sdk.DecCoins{}
+	}
+
+	var coins sdk.DecCoins
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bytes, &coins)
+	return coins
+}
+
+// SetReward saves the rewards accrued by addr to store, and deletes them if empty
+func (k Keeper) SetReward(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins) {
+	store := ctx.KVStore(k.storeKey)
+	if coins.IsZero() {
+		store.Delete(types.GetRewardKey(addr))
+		return
+	}
+	store.Set(types.GetRewardKey(addr), k.cdc.MustMarshalBinaryLengthPrefixed(coins))
+}
+
+// GetRewards returns the rewards accrued by all the addresses
+func (k Keeper) GetRewards(ctx sdk.Context) (rewards types.Rewards) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.RewardPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var coins sdk.DecCoins
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &coins)
+		rewards = append(rewards, types.Reward{
+			Address: sdk.AccAddress(iterator.Key()[len(types.RewardPrefix):]),
+			Coins:   coins,
+		})
+	}
+	return rewards
+}
+
+// GetParams gets the params of farm module
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSubspace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the params of farm module
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSubspace.SetParamSet(ctx, &params)
+}
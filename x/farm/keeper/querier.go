@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/farm/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case types.QueryFarmPool:
+			return queryFarmPool(ctx, path[1:], keeper)
+		case types.QueryFarmPools:
+			return queryFarmPools(ctx, keeper)
+		case types.QueryRewards:
+			return queryRewards(ctx, path[1:], keeper)
+		case types.QueryParameters:
+			return queryParams(ctx, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown farm query endpoint")
+		}
+	}
+}
+
+// nolint: unparam
+func queryFarmPool(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("product is required")
+	}
+
+	pool, isExist := keeper.GetFarmPool(ctx, path[0])
+	if !isExist {
+		return nil, types.ErrFarmPoolNotExist(path[0])
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), pool)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+// nolint: unparam
+func queryFarmPools(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	pools := keeper.GetFarmPools(ctx)
+	if pools == nil {
+		pools = types.FarmPools{}
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), pools)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+// queryRewards returns the rewards accrued by the address and not claimed yet
+func queryRewards(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("address is required")
+	}
+
+	addr, errAddr := sdk.AccAddressFromBech32(path[0])
+	if errAddr != nil {
+		return nil, sdk.ErrInvalidAddress(path[0])
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), types.Reward{Address: addr, Coins: keeper.GetReward(ctx, addr)})
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+// nolint: unparam
+func queryParams(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), keeper.GetParams(ctx))
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
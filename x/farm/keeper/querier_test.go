@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/farm/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestQuerier(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.FarmKeeper
+	querier := NewQuerier(keeper)
+
+	// fail case : the pool not exist
+	_, err := querier(ctx, []string{types.QueryFarmPool, TestProduct}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	initFarmPool(t, testInput, sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10)),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(15)))
+
+	res, err := querier(ctx, []string{types.QueryFarmPool, TestProduct}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var pool types.FarmPool
+	testInput.Cdc.MustUnmarshalJSON(res, &pool)
+	require.Equal(t, TestProduct, pool.Product)
+
+	res, err = querier(ctx, []string{types.QueryFarmPools}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var pools types.FarmPools
+	testInput.Cdc.MustUnmarshalJSON(res, &pools)
+	require.Equal(t, 1, len(pools))
+
+	// the rewards accrued by the address
+	rewardCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1))}
+	keeper.SetReward(ctx, testInput.TestAddrs[1], rewardCoins)
+	res, err = querier(ctx, []string{types.QueryRewards, testInput.TestAddrs[1].String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var reward types.Reward
+	testInput.Cdc.MustUnmarshalJSON(res, &reward)
+	require.Equal(t, rewardCoins, reward.Coins)
+
+	// fail case : invalid address
+	_, err = querier(ctx, []string{types.QueryRewards, "invalid"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	res, err = querier(ctx, []string{types.QueryParameters}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var params types.Params
+	testInput.Cdc.MustUnmarshalJSON(res, &params)
+	require.Equal(t, types.DefaultParams(), params)
+
+	// fail case : unknown endpoint
+	_, err = querier(ctx, []string{"unknown"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
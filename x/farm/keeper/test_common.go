@@ -0,0 +1,202 @@
+package keeper
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okchain/x/params"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/farm/types"
+	order "github.com/okex/okchain/x/order/types"
+	"github.com/okex/okchain/x/token"
+)
+
+// TestProduct is the product of the token pair listed in test input
+var TestProduct = fmt.Sprintf("%s_%s", common.TestToken, common.NativeToken)
+
+// MockOrderKeeper keeps the resting orders in memory
+type MockOrderKeeper struct {
+	orders     map[string]*order.Order
+	depthBooks map[string]*order.DepthBook
+	orderIDs   map[string][]string
+}
+
+func NewMockOrderKeeper() *MockOrderKeeper {
+	return &MockOrderKeeper{
+		orders:     make(map[string]*order.Order),
+		depthBooks: make(map[string]*order.DepthBook),
+		orderIDs:   make(map[string][]string),
+	}
+}
+
+// InsertOrder puts the order into the depth book of its product
+func (k *MockOrderKeeper) InsertOrder(o *order.Order) {
+	k.orders[o.OrderID] = o
+	if _, ok := k.depthBooks[o.Product]; !ok {
+		k.depthBooks[o.Product] = &order.DepthBook{}
+	}
+	k.depthBooks[o.Product].InsertOrder(o)
+	key := order.FormatOrderIDsKey(o.Product, o.Price, o.Side)
+	k.orderIDs[key] = append(k.orderIDs[key], o.OrderID)
+}
+
+func (k *MockOrderKeeper) GetOrder(ctx sdk.Context, orderID string) *order.Order {
+	return k.orders[orderID]
+}
+
+func (k *MockOrderKeeper) GetDepthBookFromDB(ctx sdk.Context, product string) *order.DepthBook {
+	if book, ok := k.depthBooks[product]; ok {
+		return book
+	}
+	return &order.DepthBook{}
+}
+
+func (k *MockOrderKeeper) GetProductPriceOrderIDsFromDB(ctx sdk.Context, key string) []string {
+	return k.orderIDs[key]
+}
+
+type TestInput struct {
+	Ctx       sdk.Context
+	Cdc       *codec.Codec
+	TestAddrs []sdk.AccAddress
+
+	FarmKeeper   Keeper
+	TokenKeeper  token.Keeper
+	SupplyKeeper supply.Keeper
+	DexKeeper    dex.Keeper
+	OrderKeeper  *MockOrderKeeper
+}
+
+// create a codec used only for testing
+func MakeTestCodec() *codec.Codec {
+	var cdc = codec.New()
+	bank.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	dex.RegisterCodec(cdc)
+	token.RegisterCodec(cdc)
+	types.RegisterCodec(cdc) // farm
+	return cdc
+}
+
+func CreateTestInputWithBalance(t *testing.T, numAddrs, initQuantity int64) TestInput {
+	db := dbm.NewMemDB()
+
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	// token module
+	keyToken := sdk.NewKVStoreKey(token.StoreKey)
+	keyLock := sdk.NewKVStoreKey(token.KeyLock)
+
+	// dex module
+	keyDex := sdk.NewKVStoreKey(dex.StoreKey)
+	keyTokenPair := sdk.NewKVStoreKey(dex.TokenPairStoreKey)
+
+	// farm module
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+
+	ms.MountStoreWithDB(keyToken, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyLock, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyDex, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyTokenPair, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, db)
+
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(0, 0)}, false, log.NewTMLogger(os.Stdout))
+	cdc := MakeTestCodec()
+
+	feeCollectorAcc := supply.NewEmptyModuleAccount(auth.FeeCollectorName)
+
+	blacklistedAddrs := make(map[string]bool)
+	blacklistedAddrs[feeCollectorAcc.String()] = true
+
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc,
+		paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := token.NewFreezableBankKeeper(bank.NewBaseKeeper(accountKeeper,
+		paramsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, blacklistedAddrs), keyToken, keyLock)
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName: nil,
+		token.ModuleName:      {supply.Minter, supply.Burner},
+		dex.ModuleName:        nil,
+		types.ModuleName:      nil,
+	}
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.Coins{}))
+
+	// set module accounts
+	supplyKeeper.SetModuleAccount(ctx, feeCollectorAcc)
+
+	// token keeper
+	tokenKeeper := token.NewKeeper(bankKeeper, paramsKeeper,
+		paramsKeeper.Subspace(token.DefaultParamspace), auth.FeeCollectorName, supplyKeeper,
+		keyToken, keyLock, cdc, true)
+
+	// dex keeper
+	dexKeeper := dex.NewKeeper(auth.FeeCollectorName, supplyKeeper, paramsKeeper.Subspace(dex.DefaultParamspace),
+		tokenKeeper, nil, bankKeeper, keyDex, keyTokenPair, cdc)
+	err = dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	// farm keeper
+	orderKeeper := NewMockOrderKeeper()
+	farmKeeper := NewKeeper(supplyKeeper, dexKeeper, orderKeeper, storeKey,
+		paramsKeeper.Subspace(types.DefaultParamspace), cdc)
+	farmKeeper.SetParams(ctx, types.DefaultParams())
+
+	// init account tokens
+	initCoins, err := sdk.ParseDecCoins(fmt.Sprintf("%d%s,%d%s",
+		initQuantity, common.NativeToken, initQuantity, common.TestToken))
+	require.Nil(t, err)
+
+	var testAddrs []sdk.AccAddress
+	for i := int64(0); i < numAddrs; i++ {
+		pk := ed25519.GenPrivKey().PubKey()
+		addr := sdk.AccAddress(pk.Address())
+		testAddrs = append(testAddrs, addr)
+		err := supplyKeeper.MintCoins(ctx, token.ModuleName, initCoins)
+		require.Nil(t, err)
+		err = supplyKeeper.SendCoinsFromModuleToAccount(ctx, token.ModuleName, addr, initCoins)
+		require.Nil(t, err)
+	}
+
+	// the first address owns the token pair
+	tokenPair := dexKeeper.GetTokenPair(ctx, TestProduct)
+	tokenPair.Owner = testAddrs[0]
+	dexKeeper.UpdateTokenPair(ctx, TestProduct, tokenPair)
+
+	return TestInput{ctx, cdc, testAddrs, farmKeeper, tokenKeeper, supplyKeeper, dexKeeper, orderKeeper}
+}
+
+func CreateTestInput(t *testing.T) TestInput {
+	return CreateTestInputWithBalance(t, 2, 1000)
+}
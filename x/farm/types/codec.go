@@ -0,0 +1,21 @@
+package types
+
+import "github.com/cosmos/cosmos-sdk/codec"
+
+// RegisterCodec registers concrete types on the Amino codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgSetFarmPool{}, "okchain/farm/MsgSetFarmPool", nil)
+	cdc.RegisterConcrete(MsgFundFarmPool{}, "okchain/farm/MsgFundFarmPool", nil)
+	cdc.RegisterConcrete(MsgWithdrawFarmPool{}, "okchain/farm/MsgWithdrawFarmPool", nil)
+	cdc.RegisterConcrete(MsgClaimReward{}, "okchain/farm/MsgClaimReward", nil)
+}
+
+// ModuleCdc represents generic sealed codec to be used throughout this module
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// const CodeType
+const (
+	CodeFarmPoolNotExist   sdk.CodeType = 1
+	CodeTokenPairNotListed sdk.CodeType = 2
+	CodeNotTokenPairOwner  sdk.CodeType = 3
+	CodeInvalidRewardDenom sdk.CodeType = 4
+	CodeNoReward           sdk.CodeType = 5
+	CodeNotFarmPoolOwner   sdk.CodeType = 6
+)
+
+// CodeType to Message
+func CodeToDefaultMsg(code sdk.CodeType) string {
+	switch code {
+	case CodeFarmPoolNotExist:
+		return "farm pool not exist"
+	case CodeTokenPairNotListed:
+		return "token pair not listed on dex"
+	case CodeNotTokenPairOwner:
+		return "not the owner of the token pair"
+	case CodeInvalidRewardDenom:
+		return "invalid reward denom"
+	case CodeNoReward:
+		return "no reward to claim"
+	case CodeNotFarmPoolOwner:
+		return "not the owner of the farm pool"
+	default:
+		return fmt.Sprintf("unknown code %d", code)
+	}
+}
+
+func ErrFarmPoolNotExist(product string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeFarmPoolNotExist, CodeToDefaultMsg(CodeFarmPoolNotExist)+": %s", product)
+}
+
+func ErrTokenPairNotListed(product string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeTokenPairNotListed, CodeToDefaultMsg(CodeTokenPairNotListed)+": %s", product)
+}
+
+func ErrNotTokenPairOwner(addr sdk.AccAddress, product string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeNotTokenPairOwner, CodeToDefaultMsg(CodeNotTokenPairOwner)+": %s is not the owner of %s", addr, product)
+}
+
+func ErrInvalidRewardDenom(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidRewardDenom, CodeToDefaultMsg(CodeInvalidRewardDenom)+": %s", msg)
+}
+
+func ErrNoReward(addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeNoReward, CodeToDefaultMsg(CodeNoReward)+": %s", addr)
+}
+
+func ErrNotFarmPoolOwner(addr sdk.AccAddress, product string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeNotFarmPoolOwner, CodeToDefaultMsg(CodeNotFarmPoolOwner)+": %s is not the owner of the farm pool of %s", addr, product)
+}
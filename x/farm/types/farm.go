@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FarmPool is the incentive program of a token pair, which rewards the orders resting near the mid price
+type FarmPool struct {
+	Product        string         `json:"product"`
+	Owner          sdk.AccAddress `json:"owner"`
+	RewardPerBlock sdk.DecCoin    `json:"reward_per_block"` // rewards distributed in each block
+	MaxSpread      sdk.Dec        `json:"max_spread"`       // max distance of the rewarded orders from the mid price, as a ratio of it
+	Balance        sdk.DecCoin    `json:"balance"`          // rewards left in the pool
+}
+
+// NewFarmPool creates a farm pool without balance
+func NewFarmPool(product string, owner sdk.AccAddress, rewardPerBlock sdk.DecCoin, maxSpread sdk.Dec) FarmPool {
+	return FarmPool{
+		Product:        product,
+		Owner:          owner,
+		RewardPerBlock: rewardPerBlock,
+		MaxSpread:      maxSpread,
+		Balance:        sdk.NewDecCoinFromDec(rewardPerBlock.Denom, sdk.ZeroDec()),
+	}
+}
+
+// String implements fmt.Stringer
+func (pool FarmPool) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`FarmPool:
+  Product:          %s
+  Owner:            %s
+  RewardPerBlock:   %s
+  MaxSpread:        %s
+  Balance:          %s`,
+		pool.Product, pool.Owner, pool.RewardPerBlock, pool.MaxSpread, pool.Balance))
+}
+
+// BlockReward returns the rewards to be distributed in a block, which are limited by the balance
+func (pool FarmPool) BlockReward() sdk.DecCoin {
+	if pool.Balance.IsLT(pool.RewardPerBlock) {
+		return pool.Balance
+	}
+	return pool.RewardPerBlock
+}
+
+// IsInSpread returns whether the price is within the max spread of the mid price
+func (pool FarmPool) IsInSpread(price, midPrice sdk.Dec) bool {
+	return price.Sub(midPrice).Abs().LTE(midPrice.MulTruncate(pool.MaxSpread))
+}
+
+type FarmPools []FarmPool
+
+// String implements fmt.Stringer
+func (pools FarmPools) String() string {
+	strs := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		strs = append(strs, pool.String())
+	}
+	return strings.Join(strs, "\n")
+}
+
+// Reward is the rewards accrued by an address and not claimed yet
+type Reward struct {
+	Address sdk.AccAddress `json:"address"`
+	Coins   sdk.DecCoins   `json:"coins"`
+}
+
+// String implements fmt.Stringer
+func (reward Reward) String() string {
+	return fmt.Sprintf("%s: %s", reward.Address, reward.Coins)
+}
+
+type Rewards []Reward
+
+// CalculateOrderWeight returns the weight of a resting order in the distribution of the rewards, which is its
+// remaining quantity multiplied by a time weight growing from 1 to 2 linearly as the order rests in the book for
+// timeWeightPeriod seconds
+func CalculateOrderWeight(remainQuantity sdk.Dec, restSeconds, timeWeightPeriod int64) sdk.Dec {
+	if restSeconds < 0 {
+		restSeconds = 0
+	}
+	if restSeconds > timeWeightPeriod {
+		restSeconds = timeWeightPeriod
+	}
+	timeWeight := sdk.OneDec()
+	if timeWeightPeriod > 0 {
+		timeWeight = timeWeight.Add(sdk.NewDec(restSeconds).QuoTruncate(sdk.NewDec(timeWeightPeriod)))
+	}
+	return remainQuantity.MulTruncate(timeWeight)
+}
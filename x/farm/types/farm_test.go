@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateOrderWeight(t *testing.T) {
+	quantity := sdk.NewDec(10)
+	require.Equal(t, sdk.NewDec(10), CalculateOrderWeight(quantity, 0, 100))
+	require.Equal(t, sdk.NewDec(10), CalculateOrderWeight(quantity, -5, 100))
+	require.Equal(t, sdk.NewDec(15), CalculateOrderWeight(quantity, 50, 100))
+	require.Equal(t, sdk.NewDec(20), CalculateOrderWeight(quantity, 200, 100))
+	require.Equal(t, sdk.NewDec(10), CalculateOrderWeight(quantity, 200, 0))
+}
+
+func TestFarmPool(t *testing.T) {
+	pool := NewFarmPool("xxb_okt", nil, sdk.NewDecCoinFromDec("okt", sdk.NewDec(10)), sdk.MustNewDecFromStr("0.1"))
+	require.True(t, pool.BlockReward().IsZero())
+	pool.Balance.Amount = sdk.NewDec(5)
+	require.Equal(t, sdk.NewDec(5), pool.BlockReward().Amount)
+	pool.Balance.Amount = sdk.NewDec(50)
+	require.Equal(t, sdk.NewDec(10), pool.BlockReward().Amount)
+
+	require.True(t, pool.IsInSpread(sdk.NewDec(11), sdk.NewDec(10)))
+	require.True(t, pool.IsInSpread(sdk.NewDec(9), sdk.NewDec(10)))
+	require.False(t, pool.IsInSpread(sdk.MustNewDecFromStr("8.99"), sdk.NewDec(10)))
+}
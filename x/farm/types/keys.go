@@ -0,0 +1,41 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the farm module
+	ModuleName        = "farm"
+	DefaultParamspace = ModuleName
+	DefaultCodespace  = ModuleName
+
+	// QuerierRoute is the querier route for the farm module
+	QuerierRoute = ModuleName
+
+	// RouterKey is the msg router key for the farm module
+	RouterKey = ModuleName
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	QueryFarmPool   = "farmPool"
+	QueryFarmPools  = "farmPools"
+	QueryRewards    = "rewards"
+	QueryParameters = "params"
+)
+
+var (
+	FarmPoolPrefix = []byte{0x01} // the prefix of the farm pool's product
+	RewardPrefix   = []byte{0x02} // the prefix of the address with accrued rewards
+)
+
+// GetFarmPoolKey returns store key of the farm pool of the product
+func GetFarmPoolKey(product string) []byte {
+	return append(FarmPoolPrefix, []byte(product)...)
+}
+
+// GetRewardKey returns store key of the accrued rewards of the address
+func GetRewardKey(addr sdk.AccAddress) []byte {
+	return append(RewardPrefix, addr.Bytes()...)
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgSetFarmPool      = "setFarmPool"
+	TypeMsgFundFarmPool     = "fundFarmPool"
+	TypeMsgWithdrawFarmPool = "withdrawFarmPool"
+	TypeMsgClaimReward      = "claimReward"
+)
+
+// MsgSetFarmPool creates or updates the incentive program of a token pair by its owner
+type MsgSetFarmPool struct {
+	Product        string         `json:"product"`
+	RewardPerBlock sdk.DecCoin    `json:"reward_per_block"` // rewards distributed in each block
+	MaxSpread      sdk.Dec        `json:"max_spread"`       // max distance of the rewarded orders from the mid price, as a ratio of it
+	Owner          sdk.AccAddress `json:"owner"`
+}
+
+func NewMsgSetFarmPool(product string, rewardPerBlock sdk.DecCoin, maxSpread sdk.Dec, owner sdk.AccAddress) MsgSetFarmPool {
+	return MsgSetFarmPool{
+		Product:        product,
+		RewardPerBlock: rewardPerBlock,
+		MaxSpread:      maxSpread,
+		Owner:          owner,
+	}
+}
+
+// nolint
+func (msg MsgSetFarmPool) Route() string { return RouterKey }
+func (msg MsgSetFarmPool) Type() string  { return TypeMsgSetFarmPool }
+
+// Implements Msg.
+func (msg MsgSetFarmPool) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Product) == 0 {
+		return sdk.ErrUnknownRequest("product cannot be empty")
+	}
+	if !msg.RewardPerBlock.IsValid() || !msg.RewardPerBlock.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.RewardPerBlock.String())
+	}
+	if msg.MaxSpread.IsNil() || !msg.MaxSpread.IsPositive() || msg.MaxSpread.GT(sdk.OneDec()) {
+		return sdk.ErrUnknownRequest("max spread should be in (0, 1]")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgSetFarmPool) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgSetFarmPool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgFundFarmPool adds rewards into the farm pool of a token pair by its owner
+type MsgFundFarmPool struct {
+	Product string         `json:"product"`
+	Amount  sdk.DecCoin    `json:"amount"`
+	Owner   sdk.AccAddress `json:"owner"`
+}
+
+func NewMsgFundFarmPool(product string, amount sdk.DecCoin, owner sdk.AccAddress) MsgFundFarmPool {
+	return MsgFundFarmPool{
+		Product: product,
+		Amount:  amount,
+		Owner:   owner,
+	}
+}
+
+// nolint
+func (msg MsgFundFarmPool) Route() string { return RouterKey }
+func (msg MsgFundFarmPool) Type() string  { return TypeMsgFundFarmPool }
+
+// Implements Msg.
+func (msg MsgFundFarmPool) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Product) == 0 {
+		return sdk.ErrUnknownRequest("product cannot be empty")
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgFundFarmPool) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgFundFarmPool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgWithdrawFarmPool takes back rewards left in a farm pool by its owner
+type MsgWithdrawFarmPool struct {
+	Product string         `json:"product"`
+	Amount  sdk.DecCoin    `json:"amount"`
+	Owner   sdk.AccAddress `json:"owner"`
+}
+
+func NewMsgWithdrawFarmPool(product string, amount sdk.DecCoin, owner sdk.AccAddress) MsgWithdrawFarmPool {
+	return MsgWithdrawFarmPool{
+		Product: product,
+		Amount:  amount,
+		Owner:   owner,
+	}
+}
+
+// nolint
+func (msg MsgWithdrawFarmPool) Route() string { return RouterKey }
+func (msg MsgWithdrawFarmPool) Type() string  { return TypeMsgWithdrawFarmPool }
+
+// Implements Msg.
+func (msg MsgWithdrawFarmPool) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Product) == 0 {
+		return sdk.ErrUnknownRequest("product cannot be empty")
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgWithdrawFarmPool) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgWithdrawFarmPool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgClaimReward claims all the rewards accrued by the address
+type MsgClaimReward struct {
+	Address sdk.AccAddress `json:"address"`
+}
+
+func NewMsgClaimReward(address sdk.AccAddress) MsgClaimReward {
+	return MsgClaimReward{
+		Address: address,
+	}
+}
+
+// nolint
+func (msg MsgClaimReward) Route() string { return RouterKey }
+func (msg MsgClaimReward) Type() string  { return TypeMsgClaimReward }
+
+// Implements Msg.
+func (msg MsgClaimReward) ValidateBasic() sdk.Error {
+	if msg.Address.Empty() {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgClaimReward) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgClaimReward) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+	"github.com/okex/okchain/x/params"
+)
+
+const (
+	// DefaultTimeWeightPeriod is one day in seconds
+	DefaultTimeWeightPeriod = 24 * 60 * 60
+)
+
+var (
+	KeyTimeWeightPeriod = []byte("TimeWeightPeriod")
+)
+
+// Params defines the parameters of the farm module
+type Params struct {
+	// seconds for a resting order to reach the max time weight, which doubles its share of rewards
+	TimeWeightPeriod int64 `json:"time_weight_period"`
+}
+
+func (p *Params) ParamSetPairs() subspace.ParamSetPairs {
+	return params.ParamSetPairs{
+		{Key: KeyTimeWeightPeriod, Value: &p.TimeWeightPeriod},
+	}
+}
+
+// ParamKeyTable for farm module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns a default set of parameters.
+func DefaultParams() Params {
+	return Params{
+		TimeWeightPeriod: DefaultTimeWeightPeriod,
+	}
+}
+
+// Validate checks the time weight period is not negative
+func (p Params) Validate() error {
+	if p.TimeWeightPeriod < 0 {
+		return fmt.Errorf("invalid time weight period: %d", p.TimeWeightPeriod)
+	}
+	return nil
+}
+
+// String implements the stringer interface.
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params: \n")
+	sb.WriteString(fmt.Sprintf("TimeWeightPeriod:%d\n", p.TimeWeightPeriod))
+	return sb.String()
+}
@@ -12,11 +12,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
 	extypes "github.com/cosmos/cosmos-sdk/x/genutil"
+	v010 "github.com/okex/okchain/x/genutil/legacy/v0_10"
 	v09 "github.com/okex/okchain/x/genutil/legacy/v0_9"
 )
 
 var migrationMap = extypes.MigrationMap{
-	"v0.9": v09.Migrate,
+	"v0.9":  v09.Migrate,
+	"v0.10": v010.Migrate,
 }
 
 const (
@@ -0,0 +1,29 @@
+package v0_10
+
+import (
+	v010token "github.com/okex/okchain/x/token/legacy/v0_10"
+	v09token "github.com/okex/okchain/x/token/legacy/v0_9"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+)
+
+// Migrate migrates exported state from v0.9 to a v0.10 genesis state
+func Migrate(appState genutil.AppMap) genutil.AppMap {
+	v09Codec := codec.New()
+	codec.RegisterCrypto(v09Codec)
+
+	v010Codec := codec.New()
+	codec.RegisterCrypto(v010Codec)
+
+	// migrate token state
+	if appState[v09token.ModuleName] != nil {
+		var tokenGenState v09token.GenesisState
+		v09Codec.MustUnmarshalJSON(appState[v09token.ModuleName], &tokenGenState)
+
+		delete(appState, v09token.ModuleName) // delete old key in case the name changed
+		appState[v010token.ModuleName] = v010Codec.MustMarshalJSON(v010token.Migrate(tokenGenState))
+	}
+
+	return appState
+}
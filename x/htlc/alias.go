@@ -0,0 +1,59 @@
+// nolint
+// aliases generated for the following subdirectories:
+// ALIASGEN: github.com/okex/okchain/x/htlc/keeper
+// ALIASGEN: github.com/okex/okchain/x/htlc/types
+package htlc
+
+import (
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/htlc/keeper"
+	"github.com/okex/okchain/x/htlc/types"
+)
+
+const (
+	ModuleName       = types.ModuleName
+	DefaultCodespace = types.DefaultCodespace
+	QuerierRoute     = types.QuerierRoute
+	RouterKey        = types.RouterKey
+	StoreKey         = types.StoreKey
+
+	StateOpen      = types.StateOpen
+	StateCompleted = types.StateCompleted
+	StateExpired   = types.StateExpired
+	StateRefunded  = types.StateRefunded
+)
+
+type (
+	// Keepers
+	Keeper              = keeper.Keeper
+	SupplyKeeper        = keeper.SupplyKeeper
+	ProtocolVersionType = version.ProtocolVersionType
+
+	// Messages
+	MsgCreateHTLC = types.MsgCreateHTLC
+	MsgClaimHTLC  = types.MsgClaimHTLC
+	MsgRefundHTLC = types.MsgRefundHTLC
+
+	//
+	HTLC      = types.HTLC
+	HTLCs     = types.HTLCs
+	HTLCState = types.HTLCState
+)
+
+var (
+	ModuleCdc = types.ModuleCdc
+
+	RegisterCodec      = types.RegisterCodec
+	NewQuerier         = keeper.NewQuerier
+	NewKeeper          = keeper.NewKeeper
+	RegisterInvariants = keeper.RegisterInvariants
+
+	NewMsgCreateHTLC = types.NewMsgCreateHTLC
+	NewMsgClaimHTLC  = types.NewMsgClaimHTLC
+	NewMsgRefundHTLC = types.NewMsgRefundHTLC
+	NewHTLC          = types.NewHTLC
+	GetHashLock      = types.GetHashLock
+	DecodeHashLock   = types.DecodeHashLock
+	GetHTLCID        = types.GetHTLCID
+	DecodeHTLCID     = types.DecodeHTLCID
+)
@@ -0,0 +1,13 @@
+package htlc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common/perf"
+)
+
+// BeginBlocker called every block, marks the open htlcs reaching their expire height as expired.
+func BeginBlocker(ctx sdk.Context, keeper Keeper) {
+	seq := perf.GetPerf().OnBeginBlockEnter(ctx, ModuleName)
+	defer perf.GetPerf().OnBeginBlockExit(ctx, ModuleName, seq)
+	keeper.ExpireHTLCs(ctx)
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/okex/okchain/x/htlc/types"
+	"github.com/spf13/cobra"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	queryCmd := &cobra.Command{
+		Use:   "htlc",
+		Short: "Querying commands for the htlc module",
+	}
+
+	queryCmd.AddCommand(client.GetCommands(
+		GetCmdQueryHTLC(queryRoute, cdc),
+		GetCmdQueryHTLCs(queryRoute, cdc),
+	)...)
+
+	return queryCmd
+}
+
+// GetCmdQueryHTLC queries the htlc with the id
+func GetCmdQueryHTLC(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "htlc [id]",
+		Short: "Query the htlc with the id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryHTLC, args[0]), nil)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryHTLCs queries all the htlcs, or the ones sent by or to an address
+func GetCmdQueryHTLCs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "htlcs [address]",
+		Short: "Query all the htlcs, or the ones sent by or to an address",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			path := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryHTLCs)
+			if len(args) == 1 {
+				path = fmt.Sprintf("%s/%s", path, args[0])
+			}
+			res, _, err := cliCtx.QueryWithData(path, nil)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
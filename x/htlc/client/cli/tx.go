@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/okchain/x/htlc/types"
+	"github.com/spf13/cobra"
+)
+
+// htlc flags
+const (
+	FlagHashLock             = "hash-lock"
+	FlagTimeLock             = "time-lock"
+	FlagReceiverOnOtherChain = "receiver-on-other-chain"
+)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   "htlc",
+		Short: "Hash time-locked contract subcommands",
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		GetCmdCreateHTLC(cdc),
+		GetCmdClaimHTLC(cdc),
+		GetCmdRefundHTLC(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdCreateHTLC implements the create htlc command handler
+func GetCmdCreateHTLC(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [to] [amount]",
+		Short: "escrow coins in a hash time-locked contract",
+		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(`Escrow coins of the sender in a hash time-locked contract, which are sent to the recipient
+once the secret of the hash lock is revealed before the time lock expires, or can be refunded to the sender after that:
+
+$ okchaincli tx htlc create okchain1xxx 100okt --hash-lock <hex sha256 of the secret> --time-lock 200 --from mykey
+
+If no hash lock is given, a random secret is generated and printed, which should be kept until the swap is done.
+The id of the htlc, which is used to claim and refund it, is printed too.
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			to, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			hashLock, err := flags.GetString(FlagHashLock)
+			if err != nil {
+				return err
+			}
+			if len(hashLock) == 0 {
+				secret := make([]byte, types.SecretLength)
+				if _, err := rand.Read(secret); err != nil {
+					return err
+				}
+				hashLock = types.GetHashLock(secret)
+				fmt.Printf("secret: %s\nhash lock: %s\n", hex.EncodeToString(secret), hashLock)
+			}
+			fmt.Printf("htlc id: %s\n", types.GetHTLCID(hashLock, cliCtx.GetFromAddress(), to, amount))
+			timeLock, err := flags.GetInt64(FlagTimeLock)
+			if err != nil {
+				return err
+			}
+			receiverOnOtherChain, err := flags.GetString(FlagReceiverOnOtherChain)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateHTLC(cliCtx.GetFromAddress(), to, receiverOnOtherChain, amount, hashLock, timeLock)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagHashLock, "", "hex encoded sha256 hash of the secret, generated randomly if empty")
+	cmd.Flags().Int64(FlagTimeLock, types.MinTimeLock, "blocks before the htlc expires")
+	cmd.Flags().String(FlagReceiverOnOtherChain, "", "the address of the sender on the other chain of the swap")
+
+	return cmd
+}
+
+// GetCmdClaimHTLC implements the claim htlc command handler
+func GetCmdClaimHTLC(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim [id] [secret]",
+		Short: "reveal the secret of an open htlc and send its coins to the recipient",
+		Args:  cobra.ExactArgs(2),
+		Long: strings.TrimSpace(`Reveal the hex encoded secret of the hash lock of an open htlc, and send its coins to the recipient:
+
+$ okchaincli tx htlc claim <htlc id> <secret> --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimHTLC(cliCtx.GetFromAddress(), args[0], args[1])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRefundHTLC implements the refund htlc command handler
+func GetCmdRefundHTLC(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "refund [id]",
+		Short: "refund the coins of an expired htlc to its sender",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(`Refund the coins of an expired htlc created by the sender:
+
+$ okchaincli tx htlc refund <htlc id> --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRefundHTLC(cliCtx.GetFromAddress(), args[0])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package htlc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all htlc state that must be provided at genesis
+type GenesisState struct {
+	HTLCs HTLCs `json:"htlcs"`
+}
+
+// DefaultGenesisState - default GenesisState used by Cosmos Hub
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		HTLCs: nil,
+	}
+}
+
+// ValidateGenesis validates the htlc genesis parameters
+func ValidateGenesis(data GenesisState) error {
+	ids := make(map[string]struct{}, len(data.HTLCs))
+	for _, htlc := range data.HTLCs {
+		if _, err := DecodeHashLock(htlc.HashLock); err != nil {
+			return err
+		}
+		if htlc.ID != GetHTLCID(htlc.HashLock, htlc.Sender, htlc.To, htlc.Amount) {
+			return fmt.Errorf("invalid id %s of htlc with hash lock %s", htlc.ID, htlc.HashLock)
+		}
+		if _, ok := ids[htlc.ID]; ok {
+			return fmt.Errorf("duplicated htlc %s", htlc.ID)
+		}
+		ids[htlc.ID] = struct{}{}
+		if htlc.Sender.Empty() || htlc.To.Empty() {
+			return fmt.Errorf("invalid address of htlc %s", htlc.ID)
+		}
+		if !htlc.Amount.IsValid() || !htlc.Amount.IsAllPositive() {
+			return fmt.Errorf("invalid amount of htlc %s", htlc.ID)
+		}
+		if !htlc.State.IsValid() {
+			return fmt.Errorf("invalid state %s of htlc %s", htlc.State, htlc.ID)
+		}
+	}
+	return nil
+}
+
+// InitGenesis initialize the htlcs, and puts the open ones into the expire queue
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, htlc := range data.HTLCs {
+		id, err := DecodeHTLCID(htlc.ID)
+		if err != nil {
+			panic(err)
+		}
+		keeper.SetHTLC(ctx, id, htlc)
+		if htlc.State == StateOpen {
+			keeper.InsertExpireQueue(ctx, htlc.ExpireHeight, id)
+		}
+	}
+}
+
+// ExportGenesis writes the current store values
+// to a genesis file, which can be imported again
+// with InitGenesis
+func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
+	return GenesisState{
+		HTLCs: keeper.GetHTLCs(ctx),
+	}
+}
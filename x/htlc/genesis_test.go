@@ -0,0 +1,46 @@
+package htlc
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/htlc/keeper"
+	"github.com/okex/okchain/x/htlc/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesis(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.HTLCKeeper
+
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(10))
+	secret := make([]byte, types.SecretLength)
+	htlc := NewHTLC(GetHashLock(secret), testInput.TestAddrs[0], testInput.TestAddrs[1], "", amount,
+		ctx.BlockHeight()+types.MinTimeLock)
+	genesisState := DefaultGenesisState()
+	genesisState.HTLCs = HTLCs{htlc}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	InitGenesis(ctx, k, genesisState)
+	require.Equal(t, genesisState, ExportGenesis(ctx, k))
+
+	// the open htlc in genesis expires
+	BeginBlocker(ctx.WithBlockHeight(htlc.ExpireHeight), k)
+	require.Equal(t, StateExpired, ExportGenesis(ctx, k).HTLCs[0].State)
+
+	// invalid genesis
+	genesisState.HTLCs = append(genesisState.HTLCs, htlc)
+	require.NotNil(t, ValidateGenesis(genesisState))
+	htlc.State = "unknown"
+	genesisState.HTLCs = HTLCs{htlc}
+	require.NotNil(t, ValidateGenesis(genesisState))
+	htlc.State = StateOpen
+	htlc.ID = GetHTLCID(htlc.HashLock, htlc.To, htlc.Sender, htlc.Amount)
+	genesisState.HTLCs = HTLCs{htlc}
+	require.NotNil(t, ValidateGenesis(genesisState))
+	htlc.HashLock = "invalid"
+	htlc.ID = GetHTLCID(htlc.HashLock, htlc.Sender, htlc.To, htlc.Amount)
+	genesisState.HTLCs = HTLCs{htlc}
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
@@ -0,0 +1,115 @@
+package htlc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common/perf"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// NewHandler handles all "htlc" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		logger := ctx.Logger().With("module", ModuleName)
+
+		var handlerFun func() sdk.Result
+		var name string
+		switch msg := msg.(type) {
+		case MsgCreateHTLC:
+			name = "handleMsgCreateHTLC"
+			handlerFun = func() sdk.Result {
+				return handleMsgCreateHTLC(ctx, k, msg, logger)
+			}
+		case MsgClaimHTLC:
+			name = "handleMsgClaimHTLC"
+			handlerFun = func() sdk.Result {
+				return handleMsgClaimHTLC(ctx, k, msg, logger)
+			}
+		case MsgRefundHTLC:
+			name = "handleMsgRefundHTLC"
+			handlerFun = func() sdk.Result {
+				return handleMsgRefundHTLC(ctx, k, msg, logger)
+			}
+		default:
+			errMsg := fmt.Sprintf("unrecognized htlc message type: %T", msg)
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+
+		seq := perf.GetPerf().OnDeliverTxEnter(ctx, ModuleName, name)
+		defer perf.GetPerf().OnDeliverTxExit(ctx, ModuleName, name, seq)
+		return handlerFun()
+	}
+}
+
+func handleMsgCreateHTLC(ctx sdk.Context, keeper Keeper, msg MsgCreateHTLC, logger log.Logger) sdk.Result {
+	htlc, err := keeper.CreateHTLC(ctx, msg.Sender, msg.To, msg.ReceiverOnOtherChain, msg.Amount, msg.HashLock,
+		msg.TimeLock)
+	if err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgCreateHTLC: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute("to", msg.To.String()),
+			sdk.NewAttribute("id", htlc.ID),
+			sdk.NewAttribute("hash_lock", htlc.HashLock),
+			sdk.NewAttribute("amount", htlc.Amount.String()),
+			sdk.NewAttribute("expire_height", fmt.Sprintf("%d", htlc.ExpireHeight)),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgClaimHTLC(ctx sdk.Context, keeper Keeper, msg MsgClaimHTLC, logger log.Logger) sdk.Result {
+	htlc, err := keeper.ClaimHTLC(ctx, msg.ID, msg.Secret)
+	if err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgClaimHTLC: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute("to", htlc.To.String()),
+			sdk.NewAttribute("id", htlc.ID),
+			sdk.NewAttribute("hash_lock", htlc.HashLock),
+			sdk.NewAttribute("secret", htlc.Secret),
+			sdk.NewAttribute("amount", htlc.Amount.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRefundHTLC(ctx sdk.Context, keeper Keeper, msg MsgRefundHTLC, logger log.Logger) sdk.Result {
+	htlc, err := keeper.RefundHTLC(ctx, msg.Sender, msg.ID)
+	if err != nil {
+		return err.Result()
+	}
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgRefundHTLC: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute("id", htlc.ID),
+			sdk.NewAttribute("hash_lock", htlc.HashLock),
+			sdk.NewAttribute("amount", htlc.Amount.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -0,0 +1,53 @@
+package htlc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/htlc/keeper"
+	"github.com/okex/okchain/x/htlc/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_AtomicSwap(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx, k := testInput.Ctx, testInput.HTLCKeeper
+	handler := NewHandler(k)
+	sender, to := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	secret := make([]byte, types.SecretLength)
+	secret[0] = 1
+	hashLock := GetHashLock(secret)
+
+	// the recipient claims with the secret
+	msg := NewMsgCreateHTLC(sender, to, "", amount, hashLock, types.MinTimeLock)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	claimMsg := NewMsgClaimHTLC(to, GetHTLCID(hashLock, sender, to, amount), hex.EncodeToString(secret))
+	require.True(t, handler(ctx, claimMsg).Code.IsOK())
+	require.Equal(t, types.CodeHTLCNotOpen, handler(ctx, claimMsg).Code)
+	require.Equal(t, sdk.NewDec(1100), testInput.TokenKeeper.GetCoins(ctx, to).AmountOf(common.NativeToken))
+
+	// fail case : the htlc exists
+	require.Equal(t, types.CodeHTLCExist, handler(ctx, msg).Code)
+
+	// the sender refunds after expired
+	secret[0] = 2
+	hashLock = GetHashLock(secret)
+	msg = NewMsgCreateHTLC(sender, to, "", amount, hashLock, types.MinTimeLock)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	id := GetHTLCID(hashLock, sender, to, amount)
+	refundMsg := NewMsgRefundHTLC(sender, id)
+	require.Equal(t, types.CodeHTLCNotExpired, handler(ctx, refundMsg).Code)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + types.MinTimeLock)
+	BeginBlocker(ctx, k)
+	claimMsg = NewMsgClaimHTLC(to, id, hex.EncodeToString(secret))
+	require.Equal(t, types.CodeHTLCNotOpen, handler(ctx, claimMsg).Code)
+	require.True(t, handler(ctx, refundMsg).Code.IsOK())
+	require.Equal(t, sdk.NewDec(900), testInput.TokenKeeper.GetCoins(ctx, sender).AmountOf(common.NativeToken))
+
+	// fail case : unknown msg
+	require.Equal(t, sdk.CodeUnknownRequest, handler(ctx, sdk.NewTestMsg()).Code)
+}
@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/htlc/types"
+)
+
+// CreateHTLC escrows the amount of the sender in the module account, and opens a htlc expiring after time lock blocks
+func (k Keeper) CreateHTLC(ctx sdk.Context, sender, to sdk.AccAddress, receiverOnOtherChain string,
+	amount sdk.DecCoins, hashLockStr string, timeLock int64) (types.HTLC, sdk.Error) {
+	if _, err := types.DecodeHashLock(hashLockStr); err != nil {
+		return types.HTLC{}, err
+	}
+	htlc := types.NewHTLC(hashLockStr, sender, to, receiverOnOtherChain, amount, ctx.BlockHeight()+timeLock)
+	id, err := types.DecodeHTLCID(htlc.ID)
+	if err != nil {
+		return types.HTLC{}, err
+	}
+	if _, isExist := k.GetHTLC(ctx, id); isExist {
+		return types.HTLC{}, types.ErrHTLCExist(htlc.ID)
+	}
+
+	// the frozen balances are refused by the bank keeper, with their own error
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, amount); err != nil {
+		return types.HTLC{}, err
+	}
+
+	k.SetHTLC(ctx, id, htlc)
+	k.InsertExpireQueue(ctx, htlc.ExpireHeight, id)
+	return htlc, nil
+}
+
+// ClaimHTLC reveals the secret of the hash lock of the open htlc, and sends its amount to the recipient
+func (k Keeper) ClaimHTLC(ctx sdk.Context, idStr, secretStr string) (types.HTLC, sdk.Error) {
+	id, err := types.DecodeHTLCID(idStr)
+	if err != nil {
+		return types.HTLC{}, err
+	}
+	secret, err := types.DecodeSecret(secretStr)
+	if err != nil {
+		return types.HTLC{}, err
+	}
+
+	htlc, isExist := k.GetHTLC(ctx, id)
+	if !isExist {
+		return types.HTLC{}, types.ErrHTLCNotExist(idStr)
+	}
+	hashLock, err := types.DecodeHashLock(htlc.HashLock)
+	if err != nil {
+		return types.HTLC{}, err
+	}
+	if hash := sha256.Sum256(secret); !bytes.Equal(hash[:], hashLock) {
+		return types.HTLC{}, types.ErrInvalidSecret(fmt.Sprintf("secret does not match the hash lock %s", htlc.HashLock))
+	}
+	if htlc.State != types.StateOpen {
+		return types.HTLC{}, types.ErrHTLCNotOpen(idStr, htlc.State)
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.To, htlc.Amount); err != nil {
+		return types.HTLC{}, sdk.ErrInternal(fmt.Sprintf("failed to send %s: %s", htlc.Amount, err.Error()))
+	}
+
+	htlc.Secret = strings.ToLower(secretStr)
+	htlc.State = types.StateCompleted
+	k.SetHTLC(ctx, id, htlc)
+	k.removeExpireQueue(ctx, htlc.ExpireHeight, id)
+	return htlc, nil
+}
+
+// RefundHTLC sends the amount of the expired htlc back to its sender
+func (k Keeper) RefundHTLC(ctx sdk.Context, sender sdk.AccAddress, idStr string) (types.HTLC, sdk.Error) {
+	id, err := types.DecodeHTLCID(idStr)
+	if err != nil {
+		return types.HTLC{}, err
+	}
+
+	htlc, isExist := k.GetHTLC(ctx, id)
+	if !isExist {
+		return types.HTLC{}, types.ErrHTLCNotExist(idStr)
+	}
+	if !htlc.Sender.Equals(sender) {
+		return types.HTLC{}, types.ErrNotHTLCSender(sender, idStr)
+	}
+	if htlc.State != types.StateExpired {
+		return types.HTLC{}, types.ErrHTLCNotExpired(idStr, htlc.State)
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Sender, htlc.Amount); err != nil {
+		return types.HTLC{}, sdk.ErrInternal(fmt.Sprintf("failed to refund %s: %s", htlc.Amount, err.Error()))
+	}
+
+	htlc.State = types.StateRefunded
+	k.SetHTLC(ctx, id, htlc)
+	return htlc, nil
+}
+
+// ExpireHTLCs marks the open htlcs whose expire height has been reached as expired
+func (k Keeper) ExpireHTLCs(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.ExpireQueuePrefix, types.GetExpireQueueHeightPrefix(ctx.BlockHeight()+1))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	heightPrefixLen := len(types.GetExpireQueueHeightPrefix(0))
+	for _, key := range keys {
+		id := key[heightPrefixLen:]
+		if htlc, isExist := k.GetHTLC(ctx, id); isExist && htlc.State == types.StateOpen {
+			htlc.State = types.StateExpired
+			k.SetHTLC(ctx, id, htlc)
+		}
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,185 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/htlc/types"
+	tokentypes "github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSecret(b byte) (secret, hashLock string) {
+	bz := make([]byte, types.SecretLength)
+	for i := range bz {
+		bz[i] = b
+	}
+	return hex.EncodeToString(bz), types.GetHashLock(bz)
+}
+
+func TestKeeper_CreateHTLC(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.HTLCKeeper
+	sender, to := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	_, hashLock := newTestSecret(1)
+
+	// fail case : insufficient coins
+	_, err := keeper.CreateHTLC(ctx, sender, to, "", sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(10000)),
+		hashLock, types.MinTimeLock)
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+
+	// fail case : the token is frozen
+	testInput.TokenKeeper.FreezeAccount(ctx, common.TestToken, sender)
+	_, err = keeper.CreateHTLC(ctx, sender, to, "", sdk.NewDecCoinsFromDec(common.TestToken, sdk.NewDec(100)),
+		hashLock, types.MinTimeLock)
+	require.Equal(t, tokentypes.CodeAccountFrozen, err.Code())
+
+	// successful case
+	htlc, err := keeper.CreateHTLC(ctx, sender, to, "bnb1receiver", amount, hashLock, types.MinTimeLock)
+	require.Nil(t, err)
+	require.Equal(t, types.StateOpen, htlc.State)
+	require.Equal(t, ctx.BlockHeight()+types.MinTimeLock, htlc.ExpireHeight)
+	require.Equal(t, types.HTLCs{htlc}, keeper.GetHTLCs(ctx))
+	require.Equal(t, types.HTLCs{htlc}, keeper.GetHTLCsByAddress(ctx, to))
+	require.Equal(t, sdk.NewDec(900), testInput.TokenKeeper.GetCoins(ctx, sender).AmountOf(common.NativeToken))
+
+	require.Equal(t, types.GetHTLCID(hashLock, sender, to, amount), htlc.ID)
+
+	// fail case : the same htlc exists
+	_, err = keeper.CreateHTLC(ctx, sender, to, "", amount, hashLock, types.MinTimeLock)
+	require.Equal(t, types.CodeHTLCExist, err.Code())
+
+	// the hash lock can be used by other htlcs, so that taking it first blocks nobody
+	dust := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDecWithPrec(1, 8))
+	other, err := keeper.CreateHTLC(ctx, to, to, "", dust, hashLock, types.MinTimeLock)
+	require.Nil(t, err)
+	require.NotEqual(t, htlc.ID, other.ID)
+	require.Equal(t, 2, len(keeper.GetHTLCs(ctx)))
+
+	_, broken := ModuleAccountInvariant(keeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestKeeper_ClaimHTLC(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.HTLCKeeper
+	sender, to := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	secret, hashLock := newTestSecret(1)
+	wrongSecret, otherHashLock := newTestSecret(2)
+
+	// fail case : the htlc not exist
+	_, err := keeper.ClaimHTLC(ctx, types.GetHTLCID(otherHashLock, sender, to, amount), wrongSecret)
+	require.Equal(t, types.CodeHTLCNotExist, err.Code())
+
+	// a htlc front-running the swap with the same hash lock
+	dust := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDecWithPrec(1, 8))
+	_, err = keeper.CreateHTLC(ctx, to, sender, "", dust, hashLock, types.MinTimeLock)
+	require.Nil(t, err)
+
+	created, err := keeper.CreateHTLC(ctx, sender, to, "", amount, hashLock, types.MinTimeLock)
+	require.Nil(t, err)
+
+	// fail case : the secret does not match
+	_, err = keeper.ClaimHTLC(ctx, created.ID, wrongSecret)
+	require.Equal(t, types.CodeInvalidSecret, err.Code())
+
+	// fail case : invalid id
+	_, err = keeper.ClaimHTLC(ctx, hashLock[:10], secret)
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+
+	// successful case
+	htlc, err := keeper.ClaimHTLC(ctx, created.ID, secret)
+	require.Nil(t, err)
+	require.Equal(t, types.StateCompleted, htlc.State)
+	require.Equal(t, secret, htlc.Secret)
+	require.Equal(t, sdk.NewDec(1100).Sub(dust.AmountOf(common.NativeToken)),
+		testInput.TokenKeeper.GetCoins(ctx, to).AmountOf(common.NativeToken))
+
+	// fail case : claimed already
+	_, err = keeper.ClaimHTLC(ctx, created.ID, secret)
+	require.Equal(t, types.CodeHTLCNotOpen, err.Code())
+
+	// the completed htlc never expires
+	keeper.ExpireHTLCs(ctx.WithBlockHeight(htlc.ExpireHeight))
+	bz, _ := types.DecodeHTLCID(created.ID)
+	htlc, _ = keeper.GetHTLC(ctx, bz)
+	require.Equal(t, types.StateCompleted, htlc.State)
+
+	_, broken := ModuleAccountInvariant(keeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestKeeper_ClaimHTLC_UppercaseHashLock(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.HTLCKeeper
+	sender, to := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	secret, hashLock := newTestSecret(1)
+	upperHashLock := strings.ToUpper(hashLock)
+
+	created, err := keeper.CreateHTLC(ctx, sender, to, "", amount, upperHashLock, types.MinTimeLock)
+	require.Nil(t, err)
+	require.Equal(t, hashLock, created.HashLock)
+	require.Equal(t, types.GetHTLCID(upperHashLock, sender, to, amount), created.ID)
+
+	// the same htlc in another case exists already
+	_, err = keeper.CreateHTLC(ctx, sender, to, "", amount, hashLock, types.MinTimeLock)
+	require.Equal(t, types.CodeHTLCExist, err.Code())
+
+	htlc, err := keeper.ClaimHTLC(ctx, created.ID, strings.ToUpper(secret))
+	require.Nil(t, err)
+	require.Equal(t, types.StateCompleted, htlc.State)
+	require.Equal(t, sdk.NewDec(1100), testInput.TokenKeeper.GetCoins(ctx, to).AmountOf(common.NativeToken))
+}
+
+func TestKeeper_RefundHTLC(t *testing.T) {
+	testInput := CreateTestInput(t)
+	ctx, keeper := testInput.Ctx, testInput.HTLCKeeper
+	sender, to := testInput.TestAddrs[0], testInput.TestAddrs[1]
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	secret, hashLock := newTestSecret(1)
+
+	htlc, err := keeper.CreateHTLC(ctx, sender, to, "", amount, hashLock, types.MinTimeLock)
+	require.Nil(t, err)
+
+	// fail case : not expired
+	_, err = keeper.RefundHTLC(ctx, sender, htlc.ID)
+	require.Equal(t, types.CodeHTLCNotExpired, err.Code())
+
+	// the htlc is still open before the expire height
+	keeper.ExpireHTLCs(ctx.WithBlockHeight(htlc.ExpireHeight - 1))
+	_, err = keeper.RefundHTLC(ctx, sender, htlc.ID)
+	require.Equal(t, types.CodeHTLCNotExpired, err.Code())
+
+	ctx = ctx.WithBlockHeight(htlc.ExpireHeight)
+	keeper.ExpireHTLCs(ctx)
+
+	// fail case : the expired htlc can not be claimed
+	_, err = keeper.ClaimHTLC(ctx, htlc.ID, secret)
+	require.Equal(t, types.CodeHTLCNotOpen, err.Code())
+
+	// fail case : not the sender
+	_, err = keeper.RefundHTLC(ctx, to, htlc.ID)
+	require.Equal(t, types.CodeNotHTLCSender, err.Code())
+
+	_, broken := ModuleAccountInvariant(keeper)(ctx)
+	require.False(t, broken)
+
+	// successful case
+	htlc, err = keeper.RefundHTLC(ctx, sender, htlc.ID)
+	require.Nil(t, err)
+	require.Equal(t, types.StateRefunded, htlc.State)
+	require.Equal(t, sdk.NewDec(1000), testInput.TokenKeeper.GetCoins(ctx, sender).AmountOf(common.NativeToken))
+
+	// fail case : refunded already
+	_, err = keeper.RefundHTLC(ctx, sender, htlc.ID)
+	require.Equal(t, types.CodeHTLCNotExpired, err.Code())
+
+	_, broken = ModuleAccountInvariant(keeper)(ctx)
+	require.False(t, broken)
+}
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/htlc/types"
+)
+
+// RegisterInvariants registers all htlc invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "module-account", ModuleAccountInvariant(k))
+}
+
+// ModuleAccountInvariant checks that the module account holds the amounts of all the open and expired htlcs
+func ModuleAccountInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		escrowed := sdk.DecCoins{}
+		k.IterateHTLCs(ctx, func(htlc types.HTLC) bool {
+			if htlc.IsEscrowed() {
+				escrowed = escrowed.Add(htlc.Amount)
+			}
+			return false
+		})
+
+		balance := k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins()
+		broken := !balance.IsEqual(escrowed)
+
+		return sdk.FormatInvariant(types.ModuleName, "module account coins", fmt.Sprintf(
+			"\tModule account coins: %s\n"+
+				"\tsum of escrowed htlc amounts: %s\n",
+			balance, escrowed)), broken
+	}
+}
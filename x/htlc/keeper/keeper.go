@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/htlc/types"
+)
+
+// Keeper maintains the link to data storage and exposes getter/setter methods for the various parts of the state machine
+type Keeper struct {
+	supplyKeeper SupplyKeeper
+	storeKey     sdk.StoreKey
+	cdc          *codec.Codec // The wire codec for binary encoding/decoding.
+}
+
+// NewKeeper creates new instances of the htlc Keeper
+func NewKeeper(supplyKeeper SupplyKeeper, storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+	return Keeper{
+		supplyKeeper: supplyKeeper,
+		storeKey:     storeKey,
+		cdc:          cdc,
+	}
+}
+
+func (k Keeper) GetSupplyKeeper() SupplyKeeper {
+	return k.supplyKeeper
+}
+
+func (k Keeper) GetCDC() *codec.Codec {
+	return k.cdc
+}
+
+// GetHTLC returns the htlc with the id
+func (k Keeper) GetHTLC(ctx sdk.Context, id []byte) (htlc types.HTLC, isExist bool) {
+	bytes := ctx.KVStore(k.storeKey).Get(types.GetHTLCKey(id))
+	if bytes == nil {
+		return
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bytes, &htlc)
+	return htlc, true
+}
+
+// SetHTLC saves the htlc with the id to store
+func (k Keeper) SetHTLC(ctx sdk.Context, id []byte, htlc types.HTLC) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetHTLCKey(id), k.cdc.MustMarshalBinaryLengthPrefixed(htlc))
+}
+
+// IterateHTLCs iterates all the htlcs ordered by id
+func (k Keeper) IterateHTLCs(ctx sdk.Context, fn func(htlc types.HTLC) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.HTLCPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var htlc types.HTLC
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &htlc)
+		if stop := fn(htlc); stop {
+			break
+		}
+	}
+}
+
+// GetHTLCs returns all the htlcs ordered by id
+func (k Keeper) GetHTLCs(ctx sdk.Context) (htlcs types.HTLCs) {
+	k.IterateHTLCs(ctx, func(htlc types.HTLC) bool {
+		htlcs = append(htlcs, htlc)
+		return false
+	})
+	return htlcs
+}
+
+// GetHTLCsByAddress returns the htlcs sent by or to the address
+func (k Keeper) GetHTLCsByAddress(ctx sdk.Context, addr sdk.AccAddress) (htlcs types.HTLCs) {
+	k.IterateHTLCs(ctx, func(htlc types.HTLC) bool {
+		if htlc.Sender.Equals(addr) || htlc.To.Equals(addr) {
+			htlcs = append(htlcs, htlc)
+		}
+		return false
+	})
+	return htlcs
+}
+
+// InsertExpireQueue adds the open htlc to the queue expiring at height
+func (k Keeper) InsertExpireQueue(ctx sdk.Context, height int64, id []byte) {
+	ctx.KVStore(k.storeKey).Set(types.GetExpireQueueKey(height, id), []byte{})
+}
+
+// removeExpireQueue removes the htlc from the queue expiring at height
+func (k Keeper) removeExpireQueue(ctx sdk.Context, height int64, id []byte) {
+	ctx.KVStore(k.storeKey).Delete(types.GetExpireQueueKey(height, id))
+}
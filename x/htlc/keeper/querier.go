@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/htlc/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case types.QueryHTLC:
+			return queryHTLC(ctx, path[1:], keeper)
+		case types.QueryHTLCs:
+			return queryHTLCs(ctx, path[1:], keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown htlc query endpoint")
+		}
+	}
+}
+
+// nolint: unparam
+func queryHTLC(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("htlc id is required")
+	}
+
+	id, err := types.DecodeHTLCID(path[0])
+	if err != nil {
+		return nil, err
+	}
+	htlc, isExist := keeper.GetHTLC(ctx, id)
+	if !isExist {
+		return nil, types.ErrHTLCNotExist(path[0])
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), htlc)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
+
+// queryHTLCs returns all the htlcs, or the ones sent by or to the address if given
+func queryHTLCs(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	var htlcs types.HTLCs
+	if len(path) == 0 || len(path[0]) == 0 {
+		htlcs = keeper.GetHTLCs(ctx)
+	} else {
+		addr, errAddr := sdk.AccAddressFromBech32(path[0])
+		if errAddr != nil {
+			return nil, sdk.ErrInvalidAddress(path[0])
+		}
+		htlcs = keeper.GetHTLCsByAddress(ctx, addr)
+	}
+	if htlcs == nil {
+		htlcs = types.HTLCs{}
+	}
+
+	res, errMarshal := codec.MarshalJSONIndent(keeper.GetCDC(), htlcs)
+	if errMarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errMarshal.Error()))
+	}
+	return res, nil
+}
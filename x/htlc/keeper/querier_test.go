@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/htlc/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestQuerier(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 3, 1000)
+	ctx, keeper := testInput.Ctx, testInput.HTLCKeeper
+	querier := NewQuerier(keeper)
+	_, hashLock := newTestSecret(1)
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	id := types.GetHTLCID(hashLock, testInput.TestAddrs[0], testInput.TestAddrs[1], amount)
+
+	// fail case : the htlc not exist
+	_, err := querier(ctx, []string{types.QueryHTLC, id}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	// fail case : invalid id
+	_, err = querier(ctx, []string{types.QueryHTLC, "invalid"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	_, err = keeper.CreateHTLC(ctx, testInput.TestAddrs[0], testInput.TestAddrs[1], "", amount, hashLock,
+		types.MinTimeLock)
+	require.Nil(t, err)
+
+	res, err := querier(ctx, []string{types.QueryHTLC, id}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var htlc types.HTLC
+	testInput.Cdc.MustUnmarshalJSON(res, &htlc)
+	require.Equal(t, id, htlc.ID)
+	require.Equal(t, hashLock, htlc.HashLock)
+
+	res, err = querier(ctx, []string{types.QueryHTLCs}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var htlcs types.HTLCs
+	testInput.Cdc.MustUnmarshalJSON(res, &htlcs)
+	require.Equal(t, 1, len(htlcs))
+
+	// the htlcs of an address not involved
+	res, err = querier(ctx, []string{types.QueryHTLCs, testInput.TestAddrs[2].String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	testInput.Cdc.MustUnmarshalJSON(res, &htlcs)
+	require.Equal(t, 0, len(htlcs))
+
+	// fail case : invalid address
+	_, err = querier(ctx, []string{types.QueryHTLCs, "invalid"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	// fail case : unknown endpoint
+	_, err = querier(ctx, []string{"unknown"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
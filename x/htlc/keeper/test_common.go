@@ -0,0 +1,132 @@
+package keeper
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okchain/x/params"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/htlc/types"
+	"github.com/okex/okchain/x/token"
+)
+
+type TestInput struct {
+	Ctx       sdk.Context
+	Cdc       *codec.Codec
+	TestAddrs []sdk.AccAddress
+
+	HTLCKeeper   Keeper
+	TokenKeeper  token.Keeper
+	SupplyKeeper supply.Keeper
+}
+
+// create a codec used only for testing
+func MakeTestCodec() *codec.Codec {
+	var cdc = codec.New()
+	bank.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	token.RegisterCodec(cdc)
+	types.RegisterCodec(cdc) // htlc
+	return cdc
+}
+
+func CreateTestInputWithBalance(t *testing.T, numAddrs, initQuantity int64) TestInput {
+	db := dbm.NewMemDB()
+
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	// token module
+	keyToken := sdk.NewKVStoreKey(token.StoreKey)
+	keyLock := sdk.NewKVStoreKey(token.KeyLock)
+
+	// htlc module
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+
+	ms.MountStoreWithDB(keyToken, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyLock, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, db)
+
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(0, 0)}, false, log.NewTMLogger(os.Stdout))
+	cdc := MakeTestCodec()
+
+	feeCollectorAcc := supply.NewEmptyModuleAccount(auth.FeeCollectorName)
+
+	blacklistedAddrs := make(map[string]bool)
+	blacklistedAddrs[feeCollectorAcc.String()] = true
+
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc,
+		paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := token.NewFreezableBankKeeper(bank.NewBaseKeeper(accountKeeper,
+		paramsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, blacklistedAddrs), keyToken, keyLock)
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName: nil,
+		token.ModuleName:      {supply.Minter, supply.Burner},
+		types.ModuleName:      nil,
+	}
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.Coins{}))
+
+	// set module accounts
+	supplyKeeper.SetModuleAccount(ctx, feeCollectorAcc)
+
+	// token keeper
+	tokenKeeper := token.NewKeeper(bankKeeper, paramsKeeper,
+		paramsKeeper.Subspace(token.DefaultParamspace), auth.FeeCollectorName, supplyKeeper,
+		keyToken, keyLock, cdc, true)
+
+	// htlc keeper
+	htlcKeeper := NewKeeper(supplyKeeper, storeKey, cdc)
+
+	// init account tokens
+	initCoins, err := sdk.ParseDecCoins(fmt.Sprintf("%d%s,%d%s",
+		initQuantity, common.NativeToken, initQuantity, common.TestToken))
+	require.Nil(t, err)
+
+	var testAddrs []sdk.AccAddress
+	for i := int64(0); i < numAddrs; i++ {
+		pk := ed25519.GenPrivKey().PubKey()
+		addr := sdk.AccAddress(pk.Address())
+		testAddrs = append(testAddrs, addr)
+		err := supplyKeeper.MintCoins(ctx, token.ModuleName, initCoins)
+		require.Nil(t, err)
+		err = supplyKeeper.SendCoinsFromModuleToAccount(ctx, token.ModuleName, addr, initCoins)
+		require.Nil(t, err)
+	}
+
+	return TestInput{ctx, cdc, testAddrs, htlcKeeper, tokenKeeper, supplyKeeper}
+}
+
+func CreateTestInput(t *testing.T) TestInput {
+	return CreateTestInputWithBalance(t, 2, 1000)
+}
@@ -0,0 +1,20 @@
+package types
+
+import "github.com/cosmos/cosmos-sdk/codec"
+
+// RegisterCodec registers concrete types on the Amino codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgCreateHTLC{}, "okchain/htlc/MsgCreateHTLC", nil)
+	cdc.RegisterConcrete(MsgClaimHTLC{}, "okchain/htlc/MsgClaimHTLC", nil)
+	cdc.RegisterConcrete(MsgRefundHTLC{}, "okchain/htlc/MsgRefundHTLC", nil)
+}
+
+// ModuleCdc represents generic sealed codec to be used throughout this module
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
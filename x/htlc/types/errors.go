@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// const CodeType
+const (
+	CodeHTLCExist      sdk.CodeType = 1
+	CodeHTLCNotExist   sdk.CodeType = 2
+	CodeHTLCNotOpen    sdk.CodeType = 3
+	CodeHTLCNotExpired sdk.CodeType = 4
+	CodeNotHTLCSender  sdk.CodeType = 5
+	CodeInvalidSecret  sdk.CodeType = 6
+)
+
+// CodeType to Message
+func CodeToDefaultMsg(code sdk.CodeType) string {
+	switch code {
+	case CodeHTLCExist:
+		return "htlc already exists"
+	case CodeHTLCNotExist:
+		return "htlc not exist"
+	case CodeHTLCNotOpen:
+		return "htlc is not open"
+	case CodeHTLCNotExpired:
+		return "htlc is not expired"
+	case CodeNotHTLCSender:
+		return "not the sender of the htlc"
+	case CodeInvalidSecret:
+		return "invalid secret"
+	default:
+		return fmt.Sprintf("unknown code %d", code)
+	}
+}
+
+func ErrHTLCExist(id string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeHTLCExist, CodeToDefaultMsg(CodeHTLCExist)+": %s", id)
+}
+
+func ErrHTLCNotExist(id string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeHTLCNotExist, CodeToDefaultMsg(CodeHTLCNotExist)+": %s", id)
+}
+
+func ErrHTLCNotOpen(id string, state HTLCState) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeHTLCNotOpen, CodeToDefaultMsg(CodeHTLCNotOpen)+": %s is %s", id, state)
+}
+
+func ErrHTLCNotExpired(id string, state HTLCState) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeHTLCNotExpired, CodeToDefaultMsg(CodeHTLCNotExpired)+": %s is %s", id, state)
+}
+
+func ErrNotHTLCSender(addr sdk.AccAddress, id string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeNotHTLCSender, CodeToDefaultMsg(CodeNotHTLCSender)+": %s is not the sender of %s", addr, id)
+}
+
+func ErrInvalidSecret(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidSecret, CodeToDefaultMsg(CodeInvalidSecret)+": %s", msg)
+}
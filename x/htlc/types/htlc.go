@@ -0,0 +1,149 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	HashLockLength = 32 // length of the sha256 hash of the secret
+	SecretLength   = 32
+	IDLength       = 32 // length of the sha256 hash identifying a htlc
+
+	MinTimeLock int64 = 50    // min blocks the htlc is locked
+	MaxTimeLock int64 = 25480 // max blocks the htlc is locked, about 48 hours
+
+	MaxReceiverOnOtherChainLength = 128
+)
+
+// HTLCState is the state of a htlc
+type HTLCState string
+
+const (
+	StateOpen      HTLCState = "open"      // waiting for the secret to be claimed
+	StateCompleted HTLCState = "completed" // claimed by the secret
+	StateExpired   HTLCState = "expired"   // expired without being claimed, waiting for the refund
+	StateRefunded  HTLCState = "refunded"  // refunded to the sender after expired
+)
+
+// IsValid returns whether the state is one of the htlc states
+func (s HTLCState) IsValid() bool {
+	switch s {
+	case StateOpen, StateCompleted, StateExpired, StateRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// HTLC escrows the coins of the sender, which are sent to the recipient once the secret of the hash lock is revealed
+// before the expire height, or refunded to the sender after that.
+// It is identified by the hash of its hash lock, sender, recipient and amount, so that anyone seeing the hash lock
+// can not take it first with another htlc
+type HTLC struct {
+	ID                   string         `json:"id"`        // hex encoded id, see GetHTLCID
+	HashLock             string         `json:"hash_lock"` // hex encoded sha256 hash of the secret
+	Sender               sdk.AccAddress `json:"sender"`
+	To                   sdk.AccAddress `json:"to"`
+	ReceiverOnOtherChain string         `json:"receiver_on_other_chain"`
+	Amount               sdk.DecCoins   `json:"amount"`
+	Secret               string         `json:"secret"` // hex encoded secret, revealed when claimed
+	ExpireHeight         int64          `json:"expire_height"`
+	State                HTLCState      `json:"state"`
+}
+
+// NewHTLC creates an open htlc
+func NewHTLC(hashLock string, sender, to sdk.AccAddress, receiverOnOtherChain string, amount sdk.DecCoins,
+	expireHeight int64) HTLC {
+	hashLock = strings.ToLower(hashLock)
+	return HTLC{
+		ID:                   GetHTLCID(hashLock, sender, to, amount),
+		HashLock:             hashLock,
+		Sender:               sender,
+		To:                   to,
+		ReceiverOnOtherChain: receiverOnOtherChain,
+		Amount:               amount,
+		ExpireHeight:         expireHeight,
+		State:                StateOpen,
+	}
+}
+
+// String implements fmt.Stringer
+func (h HTLC) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`HTLC:
+  ID:                     %s
+  HashLock:               %s
+  Sender:                 %s
+  To:                     %s
+  ReceiverOnOtherChain:   %s
+  Amount:                 %s
+  Secret:                 %s
+  ExpireHeight:           %d
+  State:                  %s`,
+		h.ID, h.HashLock, h.Sender, h.To, h.ReceiverOnOtherChain, h.Amount, h.Secret, h.ExpireHeight, h.State))
+}
+
+// IsEscrowed returns whether the amount of the htlc is still held by the module account
+func (h HTLC) IsEscrowed() bool {
+	return h.State == StateOpen || h.State == StateExpired
+}
+
+type HTLCs []HTLC
+
+// String implements fmt.Stringer
+func (hs HTLCs) String() string {
+	strs := make([]string, 0, len(hs))
+	for _, h := range hs {
+		strs = append(strs, h.String())
+	}
+	return strings.Join(strs, "\n")
+}
+
+// GetHashLock returns the hex encoded sha256 hash of the secret
+func GetHashLock(secret []byte) string {
+	hash := sha256.Sum256(secret)
+	return hex.EncodeToString(hash[:])
+}
+
+// GetHTLCID returns the hex encoded sha256 hash of the hash lock, the sender, the recipient and the amount of a htlc.
+// The hash lock and the addresses have fixed lengths, so that the concatenation is unambiguous.
+// The hash lock is hashed in lowercase, so that the id doesn't depend on the case it was given in
+func GetHTLCID(hashLock string, sender, to sdk.AccAddress, amount sdk.DecCoins) string {
+	hash := sha256.New()
+	hash.Write([]byte(strings.ToLower(hashLock)))
+	hash.Write(sender)
+	hash.Write(to)
+	hash.Write([]byte(amount.String()))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// DecodeHTLCID decodes the hex encoded id of a htlc
+func DecodeHTLCID(id string) ([]byte, sdk.Error) {
+	bz, err := hex.DecodeString(id)
+	if err != nil || len(bz) != IDLength {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("htlc id should be %d bytes in hex: %s", IDLength, id))
+	}
+	return bz, nil
+}
+
+// DecodeHashLock decodes the hex encoded hash lock
+func DecodeHashLock(hashLock string) ([]byte, sdk.Error) {
+	bz, err := hex.DecodeString(hashLock)
+	if err != nil || len(bz) != HashLockLength {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("hash lock should be %d bytes in hex: %s", HashLockLength, hashLock))
+	}
+	return bz, nil
+}
+
+// DecodeSecret decodes the hex encoded secret
+func DecodeSecret(secret string) ([]byte, sdk.Error) {
+	bz, err := hex.DecodeString(secret)
+	if err != nil || len(bz) != SecretLength {
+		return nil, ErrInvalidSecret(fmt.Sprintf("secret should be %d bytes in hex", SecretLength))
+	}
+	return bz, nil
+}
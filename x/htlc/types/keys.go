@@ -0,0 +1,43 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the htlc module
+	ModuleName       = "htlc"
+	DefaultCodespace = ModuleName
+
+	// QuerierRoute is the querier route for the htlc module
+	QuerierRoute = ModuleName
+
+	// RouterKey is the msg router key for the htlc module
+	RouterKey = ModuleName
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	QueryHTLC  = "htlc"
+	QueryHTLCs = "htlcs"
+)
+
+var (
+	HTLCPrefix        = []byte{0x01} // the prefix of the id of htlc
+	ExpireQueuePrefix = []byte{0x02} // the prefix of the expire height of the open htlc
+)
+
+// GetHTLCKey returns store key of the htlc with the id
+func GetHTLCKey(id []byte) []byte {
+	return append(HTLCPrefix, id...)
+}
+
+// GetExpireQueueHeightPrefix returns the prefix of the open htlcs expiring at height
+func GetExpireQueueHeightPrefix(height int64) []byte {
+	return append(ExpireQueuePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetExpireQueueKey returns store key of the open htlc with the id expiring at height
+func GetExpireQueueKey(height int64, id []byte) []byte {
+	return append(GetExpireQueueHeightPrefix(height), id...)
+}
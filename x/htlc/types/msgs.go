@@ -0,0 +1,157 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgCreateHTLC = "createHTLC"
+	TypeMsgClaimHTLC  = "claimHTLC"
+	TypeMsgRefundHTLC = "refundHTLC"
+)
+
+// MsgCreateHTLC escrows the amount of the sender in a htlc locked by the hash lock for time lock blocks
+type MsgCreateHTLC struct {
+	Sender               sdk.AccAddress `json:"sender"`
+	To                   sdk.AccAddress `json:"to"`
+	ReceiverOnOtherChain string         `json:"receiver_on_other_chain"`
+	Amount               sdk.DecCoins   `json:"amount"`
+	HashLock             string         `json:"hash_lock"` // hex encoded sha256 hash of the secret
+	TimeLock             int64          `json:"time_lock"` // blocks before the htlc expires
+}
+
+func NewMsgCreateHTLC(sender, to sdk.AccAddress, receiverOnOtherChain string, amount sdk.DecCoins,
+	hashLock string, timeLock int64) MsgCreateHTLC {
+	return MsgCreateHTLC{
+		Sender:               sender,
+		To:                   to,
+		ReceiverOnOtherChain: receiverOnOtherChain,
+		Amount:               amount,
+		HashLock:             hashLock,
+		TimeLock:             timeLock,
+	}
+}
+
+// nolint
+func (msg MsgCreateHTLC) Route() string { return RouterKey }
+func (msg MsgCreateHTLC) Type() string  { return TypeMsgCreateHTLC }
+
+// Implements Msg.
+func (msg MsgCreateHTLC) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.To.Empty() {
+		return sdk.ErrInvalidAddress(msg.To.String())
+	}
+	if len(msg.ReceiverOnOtherChain) > MaxReceiverOnOtherChainLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("receiver on other chain should not be longer than %d",
+			MaxReceiverOnOtherChainLength))
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsAllPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	if _, err := DecodeHashLock(msg.HashLock); err != nil {
+		return err
+	}
+	if msg.TimeLock < MinTimeLock || msg.TimeLock > MaxTimeLock {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("time lock should be in [%d, %d]", MinTimeLock, MaxTimeLock))
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgCreateHTLC) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCreateHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgClaimHTLC reveals the secret of an open htlc, and sends its amount to the recipient
+type MsgClaimHTLC struct {
+	Sender sdk.AccAddress `json:"sender"`
+	ID     string         `json:"id"`     // hex encoded id of the htlc
+	Secret string         `json:"secret"` // hex encoded secret
+}
+
+func NewMsgClaimHTLC(sender sdk.AccAddress, id, secret string) MsgClaimHTLC {
+	return MsgClaimHTLC{
+		Sender: sender,
+		ID:     id,
+		Secret: secret,
+	}
+}
+
+// nolint
+func (msg MsgClaimHTLC) Route() string { return RouterKey }
+func (msg MsgClaimHTLC) Type() string  { return TypeMsgClaimHTLC }
+
+// Implements Msg.
+func (msg MsgClaimHTLC) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if _, err := DecodeHTLCID(msg.ID); err != nil {
+		return err
+	}
+	if _, err := DecodeSecret(msg.Secret); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgClaimHTLC) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgClaimHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgRefundHTLC sends the amount of an expired htlc back to its sender
+type MsgRefundHTLC struct {
+	Sender sdk.AccAddress `json:"sender"`
+	ID     string         `json:"id"` // hex encoded id of the htlc
+}
+
+func NewMsgRefundHTLC(sender sdk.AccAddress, id string) MsgRefundHTLC {
+	return MsgRefundHTLC{
+		Sender: sender,
+		ID:     id,
+	}
+}
+
+// nolint
+func (msg MsgRefundHTLC) Route() string { return RouterKey }
+func (msg MsgRefundHTLC) Type() string  { return TypeMsgRefundHTLC }
+
+// Implements Msg.
+func (msg MsgRefundHTLC) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if _, err := DecodeHTLCID(msg.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRefundHTLC) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRefundHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestMsgCreateHTLC(t *testing.T) {
+	sender := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewDecCoinsFromDec("okt", sdk.NewDec(10))
+	hashLock := GetHashLock(make([]byte, SecretLength))
+
+	msg := NewMsgCreateHTLC(sender, to, "", amount, hashLock, MinTimeLock)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgCreateHTLC, msg.Type())
+	require.Equal(t, []sdk.AccAddress{sender}, msg.GetSigners())
+	require.NotEmpty(t, msg.GetSignBytes())
+
+	require.NotNil(t, NewMsgCreateHTLC(nil, to, "", amount, hashLock, MinTimeLock).ValidateBasic())
+	require.NotNil(t, NewMsgCreateHTLC(sender, nil, "", amount, hashLock, MinTimeLock).ValidateBasic())
+	require.NotNil(t, NewMsgCreateHTLC(sender, to, "", sdk.DecCoins{}, hashLock, MinTimeLock).ValidateBasic())
+	require.NotNil(t, NewMsgCreateHTLC(sender, to, "", amount, "abcd", MinTimeLock).ValidateBasic())
+	require.NotNil(t, NewMsgCreateHTLC(sender, to, "", amount, hashLock, MinTimeLock-1).ValidateBasic())
+	require.NotNil(t, NewMsgCreateHTLC(sender, to, "", amount, hashLock, MaxTimeLock+1).ValidateBasic())
+}
+
+func TestMsgClaimHTLC(t *testing.T) {
+	sender := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	secret := make([]byte, SecretLength)
+	hashLock := GetHashLock(secret)
+	id := GetHTLCID(hashLock, sender, sender, sdk.NewDecCoinsFromDec("okt", sdk.NewDec(10)))
+
+	msg := NewMsgClaimHTLC(sender, id, hex.EncodeToString(secret))
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, TypeMsgClaimHTLC, msg.Type())
+	require.Equal(t, []sdk.AccAddress{sender}, msg.GetSigners())
+
+	err := NewMsgClaimHTLC(sender, id, "0102").ValidateBasic()
+	require.Equal(t, CodeInvalidSecret, err.Code())
+	require.NotNil(t, NewMsgClaimHTLC(sender, "abcd", hex.EncodeToString(secret)).ValidateBasic())
+	require.NotNil(t, NewMsgClaimHTLC(nil, id, hex.EncodeToString(secret)).ValidateBasic())
+}
+
+func TestMsgRefundHTLC(t *testing.T) {
+	sender := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	id := GetHTLCID(GetHashLock(make([]byte, SecretLength)), sender, sender,
+		sdk.NewDecCoinsFromDec("okt", sdk.NewDec(10)))
+
+	msg := NewMsgRefundHTLC(sender, id)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, TypeMsgRefundHTLC, msg.Type())
+	require.Equal(t, []sdk.AccAddress{sender}, msg.GetSigners())
+
+	require.NotNil(t, NewMsgRefundHTLC(nil, id).ValidateBasic())
+	require.NotNil(t, NewMsgRefundHTLC(sender, "xyz").ValidateBasic())
+}
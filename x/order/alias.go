@@ -41,4 +41,5 @@ var (
 	NewKeeper         = keeper.NewKeeper
 	NewQuerier        = keeper.NewQuerier
 	FormatOrderIDsKey = types.FormatOrderIDsKey
+	IsSystemFree      = types.IsSystemFree
 )
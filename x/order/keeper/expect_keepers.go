@@ -17,6 +17,7 @@ type TokenKeeper interface {
 	UnlockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins, lockCoinsType int) error
 
 	BalanceAccount(ctx sdk.Context, addr sdk.AccAddress, outputCoins sdk.DecCoins, inputCoins sdk.DecCoins) error
+	IsFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress) bool
 
 	SendCoinsFromAccountToAccount(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.DecCoins) error
 
@@ -52,3 +53,9 @@ type DexKeeper interface {
 	GetLockedProductsCopy() *types.ProductLockMap
 	IsAnyProductLocked() bool
 }
+
+// expected swap keeper, which provides the liquidity pools to fill the residual orders
+type SwapKeeper interface {
+	GetPoolCapacity(ctx sdk.Context, product string, price sdk.Dec) (sellable, buyable sdk.Dec)
+	SettleOrderBookTrade(ctx sdk.Context, product string, soldByPool, boughtByPool sdk.DecCoin) sdk.Error
+}
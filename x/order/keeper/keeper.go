@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/okex/okchain/x/common/monitor"
@@ -24,6 +25,8 @@ type Keeper struct {
 	paramSpace params.Subspace
 
 	dexKeeper DexKeeper
+	// The reference to the SwapKeeper to fill orders against the liquidity pools, set after initialization
+	swapKeeper SwapKeeper
 
 	supplyKeeper     SupplyKeeper
 	feeCollectorName string
@@ -157,6 +160,15 @@ func (k Keeper) GetDexKeeper() DexKeeper {
 	return k.dexKeeper
 }
 
+// SetSwapKeeper sets the swap keeper after initialization
+func (k *Keeper) SetSwapKeeper(swapKeeper SwapKeeper) {
+	k.swapKeeper = swapKeeper
+}
+
+func (k Keeper) GetSwapKeeper() SwapKeeper {
+	return k.swapKeeper
+}
+
 func (k Keeper) GetExpireBlockHeight(ctx sdk.Context, blockHeight int64) []int64 {
 	store := ctx.KVStore(k.orderStoreKey)
 	orderInfo := store.Get(types.GetExpireBlockHeightKey(blockHeight))
@@ -325,8 +337,13 @@ func (k Keeper) SetBlockMatchResult(result *types.BlockMatchResult) {
 	}
 }
 
-// use TokenKeeper
+// use TokenKeeper, the frozen balances can not be locked for trading
 func (k Keeper) LockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins, lockCoinsType int) error {
+	for _, coin := range coins {
+		if k.tokenKeeper.IsFrozen(ctx, coin.Denom, addr) {
+			return fmt.Errorf("the balance of %s at %s is frozen", coin.Denom, addr)
+		}
+	}
 	return k.tokenKeeper.LockCoins(ctx, addr, coins, lockCoinsType)
 }
 
@@ -141,6 +141,21 @@ func TestKeeper_LockCoins(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestKeeper_LockFrozenCoins(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 1, 100)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+
+	coins := sdk.DecCoins{{Denom: common.NativeToken, Amount: sdk.MustNewDecFromStr("10")}}
+	testInput.TokenKeeper.FreezeAccount(ctx, common.NativeToken, testInput.TestAddrs[0])
+	err := keeper.LockCoins(ctx, testInput.TestAddrs[0], coins, token.LockCoinsTypeQuantity)
+	require.NotNil(t, err)
+
+	testInput.TokenKeeper.UnfreezeAccount(ctx, common.NativeToken, testInput.TestAddrs[0])
+	err = keeper.LockCoins(ctx, testInput.TestAddrs[0], coins, token.LockCoinsTypeQuantity)
+	require.Nil(t, err)
+}
+
 func TestKeeper_BurnLockedCoins(t *testing.T) {
 	testInput := CreateTestInputWithBalance(t, 1, 100)
 	keeper := testInput.OrderKeeper
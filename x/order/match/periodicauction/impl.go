@@ -11,5 +11,7 @@ type PaEngine struct {
 }
 
 // Run
+// the residual orders left by the auction are filled against the liquidity pools at the clearing price
 func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
+	fillOrdersWithPools(ctx, keeper)
 }
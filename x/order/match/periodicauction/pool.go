@@ -0,0 +1,184 @@
+package periodicauction
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+	token "github.com/okex/okchain/x/token/types"
+)
+
+// fillOrdersWithPools fills the residual orders of every product against its liquidity pool at the clearing price,
+// the pool sells to buy orders priced at or above the clearing price and buys from sell orders priced at or below it
+func fillOrdersWithPools(ctx sdk.Context, k keeper.Keeper) {
+	swapKeeper := k.GetSwapKeeper()
+	if swapKeeper == nil {
+		return
+	}
+
+	products := k.FilterDelistedProducts(ctx, k.GetProductsFromDepthBookMap())
+	sort.Strings(products)
+	for _, product := range products {
+		if k.IsProductLocked(product) {
+			continue
+		}
+
+		price := k.GetLastPrice(ctx, product)
+		sellable, buyable := swapKeeper.GetPoolCapacity(ctx, product, price)
+		if !sellable.IsPositive() && !buyable.IsPositive() {
+			continue
+		}
+
+		book := k.GetDepthBookCopy(product)
+		var deals []types.Deal
+		if sellable.IsPositive() {
+			// depth book is sorted by price desc
+			for i := 0; i < len(book.Items) && book.Items[i].Price.GTE(price) && sellable.IsPositive(); i++ {
+				filled, levelDeals := fillPriceLevel(ctx, k, swapKeeper, product, book.Items[i].Price,
+					types.BuyOrder, price, sellable)
+				book.Sub(i, filled, types.BuyOrder)
+				sellable = sellable.Sub(filled)
+				deals = append(deals, levelDeals...)
+			}
+		} else {
+			for i := len(book.Items) - 1; i >= 0 && book.Items[i].Price.LTE(price) && buyable.IsPositive(); i-- {
+				filled, levelDeals := fillPriceLevel(ctx, k, swapKeeper, product, book.Items[i].Price,
+					types.SellOrder, price, buyable)
+				book.Sub(i, filled, types.SellOrder)
+				buyable = buyable.Sub(filled)
+				deals = append(deals, levelDeals...)
+			}
+		}
+		if len(deals) == 0 {
+			continue
+		}
+
+		for i := len(book.Items) - 1; i >= 0; i-- {
+			book.RemoveIfEmpty(i)
+		}
+		k.SetDepthBook(product, book)
+		recordPoolDeals(ctx, k, product, price, deals)
+	}
+}
+
+// fillPriceLevel fills the orders of one side at levelPrice in time priority, until capacity is used up
+func fillPriceLevel(ctx sdk.Context, k keeper.Keeper, swapKeeper keeper.SwapKeeper, product string,
+	levelPrice sdk.Dec, side string, price, capacity sdk.Dec) (filled sdk.Dec, deals []types.Deal) {
+
+	filled = sdk.ZeroDec()
+	key := types.FormatOrderIDsKey(product, levelPrice, side)
+	orderIDs := k.GetProductPriceOrderIDs(key)
+	remainIDs := make([]string, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		remain := capacity.Sub(filled)
+		order := k.GetOrder(ctx, orderID)
+		if !remain.IsPositive() || order == nil {
+			remainIDs = append(remainIDs, orderID)
+			continue
+		}
+
+		fillAmount := sdk.MinDec(remain, order.RemainQuantity)
+		deal, ok := fillOrderWithPool(ctx, k, swapKeeper, order, price, fillAmount)
+		if !ok {
+			remainIDs = append(remainIDs, orderID)
+			continue
+		}
+		filled = filled.Add(fillAmount)
+		deals = append(deals, deal)
+		if order.Status != types.OrderStatusFilled {
+			remainIDs = append(remainIDs, orderID)
+		}
+	}
+
+	if len(remainIDs) != len(orderIDs) {
+		k.SetOrderIDs(key, remainIDs)
+	}
+	return filled, deals
+}
+
+// fillOrderWithPool settles fillAmount of the order with the liquidity pool at price
+func fillOrderWithPool(ctx sdk.Context, k keeper.Keeper, swapKeeper keeper.SwapKeeper, order *types.Order,
+	price, fillAmount sdk.Dec) (types.Deal, bool) {
+
+	symbols := strings.Split(order.Product, "_")
+	baseCoin := sdk.NewDecCoinFromDec(symbols[0], fillAmount)
+	quoteCoin := sdk.NewDecCoinFromDec(symbols[1], fillAmount.Mul(price))
+	if !baseCoin.IsPositive() || !quoteCoin.IsPositive() {
+		return types.Deal{}, false
+	}
+
+	// the pool trades against the coins locked in the token module
+	outputCoins, inputCoins := sdk.DecCoins{quoteCoin}, sdk.DecCoins{baseCoin}
+	soldByPool, boughtByPool := baseCoin, quoteCoin
+	if order.Side == types.SellOrder {
+		outputCoins, inputCoins = inputCoins, outputCoins
+		soldByPool, boughtByPool = boughtByPool, soldByPool
+	}
+	cacheCtx, write := ctx.CacheContext()
+	if err := swapKeeper.SettleOrderBookTrade(cacheCtx, order.Product, soldByPool, boughtByPool); err != nil {
+		ctx.Logger().Error(fmt.Sprintf("failed to fill order(%s) with pool: %s", order.OrderID, err.Error()))
+		return types.Deal{}, false
+	}
+	write()
+
+	order.Fill(price, fillAmount)
+	k.BalanceAccount(ctx, order.Sender, outputCoins, inputCoins)
+
+	// charge deal fee
+	fee := keeper.GetDealFee(order, fillAmount, ctx, k, k.GetParams(ctx))
+	order.RecordOrderDealFee(fee)
+	if err := k.SendFeesToProductOwner(ctx, fee, order.Sender, types.FeeTypeOrderDeal, order.Product); err != nil {
+		ctx.Logger().Error(fmt.Sprintf("failed to charge order(%s) deal fee: %v", order.OrderID, err))
+	}
+
+	// unlock the remaining coins & charge fee for the new order when the order is finished
+	if order.Status == types.OrderStatusFilled {
+		if order.RemainLocked.IsPositive() {
+			k.UnlockCoins(ctx, order.Sender, order.NeedUnlockCoins(), token.LockCoinsTypeQuantity)
+			order.Unlock()
+		}
+
+		lockedFee := keeper.GetOrderNewFee(order)
+		feeForNewOrder := keeper.GetOrderCostFee(order, ctx)
+		receiveFee := lockedFee.Sub(feeForNewOrder)
+		k.UnlockCoins(ctx, order.Sender, lockedFee, token.LockCoinsTypeFee)
+		k.AddFeeDetail(ctx, order.Sender, receiveFee, types.FeeTypeOrderReceive)
+		order.RecordOrderReceiveFee(receiveFee)
+		if err := k.AddCollectedFees(ctx, feeForNewOrder, order.Sender, types.FeeTypeOrderNew, false); err != nil {
+			ctx.Logger().Error(fmt.Sprintf("failed to charge order(%s) new fee: %v", order.OrderID, err))
+		}
+	}
+	k.UpdateOrder(order, ctx)
+
+	return types.Deal{OrderID: order.OrderID, Side: order.Side, Quantity: fillAmount, Fee: fee.String()}, true
+}
+
+// recordPoolDeals appends the deals filled with the pool to the match result of the block
+func recordPoolDeals(ctx sdk.Context, k keeper.Keeper, product string, price sdk.Dec, deals []types.Deal) {
+	blockMatchResult := k.GetBlockMatchResult()
+	if blockMatchResult == nil {
+		return
+	}
+	if blockMatchResult.ResultMap == nil {
+		blockMatchResult.BlockHeight = ctx.BlockHeight()
+		blockMatchResult.ResultMap = make(map[string]types.MatchResult)
+		blockMatchResult.TimeStamp = ctx.BlockHeader().Time.Unix()
+	}
+
+	quantity := sdk.ZeroDec()
+	for _, deal := range deals {
+		quantity = quantity.Add(deal.Quantity)
+	}
+	matchResult, ok := blockMatchResult.ResultMap[product]
+	if !ok {
+		matchResult = types.MatchResult{BlockHeight: ctx.BlockHeight(), Price: price, Quantity: sdk.ZeroDec()}
+	}
+	matchResult.Quantity = matchResult.Quantity.Add(quantity)
+	matchResult.Deals = append(matchResult.Deals, deals...)
+	blockMatchResult.ResultMap[product] = matchResult
+	k.SetBlockMatchResult(blockMatchResult)
+}
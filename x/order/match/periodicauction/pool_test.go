@@ -0,0 +1,108 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/dex"
+	"github.com/okex/okchain/x/order/keeper"
+	"github.com/okex/okchain/x/order/types"
+	token "github.com/okex/okchain/x/token/types"
+)
+
+// mockSwapKeeper is a pool with fixed capacity, which mints what it sells and burns what it buys
+type mockSwapKeeper struct {
+	supplyKeeper supply.Keeper
+	sellable     sdk.Dec
+	buyable      sdk.Dec
+	sold         sdk.DecCoins
+	bought       sdk.DecCoins
+}
+
+func (k *mockSwapKeeper) GetPoolCapacity(ctx sdk.Context, product string, price sdk.Dec) (sellable, buyable sdk.Dec) {
+	return k.sellable, k.buyable
+}
+
+func (k *mockSwapKeeper) SettleOrderBookTrade(ctx sdk.Context, product string,
+	soldByPool, boughtByPool sdk.DecCoin) sdk.Error {
+	if err := k.supplyKeeper.MintCoins(ctx, token.ModuleName, sdk.DecCoins{soldByPool}); err != nil {
+		return err
+	}
+	if err := k.supplyKeeper.BurnCoins(ctx, token.ModuleName, sdk.DecCoins{boughtByPool}); err != nil {
+		return err
+	}
+	k.sold = k.sold.Add(sdk.DecCoins{soldByPool})
+	k.bought = k.bought.Add(sdk.DecCoins{boughtByPool})
+	return nil
+}
+
+func TestFillOrdersWithPools(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	k := testInput.OrderKeeper
+	k.ResetCache(ctx)
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair()))
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "11.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "9.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "12.0", "1.0"),
+	}
+	for i, order := range orders {
+		order.Sender = testInput.TestAddrs[i%2]
+		require.Nil(t, k.PlaceOrder(ctx, order))
+	}
+
+	// without swap keeper, nothing happens
+	fillOrdersWithPools(ctx, k)
+	require.Equal(t, 4, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// the pool sells 1.5 base at the last price 10
+	swapKeeper := &mockSwapKeeper{supplyKeeper: testInput.SupplyKeeper,
+		sellable: sdk.MustNewDecFromStr("1.5"), buyable: sdk.ZeroDec()}
+	k.SetSwapKeeper(swapKeeper)
+	fillOrdersWithPools(ctx, k)
+
+	require.Equal(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("1.5"))},
+		swapKeeper.sold)
+	require.Equal(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(15))}, swapKeeper.bought)
+
+	order0 := k.GetOrder(ctx, orders[0].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order0.Status)
+	require.Equal(t, sdk.NewDec(10), order0.FilledAvgPrice)
+	order1 := k.GetOrder(ctx, orders[1].OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, order1.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), order1.RemainQuantity)
+	order2 := k.GetOrder(ctx, orders[2].OrderID)
+	require.Equal(t, sdk.OneDec(), order2.RemainQuantity)
+
+	// the buyer of order0 pays 10 instead of 11 and gets 1 base minus deal fee
+	coins := k.GetCoins(ctx, testInput.TestAddrs[0])
+	require.True(t, coins.AmountOf(common.TestToken).GT(sdk.NewDec(100)))
+	require.Equal(t, sdk.ZeroDec(), order0.RemainLocked)
+
+	// check depth book and orderIDs
+	depthBook := k.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 3, len(depthBook.Items))
+	require.Equal(t, sdk.MustNewDecFromStr("12.0"), depthBook.Items[0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), depthBook.Items[1].BuyQuantity)
+	require.Equal(t, 0, len(k.GetProductPriceOrderIDs(
+		types.FormatOrderIDsKey(types.TestTokenPair, sdk.MustNewDecFromStr("11.0"), types.BuyOrder))))
+
+	// check match result
+	matchResult := k.GetBlockMatchResult().ResultMap[types.TestTokenPair]
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), matchResult.Quantity)
+	require.Equal(t, 2, len(matchResult.Deals))
+
+	// the pool buys base from sell orders priced at or below the last price
+	swapKeeper.sellable, swapKeeper.buyable = sdk.ZeroDec(), sdk.NewDec(1)
+	fillOrdersWithPools(ctx, k)
+	order3 := k.GetOrder(ctx, orders[3].OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, order3.Status)
+	require.Equal(t, sdk.OneDec(), order3.RemainQuantity)
+}
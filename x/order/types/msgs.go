@@ -206,3 +206,21 @@ type OrderResult struct {
 	Message string       `json:"msg"`     // order return error message
 	OrderID string       `json:"orderid"` // order return orderid
 }
+
+// IsSystemFree returns whether a tx of the msgs is free of the system fee charged by the ante handler,
+// which is the case of the txs of orders only and of the txs delivered at genesis
+func IsSystemFree(ctx sdk.Context, msgs []sdk.Msg) bool {
+	if ctx.BlockHeight() < 1 {
+		return true
+	}
+
+	for _, msg := range msgs {
+		switch msg.(type) {
+		case MsgNewOrders, MsgCancelOrders:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -8,9 +8,11 @@ import (
 )
 
 // BeginBlocker Called every block
-func BeginBlocker(ctx sdk.Context, keeper Keeper) {
+func BeginBlocker(ctx sdk.Context, keeper Keeper, accountKeeper SnapshotAccountKeeper) {
 	seq := perf.GetPerf().OnBeginBlockEnter(ctx, types.ModuleName)
 	defer perf.GetPerf().OnBeginBlockExit(ctx, types.ModuleName, seq)
 
 	keeper.ResetCache(ctx)
+	keeper.ReleaseVestedCoins(ctx)
+	keeper.ProcessDistributions(ctx, accountKeeper)
 }
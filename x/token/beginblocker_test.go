@@ -1,9 +1,15 @@
 package token
 
-import "testing"
+import (
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
 
 func TestBeginBlocker(t *testing.T) {
-	ctx, kpr, _, _ := CreateParam(t, false)
+	mapp, kpr, _ := getMockDexApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
 
-	BeginBlocker(ctx, kpr)
+	BeginBlocker(ctx, kpr, NewSnapshotAccountKeeper(mapp.AccountKeeper, mapp.KeyAccount))
 }
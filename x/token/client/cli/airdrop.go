@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BatchSize  = "batch-size"
+	MaxTxBytes = "max-tx-bytes"
+	Receipt    = "receipt"
+	Offline    = "offline"
+	OutputDir  = "output-dir"
+
+	// the bytes reserved in a tx for the fee, the signature and the memo
+	txOverheadBytes = 1024
+
+	airdropStatusSigned    = "signed"
+	airdropStatusBroadcast = "broadcast"
+	airdropStatusFailed    = "failed"
+)
+
+// airdropReceipt records the progress of an airdrop, with which an interrupted airdrop is resumed
+type airdropReceipt struct {
+	CSV     string         `json:"csv"`
+	CSVHash string         `json:"csv_hash"`
+	From    string         `json:"from"`
+	Total   string         `json:"total"`
+	Chunks  []airdropChunk `json:"chunks"`
+}
+
+// airdropChunk is a MsgMultiSend tx of the transfers [Start, Start+Count) in the csv file
+type airdropChunk struct {
+	Start    int    `json:"start"`
+	Count    int    `json:"count"`
+	Total    string `json:"total"`
+	Sequence uint64 `json:"sequence"`
+	Status   string `json:"status,omitempty"`
+	TxHash   string `json:"tx_hash,omitempty"`
+	File     string `json:"file,omitempty"`
+	Log      string `json:"log,omitempty"`
+}
+
+func (chunk airdropChunk) isDone() bool {
+	return chunk.Status == airdropStatusSigned || chunk.Status == airdropStatusBroadcast
+}
+
+// GetCmdAirdrop is the CLI command for sending the coins listed in a csv file by multiple multi send txs
+func GetCmdAirdrop(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "airdrop [csv]",
+		Short: "send the coins listed in a csv file of address and amount rows by multiple multi send txs",
+		Long: `Send the coins listed in a csv file of address and amount rows, e.g.
+
+address,amount
+okchain1dfpljpe0g0206jch32fx95lyagq3z5ws2vgwx3,10okt
+okchain1upyg3vl6vqaxqvzts69zpus2c027p7paw63s99,"1okt,2xxb-123"
+
+The transfers are split into multi send txs by --batch-size and --max-tx-bytes, which are signed with
+sequential sequences and broadcast one by one, or written into --output-dir with --offline.
+The progress is recorded in the receipt file, and the airdrop is resumed from it when run again.
+Wait for the broadcast txs to be committed before resuming, otherwise their sequences are reused.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			flags := cmd.Flags()
+
+			csvBytes, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			transfers, err := types.ParseTransfersCSV(bytes.NewReader(csvBytes))
+			if err != nil {
+				return err
+			}
+			if len(transfers) == 0 {
+				return errTransfersFileNotValid
+			}
+			from := cliCtx.GetFromAddress()
+			for _, transfer := range transfers {
+				if transfer.To.Equals(from) {
+					return fmt.Errorf("can not transfer coins to the sender %s", from)
+				}
+			}
+
+			batchSize, err := flags.GetInt(BatchSize)
+			if err != nil {
+				return err
+			}
+			maxTxBytes, err := flags.GetInt(MaxTxBytes)
+			if err != nil {
+				return err
+			}
+			offline, err := flags.GetBool(Offline)
+			if err != nil {
+				return err
+			}
+			outputDir, err := flags.GetString(OutputDir)
+			if err != nil {
+				return err
+			}
+			receiptFile, err := flags.GetString(Receipt)
+			if err != nil {
+				return err
+			}
+			if receiptFile == "" {
+				receiptFile = args[0] + ".receipt.json"
+			}
+
+			hash := sha256.Sum256(csvBytes)
+			receipt, err := loadAirdropReceipt(receiptFile, hex.EncodeToString(hash[:]), from)
+			if err != nil {
+				return err
+			}
+			if receipt == nil {
+				chunks, err := types.ChunkTransfers(from, transfers, batchSize, maxTxBytes-txOverheadBytes)
+				if err != nil {
+					return err
+				}
+				receipt = newAirdropReceipt(args[0], hex.EncodeToString(hash[:]), from, transfers, chunks)
+			}
+
+			remaining := sdk.DecCoins{}
+			for _, chunk := range receipt.Chunks {
+				if !chunk.isDone() {
+					remaining = remaining.Add(types.SumTransfers(transfers[chunk.Start : chunk.Start+chunk.Count]))
+				}
+			}
+			if remaining.IsZero() {
+				fmt.Printf("all the %d transfers are done, see %s\n", len(transfers), receiptFile)
+				return nil
+			}
+
+			if !offline {
+				// the account number and the sequence are queried, and the balance is checked
+				if txBldr, err = utils.PrepareTxBuilder(txBldr, cliCtx); err != nil {
+					return err
+				}
+				acc, err := authTypes.NewAccountRetriever(cliCtx).GetAccount(from)
+				if err != nil {
+					return err
+				}
+				if !acc.GetCoins().IsAllGTE(remaining) {
+					return fmt.Errorf("insufficient coins of %s: %s, need %s besides the fees", from, acc.GetCoins(), remaining)
+				}
+			} else if err := os.MkdirAll(outputDir, 0755); err != nil {
+				return err
+			}
+
+			passphrase, err := keys.GetPassphrase(cliCtx.GetFromName())
+			if err != nil {
+				return err
+			}
+
+			sequence := txBldr.Sequence()
+			for i := range receipt.Chunks {
+				chunk := &receipt.Chunks[i]
+				if chunk.isDone() {
+					continue
+				}
+
+				msgs := []sdk.Msg{types.NewMsgMultiSend(from, transfers[chunk.Start:chunk.Start+chunk.Count])}
+				chunk.Sequence = sequence
+				signedTx, err := signAirdropTx(txBldr.WithSequence(sequence), cliCtx, msgs, passphrase, offline)
+				if err != nil {
+					return err
+				}
+
+				if offline {
+					chunk.File = filepath.Join(outputDir, fmt.Sprintf("airdrop-%d.json", i))
+					if err := ioutil.WriteFile(chunk.File, cdc.MustMarshalJSON(signedTx), 0644); err != nil {
+						return err
+					}
+					chunk.Status = airdropStatusSigned
+				} else {
+					txBytes, err := txBldr.TxEncoder()(signedTx)
+					if err != nil {
+						return err
+					}
+					res, err := cliCtx.BroadcastTx(txBytes)
+					if err == nil && res.Code != 0 {
+						err = fmt.Errorf("tx %s failed: %s", res.TxHash, res.RawLog)
+					}
+					if err != nil {
+						chunk.Status, chunk.Log = airdropStatusFailed, err.Error()
+						if saveErr := saveAirdropReceipt(receiptFile, receipt); saveErr != nil {
+							return saveErr
+						}
+						return fmt.Errorf("failed to broadcast the transfers [%d, %d): %s",
+							chunk.Start, chunk.Start+chunk.Count, err)
+					}
+					chunk.Status, chunk.TxHash, chunk.Log = airdropStatusBroadcast, res.TxHash, ""
+				}
+				if err := saveAirdropReceipt(receiptFile, receipt); err != nil {
+					return err
+				}
+				fmt.Printf("%s the transfers [%d, %d) with sequence %d %s\n",
+					chunk.Status, chunk.Start, chunk.Start+chunk.Count, chunk.Sequence, chunk.TxHash+chunk.File)
+				sequence++
+			}
+			fmt.Printf("all the %d transfers are done, see %s\n", len(transfers), receiptFile)
+			return nil
+		},
+	}
+	cmd.Flags().Int(BatchSize, 200, fmt.Sprintf("the max number of transfers in a tx, at most %d", types.MultiSendLimit))
+	cmd.Flags().Int(MaxTxBytes, 1048576, "the max size of a tx in bytes accepted by the mempool")
+	cmd.Flags().String(Receipt, "", "the progress and receipt file, [csv].receipt.json by default")
+	cmd.Flags().Bool(Offline, false, "sign the txs with --account-number and --sequence into --output-dir without broadcasting")
+	cmd.Flags().String(OutputDir, ".", "the directory of the txs signed offline")
+	return cmd
+}
+
+// signAirdropTx signs the msgs with the gas estimated by simulation if --gas=auto is given online
+func signAirdropTx(txBldr authTypes.TxBuilder, cliCtx context.CLIContext, msgs []sdk.Msg, passphrase string,
+	offline bool) (authTypes.StdTx, error) {
+
+	var err error
+	if txBldr.SimulateAndExecute() && !offline {
+		if txBldr, err = utils.EnrichWithGas(txBldr, cliCtx, msgs); err != nil {
+			return authTypes.StdTx{}, err
+		}
+	}
+	signMsg, err := txBldr.BuildSignMsg(msgs)
+	if err != nil {
+		return authTypes.StdTx{}, err
+	}
+	stdTx := authTypes.NewStdTx(signMsg.Msgs, signMsg.Fee, nil, signMsg.Memo)
+	return txBldr.SignStdTx(cliCtx.GetFromName(), passphrase, stdTx, false)
+}
+
+func newAirdropReceipt(csv, csvHash string, from sdk.AccAddress, transfers []types.TransferUnit,
+	chunks [][]types.TransferUnit) *airdropReceipt {
+
+	receipt := &airdropReceipt{
+		CSV:     csv,
+		CSVHash: csvHash,
+		From:    from.String(),
+		Total:   types.SumTransfers(transfers).String(),
+	}
+	start := 0
+	for _, chunk := range chunks {
+		receipt.Chunks = append(receipt.Chunks, airdropChunk{
+			Start: start,
+			Count: len(chunk),
+			Total: types.SumTransfers(chunk).String(),
+		})
+		start += len(chunk)
+	}
+	return receipt
+}
+
+// loadAirdropReceipt loads the receipt of the csv file to resume, which is nil if the file does not exist
+func loadAirdropReceipt(file, csvHash string, from sdk.AccAddress) (*airdropReceipt, error) {
+	bz, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var receipt airdropReceipt
+	if err := json.Unmarshal(bz, &receipt); err != nil {
+		return nil, fmt.Errorf("invalid receipt file %s: %s", file, err)
+	}
+	if receipt.CSVHash != csvHash || receipt.From != from.String() {
+		return nil, fmt.Errorf("the receipt file %s belongs to another csv file or sender", file)
+	}
+	return &receipt, nil
+}
+
+func saveAirdropReceipt(file string, receipt *airdropReceipt) error {
+	bz, err := json.MarshalIndent(receipt, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, bz, 0644)
+}
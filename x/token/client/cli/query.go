@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"encoding/csv"
 	"fmt"
+	"os"
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -16,6 +18,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	Page    = "page"
+	PerPage = "per-page"
+	CSV     = "csv"
+)
+
 // GetQueryCmd returns the cli query commands for this module
 func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	queryCmd := &cobra.Command{
@@ -29,6 +37,13 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	queryCmd.AddCommand(client.GetCommands(
 		GetCmdQueryParams(queryRoute, cdc),
 		GetCmdTokenInfo(queryRoute, cdc),
+		GetCmdQueryFrozen(queryRoute, cdc),
+		GetCmdQueryVesting(queryRoute, cdc),
+		GetCmdQueryOwnership(queryRoute, cdc),
+		GetCmdQueryMultisig(queryRoute, cdc),
+		GetCmdQueryMultisigProposals(queryRoute, cdc),
+		GetCmdQueryHolders(queryRoute, cdc),
+		GetCmdQueryDistributions(queryRoute, cdc),
 		//GetAccountCmd(queryRoute, cdc),
 	)...)
 
@@ -143,6 +158,58 @@ $ okchaincli query token params
 	}
 }
 
+// GetCmdQueryFrozen queries the addresses whose balance of the token is frozen
+func GetCmdQueryFrozen(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "frozen [symbol]",
+		Short: "Query the frozen accounts of a token",
+		Long: strings.TrimSpace(`Query the addresses whose balance of the token is frozen by the token owner:
+
+$ okchaincli query token frozen xxb-781
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryFrozen, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var addrs []sdk.AccAddress
+			cdc.MustUnmarshalJSON(bz, &addrs)
+			strs := make(Strings, 0, len(addrs))
+			for _, addr := range addrs {
+				strs = append(strs, addr.String())
+			}
+			return cliCtx.PrintOutput(strs)
+		},
+	}
+}
+
+// GetCmdQueryVesting queries the vested and unvested coins of the vesting locks of an address
+func GetCmdQueryVesting(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vesting [address]",
+		Short: "Query the vested and unvested coins of the vesting locks of an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryVesting, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var infos types.VestingInfos
+			cdc.MustUnmarshalJSON(bz, &infos)
+			return cliCtx.PrintOutput(infos)
+		},
+	}
+}
+
 // just for the object of []string could be inputted into cliCtx.PrintOutput(...)
 type Strings []string
 
@@ -168,3 +235,196 @@ func getAccount(ctx *context.CLIContext, address []byte) (auth.Account, error) {
 	}
 	return account, nil
 }
+
+// GetCmdQueryOwnership queries the pending ownership transfer of a token
+func GetCmdQueryOwnership(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ownership [symbol]",
+		Short: "Query the pending ownership transfer of a token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryOwnership, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var proposal types.OwnershipProposal
+			cdc.MustUnmarshalJSON(bz, &proposal)
+			return cliCtx.PrintOutput(proposal)
+		},
+	}
+}
+
+// GetCmdQueryMultisig queries the members and the threshold of a multisig
+func GetCmdQueryMultisig(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "multisig [address]",
+		Short: "Query the members and the threshold of a multisig",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryMultisig, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var multisig types.Multisig
+			cdc.MustUnmarshalJSON(bz, &multisig)
+			return cliCtx.PrintOutput(multisig)
+		},
+	}
+}
+
+// GetCmdQueryMultisigProposals queries the pending proposals of a multisig
+func GetCmdQueryMultisigProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "multisig-proposals [<address>]",
+		Short: "Query the pending proposals of a multisig, or all the pending proposals without address",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryProposals)
+			if len(args) == 1 {
+				route = fmt.Sprintf("%s/%s", route, args[0])
+			}
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var proposals types.MultisigProposals
+			cdc.MustUnmarshalJSON(bz, &proposals)
+			return cliCtx.PrintOutput(proposals)
+		},
+	}
+}
+
+// GetCmdQueryHolders queries the holders of a token with their balances at a block height
+func GetCmdQueryHolders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "holders [symbol]",
+		Short: "Query the holders of a token with their available and locked balances at a block height",
+		Long: `Query the holders of a token sorted by the total balance, which counts the coins locked in orders.
+The holders are queried at the latest height or at --height, which fails if the node has pruned the state of the height.
+With --csv, all the holders at the same height are exported into the csv file instead of printing a page.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			flags := cmd.Flags()
+
+			page, err := flags.GetInt(Page)
+			if err != nil {
+				return err
+			}
+			perPage, err := flags.GetInt(PerPage)
+			if err != nil {
+				return err
+			}
+			csvFile, err := flags.GetString(CSV)
+			if err != nil {
+				return err
+			}
+
+			if csvFile == "" {
+				res, err := queryHolders(cliCtx, queryRoute, types.NewQueryHoldersParams(args[0], page, perPage))
+				if err != nil {
+					return err
+				}
+				return cliCtx.PrintOutput(res)
+			}
+
+			// all the pages are queried at --height, or at the height of the first page if it is not set,
+			// so that they are of the same snapshot
+			var holders types.Holders
+			var height int64
+			for page = 1; ; page++ {
+				res, err := queryHolders(cliCtx, queryRoute, types.NewQueryHoldersParams(args[0], page, types.MaxHoldersPerPage))
+				if err != nil {
+					return err
+				}
+				if cliCtx.Height == 0 {
+					cliCtx = cliCtx.WithHeight(res.Height)
+				}
+				height = res.Height
+				holders = append(holders, res.Holders...)
+				if len(res.Holders) == 0 || len(holders) >= res.Total {
+					break
+				}
+			}
+			if err := writeHoldersCSV(csvFile, holders); err != nil {
+				return err
+			}
+			fmt.Printf("exported %d holders of %s at height %d into %s\n", len(holders), args[0], height, csvFile)
+			return nil
+		},
+	}
+	cmd.Flags().Int(Page, 1, "the page of the holders, which starts from 1")
+	cmd.Flags().Int(PerPage, types.DefaultHoldersPerPage, fmt.Sprintf("the number of holders per page, at most %d", types.MaxHoldersPerPage))
+	cmd.Flags().String(CSV, "", "export all the holders into the csv file")
+	return cmd
+}
+
+// GetCmdQueryDistributions queries the progress of the distributions to the holders of a token
+func GetCmdQueryDistributions(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "distributions [symbol|id]",
+		Short: "Query the distributions to the holders of a token, or a distribution by id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryDistributions, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var distributions types.Distributions
+			cdc.MustUnmarshalJSON(bz, &distributions)
+			return cliCtx.PrintOutput(distributions)
+		},
+	}
+}
+
+func queryHolders(cliCtx context.CLIContext, queryRoute string, params types.QueryHoldersParams) (
+	res types.HoldersResponse, err error) {
+
+	bz, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return res, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryHolders)
+	resBytes, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		return res, err
+	}
+	err = cliCtx.Codec.UnmarshalJSON(resBytes, &res)
+	return res, err
+}
+
+func writeHoldersCSV(file string, holders types.Holders) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"address", "available", "locked", "total"}); err != nil {
+		return err
+	}
+	for _, holder := range holders {
+		record := []string{holder.Address.String(), holder.Available.String(), holder.Locked.String(), holder.Total.String()}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -16,6 +17,7 @@ import (
 	"github.com/okex/okchain/x/token/types"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const (
@@ -28,6 +30,17 @@ const (
 	WholeName     = "whole-name"
 	TokenDesc     = "desc"
 	Mintable      = "mintable"
+	Freezable     = "freezable"
+	MaxSupply     = "max-supply"
+	Decimals      = "decimals"
+	URI           = "uri"
+	Website       = "website"
+	LogoHash      = "logo-hash"
+	Multisig      = "multisig"
+	Threshold     = "threshold"
+	StartTime     = "start-time"
+	CliffTime     = "cliff-time"
+	EndTime       = "end-time"
 	Transfers     = "transfers"
 	TransfersFile = "transfers-file"
 )
@@ -44,6 +57,11 @@ var (
 	errTokenDescNotValid      = errors.New("token-desc not valid")
 	errTokenWholeNameNotValid = errors.New("token whole name not valid")
 	errMintableNotValid       = errors.New("mintable not valid")
+	errFreezableNotValid      = errors.New("freezable not valid")
+	errMaxSupplyNotValid      = errors.New("max supply not valid")
+	errDecimalsNotValid       = errors.New("decimals not valid")
+	errMetadataNotValid       = errors.New("metadata not valid")
+	errMultisigNotValid       = errors.New("multisig not valid")
 	errTransfersNotValid      = errors.New("transfers not valid")
 	errTransfersFileNotValid  = errors.New("transfers file not valid")
 	errSign                   = errors.New("sign not succeed")
@@ -65,9 +83,18 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdTokenBurn(cdc),
 		GetCmdTokenMint(cdc),
 		GetCmdTokenMultiSend(cdc),
+		GetCmdAirdrop(cdc),
 		GetCmdTransferOwnership(cdc),
 		GetMultiSignsCmd(cdc),
 		GetCmdTokenEdit(cdc),
+		GetCmdTimeLockedSend(cdc),
+		GetCmdTokenFreeze(cdc),
+		GetCmdTokenUnfreeze(cdc),
+		GetCmdProposeOwnership(cdc),
+		GetCmdConfirmOwnership(cdc),
+		GetCmdCreateMultisig(cdc),
+		GetCmdMultisigApprove(cdc),
+		GetCmdDistribute(cdc),
 	)...)
 
 	return distTxCmd
@@ -125,10 +152,36 @@ func GetCmdTokenIssue(cdc *codec.Codec) *cobra.Command {
 				return errMintableNotValid
 			}
 
+			freezable, err := flags.GetBool(Freezable)
+			if err != nil {
+				return errFreezableNotValid
+			}
+
+			maxSupply, err := flags.GetString(MaxSupply)
+			if err != nil {
+				return errMaxSupplyNotValid
+			}
+
+			decimals, err := flags.GetInt64(Decimals)
+			if err != nil {
+				return errDecimalsNotValid
+			}
+
+			uri, website, logoHash, err := getMetadataFlags(flags)
+			if err != nil {
+				return errMetadataNotValid
+			}
+
 			var symbol string
 
 			// totalSupply int64 ,coins bigint
 			msg := types.NewMsgTokenIssue(tokenDesc, symbol, originalSymbol, wholeName, totalSupply, cliCtx.FromAddress, mintable)
+			msg.Freezable = freezable
+			msg.MaxSupply = maxSupply
+			msg.Decimals = strconv.FormatInt(decimals, 10)
+			msg.URI = uri
+			msg.Website = website
+			msg.LogoHash = logoHash
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
@@ -139,10 +192,45 @@ func GetCmdTokenIssue(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(TokenDesc, "", "describe of the token")
 	cmd.Flags().StringP(TotalSupply, "n", "0", "total supply of the new token")
 	cmd.Flags().Bool(Mintable, false, "whether the token can be minted")
+	cmd.Flags().Bool(Freezable, false, "whether the owner can freeze the balances of the token")
+	cmd.Flags().String(MaxSupply, "", "max total supply the token can be minted to, no cap if empty or zero")
+	cmd.Flags().Int64(Decimals, types.DefaultDecimals, "decimals used by wallets to display the token")
+	cmd.Flags().String(URI, "", "uri of the token metadata")
+	cmd.Flags().String(Website, "", "website of the token")
+	cmd.Flags().String(LogoHash, "", "hex encoded sha256 hash of the token logo")
 
 	return cmd
 }
 
+// getActingOwner returns the multisig given by the flag, or the from address without the flag
+func getActingOwner(flags *pflag.FlagSet, from sdk.AccAddress) (sdk.AccAddress, error) {
+	multisig, err := flags.GetString(Multisig)
+	if err != nil || len(multisig) == 0 {
+		return from, err
+	}
+	return sdk.AccAddressFromBech32(multisig)
+}
+
+// wrapMultisigMsg submits the msg to the multisig signing it, unless it is signed by the from address
+func wrapMultisigMsg(msg sdk.Msg, from sdk.AccAddress) sdk.Msg {
+	if msg.GetSigners()[0].Equals(from) {
+		return msg
+	}
+	return types.NewMsgMultisigSubmit(from, msg)
+}
+
+// getMetadataFlags returns the uri, website and logo hash of the token metadata from the flags
+func getMetadataFlags(flags *pflag.FlagSet) (uri, website, logoHash string, err error) {
+	if uri, err = flags.GetString(URI); err != nil {
+		return
+	}
+	if website, err = flags.GetString(Website); err != nil {
+		return
+	}
+	logoHash, err = flags.GetString(LogoHash)
+	return
+}
+
 // GetCmdSetName is the CLI command for sending a SetName transaction
 func GetCmdTokenBurn(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -204,11 +292,17 @@ func GetCmdTokenMint(cdc *codec.Codec) *cobra.Command {
 				return errFromNotValid
 			}
 
-			msg := types.NewMsgTokenMint(amount, cliCtx.FromAddress)
+			owner, err := getActingOwner(flags, cliCtx.FromAddress)
+			if err != nil {
+				return errMultisigNotValid
+			}
 
-			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+			msg := types.NewMsgTokenMint(amount, owner)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{wrapMultisigMsg(msg, cliCtx.FromAddress)})
 		},
 	}
+	cmd.Flags().String(Multisig, "", "the multisig owning the token, the mint is submitted to it for approvals")
 	return cmd
 }
 
@@ -415,7 +509,7 @@ func SendTxCmd(cdc *codec.Codec) *cobra.Command {
 func GetCmdTokenEdit(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "edit",
-		Short: "edit a token's whole name and desc",
+		Short: "edit a token's whole name, desc, max supply, decimals and metadata",
 		//Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -462,17 +556,292 @@ func GetCmdTokenEdit(cdc *codec.Codec) *cobra.Command {
 					return errTokenWholeNameNotValid
 				}
 			}
-			if !isWholeNameEdit && !isDescEdit {
+			var isMaxSupplyEdit bool
+			var maxSupply string
+			msEditFlag := flags.Lookup(MaxSupply)
+			if msEditFlag != nil && msEditFlag.Changed {
+				isMaxSupplyEdit = true
+				maxSupply, err = flags.GetString(MaxSupply)
+				if err != nil {
+					return errMaxSupplyNotValid
+				}
+			}
+			var isDecimalsEdit bool
+			var decimals int64
+			decimalsEditFlag := flags.Lookup(Decimals)
+			if decimalsEditFlag != nil && decimalsEditFlag.Changed {
+				isDecimalsEdit = true
+				decimals, err = flags.GetInt64(Decimals)
+				if err != nil {
+					return errDecimalsNotValid
+				}
+			}
+			// the metadata is replaced as a whole, the fields not given are cleared
+			var isMetadataEdit bool
+			for _, name := range []string{URI, Website, LogoHash} {
+				if f := flags.Lookup(name); f != nil && f.Changed {
+					isMetadataEdit = true
+				}
+			}
+			uri, website, logoHash, err := getMetadataFlags(flags)
+			if err != nil {
+				return errMetadataNotValid
+			}
+			if !isWholeNameEdit && !isDescEdit && !isMaxSupplyEdit && !isDecimalsEdit && !isMetadataEdit {
 				return errParam
 			}
 
-			msg := types.NewMsgTokenModify(symbol, tokenDesc, wholeName, isDescEdit, isWholeNameEdit, cliCtx.FromAddress)
-			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+			owner, err := getActingOwner(flags, cliCtx.FromAddress)
+			if err != nil {
+				return errMultisigNotValid
+			}
+
+			msg := types.NewMsgTokenModify(symbol, tokenDesc, wholeName, isDescEdit, isWholeNameEdit, owner)
+			msg.MaxSupply = maxSupply
+			msg.IsMaxSupplyModified = isMaxSupplyEdit
+			msg.Decimals = decimals
+			msg.IsDecimalsModified = isDecimalsEdit
+			if isMetadataEdit {
+				msg.URI = uri
+				msg.Website = website
+				msg.LogoHash = logoHash
+				msg.IsMetadataModified = true
+			}
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{wrapMultisigMsg(msg, cliCtx.FromAddress)})
 		},
 	}
 	cmd.Flags().StringP(Symbol, "s", "", "symbol of the token")
 	cmd.Flags().StringP(WholeName, "w", "", "whole name of the token")
 	cmd.Flags().String(TokenDesc, "", "description of the token")
+	cmd.Flags().String(MaxSupply, "", "max total supply of the token, 0 to remove the cap")
+	cmd.Flags().Int64(Decimals, types.DefaultDecimals, "decimals used by wallets to display the token")
+	cmd.Flags().String(URI, "", "uri of the token metadata, the metadata is replaced as a whole")
+	cmd.Flags().String(Website, "", "website of the token, the metadata is replaced as a whole")
+	cmd.Flags().String(LogoHash, "", "hex encoded sha256 hash of the token logo, the metadata is replaced as a whole")
+	cmd.Flags().String(Multisig, "", "the multisig owning the token, the edit is submitted to it for approvals")
+
+	return cmd
+}
 
+// GetCmdTimeLockedSend is the CLI command for sending coins released by a vesting schedule
+func GetCmdTimeLockedSend(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "time-locked-send [to] [amount]",
+		Short: "send coins which are locked for the recipient and released linearly by block time",
+		Long: strings.TrimSpace(`Send coins which are locked for the recipient. Nothing is released before the cliff time,
+then the coins are released linearly from the start time to the end time. The times are unix timestamps in seconds:
+
+$ okchaincli tx token time-locked-send okchain1... 1000xxb-781 --start-time=1590000000 --cliff-time=1600000000 --end-time=1620000000
+`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			to, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			coins, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			startTime, err := flags.GetInt64(StartTime)
+			if err != nil {
+				return err
+			}
+			cliffTime, err := flags.GetInt64(CliffTime)
+			if err != nil {
+				return err
+			}
+			endTime, err := flags.GetInt64(EndTime)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgTimeLockedSend(cliCtx.GetFromAddress(), to, coins, startTime, cliffTime, endTime)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Int64(StartTime, 0, "unix time when the vesting starts")
+	cmd.Flags().Int64(CliffTime, 0, "unix time before which nothing is released")
+	cmd.Flags().Int64(EndTime, 0, "unix time when all the coins are released")
+	return cmd
+}
+
+// GetCmdTokenFreeze is the CLI command for freezing the balance of a token at an address
+func GetCmdTokenFreeze(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "freeze [symbol] [address]",
+		Short: "freeze the balance of a freezable token at an address",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			addr, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgFreeze(args[0], addr, cliCtx.GetFromAddress())
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdTokenUnfreeze is the CLI command for unfreezing the balance of a token at an address
+func GetCmdTokenUnfreeze(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unfreeze [symbol] [address]",
+		Short: "unfreeze the balance of a token at an address",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			addr, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUnfreeze(args[0], addr, cliCtx.GetFromAddress())
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdProposeOwnership is the CLI command for proposing to transfer the ownership of a token
+func GetCmdProposeOwnership(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-ownership [symbol] [to]",
+		Short: "propose to transfer the ownership of a token, which takes effect after the recipient confirms it",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			to, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+			owner, err := getActingOwner(cmd.Flags(), cliCtx.GetFromAddress())
+			if err != nil {
+				return errMultisigNotValid
+			}
+
+			msg := types.NewMsgProposeOwnership(owner, to, args[0])
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{wrapMultisigMsg(msg, cliCtx.GetFromAddress())})
+		},
+	}
+	cmd.Flags().String(Multisig, "", "the multisig owning the token, the proposal is submitted to it for approvals")
+	return cmd
+}
+
+// GetCmdConfirmOwnership is the CLI command for accepting the pending ownership transfer of a token
+func GetCmdConfirmOwnership(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "confirm-ownership [symbol]",
+		Short: "accept the pending ownership transfer of a token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			addr, err := getActingOwner(cmd.Flags(), cliCtx.GetFromAddress())
+			if err != nil {
+				return errMultisigNotValid
+			}
+
+			msg := types.NewMsgConfirmOwnership(addr, args[0])
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{wrapMultisigMsg(msg, cliCtx.GetFromAddress())})
+		},
+	}
+	cmd.Flags().String(Multisig, "", "the multisig receiving the ownership, the confirmation is submitted to it for approvals")
+	return cmd
+}
+
+// GetCmdCreateMultisig is the CLI command for creating a multisig which can own tokens
+func GetCmdCreateMultisig(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-multisig [member1,member2,...]",
+		Short: "create a multisig governed by the approvals of the members, which can own tokens",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			var members []sdk.AccAddress
+			for _, str := range strings.Split(args[0], ",") {
+				member, err := sdk.AccAddressFromBech32(strings.TrimSpace(str))
+				if err != nil {
+					return err
+				}
+				members = append(members, member)
+			}
+			threshold, err := cmd.Flags().GetUint64(Threshold)
+			if err != nil {
+				return errMultisigNotValid
+			}
+
+			msg := types.NewMsgCreateMultisig(cliCtx.GetFromAddress(), members, threshold)
+			fmt.Printf("multisig address: %s\n", types.NewMultisig(members, threshold).Address)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Uint64(Threshold, 1, "the number of approvals needed to execute a proposal of the multisig")
+	return cmd
+}
+
+// GetCmdMultisigApprove is the CLI command for approving a pending proposal of a multisig
+func GetCmdMultisigApprove(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "multisig-approve [proposal-id]",
+		Short: "approve a pending proposal of a multisig, which is executed once it gets enough approvals",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid proposal id %s", args[0])
+			}
+
+			msg := types.NewMsgMultisigApprove(cliCtx.GetFromAddress(), id)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdDistribute is the CLI command for distributing coins to the holders of a token pro rata
+func GetCmdDistribute(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "distribute [symbol] [amount]",
+		Short: "distribute coins to the holders of a token pro rata to their balances, including the coins locked in orders",
+		Long: `Distribute coins to the holders of a token pro rata to their balances, including the coins locked in orders.
+The holders except the owner are snapshotted at the height of the tx, and are paid by batches in the following blocks.
+The coins left by the truncation of the shares are returned to the owner, see the progress by "query token distributions".`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			amount, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return err
+			}
+			owner, err := getActingOwner(cmd.Flags(), cliCtx.GetFromAddress())
+			if err != nil {
+				return errMultisigNotValid
+			}
+
+			msg := types.NewMsgDistribute(owner, args[0], amount)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{wrapMultisigMsg(msg, cliCtx.GetFromAddress())})
+		},
+	}
+	cmd.Flags().String(Multisig, "", "the multisig owning the token, the distribution is submitted to it for approvals")
 	return cmd
 }
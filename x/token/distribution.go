@@ -0,0 +1,360 @@
+package token
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+
+	"github.com/okex/okchain/x/token/types"
+)
+
+// snapshotAccountKeeper iterates the accounts from a cursor over the store of the auth module
+type snapshotAccountKeeper struct {
+	auth.AccountKeeper
+	storeKey sdk.StoreKey
+}
+
+// NewSnapshotAccountKeeper returns the account keeper of the snapshots of the holders.
+// storeKey must be the store key of the account keeper
+func NewSnapshotAccountKeeper(ak auth.AccountKeeper, storeKey sdk.StoreKey) SnapshotAccountKeeper {
+	return snapshotAccountKeeper{AccountKeeper: ak, storeKey: storeKey}
+}
+
+// IterateAccountsFrom iterates the accounts in the order of their addresses, from the start address included
+func (k snapshotAccountKeeper) IterateAccountsFrom(ctx sdk.Context, start sdk.AccAddress,
+	process func(authexported.Account) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(auth.AddressStoreKey(start), sdk.PrefixEndBytes(auth.AddressStoreKeyPrefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		acc := k.GetAccount(ctx, iter.Key()[len(auth.AddressStoreKeyPrefix):])
+		if acc != nil && process(acc) {
+			break
+		}
+	}
+}
+
+// IterateHolders iterates the accounts holding the token with their available and locked balances,
+// except the module accounts
+func (k Keeper) IterateHolders(ctx sdk.Context, accountKeeper AccountKeeper, symbol string,
+	fn func(holder types.Holder) (stop bool)) {
+
+	accountKeeper.IterateAccounts(ctx, func(acc authexported.Account) bool {
+		if holder, ok := k.holderOf(ctx, acc, symbol); ok {
+			return fn(holder)
+		}
+		return false
+	})
+}
+
+// holderOf returns the holding of the account, the module accounts are not holders
+func (k Keeper) holderOf(ctx sdk.Context, acc authexported.Account, symbol string) (types.Holder, bool) {
+	if _, ok := acc.(supplyexported.ModuleAccountI); ok {
+		return types.Holder{}, false
+	}
+	available := acc.GetCoins().AmountOf(symbol)
+	locked := k.GetLockCoins(ctx, acc.GetAddress()).AmountOf(symbol)
+	holder := types.NewHolder(acc.GetAddress(), available, locked)
+	return holder, holder.Total.IsPositive()
+}
+
+// snapshotBeforeChange saves the holdings of the coins at addr in the snapshots being taken, before they change.
+// An account is snapshotted once, either here ahead of the cursor or when the cursor reaches it, so that a snapshot
+// taken by batches across blocks is the holdings right after the deposit however the tokens move in between.
+// The blacklisted addresses are the module accounts of the app, which aren't holders
+func snapshotBeforeChange(ctx sdk.Context, bk bank.Keeper, tokenStoreKey, lockStoreKey sdk.StoreKey,
+	addr sdk.AccAddress, coins sdk.DecCoins) {
+
+	if bk.BlacklistedAddr(addr) {
+		return
+	}
+	store := ctx.KVStore(tokenStoreKey)
+	for _, coin := range coins {
+		symbol := coin.Denom
+		prefix := types.GetDistributionSnapshotPrefix(symbol)
+		var ids []uint64
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iter.Valid(); iter.Next() {
+			ids = append(ids, binary.BigEndian.Uint64(iter.Key()[len(prefix):]))
+		}
+		iter.Close()
+
+		for _, id := range ids {
+			bz := store.Get(types.GetDistributionKey(id))
+			if bz == nil {
+				continue
+			}
+			var distribution types.Distribution
+			types.ModuleCdc.MustUnmarshalBinaryBare(bz, &distribution)
+			holderKey := types.GetDistributionHolderKey(id, addr)
+			if distribution.Sender.Equals(addr) || store.Has(holderKey) ||
+				(distribution.SnapshotCursor != nil && bytes.Compare(addr, distribution.SnapshotCursor) < 0) {
+				continue
+			}
+
+			var locked sdk.DecCoins
+			if bz := ctx.KVStore(lockStoreKey).Get(types.GetLockAddress(addr)); bz != nil {
+				types.ModuleCdc.MustUnmarshalBinaryBare(bz, &locked)
+			}
+			holder := types.NewHolder(addr, bk.GetCoins(ctx, addr).AmountOf(symbol), locked.AmountOf(symbol))
+			// an account without holding is saved as well, to be skipped by the cursor
+			store.Set(holderKey, types.ModuleCdc.MustMarshalBinaryBare(types.DistributionHolder{
+				DistributionID: id,
+				Address:        addr,
+				Holding:        holder.Total,
+			}))
+			if holder.Total.IsPositive() {
+				distribution.TotalHolding = distribution.TotalHolding.Add(holder.Total)
+				distribution.Holders++
+				store.Set(types.GetDistributionKey(id), types.ModuleCdc.MustMarshalBinaryBare(distribution))
+			}
+		}
+	}
+}
+
+// Distribute deposits the coins of the sender into the token module account,
+// which are distributed to the holders of the token by the BeginBlocker
+func (k Keeper) Distribute(ctx sdk.Context, sender sdk.AccAddress, symbol string,
+	amount sdk.DecCoins) (types.Distribution, error) {
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, amount); err != nil {
+		return types.Distribution{}, err
+	}
+	distribution := types.NewDistribution(k.getNextDistributionID(ctx), symbol, sender, amount, ctx.BlockHeight())
+	k.SetDistribution(ctx, distribution)
+	return distribution, nil
+}
+
+// ProcessDistributions snapshots the holders of the pending distributions and pays the holders of the distributing
+// ones in the order of their ids, scanning and paying at most DistributeBatchSize accounts in total
+func (k Keeper) ProcessDistributions(ctx sdk.Context, accountKeeper SnapshotAccountKeeper) {
+	var distributions []types.Distribution
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.DistributionQueueKey)
+	for ; iter.Valid(); iter.Next() {
+		distribution, found := k.GetDistribution(ctx, binary.BigEndian.Uint64(iter.Key()[len(types.DistributionQueueKey):]))
+		if found {
+			distributions = append(distributions, distribution)
+		}
+	}
+	iter.Close()
+	if len(distributions) == 0 {
+		return
+	}
+
+	budget := k.GetParams(ctx).DistributeBatchSize
+	for _, distribution := range distributions {
+		if budget <= 0 {
+			return
+		}
+		if distribution.Status == types.DistributionStatusPending {
+			budget -= k.snapshotHolders(ctx, accountKeeper, &distribution, budget)
+		}
+		if distribution.Status == types.DistributionStatusDistributing {
+			budget -= k.payHolders(ctx, &distribution, budget)
+			if !k.hasDistributionHolders(ctx, distribution.ID) {
+				k.finishDistribution(ctx, &distribution)
+			}
+		}
+		k.SetDistribution(ctx, distribution)
+	}
+}
+
+// snapshotHolders saves the holders of the token except the sender with the sum of their holdings, scanning at most
+// limit accounts from the cursor of the distribution, and returns the number of the scanned ones.
+// The accounts whose holdings changed ahead of the cursor are already saved by snapshotBeforeChange
+func (k Keeper) snapshotHolders(ctx sdk.Context, accountKeeper SnapshotAccountKeeper,
+	distribution *types.Distribution, limit int64) (scanned int64) {
+
+	completed := true
+	accountKeeper.IterateAccountsFrom(ctx, distribution.SnapshotCursor, func(acc authexported.Account) bool {
+		if scanned >= limit {
+			distribution.SnapshotCursor = acc.GetAddress()
+			completed = false
+			return true
+		}
+		scanned++
+		holder, ok := k.holderOf(ctx, acc, distribution.Symbol)
+		if !ok || holder.Address.Equals(distribution.Sender) ||
+			ctx.KVStore(k.tokenStoreKey).Has(types.GetDistributionHolderKey(distribution.ID, holder.Address)) {
+			return false
+		}
+		k.SetDistributionHolder(ctx, types.DistributionHolder{
+			DistributionID: distribution.ID,
+			Address:        holder.Address,
+			Holding:        holder.Total,
+		})
+		distribution.TotalHolding = distribution.TotalHolding.Add(holder.Total)
+		distribution.Holders++
+		return false
+	})
+	if completed {
+		distribution.SnapshotCursor = nil
+		distribution.Status = types.DistributionStatusDistributing
+	}
+	return scanned
+}
+
+// payHolders pays at most limit holders in the snapshot of the distribution, and returns the number of the paid ones.
+// The accounts saved without holding are deleted without being counted as paid
+func (k Keeper) payHolders(ctx sdk.Context, distribution *types.Distribution, limit int64) (paid int64) {
+	var holders []types.DistributionHolder
+	k.IterateDistributionHolders(ctx, distribution.ID, func(holder types.DistributionHolder) bool {
+		holders = append(holders, holder)
+		return int64(len(holders)) >= limit
+	})
+
+	store := ctx.KVStore(k.tokenStoreKey)
+	for _, holder := range holders {
+		share := distribution.ShareOf(holder.Holding)
+		if !share.IsZero() {
+			// the share failed to be paid is returned to the sender with the dust
+			if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, holder.Address, share); err != nil {
+				ctx.Logger().Error(fmt.Sprintf("failed to pay %s to %s by distribution %d: %s",
+					share, holder.Address, distribution.ID, err))
+			} else {
+				distribution.Distributed = distribution.Distributed.Add(share)
+			}
+		}
+		store.Delete(types.GetDistributionHolderKey(distribution.ID, holder.Address))
+		if holder.Holding.IsPositive() {
+			distribution.Paid++
+		}
+		paid++
+	}
+	return paid
+}
+
+// finishDistribution returns the coins left by the truncation of the shares to the sender
+func (k Keeper) finishDistribution(ctx sdk.Context, distribution *types.Distribution) {
+	left, isNegative := distribution.Amount.SafeSub(distribution.Distributed)
+	if isNegative {
+		ctx.Logger().Error(fmt.Sprintf("distribution %d distributed %s more than the deposit %s",
+			distribution.ID, distribution.Distributed, distribution.Amount))
+		left = sdk.DecCoins{}
+	}
+	if !left.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, distribution.Sender, left); err != nil {
+			ctx.Logger().Error(fmt.Sprintf("failed to return %s to %s by distribution %d: %s",
+				left, distribution.Sender, distribution.ID, err))
+			return
+		}
+		distribution.Returned = left
+	}
+	distribution.Status = types.DistributionStatusFinished
+	ctx.KVStore(k.tokenStoreKey).Delete(types.GetDistributionQueueKey(distribution.ID))
+}
+
+// SetDistribution saves the distribution, which is queued to be processed by the BeginBlocker until it finishes.
+// The changes of the holdings of the token are followed while its snapshot is taken
+func (k Keeper) SetDistribution(ctx sdk.Context, distribution types.Distribution) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetDistributionKey(distribution.ID), k.cdc.MustMarshalBinaryBare(distribution))
+	if distribution.Status != types.DistributionStatusFinished {
+		store.Set(types.GetDistributionQueueKey(distribution.ID), []byte{})
+	}
+	if distribution.Status == types.DistributionStatusPending {
+		store.Set(types.GetDistributionSnapshotKey(distribution.Symbol, distribution.ID), []byte{})
+	} else {
+		store.Delete(types.GetDistributionSnapshotKey(distribution.Symbol, distribution.ID))
+	}
+}
+
+// GetDistribution gets the distribution by id
+func (k Keeper) GetDistribution(ctx sdk.Context, id uint64) (distribution types.Distribution, found bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	bz := store.Get(types.GetDistributionKey(id))
+	if bz == nil {
+		return distribution, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &distribution)
+	return distribution, true
+}
+
+// IterateDistributions iterates all the distributions in the order of their ids
+func (k Keeper) IterateDistributions(ctx sdk.Context, fn func(distribution types.Distribution) (stop bool)) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.DistributionKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var distribution types.Distribution
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &distribution)
+		if fn(distribution) {
+			break
+		}
+	}
+}
+
+// GetDistributions gets the distributions of the token, or all the distributions if symbol is empty
+func (k Keeper) GetDistributions(ctx sdk.Context, symbol string) (distributions []types.Distribution) {
+	k.IterateDistributions(ctx, func(distribution types.Distribution) bool {
+		if symbol == "" || distribution.Symbol == symbol {
+			distributions = append(distributions, distribution)
+		}
+		return false
+	})
+	return distributions
+}
+
+// IterateDistributionHolders iterates the unpaid holders in the snapshot of the distribution
+func (k Keeper) IterateDistributionHolders(ctx sdk.Context, id uint64,
+	fn func(holder types.DistributionHolder) (stop bool)) {
+
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetDistributionHolderPrefix(id))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var holder types.DistributionHolder
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &holder)
+		if fn(holder) {
+			break
+		}
+	}
+}
+
+// hasDistributionHolders checks whether any holder in the snapshot of the distribution is left to be paid
+func (k Keeper) hasDistributionHolders(ctx sdk.Context, id uint64) bool {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.tokenStoreKey), types.GetDistributionHolderPrefix(id))
+	defer iter.Close()
+	return iter.Valid()
+}
+
+// GetAllDistributionHolders gets the unpaid holders in the snapshots of all the distributions
+func (k Keeper) GetAllDistributionHolders(ctx sdk.Context) (holders []types.DistributionHolder) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.DistributionHolderKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var holder types.DistributionHolder
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &holder)
+		holders = append(holders, holder)
+	}
+	return holders
+}
+
+// SetDistributionHolder saves a holder in the snapshot of a distribution
+func (k Keeper) SetDistributionHolder(ctx sdk.Context, holder types.DistributionHolder) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetDistributionHolderKey(holder.DistributionID, holder.Address), k.cdc.MustMarshalBinaryBare(holder))
+}
+
+func (k Keeper) getNextDistributionID(ctx sdk.Context) (id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	if b := store.Get(types.DistributionNumberKey); b != nil {
+		k.cdc.MustUnmarshalBinaryBare(b, &id)
+	}
+	k.setNextDistributionID(ctx, id+1)
+	return id
+}
+
+func (k Keeper) setNextDistributionID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.DistributionNumberKey, k.cdc.MustMarshalBinaryBare(id))
+}
@@ -0,0 +1,162 @@
+package token
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestHandleMsgDistribute(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(4,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 3})
+	params := types.DefaultParams()
+	params.DistributeBatchSize = 2
+	keeper.SetParams(ctx, params)
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	var addrs []sdk.AccAddress
+	for _, acc := range testAccounts {
+		addrs = append(addrs, acc.baseAccount.Address)
+	}
+
+	require.True(t, handler(ctx, types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", addrs[0], true)).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+	for i, amount := range []int64{300, 100, 200} {
+		coins := sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.NewDec(amount))}
+		require.NoError(t, keeper.SendCoinsFromAccountToAccount(ctx, addrs[0], addrs[i+1], coins))
+	}
+	// the coins locked in orders are counted
+	lockCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.NewDec(50))}
+	require.NoError(t, keeper.LockCoins(ctx, addrs[2], lockCoins, types.LockCoinsTypeQuantity))
+
+	amount := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10))}
+	require.Equal(t, sdk.CodeUnauthorized, handler(ctx, types.NewMsgDistribute(addrs[1], symbol, amount)).Code)
+	tooMuch := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000000))}
+	require.False(t, handler(ctx, types.NewMsgDistribute(addrs[0], symbol, tooMuch)).IsOK())
+
+	var balances []sdk.Dec
+	for _, addr := range addrs {
+		balances = append(balances, keeper.GetCoins(ctx, addr).AmountOf(common.NativeToken))
+	}
+	require.True(t, handler(ctx, types.NewMsgDistribute(addrs[0], symbol, amount)).IsOK())
+	fee := params.FeeDistribute.Amount
+	require.Equal(t, balances[0].Sub(sdk.NewDec(10)).Sub(fee), keeper.GetCoins(ctx, addrs[0]).AmountOf(common.NativeToken))
+	distribution, found := keeper.GetDistribution(ctx, 0)
+	require.True(t, found)
+	require.Equal(t, types.DistributionStatusPending, distribution.Status)
+	require.Equal(t, int64(3), distribution.Height)
+	_, broken := AllInvariants(keeper, mapp.AccountKeeper)(ctx)
+	require.False(t, broken)
+
+	// the holders except the sender are snapshotted by batches of accounts across blocks
+	accountKeeper := NewSnapshotAccountKeeper(mapp.AccountKeeper, mapp.KeyAccount)
+	BeginBlocker(ctx, keeper, accountKeeper)
+	distribution, _ = keeper.GetDistribution(ctx, 0)
+	require.Equal(t, types.DistributionStatusPending, distribution.Status)
+	require.NotEmpty(t, distribution.SnapshotCursor)
+	exported := ExportGenesis(ctx, keeper)
+	require.Equal(t, []types.Distribution{distribution}, exported.Distributions)
+	require.Equal(t, int(distribution.Holders), len(exported.DistributionHolders))
+	require.NoError(t, ValidateGenesis(exported))
+
+	// the tokens moved while the snapshot is taken don't change the holdings at the deposit,
+	// whichever side of the cursor the accounts are
+	moved := sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.NewDec(100))}
+	require.NoError(t, keeper.SendCoinsFromAccountToAccount(ctx, addrs[1], addrs[3], moved))
+	moved = sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.NewDec(50))}
+	require.NoError(t, keeper.SendCoinsFromAccountToAccount(ctx, addrs[2], addrs[1], moved))
+	blocks := 1
+	for distribution.Status == types.DistributionStatusPending {
+		BeginBlocker(ctx, keeper, accountKeeper)
+		distribution, _ = keeper.GetDistribution(ctx, 0)
+		blocks++
+	}
+	// 4 accounts and the token module account keeping the locked coins, the budget left pays a holder
+	require.Equal(t, 3, blocks)
+	require.Empty(t, distribution.SnapshotCursor)
+	require.Equal(t, sdk.NewDec(600), distribution.TotalHolding)
+	require.Equal(t, int64(3), distribution.Holders)
+	require.Equal(t, int64(1), distribution.Paid)
+	require.Equal(t, 2, len(ExportGenesis(ctx, keeper).DistributionHolders))
+	_, broken = AllInvariants(keeper, mapp.AccountKeeper)(ctx)
+	require.False(t, broken)
+
+	// the dust of the truncated shares is returned to the sender
+	BeginBlocker(ctx, keeper, accountKeeper)
+	distribution, _ = keeper.GetDistribution(ctx, 0)
+	require.Equal(t, types.DistributionStatusFinished, distribution.Status)
+	require.Equal(t, int64(3), distribution.Paid)
+	require.Equal(t, "9.99999999okt", distribution.Distributed.String())
+	require.Equal(t, "0.00000001okt", distribution.Returned.String())
+	require.Nil(t, keeper.GetAllDistributionHolders(ctx))
+	for i, share := range []string{"-19.99999999", "5", "1.66666666", "3.33333333"} {
+		expected := balances[i].Add(sdk.MustNewDecFromStr(share))
+		require.Equal(t, expected, keeper.GetCoins(ctx, addrs[i]).AmountOf(common.NativeToken))
+	}
+	BeginBlocker(ctx, keeper, accountKeeper)
+	finished, _ := keeper.GetDistribution(ctx, 0)
+	require.Equal(t, distribution, finished)
+
+	// all the coins are returned if there is no holder except the sender
+	issueMsg := types.NewMsgTokenIssue("yyb", "", "yyb", "yyb", "1000", addrs[0], true)
+	require.True(t, handler(ctx.WithTxBytes([]byte("yyb")), issueMsg).IsOK())
+	require.True(t, handler(ctx, types.NewMsgDistribute(addrs[0], getTokenSymbol(ctx, keeper, "yyb"), amount)).IsOK())
+	for i := 0; i < 3; i++ {
+		BeginBlocker(ctx, keeper, accountKeeper)
+	}
+	distribution, _ = keeper.GetDistribution(ctx, 1)
+	require.Equal(t, types.DistributionStatusFinished, distribution.Status)
+	require.Equal(t, amount, distribution.Returned)
+
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
+	bz, err := querier(ctx, []string{types.QueryDistributions, symbol}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var distributions types.Distributions
+	keeper.cdc.MustUnmarshalJSON(bz, &distributions)
+	require.Equal(t, 1, len(distributions))
+	require.Equal(t, uint64(0), distributions[0].ID)
+	bz, err = querier(ctx, []string{types.QueryDistributions, "1"}, abci.RequestQuery{})
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &distributions)
+	require.Equal(t, types.Distributions{distribution}, distributions)
+	_, err = querier(ctx, []string{types.QueryDistributions, "2"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestValidateGenesisDistributions(t *testing.T) {
+	gs := DefaultGenesisState()
+	distribution := types.NewDistribution(0, common.NativeToken, gs.Tokens[0].Owner,
+		sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1))}, 1)
+	gs.Distributions = []types.Distribution{distribution}
+	gs.DistributionHolders = []types.DistributionHolder{
+		{DistributionID: 0, Address: gs.Tokens[0].Owner, Holding: sdk.NewDec(1)},
+	}
+	require.NoError(t, ValidateGenesis(gs))
+
+	gs.Distributions[0].Status = types.DistributionStatusDistributing
+	require.NoError(t, ValidateGenesis(gs))
+
+	gs.Distributions[0].Status = types.DistributionStatusFinished
+	require.Error(t, ValidateGenesis(gs))
+
+	gs.Distributions[0].Status = "unknown"
+	require.Error(t, ValidateGenesis(gs))
+
+	// an account without holding is saved ahead of the cursor
+	gs.Distributions[0].Status = types.DistributionStatusPending
+	gs.DistributionHolders[0].Holding = sdk.ZeroDec()
+	require.NoError(t, ValidateGenesis(gs))
+	gs.DistributionHolders[0].Holding = sdk.NewDec(-1)
+	require.Error(t, ValidateGenesis(gs))
+}
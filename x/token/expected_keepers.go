@@ -2,6 +2,7 @@ package token
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
 )
 
@@ -24,3 +25,16 @@ type SupplyKeeper interface {
 type StakingKeeper interface {
 	IsValidator(ctx sdk.Context, addr sdk.AccAddress) bool
 }
+
+// AccountKeeper defines the expected account Keeper (noalias)
+type AccountKeeper interface {
+	IterateAccounts(ctx sdk.Context, process func(authexported.Account) (stop bool))
+	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
+}
+
+// SnapshotAccountKeeper defines the expected account Keeper of the snapshots of the holders,
+// which are taken by batches of accounts from a cursor (noalias)
+type SnapshotAccountKeeper interface {
+	AccountKeeper
+	IterateAccountsFrom(ctx sdk.Context, start sdk.AccAddress, process func(authexported.Account) (stop bool))
+}
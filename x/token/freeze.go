@@ -0,0 +1,148 @@
+package token
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+
+	"github.com/okex/okchain/x/token/types"
+)
+
+// FreezeAccount freezes the balance of the token at the addr
+func (k Keeper) FreezeAccount(ctx sdk.Context, symbol string, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetFrozenKey(symbol, addr), []byte{})
+}
+
+// UnfreezeAccount unfreezes the balance of the token at the addr
+func (k Keeper) UnfreezeAccount(ctx sdk.Context, symbol string, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Delete(types.GetFrozenKey(symbol, addr))
+}
+
+// IsFrozen checks whether the balance of the token at the addr is frozen
+func (k Keeper) IsFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress) bool {
+	return ctx.KVStore(k.tokenStoreKey).Has(types.GetFrozenKey(symbol, addr))
+}
+
+// GetFrozenAccounts gets all the addresses whose balance of the token is frozen
+func (k Keeper) GetFrozenAccounts(ctx sdk.Context, symbol string) (addrs []sdk.AccAddress) {
+	prefix := types.GetFrozenPrefix(symbol)
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addrs = append(addrs, sdk.AccAddress(iter.Key()[len(prefix):]))
+	}
+	return addrs
+}
+
+// GetAllFrozenAccounts gets the frozen accounts of all the tokens
+func (k Keeper) GetAllFrozenAccounts(ctx sdk.Context) (frozenAccounts []types.FrozenAccount) {
+	for _, token := range k.GetTokensInfo(ctx) {
+		for _, addr := range k.GetFrozenAccounts(ctx, token.Symbol) {
+			frozenAccounts = append(frozenAccounts, types.FrozenAccount{Symbol: token.Symbol, Address: addr})
+		}
+	}
+	return frozenAccounts
+}
+
+// FreezableBankKeeper is a bank keeper refusing to move the frozen balances out of their accounts. The app builds
+// the supply keeper and routes the bank module with it, so the freezes hold for the transfers of every module.
+// It also saves the holdings in the snapshots of the distributions before they change
+type FreezableBankKeeper struct {
+	bank.Keeper
+	tokenStoreKey sdk.StoreKey
+	lockStoreKey  sdk.StoreKey
+}
+
+// NewFreezableBankKeeper wraps bk with the freezes kept in the token store
+func NewFreezableBankKeeper(bk bank.Keeper, tokenStoreKey, lockStoreKey sdk.StoreKey) FreezableBankKeeper {
+	return FreezableBankKeeper{Keeper: bk, tokenStoreKey: tokenStoreKey, lockStoreKey: lockStoreKey}
+}
+
+// SendCoins sends the coins unless any of them is frozen at fromAddr
+func (k FreezableBankKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress,
+	amt sdk.Coins) sdk.Error {
+	if err := k.checkFrozenCoins(ctx, fromAddr, amt); err != nil {
+		return err
+	}
+	k.snapshotBeforeChange(ctx, fromAddr, amt)
+	k.snapshotBeforeChange(ctx, toAddr, amt)
+	return k.Keeper.SendCoins(ctx, fromAddr, toAddr, amt)
+}
+
+// InputOutputCoins moves the coins unless any of them is frozen at an input
+func (k FreezableBankKeeper) InputOutputCoins(ctx sdk.Context, inputs []bank.Input, outputs []bank.Output) sdk.Error {
+	for _, input := range inputs {
+		if err := k.checkFrozenCoins(ctx, input.Address, input.Coins); err != nil {
+			return err
+		}
+	}
+	for _, input := range inputs {
+		k.snapshotBeforeChange(ctx, input.Address, input.Coins)
+	}
+	for _, output := range outputs {
+		k.snapshotBeforeChange(ctx, output.Address, output.Coins)
+	}
+	return k.Keeper.InputOutputCoins(ctx, inputs, outputs)
+}
+
+// DelegateCoins delegates the coins unless any of them is frozen at delegatorAddr
+func (k FreezableBankKeeper) DelegateCoins(ctx sdk.Context, delegatorAddr, moduleAccAddr sdk.AccAddress,
+	amt sdk.Coins) sdk.Error {
+	if err := k.checkFrozenCoins(ctx, delegatorAddr, amt); err != nil {
+		return err
+	}
+	k.snapshotBeforeChange(ctx, delegatorAddr, amt)
+	return k.Keeper.DelegateCoins(ctx, delegatorAddr, moduleAccAddr, amt)
+}
+
+// UndelegateCoins undelegates the coins
+func (k FreezableBankKeeper) UndelegateCoins(ctx sdk.Context, moduleAccAddr, delegatorAddr sdk.AccAddress,
+	amt sdk.Coins) sdk.Error {
+	k.snapshotBeforeChange(ctx, delegatorAddr, amt)
+	return k.Keeper.UndelegateCoins(ctx, moduleAccAddr, delegatorAddr, amt)
+}
+
+// AddCoins adds the coins to the account
+func (k FreezableBankKeeper) AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error) {
+	k.snapshotBeforeChange(ctx, addr, amt)
+	return k.Keeper.AddCoins(ctx, addr, amt)
+}
+
+// SubtractCoins subtracts the coins from the account
+func (k FreezableBankKeeper) SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Error) {
+	k.snapshotBeforeChange(ctx, addr, amt)
+	return k.Keeper.SubtractCoins(ctx, addr, amt)
+}
+
+// snapshotBeforeChange saves the holdings of the coins at addr in the snapshots being taken
+func (k FreezableBankKeeper) snapshotBeforeChange(ctx sdk.Context, addr sdk.AccAddress, coins sdk.Coins) {
+	snapshotBeforeChange(ctx, k.Keeper, k.tokenStoreKey, k.lockStoreKey, addr, coins)
+}
+
+// checkFrozenCoins returns an error if the balance of any of the coins at the addr is frozen.
+// The module accounts are never frozen, even by a freeze imported from the genesis
+func (k FreezableBankKeeper) checkFrozenCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.Coins) sdk.Error {
+	if k.BlacklistedAddr(addr) {
+		return nil
+	}
+	store := ctx.KVStore(k.tokenStoreKey)
+	for _, coin := range coins {
+		if store.Has(types.GetFrozenKey(coin.Denom, addr)) {
+			return types.ErrAccountFrozen(types.DefaultCodespace, coin.Denom, addr)
+		}
+	}
+	return nil
+}
+
+// transferFailed is the result of a failed transfer, it keeps the errors of the frozen balances and reports the
+// others as insufficient coins
+func transferFailed(err error, coins sdk.DecCoins) sdk.Result {
+	if sdkErr, ok := err.(sdk.Error); ok && sdkErr.Code() == types.CodeAccountFrozen {
+		return sdkErr.Result()
+	}
+	return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s)", coins.String())).Result()
+}
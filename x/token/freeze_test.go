@@ -0,0 +1,122 @@
+package token
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestHandleMsgFreeze(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(2,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	owner, holder := testAccounts[0].baseAccount.Address, testAccounts[1].baseAccount.Address
+
+	issueMsg := types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", owner, false)
+	issueMsg.Freezable = true
+	require.True(t, handler(ctx, issueMsg).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+	require.True(t, keeper.GetTokenInfo(ctx, symbol).Freezable)
+	coins := sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, sdk.NewDec(100))}
+	require.True(t, handler(ctx, types.NewMsgTokenSend(owner, holder, coins)).IsOK())
+
+	// only the owner can freeze
+	require.False(t, handler(ctx, types.NewMsgFreeze(symbol, holder, holder)).IsOK())
+	require.True(t, handler(ctx, types.NewMsgFreeze(symbol, holder, owner)).IsOK())
+	require.False(t, handler(ctx, types.NewMsgFreeze(symbol, holder, owner)).IsOK())
+	require.True(t, keeper.IsFrozen(ctx, symbol, holder))
+	require.Equal(t, []sdk.AccAddress{holder}, keeper.GetFrozenAccounts(ctx, symbol))
+	require.Equal(t, []types.FrozenAccount{{Symbol: symbol, Address: holder}}, keeper.GetAllFrozenAccounts(ctx))
+
+	// the module accounts can not be frozen
+	feeCollector := mapp.supplyKeeper.GetModuleAddress(auth.FeeCollectorName)
+	res := handler(ctx, types.NewMsgFreeze(symbol, feeCollector, owner))
+	require.Equal(t, sdk.CodeUnauthorized, res.Code)
+	require.False(t, keeper.IsFrozen(ctx, symbol, feeCollector))
+	// nor blocked by a freeze imported from the genesis
+	keeper.FreezeAccount(ctx, symbol, feeCollector)
+	require.NoError(t, keeper.SendCoinsFromAccountToAccount(ctx, owner, feeCollector, coins))
+	require.Nil(t, mapp.supplyKeeper.SendCoinsFromModuleToAccount(ctx, auth.FeeCollectorName, owner, coins))
+	keeper.UnfreezeAccount(ctx, symbol, feeCollector)
+
+	// frozen balances can not be sent
+	res = handler(ctx, types.NewMsgTokenSend(holder, owner, coins))
+	require.Equal(t, types.CodeAccountFrozen, res.Code)
+	res = handler(ctx, types.NewMsgMultiSend(holder, []types.TransferUnit{{To: owner, Coins: coins}}))
+	require.Equal(t, types.CodeAccountFrozen, res.Code)
+	nativeCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1))}
+	require.True(t, handler(ctx, types.NewMsgTokenSend(holder, owner, nativeCoins)).IsOK())
+
+	// nor through the bank module or any module using the supply keeper
+	mapp.bankKeeper.SetSendEnabled(ctx, true)
+	bankHandler := bank.NewHandler(mapp.bankKeeper)
+	res = bankHandler(ctx, bank.MsgSend{FromAddress: holder, ToAddress: owner, Amount: coins})
+	require.Equal(t, types.CodeAccountFrozen, res.Code)
+	res = bankHandler(ctx, bank.MsgMultiSend{Inputs: []bank.Input{bank.NewInput(holder, coins)},
+		Outputs: []bank.Output{bank.NewOutput(owner, coins)}})
+	require.Equal(t, types.CodeAccountFrozen, res.Code)
+	require.True(t, bankHandler(ctx, bank.MsgSend{FromAddress: holder, ToAddress: owner, Amount: nativeCoins}).IsOK())
+	require.NotNil(t, mapp.supplyKeeper.SendCoinsFromAccountToModule(ctx, holder, types.ModuleName, coins))
+	require.Equal(t, sdk.NewDec(100), keeper.GetCoins(ctx, holder).AmountOf(symbol))
+
+	// frozen accounts are exported
+	require.Equal(t, keeper.GetAllFrozenAccounts(ctx), ExportGenesis(ctx, keeper).FrozenAccounts)
+
+	// unfreeze
+	require.False(t, handler(ctx, types.NewMsgUnfreeze(symbol, holder, holder)).IsOK())
+	require.True(t, handler(ctx, types.NewMsgUnfreeze(symbol, holder, owner)).IsOK())
+	require.False(t, handler(ctx, types.NewMsgUnfreeze(symbol, holder, owner)).IsOK())
+	require.False(t, keeper.IsFrozen(ctx, symbol, holder))
+	require.Nil(t, keeper.GetFrozenAccounts(ctx, symbol))
+	require.True(t, handler(ctx, types.NewMsgTokenSend(holder, owner, coins)).IsOK())
+
+	// the token is not freezable
+	issueMsg = types.NewMsgTokenIssue("yyb", "", "yyb", "yyb", "1000", owner, false)
+	require.True(t, handler(ctx.WithTxBytes([]byte("yyb")), issueMsg).IsOK())
+	symbol = getTokenSymbol(ctx, keeper, "yyb")
+	require.False(t, handler(ctx, types.NewMsgFreeze(symbol, holder, owner)).IsOK())
+}
+
+func TestQueryFrozen(t *testing.T) {
+	mapp, keeper, addrs := getMockDexApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.NewToken(ctx, types.Token{Symbol: "xxb-781", Owner: addrs[0], Freezable: true})
+	keeper.FreezeAccount(ctx, "xxb-781", addrs[1])
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
+
+	res, err := querier(ctx, []string{types.QueryFrozen, "xxb-781"}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var frozen []sdk.AccAddress
+	keeper.cdc.MustUnmarshalJSON(res, &frozen)
+	require.Equal(t, []sdk.AccAddress{addrs[1]}, frozen)
+
+	_, err = querier(ctx, []string{types.QueryFrozen, "yyb-781"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	_, err = querier(ctx, []string{types.QueryFrozen}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestValidateGenesisFrozenAccounts(t *testing.T) {
+	gs := DefaultGenesisState()
+	gs.FrozenAccounts = []types.FrozenAccount{{Symbol: common.NativeToken, Address: gs.Tokens[0].Owner}}
+	require.Error(t, ValidateGenesis(gs))
+
+	gs.Tokens[0].Freezable = true
+	require.NoError(t, ValidateGenesis(gs))
+}
@@ -3,6 +3,7 @@ package token
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -18,9 +19,18 @@ const DefaultTokenOwner = "okchain10q0rk5qnyag7wfvvt7rtphlw589m7frsmyq4ya"
 
 // GenesisState - all slashing state that must be provided at genesis
 type GenesisState struct {
-	Params    types.Params     `json:"params"`
-	Tokens    []types.Token    `json:"tokens"`
-	LockCoins []types.AccCoins `json:"locked_asset"`
+	Params         types.Params          `json:"params"`
+	Tokens         []types.Token         `json:"tokens"`
+	LockCoins      []types.AccCoins      `json:"locked_asset"`
+	FrozenAccounts []types.FrozenAccount `json:"frozen_accounts"`
+	VestingLocks   []types.VestingLock   `json:"vesting_locks"`
+
+	OwnershipProposals []types.OwnershipProposal `json:"ownership_proposals"`
+	Multisigs          []types.Multisig          `json:"multisigs"`
+	MultisigProposals  []types.MultisigProposal  `json:"multisig_proposals"`
+
+	Distributions       []types.Distribution       `json:"distributions"`
+	DistributionHolders []types.DistributionHolder `json:"distribution_holders"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -49,12 +59,17 @@ func DefaultGenesisStateOKT() types.Token {
 		TotalSupply:         totalSupply,
 		Owner:               addr,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
+		Decimals:            types.DefaultDecimals,
 	}
 }
 
 // ValidateGenesis validates the slashing genesis parameters
 func ValidateGenesis(data GenesisState) error {
+	freezable := make(map[string]bool, len(data.Tokens))
 	for _, token := range data.Tokens {
+		freezable[token.Symbol] = token.Freezable
+
 		msg := types.NewMsgTokenIssue(token.Description,
 			token.Symbol,
 			token.OriginalSymbol,
@@ -67,6 +82,87 @@ func ValidateGenesis(data GenesisState) error {
 		if err != nil {
 			return errors.New(err.Error())
 		}
+
+		if err := types.ValidateDecimals(token.Decimals); err != nil {
+			return fmt.Errorf("invalid decimals of token %s: %s", token.Symbol, err.Error())
+		}
+		if err := token.Metadata.Validate(); err != nil {
+			return fmt.Errorf("invalid metadata of token %s: %s", token.Symbol, err.Error())
+		}
+		if !token.MaxSupply.IsNil() && token.MaxSupply.IsNegative() {
+			return fmt.Errorf("invalid max supply of token %s", token.Symbol)
+		}
+		if !token.TotalSupply.IsNil() && token.ExceedsMaxSupply(token.TotalSupply) {
+			return fmt.Errorf("the total supply of token %s exceeds its max supply", token.Symbol)
+		}
+	}
+
+	for _, lock := range data.VestingLocks {
+		if err := types.ValidateSchedule(lock.StartTime, lock.CliffTime, lock.EndTime); err != nil {
+			return fmt.Errorf("invalid vesting lock %d of %s: %s", lock.ID, lock.Address, err.Error())
+		}
+	}
+
+	for _, frozenAccount := range data.FrozenAccounts {
+		if !freezable[frozenAccount.Symbol] {
+			return fmt.Errorf("token %s of the frozen account %s is not freezable",
+				frozenAccount.Symbol, frozenAccount.Address)
+		}
+	}
+
+	for _, proposal := range data.OwnershipProposals {
+		msg := types.NewMsgProposeOwnership(proposal.FromAddress, proposal.ToAddress, proposal.Symbol)
+		if err := msg.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid ownership proposal of token %s: %s", proposal.Symbol, err.Error())
+		}
+	}
+
+	multisigs := make(map[string]bool, len(data.Multisigs))
+	for _, multisig := range data.Multisigs {
+		if err := types.ValidateMultisig(multisig.Members, multisig.Threshold); err != nil {
+			return fmt.Errorf("invalid multisig %s: %s", multisig.Address, err.Error())
+		}
+		if !types.NewMultisig(multisig.Members, multisig.Threshold).Address.Equals(multisig.Address) {
+			return fmt.Errorf("the address of multisig %s mismatches its members and threshold", multisig.Address)
+		}
+		multisigs[multisig.Address.String()] = true
+	}
+	for _, proposal := range data.MultisigProposals {
+		if !multisigs[proposal.Multisig.String()] {
+			return fmt.Errorf("the multisig %s of proposal %d does not exist", proposal.Multisig, proposal.ID)
+		}
+		msg := types.NewMsgMultisigSubmit(proposal.Multisig, proposal.Msg)
+		if err := msg.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid multisig proposal %d: %s", proposal.ID, err.Error())
+		}
+		if !msg.Multisig().Equals(proposal.Multisig) {
+			return fmt.Errorf("the msg of multisig proposal %d is not signed by %s", proposal.ID, proposal.Multisig)
+		}
+	}
+
+	// the holders are kept in the snapshots being taken or paid
+	unfinished := make(map[uint64]bool, len(data.Distributions))
+	for _, distribution := range data.Distributions {
+		msg := types.NewMsgDistribute(distribution.Sender, distribution.Symbol, distribution.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid distribution %d: %s", distribution.ID, err.Error())
+		}
+		switch distribution.Status {
+		case types.DistributionStatusFinished:
+		case types.DistributionStatusPending, types.DistributionStatusDistributing:
+			unfinished[distribution.ID] = true
+		default:
+			return fmt.Errorf("invalid status %s of distribution %d", distribution.Status, distribution.ID)
+		}
+	}
+	for _, holder := range data.DistributionHolders {
+		if !unfinished[holder.DistributionID] {
+			return fmt.Errorf("the distribution %d of holder %s is finished", holder.DistributionID, holder.Address)
+		}
+		// the accounts without holding are saved ahead of the cursor of a snapshot being taken
+		if holder.Holding.IsNil() || holder.Holding.IsNegative() {
+			return fmt.Errorf("invalid holding of holder %s in distribution %d", holder.Address, holder.DistributionID)
+		}
 	}
 	return nil
 }
@@ -85,6 +181,50 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 			panic(err)
 		}
 	}
+
+	for _, frozenAccount := range data.FrozenAccounts {
+		keeper.FreezeAccount(ctx, frozenAccount.Symbol, frozenAccount.Address)
+	}
+
+	// the vesting coins are already included in the locked coins
+	var nextVestingID uint64
+	for _, lock := range data.VestingLocks {
+		keeper.SetVestingLock(ctx, lock)
+		// a lock past its cliff is released by the first block
+		keeper.scheduleVestingRelease(ctx, lock, lock.CliffTime)
+		if lock.ID >= nextVestingID {
+			nextVestingID = lock.ID + 1
+		}
+	}
+	keeper.setNextVestingID(ctx, nextVestingID)
+
+	for _, proposal := range data.OwnershipProposals {
+		keeper.SetOwnershipProposal(ctx, proposal)
+	}
+	for _, multisig := range data.Multisigs {
+		keeper.SetMultisig(ctx, multisig)
+	}
+	var nextProposalID uint64
+	for _, proposal := range data.MultisigProposals {
+		keeper.SetMultisigProposal(ctx, proposal)
+		if proposal.ID >= nextProposalID {
+			nextProposalID = proposal.ID + 1
+		}
+	}
+	keeper.setNextMultisigProposalID(ctx, nextProposalID)
+
+	// the undistributed coins are already included in the holdings of the token module account
+	var nextDistributionID uint64
+	for _, distribution := range data.Distributions {
+		keeper.SetDistribution(ctx, distribution)
+		if distribution.ID >= nextDistributionID {
+			nextDistributionID = distribution.ID + 1
+		}
+	}
+	keeper.setNextDistributionID(ctx, nextDistributionID)
+	for _, holder := range data.DistributionHolders {
+		keeper.SetDistributionHolder(ctx, holder)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -94,11 +234,22 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
 	params := keeper.GetParams(ctx)
 	tokens := keeper.GetTokensInfo(ctx)
 	locks := keeper.GetAllLockCoins(ctx)
+	frozenAccounts := keeper.GetAllFrozenAccounts(ctx)
+	vestingLocks := keeper.GetAllVestingLocks(ctx)
 
 	return GenesisState{
-		Params:    params,
-		Tokens:    tokens,
-		LockCoins: locks,
+		Params:         params,
+		Tokens:         tokens,
+		LockCoins:      locks,
+		FrozenAccounts: frozenAccounts,
+		VestingLocks:   vestingLocks,
+
+		OwnershipProposals: keeper.GetAllOwnershipProposals(ctx),
+		Multisigs:          keeper.GetAllMultisigs(ctx),
+		MultisigProposals:  keeper.GetMultisigProposals(ctx, nil),
+
+		Distributions:       keeper.GetDistributions(ctx, ""),
+		DistributionHolders: keeper.GetAllDistributionHolders(ctx),
 	}
 }
 
@@ -112,6 +112,8 @@ func TestIssueToken(t *testing.T) {
 		TotalSupply:         genesisState.Tokens[0].TotalSupply,
 		Owner:               genesisState.Tokens[0].Owner,
 		Mintable:            genesisState.Tokens[0].Mintable,
+		MaxSupply:           genesisState.Tokens[0].MaxSupply,
+		Decimals:            genesisState.Tokens[0].Decimals,
 	}
 	require.EqualValues(t, expectToken, token)
 
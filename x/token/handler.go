@@ -13,7 +13,9 @@ import (
 
 // NewTokenHandler returns a handler for "token" type messages.
 func NewTokenHandler(keeper Keeper, protocolVersion version.ProtocolVersionType) sdk.Handler {
-	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+	// the handler executes the msgs approved by a multisig by itself
+	var handler sdk.Handler
+	handler = func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
 		//logger := ctx.Logger().With("module", "token")
 		// NOTE msg already has validate basic run
@@ -62,6 +64,60 @@ func NewTokenHandler(keeper Keeper, protocolVersion version.ProtocolVersionType)
 			handlerFun = func() sdk.Result {
 				return handleMsgTokenModify(ctx, keeper, msg, logger)
 			}
+
+		case types.MsgTimeLockedSend:
+			name = "handleMsgTimeLockedSend"
+			handlerFun = func() sdk.Result {
+				return handleMsgTimeLockedSend(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgFreeze:
+			name = "handleMsgFreeze"
+			handlerFun = func() sdk.Result {
+				return handleMsgFreeze(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgUnfreeze:
+			name = "handleMsgUnfreeze"
+			handlerFun = func() sdk.Result {
+				return handleMsgUnfreeze(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgProposeOwnership:
+			name = "handleMsgProposeOwnership"
+			handlerFun = func() sdk.Result {
+				return handleMsgProposeOwnership(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgConfirmOwnership:
+			name = "handleMsgConfirmOwnership"
+			handlerFun = func() sdk.Result {
+				return handleMsgConfirmOwnership(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgCreateMultisig:
+			name = "handleMsgCreateMultisig"
+			handlerFun = func() sdk.Result {
+				return handleMsgCreateMultisig(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgMultisigSubmit:
+			name = "handleMsgMultisigSubmit"
+			handlerFun = func() sdk.Result {
+				return handleMsgMultisigSubmit(ctx, keeper, handler, msg, logger)
+			}
+
+		case types.MsgMultisigApprove:
+			name = "handleMsgMultisigApprove"
+			handlerFun = func() sdk.Result {
+				return handleMsgMultisigApprove(ctx, keeper, handler, msg, logger)
+			}
+
+		case types.MsgDistribute:
+			name = "handleMsgDistribute"
+			handlerFun = func() sdk.Result {
+				return handleMsgDistribute(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Unrecognized token Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -71,6 +127,7 @@ func NewTokenHandler(keeper Keeper, protocolVersion version.ProtocolVersionType)
 		defer perf.GetPerf().OnDeliverTxExit(ctx, types.ModuleName, name, seq)
 		return handlerFun()
 	}
+	return handler
 }
 
 func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue, logger log.Logger) sdk.Result {
@@ -83,6 +140,17 @@ func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue
 		return sdk.ErrInternal(fmt.Sprintf("total-supply(%s) exceeds the upper limit(%d)",
 			msg.TotalSupply, types.TotalSupplyUpperbound)).Result()
 	}
+	maxSupply := sdk.ZeroDec()
+	if len(msg.MaxSupply) != 0 {
+		maxSupply, err = types.ParseMaxSupply(msg.MaxSupply)
+		if err != nil {
+			return err.Result()
+		}
+	}
+	decimals, err := types.ParseDecimals(msg.Decimals)
+	if err != nil {
+		return err.Result()
+	}
 
 	token := types.Token{
 		Description:         msg.Description,
@@ -93,6 +161,13 @@ func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue
 		TotalSupply:         totalSupply,
 		Owner:               msg.Owner,
 		Mintable:            msg.Mintable,
+		Freezable:           msg.Freezable,
+		MaxSupply:           maxSupply,
+		Decimals:            decimals,
+		Metadata:            types.NewTokenMetadata(msg.URI, msg.Website, msg.LogoHash),
+	}
+	if token.ExceedsMaxSupply(totalSupply) {
+		return types.ErrMaxSupplyExceeded(types.DefaultCodespace, msg.OriginalSymbol, maxSupply).Result()
 	}
 
 	// generate a random symbol
@@ -139,14 +214,19 @@ func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue
 			token.Symbol))
 	}
 
-	ctx.EventManager().EmitEvent(
+	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
 			sdk.NewAttribute(sdk.AttributeKeyFee, keeper.GetParams(ctx).FeeIssue.String()),
-			sdk.NewAttribute("symbol", token.Symbol),
+			sdk.NewAttribute(types.AttributeKeySymbol, token.Symbol),
 		),
-	)
+		// the symbol suffixed by the handler, for the indexers of the txs
+		sdk.NewEvent(
+			types.EventTypeIssue,
+			sdk.NewAttribute(types.AttributeKeySymbol, token.Symbol),
+		),
+	})
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
@@ -171,6 +251,7 @@ func handleMsgTokenBurn(ctx sdk.Context, keeper Keeper, msg types.MsgTokenBurn,
 	if err != nil {
 		return sdk.ErrInternal(fmt.Sprintf("supply burn coins error:%s", err.Error())).Result()
 	}
+	keeper.addTokenSupply(ctx, msg.Amount.Denom, msg.Amount.Amount.Neg())
 
 	// deduction fee
 	feeDecCoins := keeper.GetParams(ctx).FeeBurn.ToCoins()
@@ -212,12 +293,18 @@ func handleMsgTokenMint(ctx sdk.Context, keeper Keeper, msg types.MsgTokenMint,
 		return sdk.ErrUnauthorized(fmt.Sprintf("token(%s) is not mintable", token.Symbol)).Result()
 	}
 
+	// check whether the max supply is exceeded
+	if token.ExceedsMaxSupply(token.TotalSupply.Add(msg.Amount.Amount)) {
+		return types.ErrMaxSupplyExceeded(types.DefaultCodespace, token.Symbol, token.MaxSupply).Result()
+	}
+
 	mintCoins := msg.Amount.ToCoins()
 	// set supply
 	err := keeper.supplyKeeper.MintCoins(ctx, types.ModuleName, mintCoins)
 	if err != nil {
 		return sdk.ErrInternal(fmt.Sprintf("supply mint coins error:%s", err.Error())).Result()
 	}
+	keeper.addTokenSupply(ctx, msg.Amount.Denom, msg.Amount.Amount)
 
 	// send coins to acc
 	err = keeper.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, msg.Owner, mintCoins)
@@ -256,22 +343,12 @@ func handleMsgTokenMint(ctx sdk.Context, keeper Keeper, msg types.MsgTokenMint,
 func chargeMultiCoinsFee(ctx sdk.Context, keeper Keeper, from sdk.AccAddress,
 	coinNum int) (feeCharged sdk.DecCoins, result sdk.Result) {
 
-	feeCharged = sdk.ZeroFee().ToCoins()
-
-	if coinNum == 1 {
-		return feeCharged, result
-	}
-
-	fee := keeper.GetParams(ctx).FeeMultiSend.Amount.MulInt64(int64(coinNum))
-	feeAmount := fee.Sub(sdk.GetSystemFee().Amount)
-
-	if feeAmount.IsNegative() {
-		// charge nothing, since it's already covered by system fee
+	feeCharged = keeper.GetParams(ctx).MultiCoinsFee(coinNum)
+	if feeCharged.IsZero() {
 		return feeCharged, result
 	}
 
 	// deduction fee
-	feeCharged = sdk.NewDecCoinsFromDec(sdk.DefaultBondDenom, feeAmount)
 	err := keeper.supplyKeeper.SendCoinsFromAccountToModule(ctx, from, keeper.feeCollectorName, feeCharged)
 	if err != nil {
 		return feeCharged, sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient fee coins(need %s)",
@@ -288,8 +365,7 @@ func handleMsgMultiSend(ctx sdk.Context, keeper Keeper, msg types.MsgMultiSend,
 		coinNum += len(transferUnit.Coins)
 		err := keeper.SendCoinsFromAccountToAccount(ctx, msg.From, transferUnit.To, transferUnit.Coins)
 		if err != nil {
-			return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s)",
-				transferUnit.Coins.String())).Result()
+			return transferFailed(err, transferUnit.Coins)
 		}
 		transfers += fmt.Sprintf("                          msg<To:%s,Coin:%s>\n", transferUnit.To, transferUnit.Coins)
 	}
@@ -320,11 +396,9 @@ func handleMsgMultiSend(ctx sdk.Context, keeper Keeper, msg types.MsgMultiSend,
 }
 
 func handleMsgSend(ctx sdk.Context, keeper Keeper, msg types.MsgSend, logger log.Logger) sdk.Result {
-
 	err := keeper.SendCoinsFromAccountToAccount(ctx, msg.FromAddress, msg.ToAddress, msg.Amount)
 	if err != nil {
-		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s)",
-			msg.Amount.String())).Result()
+		return transferFailed(err, msg.Amount)
 	}
 
 	actualFee, chargeResult := chargeMultiCoinsFee(ctx, keeper, msg.FromAddress, len(msg.Amount))
@@ -351,6 +425,41 @@ func handleMsgSend(ctx sdk.Context, keeper Keeper, msg types.MsgSend, logger log
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
+func handleMsgTimeLockedSend(ctx sdk.Context, keeper Keeper, msg types.MsgTimeLockedSend, logger log.Logger) sdk.Result {
+	if msg.EndTime <= ctx.BlockHeader().Time.Unix() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("end time(%d) must be later than the block time(%d)",
+			msg.EndTime, ctx.BlockHeader().Time.Unix())).Result()
+	}
+	lock, err := keeper.TimeLockedSend(ctx, msg.FromAddress, msg.ToAddress, msg.Amount,
+		msg.StartTime, msg.CliffTime, msg.EndTime)
+	if err != nil {
+		return transferFailed(err, msg.Amount)
+	}
+
+	actualFee, chargeResult := chargeMultiCoinsFee(ctx, keeper, msg.FromAddress, len(msg.Amount))
+	if !chargeResult.IsOK() {
+		return chargeResult
+	}
+
+	var name = "handleMsgTimeLockedSend"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<From:%s,To:%s,Amount:%s,StartTime:%d,CliffTime:%d,EndTime:%d>\n",
+			ctx.BlockHeight(), name,
+			msg.FromAddress, msg.ToAddress, msg.Amount, msg.StartTime, msg.CliffTime, msg.EndTime))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyFee, actualFee.String()),
+			sdk.NewAttribute("vesting_id", fmt.Sprintf("%d", lock.ID)),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
 func handleMsgTokenChown(ctx sdk.Context, keeper Keeper, msg types.MsgTransferOwnership, logger log.Logger) sdk.Result {
 	tokenInfo := keeper.GetTokenInfo(ctx, msg.Symbol)
 
@@ -359,11 +468,9 @@ func handleMsgTokenChown(ctx sdk.Context, keeper Keeper, msg types.MsgTransferOw
 			msg.FromAddress.String(), msg.Symbol)).Result()
 	}
 
-	// first remove it from the raw owner
-	keeper.DeleteUserToken(ctx, tokenInfo.Owner, tokenInfo.Symbol)
-
-	tokenInfo.Owner = msg.ToAddress
-	keeper.NewToken(ctx, tokenInfo)
+	keeper.TransferOwnership(ctx, tokenInfo, msg.ToAddress)
+	// the pending transfer is overridden
+	keeper.DeleteOwnershipProposal(ctx, msg.Symbol)
 
 	// deduction fee
 	feeDecCoins := keeper.GetParams(ctx).FeeChown.ToCoins()
@@ -400,7 +507,8 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
 			msg.Owner.String(), msg.Symbol)).Result()
 	}
-	if !msg.IsWholeNameModified && !msg.IsDescriptionModified {
+	if !msg.IsWholeNameModified && !msg.IsDescriptionModified && !msg.IsMaxSupplyModified &&
+		!msg.IsDecimalsModified && !msg.IsMetadataModified {
 		return sdk.ErrInternal("nothing modified").Result()
 	}
 	// modify
@@ -410,6 +518,22 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	if msg.IsDescriptionModified {
 		token.Description = msg.Description
 	}
+	if msg.IsMaxSupplyModified {
+		maxSupply, err := types.ParseMaxSupply(msg.MaxSupply)
+		if err != nil {
+			return err.Result()
+		}
+		if err := checkMaxSupplyModification(token, maxSupply, keeper.GetParams(ctx)); err != nil {
+			return err.Result()
+		}
+		token.MaxSupply = maxSupply
+	}
+	if msg.IsDecimalsModified {
+		token.Decimals = msg.Decimals
+	}
+	if msg.IsMetadataModified {
+		token.Metadata = types.NewTokenMetadata(msg.URI, msg.Website, msg.LogoHash)
+	}
 
 	store := ctx.KVStore(keeper.tokenStoreKey)
 	store.Set(types.GetTokenAddress(token.Symbol), keeper.cdc.MustMarshalBinaryBare(token))
@@ -441,3 +565,368 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	)
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
+
+// checkMaxSupplyModification checks the new max supply of the token against the rules in params
+func checkMaxSupplyModification(token types.Token, maxSupply sdk.Dec, params types.Params) sdk.Error {
+	newToken := token
+	newToken.MaxSupply = maxSupply
+	// removing the cap raises it, while capping an uncapped token lowers it
+	isRaised := token.IsCapped() && (!newToken.IsCapped() || maxSupply.GT(token.MaxSupply))
+	isLowered := newToken.IsCapped() && (!token.IsCapped() || maxSupply.LT(token.MaxSupply))
+
+	if isRaised && !params.MaxSupplyIncreasable {
+		return types.ErrInvalidMaxSupply(types.DefaultCodespace,
+			fmt.Sprintf("the max supply of token(%s) is not allowed to be raised", token.Symbol))
+	}
+	if isLowered && !params.MaxSupplyDecreasable {
+		return types.ErrInvalidMaxSupply(types.DefaultCodespace,
+			fmt.Sprintf("the max supply of token(%s) is not allowed to be lowered", token.Symbol))
+	}
+	if newToken.ExceedsMaxSupply(token.TotalSupply) {
+		return types.ErrInvalidMaxSupply(types.DefaultCodespace,
+			fmt.Sprintf("the max supply(%s) is less than the total supply(%s)", maxSupply, token.TotalSupply))
+	}
+	return nil
+}
+
+func handleMsgFreeze(ctx sdk.Context, keeper Keeper, msg types.MsgFreeze, logger log.Logger) sdk.Result {
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !bytes.Equal(token.Owner.Bytes(), msg.Owner.Bytes()) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
+			msg.Owner.String(), msg.Symbol)).Result()
+	}
+
+	// check whether token is freezable
+	if !token.Freezable {
+		return sdk.ErrUnauthorized(fmt.Sprintf("token(%s) is not freezable", token.Symbol)).Result()
+	}
+
+	// the module accounts hold the coins of all their users, so that they are never frozen
+	if keeper.bankKeeper.BlacklistedAddr(msg.Address) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is a module account which can not be frozen",
+			msg.Address)).Result()
+	}
+
+	if keeper.IsFrozen(ctx, msg.Symbol, msg.Address) {
+		return sdk.ErrInternal(fmt.Sprintf("the balance of %s at %s is already frozen",
+			msg.Symbol, msg.Address)).Result()
+	}
+	keeper.FreezeAccount(ctx, msg.Symbol, msg.Address)
+
+	name := "handleMsgFreeze"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,Address:%s>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol, msg.Address))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("address", msg.Address.String()),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgUnfreeze(ctx sdk.Context, keeper Keeper, msg types.MsgUnfreeze, logger log.Logger) sdk.Result {
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !bytes.Equal(token.Owner.Bytes(), msg.Owner.Bytes()) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
+			msg.Owner.String(), msg.Symbol)).Result()
+	}
+
+	if !keeper.IsFrozen(ctx, msg.Symbol, msg.Address) {
+		return sdk.ErrInternal(fmt.Sprintf("the balance of %s at %s is not frozen",
+			msg.Symbol, msg.Address)).Result()
+	}
+	keeper.UnfreezeAccount(ctx, msg.Symbol, msg.Address)
+
+	name := "handleMsgUnfreeze"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,Address:%s>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol, msg.Address))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("address", msg.Address.String()),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgProposeOwnership(ctx sdk.Context, keeper Keeper, msg types.MsgProposeOwnership, logger log.Logger) sdk.Result {
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.FromAddress) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
+			msg.FromAddress.String(), msg.Symbol)).Result()
+	}
+
+	params := keeper.GetParams(ctx)
+	proposal := types.OwnershipProposal{
+		Symbol:      msg.Symbol,
+		FromAddress: msg.FromAddress,
+		ToAddress:   msg.ToAddress,
+		ExpireTime:  ctx.BlockHeader().Time.Add(params.OwnershipConfirmPeriod).Unix(),
+	}
+	keeper.SetOwnershipProposal(ctx, proposal)
+
+	// deduction fee
+	feeDecCoins := params.FeeChown.ToCoins()
+	err := keeper.supplyKeeper.SendCoinsFromAccountToModule(ctx, msg.FromAddress, keeper.feeCollectorName, feeDecCoins)
+	if err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient fee coins(need %s)",
+			feeDecCoins.String())).Result()
+	}
+
+	name := "handleMsgProposeOwnership"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<From:%s,To:%s,Symbol:%s>\n"+
+			"                           result<ExpireTime:%d>\n",
+			ctx.BlockHeight(), name,
+			msg.FromAddress, msg.ToAddress, msg.Symbol,
+			proposal.ExpireTime))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyFee, feeDecCoins.String()),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgConfirmOwnership(ctx sdk.Context, keeper Keeper, msg types.MsgConfirmOwnership, logger log.Logger) sdk.Result {
+	proposal, found := keeper.GetOwnershipProposal(ctx, msg.Symbol)
+	if !found {
+		return types.ErrInvalidOwnership(types.DefaultCodespace,
+			fmt.Sprintf("no pending ownership transfer of token(%s)", msg.Symbol)).Result()
+	}
+	if !proposal.ToAddress.Equals(msg.Address) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the recipient of the ownership of token(%s)",
+			msg.Address.String(), msg.Symbol)).Result()
+	}
+	if proposal.IsExpired(ctx.BlockHeader().Time.Unix()) {
+		return types.ErrInvalidOwnership(types.DefaultCodespace,
+			fmt.Sprintf("the ownership transfer of token(%s) expired at %d", msg.Symbol, proposal.ExpireTime)).Result()
+	}
+
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	if !token.Owner.Equals(proposal.FromAddress) {
+		return types.ErrInvalidOwnership(types.DefaultCodespace,
+			fmt.Sprintf("%s is no longer the owner of token(%s)", proposal.FromAddress, msg.Symbol)).Result()
+	}
+	keeper.TransferOwnership(ctx, token, msg.Address)
+	keeper.DeleteOwnershipProposal(ctx, msg.Symbol)
+
+	name := "handleMsgConfirmOwnership"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Address:%s,Symbol:%s>\n"+
+			"                           result<Owner changed from %s>\n",
+			ctx.BlockHeight(), name,
+			msg.Address, msg.Symbol,
+			proposal.FromAddress))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("owner", msg.Address.String()),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgCreateMultisig(ctx sdk.Context, keeper Keeper, msg types.MsgCreateMultisig, logger log.Logger) sdk.Result {
+	multisig := types.NewMultisig(msg.Members, msg.Threshold)
+	if _, found := keeper.GetMultisig(ctx, multisig.Address); found {
+		return types.ErrInvalidMultisig(types.DefaultCodespace,
+			fmt.Sprintf("multisig %s already exists", multisig.Address)).Result()
+	}
+	keeper.SetMultisig(ctx, multisig)
+
+	name := "handleMsgCreateMultisig"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Creator:%s,Members:%v,Threshold:%d>\n"+
+			"                           result<Multisig:%s>\n",
+			ctx.BlockHeight(), name,
+			msg.Creator, msg.Members, msg.Threshold,
+			multisig.Address))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("multisig", multisig.Address.String()),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgMultisigSubmit(ctx sdk.Context, keeper Keeper, handler sdk.Handler, msg types.MsgMultisigSubmit,
+	logger log.Logger) sdk.Result {
+
+	multisig, found := keeper.GetMultisig(ctx, msg.Multisig())
+	if !found {
+		return types.ErrInvalidMultisig(types.DefaultCodespace,
+			fmt.Sprintf("%s is not a multisig", msg.Multisig())).Result()
+	}
+	if !multisig.IsMember(msg.Member) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not a member of multisig %s",
+			msg.Member, multisig.Address)).Result()
+	}
+
+	proposal := types.MultisigProposal{
+		ID:        keeper.getNextMultisigProposalID(ctx),
+		Multisig:  multisig.Address,
+		Msg:       msg.Msg,
+		Approvals: []sdk.AccAddress{msg.Member},
+	}
+
+	name := "handleMsgMultisigSubmit"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Member:%s,Multisig:%s,Type:%s>\n"+
+			"                           result<ProposalID:%d>\n",
+			ctx.BlockHeight(), name,
+			msg.Member, multisig.Address, msg.Msg.Type(),
+			proposal.ID))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("proposal_id", fmt.Sprintf("%d", proposal.ID)),
+		),
+	)
+	return approveMultisigProposal(ctx, keeper, handler, multisig, proposal)
+}
+
+func handleMsgMultisigApprove(ctx sdk.Context, keeper Keeper, handler sdk.Handler, msg types.MsgMultisigApprove,
+	logger log.Logger) sdk.Result {
+
+	proposal, found := keeper.GetMultisigProposal(ctx, msg.ProposalID)
+	if !found {
+		return types.ErrInvalidMultisig(types.DefaultCodespace,
+			fmt.Sprintf("multisig proposal %d does not exist", msg.ProposalID)).Result()
+	}
+	multisig, found := keeper.GetMultisig(ctx, proposal.Multisig)
+	if !found {
+		return types.ErrInvalidMultisig(types.DefaultCodespace,
+			fmt.Sprintf("%s is not a multisig", proposal.Multisig)).Result()
+	}
+	if !multisig.IsMember(msg.Member) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not a member of multisig %s",
+			msg.Member, multisig.Address)).Result()
+	}
+	if proposal.IsApprovedBy(msg.Member) {
+		return types.ErrInvalidMultisig(types.DefaultCodespace,
+			fmt.Sprintf("%s has already approved multisig proposal %d", msg.Member, msg.ProposalID)).Result()
+	}
+	proposal.Approvals = append(proposal.Approvals, msg.Member)
+
+	name := "handleMsgMultisigApprove"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Member:%s,ProposalID:%d>\n"+
+			"                           result<Approvals:%d/%d>\n",
+			ctx.BlockHeight(), name,
+			msg.Member, msg.ProposalID,
+			len(proposal.Approvals), multisig.Threshold))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("proposal_id", fmt.Sprintf("%d", proposal.ID)),
+		),
+	)
+	return approveMultisigProposal(ctx, keeper, handler, multisig, proposal)
+}
+
+// approveMultisigProposal executes the proposal if it has enough approvals, or saves it otherwise.
+// If the execution fails, the whole tx fails and the approval is not recorded
+func approveMultisigProposal(ctx sdk.Context, keeper Keeper, handler sdk.Handler, multisig types.Multisig,
+	proposal types.MultisigProposal) sdk.Result {
+
+	if uint64(len(proposal.Approvals)) < multisig.Threshold {
+		keeper.SetMultisigProposal(ctx, proposal)
+		return sdk.Result{Events: ctx.EventManager().Events()}
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	res := handler(cacheCtx, proposal.Msg)
+	if !res.IsOK() {
+		return res
+	}
+	write()
+	keeper.deleteMultisigProposal(ctx, proposal.ID)
+
+	ctx.EventManager().EmitEvents(res.Events)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgDistribute(ctx sdk.Context, keeper Keeper, msg types.MsgDistribute, logger log.Logger) sdk.Result {
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	if token.Symbol == "" {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("unknown token(%s)", msg.Symbol)).Result()
+	}
+	if !token.Owner.Equals(msg.Sender) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
+			msg.Sender.String(), msg.Symbol)).Result()
+	}
+	distribution, err := keeper.Distribute(ctx, msg.Sender, msg.Symbol, msg.Amount)
+	if err != nil {
+		return transferFailed(err, msg.Amount)
+	}
+
+	// deduction fee
+	feeDecCoins := keeper.GetParams(ctx).FeeDistribute.ToCoins()
+	err = keeper.supplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Sender, keeper.feeCollectorName, feeDecCoins)
+	if err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient fee coins(need %s)",
+			feeDecCoins.String())).Result()
+	}
+
+	name := "handleMsgDistribute"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Sender:%s,Symbol:%s,Amount:%s>\n"+
+			"                           result<DistributionID:%d>\n",
+			ctx.BlockHeight(), name,
+			msg.Sender, msg.Symbol, msg.Amount,
+			distribution.ID))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyFee, feeDecCoins.String()),
+			sdk.NewAttribute("distribution_id", fmt.Sprintf("%d", distribution.ID)),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -0,0 +1,119 @@
+package token
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/token/types"
+)
+
+// RegisterInvariants registers all token invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, keeper Keeper, accountKeeper AccountKeeper) {
+	ir.RegisterRoute(types.ModuleName, "total-supply", TotalSupplyInvariant(keeper, accountKeeper))
+	ir.RegisterRoute(types.ModuleName, "locked-coins", LockedCoinsInvariant(keeper, accountKeeper))
+	ir.RegisterRoute(types.ModuleName, "nonnegative-locks", NonNegativeLocksInvariant(keeper))
+}
+
+// AllInvariants runs all invariants of the token module
+func AllInvariants(keeper Keeper, accountKeeper AccountKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		if res, stop := TotalSupplyInvariant(keeper, accountKeeper)(ctx); stop {
+			return res, stop
+		}
+		if res, stop := LockedCoinsInvariant(keeper, accountKeeper)(ctx); stop {
+			return res, stop
+		}
+		return NonNegativeLocksInvariant(keeper)(ctx)
+	}
+}
+
+// TotalSupplyInvariant checks that the total supply kept in the token store for every token equals the sum of the
+// account balances and the holdings of the module accounts in its denom. The locked coins are held by the token
+// module account, so they are part of its holdings. The native token is minted and burned by the mint and staking
+// modules without the token keeper knowing, so its total supply is the one of the supply module
+func TotalSupplyInvariant(keeper Keeper, accountKeeper AccountKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var balances, moduleHoldings sdk.DecCoins
+		accountKeeper.IterateAccounts(ctx, func(acc authexported.Account) bool {
+			if _, ok := acc.(supplyexported.ModuleAccountI); ok {
+				moduleHoldings = moduleHoldings.Add(acc.GetCoins())
+			} else {
+				balances = balances.Add(acc.GetCoins())
+			}
+			return false
+		})
+
+		var msg string
+		var count int
+		for _, token := range keeper.getStoredTokens(ctx) {
+			totalSupply := token.TotalSupply
+			if token.Symbol == common.NativeToken {
+				totalSupply = keeper.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(token.Symbol)
+			}
+			expected := balances.AmountOf(token.Symbol).Add(moduleHoldings.AmountOf(token.Symbol))
+			if !totalSupply.Equal(expected) {
+				count++
+				msg += fmt.Sprintf("\ttoken %s:\n"+
+					"\t\ttotal supply:                 %s\n"+
+					"\t\tsum of account balances:      %s\n"+
+					"\t\tsum of module holdings:       %s\n",
+					token.Symbol, totalSupply, balances.AmountOf(token.Symbol), moduleHoldings.AmountOf(token.Symbol))
+			}
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "total supply",
+			fmt.Sprintf("%d tokens with total supply mismatch found\n%s", count, msg)), count != 0
+	}
+}
+
+// LockedCoinsInvariant checks that the locked coins of every denom are backed by the token module account. The
+// account also holds the locked fees, which are not in the lock store, so it may hold more than the locked coins
+func LockedCoinsInvariant(keeper Keeper, accountKeeper AccountKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var lockedCoins sdk.DecCoins
+		for _, lock := range keeper.GetAllLockCoins(ctx) {
+			lockedCoins = lockedCoins.Add(lock.Coins)
+		}
+
+		var holdings sdk.DecCoins
+		if acc := accountKeeper.GetAccount(ctx, supply.NewModuleAddress(types.ModuleName)); acc != nil {
+			holdings = acc.GetCoins()
+		}
+
+		var msg string
+		var count int
+		for _, locked := range lockedCoins {
+			if held := holdings.AmountOf(locked.Denom); held.LT(locked.Amount) {
+				count++
+				msg += fmt.Sprintf("\t%s: locked %s, held by the token module account %s\n",
+					locked.Denom, locked.Amount, held)
+			}
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "locked coins", fmt.Sprintf(
+			"%d denoms with locked coins not held by the token module account found\n%s", count, msg)), count != 0
+	}
+}
+
+// NonNegativeLocksInvariant checks that no account has a negative amount of locked coins
+func NonNegativeLocksInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		for _, lock := range keeper.GetAllLockCoins(ctx) {
+			if lock.Coins.IsAnyNegative() {
+				count++
+				msg += fmt.Sprintf("\t%s has negative locked coins: %s\n", lock.Acc, lock.Coins)
+			}
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "nonnegative locks",
+			fmt.Sprintf("%d accounts with negative locked coins found\n%s", count, msg)), count != 0
+	}
+}
@@ -0,0 +1,159 @@
+package token
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestInvariants(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+
+	genCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000))}
+	genAccs, testAccounts := CreateGenAccounts(2, genCoins)
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(2000)),
+	}))
+	keeper.NewToken(ctx, types.Token{Symbol: common.NativeToken, Owner: testAccounts[0].baseAccount.Address})
+
+	invariant := AllInvariants(keeper, mapp.AccountKeeper)
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	// locked coins move to the token module account
+	lockCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100))}
+	err := keeper.LockCoins(ctx, testAccounts[0].baseAccount.Address, lockCoins, types.LockCoinsTypeQuantity)
+	require.NoError(t, err)
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// a lock which is not backed by the token module account breaks the locked coins invariant
+	err = keeper.updateLockCoins(ctx, testAccounts[1].baseAccount.Address, lockCoins, true)
+	require.NoError(t, err)
+	_, broken = TotalSupplyInvariant(keeper, mapp.AccountKeeper)(ctx)
+	require.False(t, broken)
+	_, broken = LockedCoinsInvariant(keeper, mapp.AccountKeeper)(ctx)
+	require.True(t, broken)
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+	err = keeper.updateLockCoins(ctx, testAccounts[1].baseAccount.Address, lockCoins, false)
+	require.NoError(t, err)
+
+	// total supply mismatch
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(3000)),
+	}))
+	_, broken = TotalSupplyInvariant(keeper, mapp.AccountKeeper)(ctx)
+	require.True(t, broken)
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+
+	// negative locked coins
+	negativeCoins := sdk.DecCoins{sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.NewDec(-1)}}
+	ctx.KVStore(keeper.lockStoreKey).Set(types.GetLockAddress(testAccounts[1].baseAccount.Address.Bytes()),
+		keeper.cdc.MustMarshalBinaryBare(negativeCoins))
+	_, broken = NonNegativeLocksInvariant(keeper)(ctx)
+	require.True(t, broken)
+}
+
+func TestTotalSupplyInvariant_Mint(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+
+	genCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000))}
+	genAccs, testAccounts := CreateGenAccounts(2, genCoins)
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 3})
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.DecCoins{
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(2000)),
+	}))
+	// the stored total supply of the token is never updated by the mint module
+	keeper.NewToken(ctx, types.Token{Symbol: common.NativeToken, TotalSupply: sdk.NewDec(2000),
+		Owner: testAccounts[0].baseAccount.Address})
+
+	mintGenesis := mint.DefaultGenesisState()
+	mintGenesis.Params.MintDenom = common.NativeToken
+	mint.InitGenesis(ctx, mapp.mintKeeper, mintGenesis)
+
+	// the inflation is minted to the fee collector
+	mint.BeginBlocker(ctx, mapp.mintKeeper)
+	minted := mapp.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(common.NativeToken).Sub(sdk.NewDec(2000))
+	require.True(t, minted.IsPositive())
+	require.Equal(t, minted, mapp.supplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins().AmountOf(common.NativeToken))
+	_, broken := TotalSupplyInvariant(keeper, mapp.AccountKeeper)(ctx)
+	require.False(t, broken)
+
+	// coins burned by another module, e.g. a slash
+	burnCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100))}
+	require.Nil(t, mapp.supplyKeeper.SendCoinsFromAccountToModule(ctx, testAccounts[0].baseAccount.Address,
+		types.ModuleName, burnCoins))
+	require.Nil(t, mapp.supplyKeeper.BurnCoins(ctx, types.ModuleName, burnCoins))
+	_, broken = TotalSupplyInvariant(keeper, mapp.AccountKeeper)(ctx)
+	require.False(t, broken)
+
+	// the coins which are not tokens of the token store are not checked
+	extraCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.NewDec(1))}
+	_, err := mapp.bankKeeper.AddCoins(ctx, testAccounts[1].baseAccount.Address, extraCoins)
+	require.Nil(t, err)
+	_, broken = TotalSupplyInvariant(keeper, mapp.AccountKeeper)(ctx)
+	require.False(t, broken)
+
+	// coins of a token held beyond its total supply
+	extraCoins = sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1))}
+	_, err = mapp.bankKeeper.AddCoins(ctx, testAccounts[1].baseAccount.Address, extraCoins)
+	require.Nil(t, err)
+	msg, broken := TotalSupplyInvariant(keeper, mapp.AccountKeeper)(ctx)
+	require.True(t, broken)
+	require.Contains(t, msg, "1 tokens with total supply mismatch found")
+	require.Contains(t, msg, "token "+common.NativeToken)
+}
+
+func TestTotalSupplyInvariant_StoredSupply(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+
+	genCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000))}
+	genAccs, testAccounts := CreateGenAccounts(1, genCoins)
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	owner := testAccounts[0].baseAccount.Address
+
+	require.True(t, handler(ctx, types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", owner, true)).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+	invariant := TotalSupplyInvariant(keeper, mapp.AccountKeeper)
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	// the stored total supply follows the mint and the burn of the token
+	require.True(t, handler(ctx, types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(200)), owner)).IsOK())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+	require.True(t, handler(ctx, types.NewMsgTokenBurn(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(50)), owner)).IsOK())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+	require.Equal(t, sdk.NewDec(1150), keeper.getStoredTokens(ctx)[0].TotalSupply)
+
+	// a corrupted stored total supply is caught although the supply module agrees with the balances
+	keeper.UpdateTokenSupply(ctx, symbol, sdk.NewDec(1151))
+	require.Equal(t, sdk.NewDec(1150), keeper.GetTokenInfo(ctx, symbol).TotalSupply)
+	msg, broken := invariant(ctx)
+	require.True(t, broken)
+	require.Contains(t, msg, "token "+symbol)
+}
@@ -105,6 +105,30 @@ func (k Keeper) GetTokensInfo(ctx sdk.Context) (tokens []types.Token) {
 	return tokens
 }
 
+// getStoredTokens gets the tokens as they are kept in the token store, with the total supply kept by the token keeper
+func (k Keeper) getStoredTokens(ctx sdk.Context) (tokens []types.Token) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.tokenStoreKey), types.TokenKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var token types.Token
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &token)
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// addTokenSupply adds the amount to the total supply kept in the token store, a negative amount is burned
+func (k Keeper) addTokenSupply(ctx sdk.Context, symbol string, amount sdk.Dec) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	bz := store.Get(types.GetTokenAddress(symbol))
+	if bz == nil {
+		return
+	}
+	var token types.Token
+	k.cdc.MustUnmarshalBinaryBare(bz, &token)
+	k.UpdateTokenSupply(ctx, symbol, token.TotalSupply.Add(amount))
+}
+
 // GetUserTokensInfo gets user token info
 func (k Keeper) GetUserTokensInfo(ctx sdk.Context, owner sdk.AccAddress) (tokens []types.Token) {
 	userTokenPrefix := types.GetUserTokenPrefix(owner)
@@ -168,6 +192,19 @@ func (k Keeper) NewToken(ctx sdk.Context, token types.Token) {
 	store.Set(types.TokenNumberKey, b)
 }
 
+// UpdateTokenSupply saves the total supply of a token minted or burned outside the token module
+func (k Keeper) UpdateTokenSupply(ctx sdk.Context, symbol string, supply sdk.Dec) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	bz := store.Get(types.GetTokenAddress(symbol))
+	if bz == nil {
+		return
+	}
+	var token types.Token
+	k.cdc.MustUnmarshalBinaryBare(bz, &token)
+	token.TotalSupply = supply
+	store.Set(types.GetTokenAddress(symbol), k.cdc.MustMarshalBinaryBare(token))
+}
+
 // SendCoinsFromAccountToAccount - send token from one account to another account
 func (k Keeper) SendCoinsFromAccountToAccount(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.DecCoins) error {
 	return k.bankKeeper.SendCoins(ctx, from, to, amt)
@@ -180,7 +217,7 @@ func (k Keeper) LockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoi
 		return err
 	}
 	// update lock coins
-	if lockCoinsType == types.LockCoinsTypeQuantity {
+	if lockCoinsType == types.LockCoinsTypeQuantity || lockCoinsType == types.LockCoinsTypeVesting {
 		return k.updateLockCoins(ctx, addr, coins, true)
 	}
 	return nil
@@ -191,6 +228,8 @@ func (k Keeper) updateLockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.
 	var newCoins sdk.DecCoins
 	var oldCoins sdk.DecCoins
 
+	snapshotBeforeChange(ctx, k.bankKeeper, k.tokenStoreKey, k.lockStoreKey, addr, coins)
+
 	store := ctx.KVStore(k.lockStoreKey)
 	coinsBytes := store.Get(types.GetLockAddress(addr.Bytes()))
 
@@ -226,7 +265,7 @@ func (k Keeper) updateLockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.
 // UnlockCoins unlock coins
 func (k Keeper) UnlockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins, lockCoinsType int) error {
 	// update lock coins
-	if lockCoinsType == types.LockCoinsTypeQuantity {
+	if lockCoinsType == types.LockCoinsTypeQuantity || lockCoinsType == types.LockCoinsTypeVesting {
 		err := k.updateLockCoins(ctx, addr, coins, false)
 		if err != nil {
 			return err
@@ -54,6 +54,16 @@ func TestKeeper_UpdateTokenSupply(t *testing.T) {
 	require.Equal(t, false, flag)
 	require.Equal(t, "", name)
 
+	// the stored total supply is updated without registering a new token
+	tokenNum := keeper.GetTokenNum(ctx)
+	keeper.UpdateTokenSupply(ctx, common.NativeToken, sdk.NewDec(100))
+	var stored types.Token
+	keeper.cdc.MustUnmarshalBinaryBare(ctx.KVStore(keeper.tokenStoreKey).Get(types.GetTokenAddress(common.NativeToken)), &stored)
+	require.Equal(t, sdk.NewDec(100), stored.TotalSupply)
+	require.Equal(t, tokenNum, keeper.GetTokenNum(ctx))
+	keeper.UpdateTokenSupply(ctx, "xxbToken", sdk.NewDec(100))
+	require.False(t, keeper.TokenExist(ctx, "xxbToken"))
+
 	token = types.Token{
 		Description:         "new token",
 		Symbol:              common.NativeToken,
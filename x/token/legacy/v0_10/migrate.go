@@ -0,0 +1,59 @@
+package v0_10
+
+import (
+	"github.com/okex/okchain/x/common"
+	v09token "github.com/okex/okchain/x/token/legacy/v0_9"
+	"github.com/okex/okchain/x/token/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Migrate adds the max supply to the tokens and the rules of changing it to the params.
+// The mintable tokens stay uncapped, while the max supply of the others is their current total supply.
+// All the existing tokens get the default decimals and an empty metadata.
+// The ownership transfers confirmed in the default period, the dividend distributions and the vesting locks released
+// in the default period are introduced as well.
+// The locked coins exported as lock_coins by v0.9 are moved to locked_asset.
+func Migrate(oldGenState v09token.GenesisState) GenesisState {
+	oldParams := oldGenState.Params
+	params := Params{
+		FeeBase:                oldParams.FeeBase,
+		FeeIssue:               oldParams.FeeIssue,
+		FeeMint:                oldParams.FeeMint,
+		FeeBurn:                oldParams.FeeBurn,
+		FeeModify:              oldParams.FeeModify,
+		FeeSend:                oldParams.FeeSend,
+		FeeMultiSend:           oldParams.FeeMultiSend,
+		FeeChown:               oldParams.FeeChown,
+		FeeDistribute:          sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(types.DefaultFeeDistribute)),
+		MaxSupplyIncreasable:   types.DefaultMaxSupplyIncreasable,
+		MaxSupplyDecreasable:   types.DefaultMaxSupplyDecreasable,
+		OwnershipConfirmPeriod: types.DefaultOwnershipConfirmPeriod,
+		DistributeBatchSize:    types.DefaultDistributeBatchSize,
+		VestingReleasePeriod:   types.DefaultVestingReleasePeriod,
+	}
+
+	tokens := make([]Token, len(oldGenState.Tokens))
+	for k, token := range oldGenState.Tokens {
+		tokens[k] = Token{
+			Description:         token.Description,
+			Symbol:              token.Symbol,
+			OriginalSymbol:      token.OriginalSymbol,
+			WholeName:           token.WholeName,
+			OriginalTotalSupply: token.OriginalTotalSupply,
+			TotalSupply:         token.TotalSupply,
+			Owner:               token.Owner,
+			Mintable:            token.Mintable,
+			MaxSupply:           token.TotalSupply,
+			Decimals:            types.DefaultDecimals,
+		}
+		if token.Mintable {
+			tokens[k].MaxSupply = sdk.ZeroDec()
+		}
+	}
+	return GenesisState{
+		Params:    params,
+		Tokens:    tokens,
+		LockCoins: oldGenState.LockCoins,
+	}
+}
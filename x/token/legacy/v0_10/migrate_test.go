@@ -0,0 +1,56 @@
+package v0_10
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okchain/x/token"
+	v09token "github.com/okex/okchain/x/token/legacy/v0_9"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	oldGenState := v09token.GenesisState{
+		Params: v09token.Params{FeeIssue: sdk.NewDecCoinFromDec("okt", sdk.NewDec(20000))},
+		Tokens: []v09token.Token{
+			{Symbol: "okt", TotalSupply: sdk.NewDec(1000), Mintable: true},
+			{Symbol: "xxb-781", TotalSupply: sdk.NewDec(500), Mintable: false},
+		},
+	}
+
+	genState := Migrate(oldGenState)
+	require.Equal(t, oldGenState.Params.FeeIssue, genState.Params.FeeIssue)
+	require.Equal(t, types.DefaultMaxSupplyIncreasable, genState.Params.MaxSupplyIncreasable)
+	require.Equal(t, types.DefaultMaxSupplyDecreasable, genState.Params.MaxSupplyDecreasable)
+	require.Equal(t, types.DefaultOwnershipConfirmPeriod, genState.Params.OwnershipConfirmPeriod)
+	require.Equal(t, types.DefaultParams().FeeDistribute, genState.Params.FeeDistribute)
+	require.Equal(t, int64(types.DefaultDistributeBatchSize), genState.Params.DistributeBatchSize)
+	require.Equal(t, types.DefaultVestingReleasePeriod, genState.Params.VestingReleasePeriod)
+	require.True(t, genState.Tokens[0].MaxSupply.IsZero())
+	require.Equal(t, sdk.NewDec(500), genState.Tokens[1].MaxSupply)
+	for _, token := range genState.Tokens {
+		require.Equal(t, int64(types.DefaultDecimals), token.Decimals)
+	}
+}
+
+func TestMigrate_LockCoins(t *testing.T) {
+	cdc := codec.New()
+	lockCoins := []types.AccCoins{{
+		Acc:   sdk.AccAddress([]byte("addr1_______________")),
+		Coins: sdk.DecCoins{sdk.NewDecCoinFromDec("okt", sdk.NewDec(10))},
+	}}
+	// the locked coins are exported as lock_coins by v0.9
+	oldJSON := cdc.MustMarshalJSON(v09token.GenesisState{LockCoins: lockCoins})
+	require.Contains(t, string(oldJSON), `"lock_coins"`)
+	var oldGenState v09token.GenesisState
+	cdc.MustUnmarshalJSON(oldJSON, &oldGenState)
+
+	newJSON := cdc.MustMarshalJSON(Migrate(oldGenState))
+	require.Contains(t, string(newJSON), `"locked_asset"`)
+	require.NotContains(t, string(newJSON), `"lock_coins"`)
+	var genState token.GenesisState
+	cdc.MustUnmarshalJSON(newJSON, &genState)
+	require.Equal(t, lockCoins, genState.LockCoins)
+}
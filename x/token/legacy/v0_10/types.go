@@ -0,0 +1,70 @@
+package v0_10
+
+import (
+	"time"
+
+	"github.com/okex/okchain/x/token/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	ModuleName = types.ModuleName
+)
+
+type (
+	Params struct {
+		FeeBase       sdk.DecCoin `json:"base_fee"`
+		FeeIssue      sdk.DecCoin `json:"issue_fee"`
+		FeeMint       sdk.DecCoin `json:"mint_fee"`
+		FeeBurn       sdk.DecCoin `json:"burn_fee"`
+		FeeModify     sdk.DecCoin `json:"modify_fee"`
+		FeeSend       sdk.DecCoin `json:"send_fee"`
+		FeeMultiSend  sdk.DecCoin `json:"multi_send_fee"`
+		FeeChown      sdk.DecCoin `json:"transfer_ownership_fee"`
+		FeeDistribute sdk.DecCoin `json:"distribute_fee"`
+
+		MaxSupplyIncreasable   bool          `json:"max_supply_increasable"`
+		MaxSupplyDecreasable   bool          `json:"max_supply_decreasable"`
+		OwnershipConfirmPeriod time.Duration `json:"ownership_confirm_period"`
+		DistributeBatchSize    int64         `json:"distribute_batch_size"`
+		VestingReleasePeriod   time.Duration `json:"vesting_release_period"`
+	}
+
+	TokenMetadata struct {
+		URI      string `json:"uri"`
+		Website  string `json:"website"`
+		LogoHash string `json:"logo_hash"`
+	}
+
+	Token struct {
+		Description         string         `json:"description"`
+		Symbol              string         `json:"symbol"`
+		OriginalSymbol      string         `json:"original_symbol"`
+		WholeName           string         `json:"whole_name"`
+		OriginalTotalSupply sdk.Dec        `json:"original_total_supply"`
+		TotalSupply         sdk.Dec        `json:"total_supply"`
+		Owner               sdk.AccAddress `json:"owner"`
+		Mintable            bool           `json:"mintable"`
+		Freezable           bool           `json:"freezable,omitempty"`
+		MaxSupply           sdk.Dec        `json:"max_supply"`
+		Decimals            int64          `json:"decimals"`
+		Metadata            TokenMetadata  `json:"metadata"`
+	}
+
+	// GenesisState - all token state that must be provided at genesis
+	GenesisState struct {
+		Params         Params                `json:"params"`
+		Tokens         []Token               `json:"tokens"`
+		LockCoins      []types.AccCoins      `json:"locked_asset"`
+		FrozenAccounts []types.FrozenAccount `json:"frozen_accounts"`
+		VestingLocks   []types.VestingLock   `json:"vesting_locks"`
+
+		OwnershipProposals []types.OwnershipProposal `json:"ownership_proposals"`
+		Multisigs          []types.Multisig          `json:"multisigs"`
+		MultisigProposals  []types.MultisigProposal  `json:"multisig_proposals"`
+
+		Distributions       []types.Distribution       `json:"distributions"`
+		DistributionHolders []types.DistributionHolder `json:"distribution_holders"`
+	}
+)
@@ -3,23 +3,22 @@ package v0_9
 import (
 	v08gov "github.com/okex/okchain/x/gov/legacy/v0_8"
 	v08token "github.com/okex/okchain/x/token/legacy/v0_8"
-	"github.com/okex/okchain/x/token/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func Migrate(oldGenState v08token.GenesisState, oldgovParams v08gov.GovParams) GenesisState {
 
-	params := types.Params{
+	params := Params{
 		//FeeIssue: sdk.NewDecCoinFromDec(common.NativeToken, oldGenState.Params.IssueAsset),
 		//FeeMint:  sdk.NewDecCoinFromDec(common.NativeToken, oldGenState.Params.MintAsset),
 		//FeeBurn:  sdk.NewDecCoinFromDec(common.NativeToken, oldGenState.Params.BurnAsset),
 		//FeeSend:  sdk.NewDecCoinFromDec(common.NativeToken, oldGenState.Params.Transfer),
 	}
 
-	tokens := make([]types.Token, len(oldGenState.Info))
+	tokens := make([]Token, len(oldGenState.Info))
 	for k, token := range oldGenState.Info {
-		tokens[k] = types.Token{
+		tokens[k] = Token{
 			Description:         token.Desc,
 			Symbol:              token.Symbol,
 			OriginalSymbol:      token.OriginalSymbol,
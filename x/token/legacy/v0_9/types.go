@@ -2,6 +2,8 @@ package v0_9
 
 import (
 	"github.com/okex/okchain/x/token/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
@@ -9,10 +11,32 @@ const (
 )
 
 type (
+	Params struct {
+		FeeBase      sdk.DecCoin `json:"base_fee"`
+		FeeIssue     sdk.DecCoin `json:"issue_fee"`
+		FeeMint      sdk.DecCoin `json:"mint_fee"`
+		FeeBurn      sdk.DecCoin `json:"burn_fee"`
+		FeeModify    sdk.DecCoin `json:"modify_fee"`
+		FeeSend      sdk.DecCoin `json:"send_fee"`
+		FeeMultiSend sdk.DecCoin `json:"multi_send_fee"`
+		FeeChown     sdk.DecCoin `json:"transfer_ownership_fee"`
+	}
+
+	Token struct {
+		Description         string         `json:"description"`
+		Symbol              string         `json:"symbol"`
+		OriginalSymbol      string         `json:"original_symbol"`
+		WholeName           string         `json:"whole_name"`
+		OriginalTotalSupply sdk.Dec        `json:"original_total_supply"`
+		TotalSupply         sdk.Dec        `json:"total_supply"`
+		Owner               sdk.AccAddress `json:"owner"`
+		Mintable            bool           `json:"mintable"`
+	}
+
 	// GenesisState - all slashing state that must be provided at genesis
 	GenesisState struct {
-		Params    types.Params     `json:"params"`
-		Tokens    []types.Token    `json:"tokens"`
+		Params    Params           `json:"params"`
+		Tokens    []Token          `json:"tokens"`
 		LockCoins []types.AccCoins `json:"lock_coins"`
 	}
 )
@@ -0,0 +1,109 @@
+package token
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestHandleMsgTokenMintMaxSupply(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(1,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	owner := testAccounts[0].baseAccount.Address
+
+	// the max supply can not be less than the total supply
+	issueMsg := types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", owner, true)
+	issueMsg.MaxSupply = "999"
+	require.Equal(t, types.CodeInvalidMaxSupply, issueMsg.ValidateBasic().Code())
+
+	issueMsg.MaxSupply = "1500"
+	require.True(t, handler(ctx, issueMsg).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+	require.Equal(t, sdk.NewDec(1500), keeper.GetTokenInfo(ctx, symbol).MaxSupply)
+
+	// mint up to the max supply
+	mintMsg := types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(500)), owner)
+	require.True(t, handler(ctx, mintMsg).IsOK())
+	mintMsg = types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(1)), owner)
+	require.Equal(t, types.CodeMaxSupplyExceeded, handler(ctx, mintMsg).Code)
+	require.Equal(t, sdk.NewDec(1500), keeper.GetTokenInfo(ctx, symbol).TotalSupply)
+
+	// the token without max supply is not capped
+	issueMsg = types.NewMsgTokenIssue("yyb", "", "yyb", "yyb", "1000", owner, true)
+	require.True(t, handler(ctx.WithTxBytes([]byte("yyb")), issueMsg).IsOK())
+	symbol = getTokenSymbol(ctx, keeper, "yyb")
+	require.False(t, keeper.GetTokenInfo(ctx, symbol).IsCapped())
+	mintMsg = types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(100000)), owner)
+	require.True(t, handler(ctx, mintMsg).IsOK())
+}
+
+func TestHandleMsgTokenModifyMaxSupply(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(1,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	owner := testAccounts[0].baseAccount.Address
+
+	issueMsg := types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", owner, true)
+	issueMsg.MaxSupply = "2000"
+	require.True(t, handler(ctx, issueMsg).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+
+	newModifyMsg := func(maxSupply string) types.MsgTokenModify {
+		msg := types.NewMsgTokenModify(symbol, "", "", false, false, owner)
+		msg.MaxSupply = maxSupply
+		msg.IsMaxSupplyModified = true
+		return msg
+	}
+
+	// raising or removing the cap is not allowed by default
+	require.Equal(t, types.CodeInvalidMaxSupply, handler(ctx, newModifyMsg("3000")).Code)
+	require.Equal(t, types.CodeInvalidMaxSupply, handler(ctx, newModifyMsg("0")).Code)
+
+	// lowering the cap is allowed, but not below the total supply
+	require.Equal(t, types.CodeInvalidMaxSupply, handler(ctx, newModifyMsg("999")).Code)
+	require.True(t, handler(ctx, newModifyMsg("1200")).IsOK())
+	require.Equal(t, sdk.NewDec(1200), keeper.GetTokenInfo(ctx, symbol).MaxSupply)
+
+	// the rules are configured in params
+	params := types.DefaultParams()
+	params.MaxSupplyIncreasable = true
+	params.MaxSupplyDecreasable = false
+	keeper.SetParams(ctx, params)
+	require.Equal(t, types.CodeInvalidMaxSupply, handler(ctx, newModifyMsg("1100")).Code)
+	require.True(t, handler(ctx, newModifyMsg("5000")).IsOK())
+	require.True(t, handler(ctx, newModifyMsg("0")).IsOK())
+	require.False(t, keeper.GetTokenInfo(ctx, symbol).IsCapped())
+
+	// capping an uncapped token lowers its max supply
+	require.Equal(t, types.CodeInvalidMaxSupply, handler(ctx, newModifyMsg("5000")).Code)
+}
+
+func TestValidateGenesisMaxSupply(t *testing.T) {
+	genesisState := DefaultGenesisState()
+	genesisState.Tokens[0].MaxSupply = genesisState.Tokens[0].TotalSupply
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.Tokens[0].MaxSupply = genesisState.Tokens[0].TotalSupply.Sub(sdk.OneDec())
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
@@ -0,0 +1,72 @@
+package token
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestHandleMsgTokenDecimalsAndMetadata(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(1,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	owner := testAccounts[0].baseAccount.Address
+	logoHash := strings.Repeat("ab", 32)
+
+	// issue with decimals and metadata
+	issueMsg := types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", owner, true)
+	issueMsg.Decimals = "9"
+	require.NotNil(t, issueMsg.ValidateBasic())
+	issueMsg.Decimals = "2"
+	issueMsg.Website = "https://xxb.com"
+	issueMsg.LogoHash = logoHash
+	require.Nil(t, issueMsg.ValidateBasic())
+	require.True(t, handler(ctx, issueMsg).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+	token := keeper.GetTokenInfo(ctx, symbol)
+	require.Equal(t, int64(2), token.Decimals)
+	require.Equal(t, types.NewTokenMetadata("", "https://xxb.com", logoHash), token.Metadata)
+
+	// issue without decimals gets the default one
+	issueMsg = types.NewMsgTokenIssue("yyb", "", "yyb", "yyb", "1000", owner, true)
+	require.True(t, handler(ctx.WithTxBytes([]byte("yyb")), issueMsg).IsOK())
+	token = keeper.GetTokenInfo(ctx, getTokenSymbol(ctx, keeper, "yyb"))
+	require.Equal(t, int64(types.DefaultDecimals), token.Decimals)
+	require.Equal(t, types.TokenMetadata{}, token.Metadata)
+
+	// modify the decimals only
+	modifyMsg := types.NewMsgTokenModify(symbol, "", "", false, false, owner)
+	modifyMsg.Decimals = 6
+	modifyMsg.IsDecimalsModified = true
+	require.True(t, handler(ctx, modifyMsg).IsOK())
+	token = keeper.GetTokenInfo(ctx, symbol)
+	require.Equal(t, int64(6), token.Decimals)
+	require.Equal(t, "https://xxb.com", token.Metadata.Website)
+
+	// the metadata is replaced as a whole
+	modifyMsg = types.NewMsgTokenModify(symbol, "", "", false, false, owner)
+	modifyMsg.URI = "https://xxb.com/xxb.json"
+	modifyMsg.IsMetadataModified = true
+	require.True(t, handler(ctx, modifyMsg).IsOK())
+	token = keeper.GetTokenInfo(ctx, symbol)
+	require.Equal(t, int64(6), token.Decimals)
+	require.Equal(t, types.NewTokenMetadata("https://xxb.com/xxb.json", "", ""), token.Metadata)
+
+	// invalid metadata
+	modifyMsg.LogoHash = "xxb"
+	require.NotNil(t, modifyMsg.ValidateBasic())
+}
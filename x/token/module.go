@@ -19,17 +19,20 @@ var (
 // AppModule app module
 type AppModule struct {
 	AppModuleBasic
-	keeper       Keeper
-	supplyKeeper authTypes.SupplyKeeper
-	version      version.ProtocolVersionType
+	keeper        Keeper
+	supplyKeeper  authTypes.SupplyKeeper
+	accountKeeper SnapshotAccountKeeper
+	version       version.ProtocolVersionType
 }
 
 // NewAppModule creates a new AppModule object
-func NewAppModule(v version.ProtocolVersionType, keeper Keeper, supplyKeeper authTypes.SupplyKeeper) AppModule {
+func NewAppModule(v version.ProtocolVersionType, keeper Keeper, supplyKeeper authTypes.SupplyKeeper,
+	accountKeeper SnapshotAccountKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         keeper,
 		supplyKeeper:   supplyKeeper,
+		accountKeeper:  accountKeeper,
 		version:        v,
 	}
 }
@@ -41,7 +44,7 @@ func (AppModule) Name() string {
 
 // RegisterInvariants register invariants
 func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
-	//RegisterInvariants(ir, am.keeper)
+	RegisterInvariants(ir, am.keeper, am.accountKeeper)
 }
 
 // Route module message route name
@@ -61,7 +64,7 @@ func (AppModule) QuerierRoute() string {
 
 // NewQuerierHandler module querier
 func (am AppModule) NewQuerierHandler() sdk.Querier {
-	return NewQuerier(am.keeper)
+	return NewQuerier(am.keeper, am.accountKeeper)
 }
 
 // InitGenesis module init-genesis
@@ -80,7 +83,7 @@ func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
 
 // BeginBlock module begin-block
 func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
-	BeginBlocker(ctx, am.keeper)
+	BeginBlocker(ctx, am.keeper, am.accountKeeper)
 }
 
 // EndBlock module end-block
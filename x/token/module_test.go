@@ -14,7 +14,8 @@ import (
 
 func TestAppModule_InitGenesis(t *testing.T) {
 	app, tokenKeeper, _ := getMockDexAppEx(t, 0)
-	module := NewAppModule(version.ProtocolVersionV0, tokenKeeper, app.supplyKeeper)
+	module := NewAppModule(version.ProtocolVersionV0, tokenKeeper, app.supplyKeeper,
+		NewSnapshotAccountKeeper(app.AccountKeeper, app.KeyAccount))
 	ctx := app.NewContext(true, abci.Header{})
 	gs := DefaultGenesisState()
 	gs.Tokens = nil
@@ -0,0 +1,131 @@
+package token
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/token/types"
+)
+
+// TransferOwnership moves the token from the old owner to the new owner
+func (k Keeper) TransferOwnership(ctx sdk.Context, token types.Token, to sdk.AccAddress) {
+	k.DeleteUserToken(ctx, token.Owner, token.Symbol)
+	token.Owner = to
+	k.NewToken(ctx, token)
+}
+
+// SetOwnershipProposal saves the pending ownership transfer of a token, which replaces the former one
+func (k Keeper) SetOwnershipProposal(ctx sdk.Context, proposal types.OwnershipProposal) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetOwnershipProposalKey(proposal.Symbol), k.cdc.MustMarshalBinaryBare(proposal))
+}
+
+// GetOwnershipProposal gets the pending ownership transfer of a token
+func (k Keeper) GetOwnershipProposal(ctx sdk.Context, symbol string) (proposal types.OwnershipProposal, found bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	bz := store.Get(types.GetOwnershipProposalKey(symbol))
+	if bz == nil {
+		return proposal, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &proposal)
+	return proposal, true
+}
+
+// DeleteOwnershipProposal deletes the pending ownership transfer of a token
+func (k Keeper) DeleteOwnershipProposal(ctx sdk.Context, symbol string) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Delete(types.GetOwnershipProposalKey(symbol))
+}
+
+// GetAllOwnershipProposals gets all the pending ownership transfers
+func (k Keeper) GetAllOwnershipProposals(ctx sdk.Context) (proposals []types.OwnershipProposal) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.OwnershipProposalKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var proposal types.OwnershipProposal
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	return proposals
+}
+
+// SetMultisig saves the multisig
+func (k Keeper) SetMultisig(ctx sdk.Context, multisig types.Multisig) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetMultisigKey(multisig.Address), k.cdc.MustMarshalBinaryBare(multisig))
+}
+
+// GetMultisig gets the multisig at the address
+func (k Keeper) GetMultisig(ctx sdk.Context, addr sdk.AccAddress) (multisig types.Multisig, found bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	bz := store.Get(types.GetMultisigKey(addr))
+	if bz == nil {
+		return multisig, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &multisig)
+	return multisig, true
+}
+
+// GetAllMultisigs gets all the multisigs
+func (k Keeper) GetAllMultisigs(ctx sdk.Context) (multisigs []types.Multisig) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.MultisigKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var multisig types.Multisig
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &multisig)
+		multisigs = append(multisigs, multisig)
+	}
+	return multisigs
+}
+
+// SetMultisigProposal saves the multisig proposal
+func (k Keeper) SetMultisigProposal(ctx sdk.Context, proposal types.MultisigProposal) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetMultisigProposalKey(proposal.ID), k.cdc.MustMarshalBinaryBare(proposal))
+}
+
+// GetMultisigProposal gets the multisig proposal by id
+func (k Keeper) GetMultisigProposal(ctx sdk.Context, id uint64) (proposal types.MultisigProposal, found bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	bz := store.Get(types.GetMultisigProposalKey(id))
+	if bz == nil {
+		return proposal, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &proposal)
+	return proposal, true
+}
+
+func (k Keeper) deleteMultisigProposal(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Delete(types.GetMultisigProposalKey(id))
+}
+
+// GetMultisigProposals gets the pending proposals of the multisig, or all the pending proposals if addr is empty
+func (k Keeper) GetMultisigProposals(ctx sdk.Context, addr sdk.AccAddress) (proposals []types.MultisigProposal) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.MultisigProposalKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var proposal types.MultisigProposal
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &proposal)
+		if addr.Empty() || proposal.Multisig.Equals(addr) {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals
+}
+
+func (k Keeper) getNextMultisigProposalID(ctx sdk.Context) (id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	if b := store.Get(types.MultisigProposalNumberKey); b != nil {
+		k.cdc.MustUnmarshalBinaryBare(b, &id)
+	}
+	k.setNextMultisigProposalID(ctx, id+1)
+	return id
+}
+
+func (k Keeper) setNextMultisigProposalID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.MultisigProposalNumberKey, k.cdc.MustMarshalBinaryBare(id))
+}
@@ -0,0 +1,133 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestHandleMsgProposeAndConfirmOwnership(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(2,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	now := time.Now()
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: now})
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	owner := testAccounts[0].baseAccount.Address
+	recipient := testAccounts[1].baseAccount.Address
+
+	issueMsg := types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", owner, true)
+	require.True(t, handler(ctx, issueMsg).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+
+	// only the owner can propose
+	require.False(t, handler(ctx, types.NewMsgProposeOwnership(recipient, owner, symbol)).IsOK())
+	require.True(t, handler(ctx, types.NewMsgProposeOwnership(owner, recipient, symbol)).IsOK())
+	proposal, found := keeper.GetOwnershipProposal(ctx, symbol)
+	require.True(t, found)
+	require.Equal(t, now.Add(types.DefaultOwnershipConfirmPeriod).Unix(), proposal.ExpireTime)
+	require.Equal(t, owner, keeper.GetTokenInfo(ctx, symbol).Owner)
+
+	// only the recipient can confirm, before the deadline
+	require.Equal(t, sdk.CodeUnauthorized, handler(ctx, types.NewMsgConfirmOwnership(owner, symbol)).Code)
+	expiredCtx := ctx.WithBlockHeader(abci.Header{Time: now.Add(types.DefaultOwnershipConfirmPeriod + time.Second)})
+	require.Equal(t, types.CodeInvalidOwnership, handler(expiredCtx, types.NewMsgConfirmOwnership(recipient, symbol)).Code)
+
+	require.True(t, handler(ctx, types.NewMsgConfirmOwnership(recipient, symbol)).IsOK())
+	require.Equal(t, recipient, keeper.GetTokenInfo(ctx, symbol).Owner)
+	_, found = keeper.GetOwnershipProposal(ctx, symbol)
+	require.False(t, found)
+	require.Equal(t, types.CodeInvalidOwnership, handler(ctx, types.NewMsgConfirmOwnership(recipient, symbol)).Code)
+}
+
+func TestHandleMsgMultisig(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(3,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Now()})
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	owner := testAccounts[0].baseAccount.Address
+	members := []sdk.AccAddress{owner, testAccounts[1].baseAccount.Address, testAccounts[2].baseAccount.Address}
+
+	// create a 2-of-3 multisig
+	require.True(t, handler(ctx, types.NewMsgCreateMultisig(owner, members, 2)).IsOK())
+	multisigAddr := types.NewMultisig(members, 2).Address
+	multisig, found := keeper.GetMultisig(ctx, multisigAddr)
+	require.True(t, found)
+	require.Equal(t, uint64(2), multisig.Threshold)
+	require.Equal(t, types.CodeInvalidMultisig, handler(ctx, types.NewMsgCreateMultisig(owner, members, 2)).Code)
+
+	// the multisig pays the fees of its msgs
+	fees := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(10000))}
+	require.True(t, handler(ctx, types.NewMsgTokenSend(owner, multisigAddr, fees)).IsOK())
+
+	issueMsg := types.NewMsgTokenIssue("xxb", "", "xxb", "xxb", "1000", owner, true)
+	require.True(t, handler(ctx, issueMsg).IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+
+	// transfer the ownership to the multisig, which confirms it with 2 approvals
+	require.True(t, handler(ctx, types.NewMsgProposeOwnership(owner, multisigAddr, symbol)).IsOK())
+	confirmMsg := types.NewMsgMultisigSubmit(members[1], types.NewMsgConfirmOwnership(multisigAddr, symbol))
+	require.Nil(t, confirmMsg.ValidateBasic())
+	require.True(t, handler(ctx, confirmMsg).IsOK())
+	proposals := keeper.GetMultisigProposals(ctx, multisigAddr)
+	require.Equal(t, 1, len(proposals))
+	require.Equal(t, owner, keeper.GetTokenInfo(ctx, symbol).Owner)
+
+	require.Equal(t, types.CodeInvalidMultisig, handler(ctx, types.NewMsgMultisigApprove(members[1], proposals[0].ID)).Code)
+	require.True(t, handler(ctx, types.NewMsgMultisigApprove(members[2], proposals[0].ID)).IsOK())
+	require.Equal(t, multisigAddr, keeper.GetTokenInfo(ctx, symbol).Owner)
+	require.Equal(t, 0, len(keeper.GetMultisigProposals(ctx, multisigAddr)))
+
+	// the members can no longer mint by themselves
+	require.False(t, handler(ctx, types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(100)), owner)).IsOK())
+
+	// the mint signed by the multisig is executed with 2 approvals
+	mintMsg := types.NewMsgMultisigSubmit(owner, types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(100)), multisigAddr))
+	require.True(t, handler(ctx, mintMsg).IsOK())
+	proposals = keeper.GetMultisigProposals(ctx, multisigAddr)
+	require.Equal(t, 1, len(proposals))
+
+	outsider := sdk.AccAddress([]byte("outsider"))
+	require.Equal(t, sdk.CodeUnauthorized, handler(ctx, types.NewMsgMultisigApprove(outsider, proposals[0].ID)).Code)
+	require.True(t, handler(ctx, types.NewMsgMultisigApprove(members[1], proposals[0].ID)).IsOK())
+	require.Equal(t, sdk.NewDec(1100), keeper.GetTokenInfo(ctx, symbol).TotalSupply)
+	require.Equal(t, sdk.NewDec(100), keeper.GetCoins(ctx, multisigAddr).AmountOf(symbol))
+
+	// the failed execution leaves the proposal pending
+	modifyMsg := types.NewMsgTokenModify(symbol, "", "", false, false, multisigAddr)
+	modifyMsg.IsMaxSupplyModified = true
+	modifyMsg.MaxSupply = "1"
+	require.True(t, handler(ctx, types.NewMsgMultisigSubmit(owner, modifyMsg)).IsOK())
+	proposals = keeper.GetMultisigProposals(ctx, multisigAddr)
+	require.Equal(t, 1, len(proposals))
+	require.Equal(t, types.CodeInvalidMaxSupply, handler(ctx, types.NewMsgMultisigApprove(members[2], proposals[0].ID)).Code)
+	proposal, found := keeper.GetMultisigProposal(ctx, proposals[0].ID)
+	require.True(t, found)
+	require.Equal(t, 1, len(proposal.Approvals))
+
+	// the state is exported and imported
+	gs := ExportGenesis(ctx, keeper)
+	require.Equal(t, 1, len(gs.Multisigs))
+	require.Equal(t, 1, len(gs.MultisigProposals))
+	require.Nil(t, ValidateGenesis(gs))
+	types.ModuleCdc.MustUnmarshalJSON(types.ModuleCdc.MustMarshalJSON(gs), &gs)
+	require.Equal(t, modifyMsg, gs.MultisigProposals[0].Msg)
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/okex/okchain/x/token/types"
 
@@ -11,7 +12,7 @@ import (
 )
 
 // NewQuerier is the module level router for state queries
-func NewQuerier(keeper Keeper) sdk.Querier {
+func NewQuerier(keeper Keeper, accountKeeper AccountKeeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
 		case types.QueryInfo:
@@ -26,6 +27,20 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryAccount(ctx, path[1:], req, keeper)
 		case types.QueryKeysNum:
 			return queryKeysNum(ctx, keeper)
+		case types.QueryFrozen:
+			return queryFrozen(ctx, path[1:], keeper)
+		case types.QueryVesting:
+			return queryVesting(ctx, path[1:], keeper)
+		case types.QueryOwnership:
+			return queryOwnership(ctx, path[1:], keeper)
+		case types.QueryMultisig:
+			return queryMultisig(ctx, path[1:], keeper)
+		case types.QueryProposals:
+			return queryMultisigProposals(ctx, path[1:], keeper)
+		case types.QueryHolders:
+			return queryHolders(ctx, req, keeper, accountKeeper)
+		case types.QueryDistributions:
+			return queryDistributions(ctx, path[1:], keeper)
 		case types.QueryAccountV2:
 			return queryAccountV2(ctx, path[1:], req, keeper)
 		case types.QueryTokensV2:
@@ -150,6 +165,169 @@ func queryParameters(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
 	return res, nil
 }
 
+func queryFrozen(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 || path[0] == "" {
+		return nil, sdk.ErrUnknownRequest("symbol is required to query frozen accounts")
+	}
+	if !keeper.TokenExist(ctx, path[0]) {
+		return nil, sdk.ErrInvalidCoins("unknown token")
+	}
+
+	addrs := keeper.GetFrozenAccounts(ctx, path[0])
+	if addrs == nil {
+		addrs = []sdk.AccAddress{}
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, addrs)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryVesting(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("address is required to query vesting locks")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", path[0]))
+	}
+
+	blockTime := ctx.BlockHeader().Time.Unix()
+	infos := types.VestingInfos{}
+	for _, lock := range keeper.GetVestingLocks(ctx, addr) {
+		infos = append(infos, types.NewVestingInfo(lock, blockTime))
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, infos)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryOwnership(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("symbol is required to query the ownership transfer")
+	}
+	proposal, found := keeper.GetOwnershipProposal(ctx, path[0])
+	if !found {
+		return nil, types.ErrInvalidOwnership(types.DefaultCodespace,
+			fmt.Sprintf("no pending ownership transfer of token(%s)", path[0]))
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, proposal)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryMultisig(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("address is required to query the multisig")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", path[0]))
+	}
+	multisig, found := keeper.GetMultisig(ctx, addr)
+	if !found {
+		return nil, types.ErrInvalidMultisig(types.DefaultCodespace, fmt.Sprintf("%s is not a multisig", addr))
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, multisig)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// queryMultisigProposals returns the pending proposals of the multisig, or all the pending proposals without address
+func queryMultisigProposals(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	var addr sdk.AccAddress
+	if len(path) > 0 && path[0] != "" {
+		var err error
+		if addr, err = sdk.AccAddressFromBech32(path[0]); err != nil {
+			return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", path[0]))
+		}
+	}
+	proposals := keeper.GetMultisigProposals(ctx, addr)
+	if proposals == nil {
+		proposals = []types.MultisigProposal{}
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, proposals)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// queryHolders returns a page of the holders of a token sorted by the balance, which counts the coins locked in orders.
+// The holders at a historical height are queried with the height of the request, which is the reported height
+func queryHolders(ctx sdk.Context, req abci.RequestQuery, keeper Keeper, accountKeeper AccountKeeper) ([]byte, sdk.Error) {
+	var params types.QueryHoldersParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if !keeper.TokenExist(ctx, params.Symbol) {
+		return nil, sdk.ErrInvalidCoins(fmt.Sprintf("unknown token %s", params.Symbol))
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PerPage <= 0 {
+		params.PerPage = types.DefaultHoldersPerPage
+	}
+	if params.PerPage > types.MaxHoldersPerPage {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("per_page should be at most %d", types.MaxHoldersPerPage))
+	}
+
+	holders := types.Holders{}
+	keeper.IterateHolders(ctx, accountKeeper, params.Symbol, func(holder types.Holder) bool {
+		holders = append(holders, holder)
+		return false
+	})
+	holders.Sort()
+
+	res := types.HoldersResponse{
+		Symbol:  params.Symbol,
+		Height:  req.Height,
+		Total:   len(holders),
+		Page:    params.Page,
+		PerPage: params.PerPage,
+		Holders: holders.Page(params.Page, params.PerPage),
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// queryDistributions returns the distribution by id, or the distributions of the token by symbol
+func queryDistributions(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 || path[0] == "" {
+		return nil, sdk.ErrUnknownRequest("id or symbol is required to query distributions")
+	}
+
+	var distributions types.Distributions
+	if id, err := strconv.ParseUint(path[0], 10, 64); err == nil {
+		distribution, found := keeper.GetDistribution(ctx, id)
+		if !found {
+			return nil, types.ErrInvalidDistribution(types.DefaultCodespace, fmt.Sprintf("distribution %d does not exist", id))
+		}
+		distributions = types.Distributions{distribution}
+	} else {
+		distributions = keeper.GetDistributions(ctx, path[0])
+		if distributions == nil {
+			distributions = types.Distributions{}
+		}
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, distributions)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
 func queryKeysNum(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
 	tokenStoreKeyNum, lockStoreKeyNum := keeper.GetNumKeys(ctx)
 	res, err := codec.MarshalJSONIndent(keeper.cdc,
@@ -30,6 +30,7 @@ func TestQueryOrder(t *testing.T) {
 		TotalSupply:         sdk.NewDec(1000000000),
 		Owner:               testAccounts[0].baseAccount.Address,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
 	}
 
 	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(token.Symbol, token.TotalSupply))
@@ -38,7 +39,7 @@ func TestQueryOrder(t *testing.T) {
 
 	keeper.NewToken(ctx, token)
 
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 	path := []string{types.QueryInfo, ""}
 	res, err := querier(ctx, path, abci.RequestQuery{})
 	require.NotNil(t, err)
@@ -70,6 +71,7 @@ func TestQueryTokens(t *testing.T) {
 		TotalSupply:         sdk.NewDec(1000000000),
 		Owner:               testAccounts[0].baseAccount.Address,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
 	}
 
 	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(token.Symbol, token.TotalSupply))
@@ -81,7 +83,7 @@ func TestQueryTokens(t *testing.T) {
 	var originTokens []types.Token
 	originTokens = append(originTokens, token)
 
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 
 	path := []string{types.QueryTokens}
 	res, err := querier(ctx, path, abci.RequestQuery{})
@@ -155,6 +157,7 @@ func TestQueryUserTokens(t *testing.T) {
 		TotalSupply:         sdk.NewDec(1000000000),
 		Owner:               testAccounts[0].baseAccount.Address,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
 	}
 
 	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(token.Symbol, token.TotalSupply))
@@ -166,7 +169,7 @@ func TestQueryUserTokens(t *testing.T) {
 	var originTokens []types.Token
 	originTokens = append(originTokens, token)
 
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 
 	path := []string{types.QueryTokens, testAccounts[0].baseAccount.Address.String()}
 	res, err := querier(ctx, path, abci.RequestQuery{})
@@ -208,7 +211,7 @@ func TestQueryCurrency(t *testing.T) {
 		},
 	}
 
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 	path := []string{types.QueryCurrency}
 	res, err := querier(ctx, path, abci.RequestQuery{})
 	require.Nil(t, err)
@@ -249,7 +252,7 @@ func TestQueryAccount(t *testing.T) {
 
 	keeper.NewToken(ctx, token)
 
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 	path := []string{types.QueryAccount, testAccounts[0].baseAccount.Address.String()}
 	var accountParam types.AccountParam
 	accountParam.Symbol = common.NativeToken
@@ -356,7 +359,7 @@ func TestQueryAccount_ShowAll(t *testing.T) {
 		},
 	}
 
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 
 	accountParam := types.AccountParam{
 		Symbol: "",
@@ -386,7 +389,7 @@ func TestQueryParameters(t *testing.T) {
 	params := types.DefaultParams()
 	keeper.SetParams(ctx, params)
 
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 	path := []string{types.QueryParameters}
 
 	res, err := querier(ctx, path, abci.RequestQuery{})
@@ -412,7 +415,7 @@ func TestQueryKeysNum(t *testing.T) {
 	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
 
 	path := []string{types.QueryKeysNum}
-	querier := NewQuerier(keeper)
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
 	res, err := querier(ctx, path, abci.RequestQuery{})
 	require.Nil(t, err)
 
@@ -434,3 +437,61 @@ func TestCreateParam(t *testing.T) {
 	require.NotNil(t, kv)
 	require.EqualValues(t, "testToken", string(data))
 }
+
+func TestQueryHolders(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+
+	genCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000))}
+	genAccs, testAccounts := CreateGenAccounts(3, genCoins)
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Height: 3})
+	keeper.NewToken(ctx, types.Token{Symbol: common.NativeToken, Owner: testAccounts[0].baseAccount.Address})
+	addr0, addr1, addr2 := testAccounts[0].baseAccount.Address, testAccounts[1].baseAccount.Address,
+		testAccounts[2].baseAccount.Address
+
+	// the coins locked in orders are counted, while the token module account holding them is not a holder
+	lockCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(300))}
+	require.NoError(t, keeper.LockCoins(ctx, addr0, lockCoins, types.LockCoinsTypeQuantity))
+	sendCoins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(200))}
+	require.NoError(t, keeper.SendCoinsFromAccountToAccount(ctx, addr1, addr2, sendCoins))
+
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
+	// the height of the request is reported, which the baseapp fills with the last committed height if it is not set
+	query := func(params types.QueryHoldersParams) (res types.HoldersResponse, err sdk.Error) {
+		bz, err := querier(ctx, []string{types.QueryHolders},
+			abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params), Height: 2})
+		if err == nil {
+			keeper.cdc.MustUnmarshalJSON(bz, &res)
+		}
+		return res, err
+	}
+
+	res, err := query(types.NewQueryHoldersParams(common.NativeToken, 0, 0))
+	require.Nil(t, err)
+	require.Equal(t, int64(2), res.Height)
+	require.Equal(t, 3, res.Total)
+	require.Equal(t, types.DefaultHoldersPerPage, res.PerPage)
+	require.Equal(t, types.Holders{
+		types.NewHolder(addr2, sdk.NewDec(1200), sdk.ZeroDec()),
+		types.NewHolder(addr0, sdk.NewDec(700), sdk.NewDec(300)),
+		types.NewHolder(addr1, sdk.NewDec(800), sdk.ZeroDec()),
+	}, res.Holders)
+
+	res, err = query(types.NewQueryHoldersParams(common.NativeToken, 2, 2))
+	require.Nil(t, err)
+	require.Equal(t, 3, res.Total)
+	require.Equal(t, types.Holders{types.NewHolder(addr1, sdk.NewDec(800), sdk.ZeroDec())}, res.Holders)
+
+	res, err = query(types.NewQueryHoldersParams(common.NativeToken, 3, 2))
+	require.Nil(t, err)
+	require.Empty(t, res.Holders)
+
+	_, err = query(types.NewQueryHoldersParams("xxb-000", 1, 10))
+	require.NotNil(t, err)
+	_, err = query(types.NewQueryHoldersParams(common.NativeToken, 1, types.MaxHoldersPerPage+1))
+	require.NotNil(t, err)
+	_, err = querier(ctx, []string{types.QueryHolders}, abci.RequestQuery{Data: []byte("x")})
+	require.NotNil(t, err)
+}
@@ -9,7 +9,9 @@ import (
 	store "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
@@ -36,14 +38,24 @@ type MockDexApp struct {
 	keySupply  *sdk.KVStoreKey
 	keyGov     *sdk.KVStoreKey
 	keyStaking *sdk.KVStoreKey
+	keyMint    *sdk.KVStoreKey
 
 	bankKeeper    bank.Keeper
 	tokenKeeper   Keeper
 	supplyKeeper  supply.Keeper
 	govKeeper     gov.Keeper
 	stakingKeeper staking.Keeper
+	mintKeeper    mint.Keeper
 }
 
+// mintStakingKeeper feeds the mint module with a fixed staking supply
+type mintStakingKeeper struct {
+	stakingSupply sdk.Dec
+}
+
+func (k mintStakingKeeper) StakingTokenSupply(ctx sdk.Context) sdk.Dec { return k.stakingSupply }
+func (k mintStakingKeeper) BondedRatio(ctx sdk.Context) sdk.Dec        { return sdk.OneDec() }
+
 func registerCodec(cdc *codec.Codec) {
 	RegisterCodec(cdc)
 	supply.RegisterCodec(cdc)
@@ -69,25 +81,30 @@ func getMockDexApp(t *testing.T, numGenAccs int) (mockDexApp *MockDexApp, keeper
 		keyLock:    sdk.NewKVStoreKey("lock"),
 		keySupply:  sdk.NewKVStoreKey(supply.StoreKey),
 		keyStaking: sdk.NewKVStoreKey(staking.StoreKey),
+		keyMint:    sdk.NewKVStoreKey(mint.StoreKey),
 	}
 
 	feeCollectorAcc := supply.NewEmptyModuleAccount(auth.FeeCollectorName)
 	blacklistedAddrs := make(map[string]bool)
-	blacklistedAddrs[feeCollectorAcc.String()] = true
+	blacklistedAddrs[feeCollectorAcc.GetAddress().String()] = true
 
-	mockDexApp.bankKeeper = bank.NewBaseKeeper(
+	mockDexApp.bankKeeper = NewFreezableBankKeeper(bank.NewBaseKeeper(
 		mockDexApp.AccountKeeper,
 		mockDexApp.ParamsKeeper.Subspace(bank.DefaultParamspace),
 		bank.DefaultCodespace,
 		blacklistedAddrs,
-	)
+	), mockDexApp.keyToken, mockDexApp.keyLock)
 
 	maccPerms := map[string][]string{
 		auth.FeeCollectorName: nil,
 		types.ModuleName:      {supply.Minter, supply.Burner},
 		gov.ModuleName:        nil,
+		mint.ModuleName:       {supply.Minter},
 	}
 	mockDexApp.supplyKeeper = supply.NewKeeper(mockDexApp.Cdc, mockDexApp.keySupply, mockDexApp.AccountKeeper, mockDexApp.bankKeeper, maccPerms)
+	mockDexApp.mintKeeper = mint.NewKeeper(mockDexApp.Cdc, mockDexApp.keyMint,
+		mockDexApp.ParamsKeeper.Subspace(mint.DefaultParamspace), mintStakingKeeper{sdk.NewDec(1000000)},
+		mockDexApp.supplyKeeper, auth.FeeCollectorName)
 	mockDexApp.tokenKeeper = NewKeeper(
 		mockDexApp.bankKeeper,
 		params.Keeper{Keeper: mockDexApp.ParamsKeeper},
@@ -102,7 +119,7 @@ func getMockDexApp(t *testing.T, numGenAccs int) (mockDexApp *MockDexApp, keeper
 	handler := NewTokenHandler(mockDexApp.tokenKeeper, version.CurrentProtocolVersion)
 
 	mockDexApp.Router().AddRoute(RouterKey, handler)
-	mockDexApp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(mockDexApp.tokenKeeper))
+	mockDexApp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(mockDexApp.tokenKeeper, mockDexApp.AccountKeeper))
 
 	mockDexApp.SetEndBlocker(getEndBlocker(mockDexApp.tokenKeeper))
 	mockDexApp.SetInitChainer(getInitChainer(mockDexApp.App, mockDexApp.supplyKeeper, []exported.ModuleAccountI{feeCollectorAcc}))
@@ -124,6 +141,7 @@ func getMockDexApp(t *testing.T, numGenAccs int) (mockDexApp *MockDexApp, keeper
 		app.keyToken,
 		app.keyLock,
 		app.keySupply,
+		app.keyMint,
 	))
 	// TODO: set genesis
 	app.BaseApp.NewContext(true, abci.Header{})
@@ -156,14 +174,14 @@ func getMockDexAppEx(t *testing.T, numGenAccs int) (mockDexApp *MockDexApp, keep
 
 	feeCollectorAcc := supply.NewEmptyModuleAccount(auth.FeeCollectorName)
 	blacklistedAddrs := make(map[string]bool)
-	blacklistedAddrs[feeCollectorAcc.String()] = true
+	blacklistedAddrs[feeCollectorAcc.GetAddress().String()] = true
 
-	mockDexApp.bankKeeper = bank.NewBaseKeeper(
+	mockDexApp.bankKeeper = NewFreezableBankKeeper(bank.NewBaseKeeper(
 		mockDexApp.AccountKeeper,
 		mockDexApp.ParamsKeeper.Subspace(bank.DefaultParamspace),
 		bank.DefaultCodespace,
 		blacklistedAddrs,
-	)
+	), mockDexApp.keyToken, mockDexApp.keyLock)
 
 	maccPerms := map[string][]string{
 		auth.FeeCollectorName:     nil,
@@ -221,7 +239,7 @@ func getMockDexAppEx(t *testing.T, numGenAccs int) (mockDexApp *MockDexApp, keep
 	handler := NewTokenHandler(mockDexApp.tokenKeeper, version.CurrentProtocolVersion)
 
 	mockDexApp.Router().AddRoute(RouterKey, handler)
-	mockDexApp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(mockDexApp.tokenKeeper))
+	mockDexApp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(mockDexApp.tokenKeeper, mockDexApp.AccountKeeper))
 
 	mockDexApp.SetEndBlocker(getEndBlocker(mockDexApp.tokenKeeper))
 	mockDexApp.SetInitChainer(getInitChainer(mockDexApp.App, mockDexApp.supplyKeeper, []exported.ModuleAccountI{feeCollectorAcc}))
@@ -262,7 +280,13 @@ func getInitChainer(mapp *mock.App, supplyKeeper supply.Keeper,
 		for _, macc := range blacklistedAddrs {
 			supplyKeeper.SetModuleAccount(ctx, macc)
 		}
-		supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.Coins{}))
+		// the supply of the genesis coins, as the supply module computes it at genesis
+		var totalSupply sdk.Coins
+		mapp.AccountKeeper.IterateAccounts(ctx, func(acc authexported.Account) bool {
+			totalSupply = totalSupply.Add(acc.GetCoins())
+			return false
+		})
+		supplyKeeper.SetSupply(ctx, supply.NewSupply(totalSupply))
 		return abci.ResponseInitChain{}
 	}
 }
@@ -2,6 +2,7 @@ package types
 
 import (
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // RegisterCodec registers concrete types on the Amino codec
@@ -13,6 +14,15 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSend{}, "okchain/token/MsgTransfer", nil)
 	cdc.RegisterConcrete(MsgTransferOwnership{}, "okchain/token/MsgTransferOwnership", nil)
 	cdc.RegisterConcrete(MsgTokenModify{}, "okchain/token/MsgModify", nil)
+	cdc.RegisterConcrete(MsgFreeze{}, "okchain/token/MsgFreeze", nil)
+	cdc.RegisterConcrete(MsgUnfreeze{}, "okchain/token/MsgUnfreeze", nil)
+	cdc.RegisterConcrete(MsgTimeLockedSend{}, "okchain/token/MsgTimeLockedSend", nil)
+	cdc.RegisterConcrete(MsgProposeOwnership{}, "okchain/token/MsgProposeOwnership", nil)
+	cdc.RegisterConcrete(MsgConfirmOwnership{}, "okchain/token/MsgConfirmOwnership", nil)
+	cdc.RegisterConcrete(MsgCreateMultisig{}, "okchain/token/MsgCreateMultisig", nil)
+	cdc.RegisterConcrete(MsgMultisigSubmit{}, "okchain/token/MsgMultisigSubmit", nil)
+	cdc.RegisterConcrete(MsgMultisigApprove{}, "okchain/token/MsgMultisigApprove", nil)
+	cdc.RegisterConcrete(MsgDistribute{}, "okchain/token/MsgDistribute", nil)
 
 	// for test
 	//cdc.RegisterConcrete(MsgTokenDestroy{}, "okchain/token/MsgDestroy", nil)
@@ -23,6 +33,8 @@ var ModuleCdc *codec.Codec
 
 func init() {
 	ModuleCdc = codec.New()
+	// the sdk.Msg interface is needed by the msgs submitted to a multisig
+	sdk.RegisterCodec(ModuleCdc)
 	RegisterCodec(ModuleCdc)
 	codec.RegisterCrypto(ModuleCdc)
 	ModuleCdc.Seal()
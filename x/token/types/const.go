@@ -5,4 +5,5 @@ const (
 
 	LockCoinsTypeQuantity = 1
 	LockCoinsTypeFee      = 2
+	LockCoinsTypeVesting  = 3
 )
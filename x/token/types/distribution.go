@@ -0,0 +1,93 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DistributionStatusPending is the status of a distribution taking the snapshot of the holders
+	DistributionStatusPending = "pending"
+	// DistributionStatusDistributing is the status of a distribution paying the holders in the snapshot by batches
+	DistributionStatusDistributing = "distributing"
+	// DistributionStatusFinished is the status of a distribution which has paid all the holders and returned the dust
+	DistributionStatusFinished = "finished"
+)
+
+// Distribution is the coins deposited by the owner of a token to be distributed to its holders pro rata.
+// The holders are snapshotted from the BeginBlocker after the deposit, and are paid by batches once the snapshot
+// completes. Both steps are bounded by DistributeBatchSize accounts per block. The holdings changed ahead of the cursor
+// are saved before they change, so that the snapshot is the holdings right after the deposit
+type Distribution struct {
+	ID           uint64         `json:"id"`
+	Symbol       string         `json:"symbol"`
+	Sender       sdk.AccAddress `json:"sender"`
+	Amount       sdk.DecCoins   `json:"amount"`
+	Height       int64          `json:"height"`
+	Status       string         `json:"status"`
+	TotalHolding sdk.Dec        `json:"total_holding"`
+	Holders      int64          `json:"holders"`
+	Paid         int64          `json:"paid"`
+	Distributed  sdk.DecCoins   `json:"distributed"`
+	Returned     sdk.DecCoins   `json:"returned"`
+	// the next account to be snapshotted, empty before the snapshot starts
+	SnapshotCursor sdk.AccAddress `json:"snapshot_cursor,omitempty"`
+}
+
+// NewDistribution creates a pending distribution of the coins deposited at the height
+func NewDistribution(id uint64, symbol string, sender sdk.AccAddress, amount sdk.DecCoins, height int64) Distribution {
+	return Distribution{
+		ID:           id,
+		Symbol:       symbol,
+		Sender:       sender,
+		Amount:       amount,
+		Height:       height,
+		Status:       DistributionStatusPending,
+		TotalHolding: sdk.ZeroDec(),
+		Distributed:  sdk.DecCoins{},
+		Returned:     sdk.DecCoins{},
+	}
+}
+
+func (distribution Distribution) String() string {
+	b, err := json.Marshal(distribution)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// ShareOf returns the coins paid to the holding, which are truncated to the precision.
+// The sum of the shares never exceeds the amount, and the dust left is returned to the sender
+func (distribution Distribution) ShareOf(holding sdk.Dec) sdk.DecCoins {
+	share := sdk.DecCoins{}
+	if !distribution.TotalHolding.IsPositive() {
+		return share
+	}
+	for _, coin := range distribution.Amount {
+		amount := coin.Amount.Mul(holding).QuoTruncate(distribution.TotalHolding)
+		if amount.IsPositive() {
+			share = append(share, sdk.NewDecCoinFromDec(coin.Denom, amount))
+		}
+	}
+	return share
+}
+
+// Distributions is the distributions of the tokens
+type Distributions []Distribution
+
+func (distributions Distributions) String() string {
+	b, err := json.Marshal(distributions)
+	if err != nil {
+		return "[{}]"
+	}
+	return string(b)
+}
+
+// DistributionHolder is a holder in the snapshot of a distribution, which is deleted after being paid
+type DistributionHolder struct {
+	DistributionID uint64         `json:"distribution_id"`
+	Address        sdk.AccAddress `json:"address"`
+	Holding        sdk.Dec        `json:"holding"`
+}
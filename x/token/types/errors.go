@@ -14,6 +14,12 @@ const (
 	CodeInvalidHeight           sdk.CodeType = 5
 	CodeInvalidAsset            sdk.CodeType = 6
 	CodeInvalidCommon           sdk.CodeType = 7
+	CodeAccountFrozen           sdk.CodeType = 8
+	CodeMaxSupplyExceeded       sdk.CodeType = 9
+	CodeInvalidMaxSupply        sdk.CodeType = 10
+	CodeInvalidOwnership        sdk.CodeType = 11
+	CodeInvalidMultisig         sdk.CodeType = 12
+	CodeInvalidDistribution     sdk.CodeType = 13
 )
 
 func ErrInvalidDexList(codespace sdk.CodespaceType, message string) sdk.Error {
@@ -31,3 +37,27 @@ func ErrInvalidHeight(codespace sdk.CodespaceType, h, ch, max int64) sdk.Error {
 func ErrInvalidCommon(codespace sdk.CodespaceType, message string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidCommon, message)
 }
+
+func ErrAccountFrozen(codespace sdk.CodespaceType, symbol string, addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeAccountFrozen, fmt.Sprintf("the balance of %s at %s is frozen", symbol, addr))
+}
+
+func ErrMaxSupplyExceeded(codespace sdk.CodespaceType, symbol string, maxSupply sdk.Dec) sdk.Error {
+	return sdk.NewError(codespace, CodeMaxSupplyExceeded, fmt.Sprintf("the total supply of %s exceeds its max supply %s", symbol, maxSupply))
+}
+
+func ErrInvalidMaxSupply(codespace sdk.CodespaceType, message string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidMaxSupply, message)
+}
+
+func ErrInvalidOwnership(codespace sdk.CodespaceType, message string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidOwnership, message)
+}
+
+func ErrInvalidMultisig(codespace sdk.CodespaceType, message string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidMultisig, message)
+}
+
+func ErrInvalidDistribution(codespace sdk.CodespaceType, message string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDistribution, message)
+}
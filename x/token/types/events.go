@@ -0,0 +1,8 @@
+package types
+
+// token module event types
+const (
+	EventTypeIssue = "issue"
+
+	AttributeKeySymbol = "symbol"
+)
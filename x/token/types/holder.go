@@ -0,0 +1,101 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultHoldersPerPage is the default page size of the holders query
+	DefaultHoldersPerPage = 100
+	// MaxHoldersPerPage is the max page size of the holders query
+	MaxHoldersPerPage = 1000
+)
+
+// QueryHoldersParams is the params of the holders query of a token
+type QueryHoldersParams struct {
+	Symbol  string `json:"symbol"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+}
+
+// NewQueryHoldersParams creates the params of the holders query of a token
+func NewQueryHoldersParams(symbol string, page, perPage int) QueryHoldersParams {
+	return QueryHoldersParams{
+		Symbol:  symbol,
+		Page:    page,
+		PerPage: perPage,
+	}
+}
+
+// Holder is the balance of a token held by an address, including the coins locked in orders
+type Holder struct {
+	Address   sdk.AccAddress `json:"address"`
+	Available sdk.Dec        `json:"available"`
+	Locked    sdk.Dec        `json:"locked"`
+	Total     sdk.Dec        `json:"total"`
+}
+
+// NewHolder creates a holder with the available and the locked amount
+func NewHolder(addr sdk.AccAddress, available, locked sdk.Dec) Holder {
+	return Holder{
+		Address:   addr,
+		Available: available,
+		Locked:    locked,
+		Total:     available.Add(locked),
+	}
+}
+
+// Holders is the holders of a token
+type Holders []Holder
+
+// Sort sorts the holders by the total amount in descending order, and by the address for the same amount
+func (holders Holders) Sort() {
+	sort.SliceStable(holders, func(i, j int) bool {
+		if !holders[i].Total.Equal(holders[j].Total) {
+			return holders[i].Total.GT(holders[j].Total)
+		}
+		return bytes.Compare(holders[i].Address, holders[j].Address) < 0
+	})
+}
+
+// Page returns the holders on the page, which starts from 1.
+// The page out of range is empty, which is checked before computing the offsets to avoid an overflow
+func (holders Holders) Page(page, perPage int) Holders {
+	if page < 1 || perPage < 1 {
+		return Holders{}
+	}
+	pages := len(holders) / perPage
+	if len(holders)%perPage != 0 {
+		pages++
+	}
+	if page > pages {
+		return Holders{}
+	}
+	start := (page - 1) * perPage
+	if perPage > len(holders)-start {
+		return holders[start:]
+	}
+	return holders[start : start+perPage]
+}
+
+// HoldersResponse is a page of the holders of a token at a block height
+type HoldersResponse struct {
+	Symbol  string  `json:"symbol"`
+	Height  int64   `json:"height"`
+	Total   int     `json:"total"`
+	Page    int     `json:"page"`
+	PerPage int     `json:"per_page"`
+	Holders Holders `json:"holders"`
+}
+
+func (res HoldersResponse) String() string {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolders_Page(t *testing.T) {
+	holders := make(Holders, 5)
+	for i := range holders {
+		holders[i] = NewHolder(sdk.AccAddress{byte(i)}, sdk.NewDec(int64(i)), sdk.ZeroDec())
+	}
+
+	require.Equal(t, holders[:2], holders.Page(1, 2))
+	require.Equal(t, holders[4:], holders.Page(3, 2))
+	require.Equal(t, holders, holders.Page(1, math.MaxInt64))
+	for _, pages := range [][2]int{{4, 2}, {0, 2}, {-1, 2}, {1, 0}, {1, -1},
+		{math.MaxInt64, 2}, {math.MaxInt64, math.MaxInt64}, {2, math.MaxInt64}, {math.MinInt64, 2}} {
+		require.Empty(t, holders.Page(pages[0], pages[1]), "page %d per page %d", pages[0], pages[1])
+	}
+	require.Empty(t, Holders{}.Page(1, 10))
+}
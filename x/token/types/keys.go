@@ -26,12 +26,19 @@ const (
 	KeyMint = "mint"
 
 	// query endpoints supported by the governance Querier
-	QueryInfo       = "info"
-	QueryTokens     = "tokens"
-	QueryParameters = "params"
-	QueryCurrency   = "currency"
-	QueryAccount    = "accounts"
-	QueryKeysNum    = "store"
+	QueryInfo          = "info"
+	QueryTokens        = "tokens"
+	QueryParameters    = "params"
+	QueryCurrency      = "currency"
+	QueryAccount       = "accounts"
+	QueryKeysNum       = "store"
+	QueryFrozen        = "frozen"
+	QueryVesting       = "vesting"
+	QueryOwnership     = "ownership"
+	QueryMultisig      = "multisig"
+	QueryProposals     = "multisig-proposals"
+	QueryHolders       = "holders"
+	QueryDistributions = "distributions"
 
 	QueryAccountV2 = "accountsV2"
 	QueryTokensV2  = "tokensV2"
@@ -43,6 +50,22 @@ var (
 	TokenNumberKey     = []byte{0x01} // key for token number address
 	LockKey            = []byte{0x02} // the address prefix of the locked coins
 	PrefixUserTokenKey = []byte{0x03} // the address prefix of the user-token relationship
+	FrozenKey          = []byte{0x04} // the address prefix of the frozen accounts
+
+	OwnershipProposalKey      = []byte{0x07} // the address prefix of the pending ownership transfers
+	MultisigKey               = []byte{0x08} // the address prefix of the multisigs
+	MultisigProposalKey       = []byte{0x09} // the address prefix of the multisig proposals
+	MultisigProposalNumberKey = []byte{0x0a} // key for the id of the next multisig proposal
+	DistributionKey           = []byte{0x0b} // the address prefix of the distributions
+	DistributionHolderKey     = []byte{0x0c} // the address prefix of the holders in the snapshots of the distributions
+	DistributionNumberKey     = []byte{0x0d} // key for the id of the next distribution
+	DistributionQueueKey      = []byte{0x0e} // the address prefix of the ids of the unfinished distributions
+	DistributionSnapshotKey   = []byte{0x0f} // the address prefix of the ids of the distributions snapshotting holders
+
+	// keys in the lock store
+	VestingKey       = []byte{0x05} // the address prefix of the vesting locks
+	VestingNumberKey = []byte{0x06} // key for the id of the next vesting lock
+	VestingQueueKey  = []byte{0x07} // the address prefix of the keys of the vesting locks by their next release time
 )
 
 func GetUserTokenPrefix(owner sdk.AccAddress) []byte {
@@ -57,6 +80,74 @@ func GetTokenAddress(symbol string) []byte {
 	return append(TokenKey, []byte(symbol)...)
 }
 
+// GetFrozenPrefix returns the prefix of the frozen accounts of the symbol.
+// The symbol is terminated by a zero byte, so that it never matches the prefix of a longer symbol
+func GetFrozenPrefix(symbol string) []byte {
+	prefix := append(FrozenKey, []byte(symbol)...)
+	return append(prefix, 0x00)
+}
+
+func GetFrozenKey(symbol string, addr sdk.AccAddress) []byte {
+	return append(GetFrozenPrefix(symbol), addr.Bytes()...)
+}
+
+func GetVestingPrefix(addr sdk.AccAddress) []byte {
+	return append(VestingKey, addr.Bytes()...)
+}
+
+func GetVestingKey(addr sdk.AccAddress, id uint64) []byte {
+	return append(GetVestingPrefix(addr), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetVestingQueueTimePrefix returns the prefix of the vesting locks released at the time, which sorts by time
+func GetVestingQueueTimePrefix(releaseTime int64) []byte {
+	return append(VestingQueueKey, sdk.Uint64ToBigEndian(uint64(releaseTime))...)
+}
+
+func GetVestingQueueKey(releaseTime int64, addr sdk.AccAddress, id uint64) []byte {
+	key := append(GetVestingQueueTimePrefix(releaseTime), addr.Bytes()...)
+	return append(key, sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetOwnershipProposalKey(symbol string) []byte {
+	return append(OwnershipProposalKey, []byte(symbol)...)
+}
+
+func GetMultisigKey(addr sdk.AccAddress) []byte {
+	return append(MultisigKey, addr.Bytes()...)
+}
+
+func GetMultisigProposalKey(id uint64) []byte {
+	return append(MultisigProposalKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetDistributionKey(id uint64) []byte {
+	return append(DistributionKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetDistributionQueueKey(id uint64) []byte {
+	return append(DistributionQueueKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetDistributionSnapshotPrefix returns the prefix of the ids of the distributions snapshotting the holders of the
+// symbol. The symbol is terminated by a zero byte, so that it never matches the prefix of a longer symbol
+func GetDistributionSnapshotPrefix(symbol string) []byte {
+	prefix := append(DistributionSnapshotKey, []byte(symbol)...)
+	return append(prefix, 0x00)
+}
+
+func GetDistributionSnapshotKey(symbol string, id uint64) []byte {
+	return append(GetDistributionSnapshotPrefix(symbol), sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetDistributionHolderPrefix(id uint64) []byte {
+	return append(DistributionHolderKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetDistributionHolderKey(id uint64, addr sdk.AccAddress) []byte {
+	return append(GetDistributionHolderPrefix(id), addr.Bytes()...)
+}
+
 func GetLockAddress(addr sdk.AccAddress) []byte {
 	return append(LockKey, addr.Bytes()...)
 }
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"strconv"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
@@ -9,6 +12,13 @@ const (
 	DescLenLimit   = 256
 	MultiSendLimit = 1000
 
+	// MetadataLenLimit is the max length of the uri and website in the token metadata
+	MetadataLenLimit = 256
+	// DefaultDecimals is the decimals of a token issued without specifying it
+	DefaultDecimals = sdk.Precision
+	// MaxDecimals is the max decimals of a token, bounded by the precision of sdk.Dec
+	MaxDecimals = sdk.Precision
+
 	// 90 billion
 	TotalSupplyUpperbound = int64(9 * 1e10)
 )
@@ -22,6 +32,12 @@ type MsgTokenIssue struct {
 	TotalSupply    string         `json:"total_supply"`
 	Owner          sdk.AccAddress `json:"owner"`
 	Mintable       bool           `json:"mintable"`
+	Freezable      bool           `json:"freezable,omitempty"`
+	MaxSupply      string         `json:"max_supply,omitempty"` // empty or zero means no cap
+	Decimals       string         `json:"decimals,omitempty"`   // empty means DefaultDecimals
+	URI            string         `json:"uri,omitempty"`
+	Website        string         `json:"website,omitempty"`
+	LogoHash       string         `json:"logo_hash,omitempty"`
 }
 
 func NewMsgTokenIssue(tokenDescription, symbol, originalSymbol, wholeName, totalSupply string, owner sdk.AccAddress, mintable bool) MsgTokenIssue {
@@ -72,7 +88,46 @@ func (msg MsgTokenIssue) ValidateBasic() sdk.Error {
 	if totalSupply.GT(sdk.NewDec(TotalSupplyUpperbound)) || totalSupply.LTE(sdk.ZeroDec()) {
 		return sdk.ErrUnknownRequest("failed to check issue msg because invalid total supply")
 	}
-	return nil
+	// check maxSupply
+	if len(msg.MaxSupply) != 0 {
+		maxSupply, err := ParseMaxSupply(msg.MaxSupply)
+		if err != nil {
+			return err
+		}
+		if maxSupply.IsPositive() && maxSupply.LT(totalSupply) {
+			return ErrInvalidMaxSupply(DefaultCodespace, "failed to check issue msg because max supply is less than total supply")
+		}
+	}
+	// check decimals and metadata
+	if _, err := ParseDecimals(msg.Decimals); err != nil {
+		return err
+	}
+	return NewTokenMetadata(msg.URI, msg.Website, msg.LogoHash).Validate()
+}
+
+// ParseDecimals parses the decimals of a token, which is DefaultDecimals if not specified
+func ParseDecimals(str string) (int64, sdk.Error) {
+	if len(str) == 0 {
+		return DefaultDecimals, nil
+	}
+	decimals, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, sdk.ErrUnknownRequest(fmt.Sprintf("invalid decimals(%s)", str))
+	}
+	return decimals, ValidateDecimals(decimals)
+}
+
+// ParseMaxSupply parses the max supply of a token, which is zero for no cap
+func ParseMaxSupply(str string) (sdk.Dec, sdk.Error) {
+	maxSupply, err := sdk.NewDecFromStr(str)
+	if err != nil {
+		return sdk.Dec{}, ErrInvalidMaxSupply(DefaultCodespace, fmt.Sprintf("invalid max supply(%s)", str))
+	}
+	if maxSupply.IsNegative() || maxSupply.GT(sdk.NewDec(TotalSupplyUpperbound)) {
+		return sdk.Dec{}, ErrInvalidMaxSupply(DefaultCodespace, fmt.Sprintf("max supply(%s) should be in [0, %d]",
+			str, TotalSupplyUpperbound))
+	}
+	return maxSupply, nil
 }
 
 // GetSignBytes Implements Msg.
@@ -350,6 +405,14 @@ type MsgTokenModify struct {
 	WholeName             string         `json:"whole_name"`
 	IsDescriptionModified bool           `json:"description_modified"`
 	IsWholeNameModified   bool           `json:"whole_name_modified"`
+	MaxSupply             string         `json:"max_supply,omitempty"`
+	IsMaxSupplyModified   bool           `json:"max_supply_modified,omitempty"`
+	Decimals              int64          `json:"decimals,omitempty"`
+	IsDecimalsModified    bool           `json:"decimals_modified,omitempty"`
+	URI                   string         `json:"uri,omitempty"`
+	Website               string         `json:"website,omitempty"`
+	LogoHash              string         `json:"logo_hash,omitempty"`
+	IsMetadataModified    bool           `json:"metadata_modified,omitempty"`
 }
 
 func NewMsgTokenModify(symbol, desc, wholeName string, isDescEdit, isWholeNameEdit bool, owner sdk.AccAddress) MsgTokenModify {
@@ -392,6 +455,24 @@ func (msg MsgTokenModify) ValidateBasic() sdk.Error {
 			return sdk.ErrUnknownRequest("failed to check modify msg because invalid desc")
 		}
 	}
+	// check maxSupply
+	if msg.IsMaxSupplyModified {
+		if _, err := ParseMaxSupply(msg.MaxSupply); err != nil {
+			return err
+		}
+	}
+	// check decimals
+	if msg.IsDecimalsModified {
+		if err := ValidateDecimals(msg.Decimals); err != nil {
+			return err
+		}
+	}
+	// check metadata
+	if msg.IsMetadataModified {
+		if err := NewTokenMetadata(msg.URI, msg.Website, msg.LogoHash).Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -405,3 +486,391 @@ func (msg MsgTokenModify) GetSignBytes() []byte {
 func (msg MsgTokenModify) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
 }
+
+// MsgFreeze freezes the balance of a freezable token at an address
+type MsgFreeze struct {
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+	Owner   sdk.AccAddress `json:"owner"`
+}
+
+func NewMsgFreeze(symbol string, addr, owner sdk.AccAddress) MsgFreeze {
+	return MsgFreeze{
+		Symbol:  symbol,
+		Address: addr,
+		Owner:   owner,
+	}
+}
+
+func (msg MsgFreeze) Route() string { return RouterKey }
+
+func (msg MsgFreeze) Type() string { return "freeze" }
+
+func (msg MsgFreeze) ValidateBasic() sdk.Error {
+	return validateFreezeMsg("freeze", msg.Symbol, msg.Address, msg.Owner)
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgFreeze) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgFreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgUnfreeze unfreezes the balance of a freezable token at an address
+type MsgUnfreeze struct {
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+	Owner   sdk.AccAddress `json:"owner"`
+}
+
+func NewMsgUnfreeze(symbol string, addr, owner sdk.AccAddress) MsgUnfreeze {
+	return MsgUnfreeze{
+		Symbol:  symbol,
+		Address: addr,
+		Owner:   owner,
+	}
+}
+
+func (msg MsgUnfreeze) Route() string { return RouterKey }
+
+func (msg MsgUnfreeze) Type() string { return "unfreeze" }
+
+func (msg MsgUnfreeze) ValidateBasic() sdk.Error {
+	return validateFreezeMsg("unfreeze", msg.Symbol, msg.Address, msg.Owner)
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgUnfreeze) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgUnfreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+func validateFreezeMsg(msgType, symbol string, addr, owner sdk.AccAddress) sdk.Error {
+	if owner.Empty() {
+		return sdk.ErrInvalidAddress(owner.String())
+	}
+	if addr.Empty() {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("failed to check %s msg because miss address", msgType))
+	}
+	if sdk.ValidateDenom(symbol) != nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("failed to check %s msg because invalid token symbol: %s", msgType, symbol))
+	}
+	return nil
+}
+
+// MsgTimeLockedSend sends coins which are locked for the recipient and released by the vesting schedule
+type MsgTimeLockedSend struct {
+	FromAddress sdk.AccAddress `json:"from_address"`
+	ToAddress   sdk.AccAddress `json:"to_address"`
+	Amount      sdk.DecCoins   `json:"amount"`
+	StartTime   int64          `json:"start_time"`
+	CliffTime   int64          `json:"cliff_time"`
+	EndTime     int64          `json:"end_time"`
+}
+
+func NewMsgTimeLockedSend(from, to sdk.AccAddress, coins sdk.DecCoins, startTime, cliffTime, endTime int64) MsgTimeLockedSend {
+	return MsgTimeLockedSend{
+		FromAddress: from,
+		ToAddress:   to,
+		Amount:      coins,
+		StartTime:   startTime,
+		CliffTime:   cliffTime,
+		EndTime:     endTime,
+	}
+}
+
+func (msg MsgTimeLockedSend) Route() string { return RouterKey }
+
+func (msg MsgTimeLockedSend) Type() string { return "time-locked-send" }
+
+func (msg MsgTimeLockedSend) ValidateBasic() sdk.Error {
+	if msg.FromAddress.Empty() {
+		return sdk.ErrInvalidAddress("failed to check time locked send msg because miss sender address")
+	}
+	if msg.ToAddress.Empty() {
+		return sdk.ErrInvalidAddress("failed to check time locked send msg because miss recipient address")
+	}
+	if !msg.Amount.IsValid() {
+		return sdk.ErrInvalidCoins("failed to check time locked send msg because send amount is invalid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("failed to check time locked send msg because send amount must be positive")
+	}
+	return ValidateSchedule(msg.StartTime, msg.CliffTime, msg.EndTime)
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgTimeLockedSend) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgTimeLockedSend) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// MsgProposeOwnership proposes to transfer the ownership of a token, which takes effect after MsgConfirmOwnership
+type MsgProposeOwnership struct {
+	FromAddress sdk.AccAddress `json:"from_address"`
+	ToAddress   sdk.AccAddress `json:"to_address"`
+	Symbol      string         `json:"symbol"`
+}
+
+func NewMsgProposeOwnership(from, to sdk.AccAddress, symbol string) MsgProposeOwnership {
+	return MsgProposeOwnership{
+		FromAddress: from,
+		ToAddress:   to,
+		Symbol:      symbol,
+	}
+}
+
+func (msg MsgProposeOwnership) Route() string { return RouterKey }
+
+func (msg MsgProposeOwnership) Type() string { return "propose_ownership" }
+
+func (msg MsgProposeOwnership) ValidateBasic() sdk.Error {
+	if msg.FromAddress.Empty() {
+		return sdk.ErrInvalidAddress("failed to check propose ownership msg because miss sender address")
+	}
+	if msg.ToAddress.Empty() {
+		return sdk.ErrInvalidAddress("failed to check propose ownership msg because miss recipient address")
+	}
+	if msg.FromAddress.Equals(msg.ToAddress) {
+		return ErrInvalidOwnership(DefaultCodespace, "failed to check propose ownership msg because the recipient is the owner")
+	}
+	if sdk.ValidateDenom(msg.Symbol) != nil {
+		return sdk.ErrUnknownRequest("failed to check propose ownership msg because invalid token symbol: " + msg.Symbol)
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgProposeOwnership) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgProposeOwnership) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// MsgConfirmOwnership accepts the pending ownership transfer of a token
+type MsgConfirmOwnership struct {
+	Address sdk.AccAddress `json:"address"`
+	Symbol  string         `json:"symbol"`
+}
+
+func NewMsgConfirmOwnership(addr sdk.AccAddress, symbol string) MsgConfirmOwnership {
+	return MsgConfirmOwnership{
+		Address: addr,
+		Symbol:  symbol,
+	}
+}
+
+func (msg MsgConfirmOwnership) Route() string { return RouterKey }
+
+func (msg MsgConfirmOwnership) Type() string { return "confirm_ownership" }
+
+func (msg MsgConfirmOwnership) ValidateBasic() sdk.Error {
+	if msg.Address.Empty() {
+		return sdk.ErrInvalidAddress("failed to check confirm ownership msg because miss address")
+	}
+	if sdk.ValidateDenom(msg.Symbol) != nil {
+		return sdk.ErrUnknownRequest("failed to check confirm ownership msg because invalid token symbol: " + msg.Symbol)
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgConfirmOwnership) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgConfirmOwnership) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+// MsgCreateMultisig creates a multisig governed by threshold approvals of the members
+type MsgCreateMultisig struct {
+	Creator   sdk.AccAddress   `json:"creator"`
+	Members   []sdk.AccAddress `json:"members"`
+	Threshold uint64           `json:"threshold"`
+}
+
+func NewMsgCreateMultisig(creator sdk.AccAddress, members []sdk.AccAddress, threshold uint64) MsgCreateMultisig {
+	return MsgCreateMultisig{
+		Creator:   creator,
+		Members:   members,
+		Threshold: threshold,
+	}
+}
+
+func (msg MsgCreateMultisig) Route() string { return RouterKey }
+
+func (msg MsgCreateMultisig) Type() string { return "create_multisig" }
+
+func (msg MsgCreateMultisig) ValidateBasic() sdk.Error {
+	if msg.Creator.Empty() {
+		return sdk.ErrInvalidAddress("failed to check create multisig msg because miss creator address")
+	}
+	return ValidateMultisig(msg.Members, msg.Threshold)
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgCreateMultisig) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCreateMultisig) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgMultisigSubmit submits a token msg signed by a multisig, with the approval of the submitting member
+type MsgMultisigSubmit struct {
+	Member sdk.AccAddress `json:"member"`
+	Msg    sdk.Msg        `json:"msg"`
+}
+
+func NewMsgMultisigSubmit(member sdk.AccAddress, msg sdk.Msg) MsgMultisigSubmit {
+	return MsgMultisigSubmit{
+		Member: member,
+		Msg:    msg,
+	}
+}
+
+func (msg MsgMultisigSubmit) Route() string { return RouterKey }
+
+func (msg MsgMultisigSubmit) Type() string { return "multisig_submit" }
+
+func (msg MsgMultisigSubmit) ValidateBasic() sdk.Error {
+	if msg.Member.Empty() {
+		return sdk.ErrInvalidAddress("failed to check multisig submit msg because miss member address")
+	}
+	if msg.Msg == nil {
+		return ErrInvalidMultisig(DefaultCodespace, "failed to check multisig submit msg because miss the msg")
+	}
+	if msg.Msg.Route() != RouterKey {
+		return ErrInvalidMultisig(DefaultCodespace, "failed to check multisig submit msg because only the token msgs are supported")
+	}
+	switch msg.Msg.(type) {
+	case MsgCreateMultisig, MsgMultisigSubmit, MsgMultisigApprove:
+		return ErrInvalidMultisig(DefaultCodespace, "failed to check multisig submit msg because the multisig msgs can not be nested")
+	}
+	if len(msg.Msg.GetSigners()) != 1 {
+		return ErrInvalidMultisig(DefaultCodespace, "failed to check multisig submit msg because the msg should have exactly one signer")
+	}
+	return msg.Msg.ValidateBasic()
+}
+
+// Multisig returns the address of the multisig signing the msg
+func (msg MsgMultisigSubmit) Multisig() sdk.AccAddress {
+	return msg.Msg.GetSigners()[0]
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgMultisigSubmit) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgMultisigSubmit) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Member}
+}
+
+// MsgMultisigApprove approves a multisig proposal, which is executed once it gets enough approvals
+type MsgMultisigApprove struct {
+	Member     sdk.AccAddress `json:"member"`
+	ProposalID uint64         `json:"proposal_id"`
+}
+
+func NewMsgMultisigApprove(member sdk.AccAddress, proposalID uint64) MsgMultisigApprove {
+	return MsgMultisigApprove{
+		Member:     member,
+		ProposalID: proposalID,
+	}
+}
+
+func (msg MsgMultisigApprove) Route() string { return RouterKey }
+
+func (msg MsgMultisigApprove) Type() string { return "multisig_approve" }
+
+func (msg MsgMultisigApprove) ValidateBasic() sdk.Error {
+	if msg.Member.Empty() {
+		return sdk.ErrInvalidAddress("failed to check multisig approve msg because miss member address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgMultisigApprove) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgMultisigApprove) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Member}
+}
+
+// MsgDistribute deposits coins to be distributed to the holders of a token pro rata to their balances
+type MsgDistribute struct {
+	Sender sdk.AccAddress `json:"sender"`
+	Symbol string         `json:"symbol"`
+	Amount sdk.DecCoins   `json:"amount"`
+}
+
+func NewMsgDistribute(sender sdk.AccAddress, symbol string, amount sdk.DecCoins) MsgDistribute {
+	return MsgDistribute{
+		Sender: sender,
+		Symbol: symbol,
+		Amount: amount,
+	}
+}
+
+func (msg MsgDistribute) Route() string { return RouterKey }
+
+func (msg MsgDistribute) Type() string { return "distribute" }
+
+func (msg MsgDistribute) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress("failed to check distribute msg because miss sender address")
+	}
+	if sdk.ValidateDenom(msg.Symbol) != nil {
+		return sdk.ErrUnknownRequest("failed to check distribute msg because invalid token symbol: " + msg.Symbol)
+	}
+	if !msg.Amount.IsValid() {
+		return sdk.ErrInvalidCoins("failed to check distribute msg because distribute amount is invalid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("failed to check distribute msg because distribute amount must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgDistribute) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgDistribute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
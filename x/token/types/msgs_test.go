@@ -357,3 +357,125 @@ bnbbbbbbbbbbbnbbbbbbbbbbnbbbbbbbbbbbnbbbbbbbbb1234`, "bnbbbbbbbbbb", false, fals
 	err := tokenEditMsg.ValidateBasic()
 	require.NoError(t, err)
 }
+
+func TestNewMsgFreeze(t *testing.T) {
+	owner := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	testCase := []struct {
+		symbol string
+		addr   sdk.AccAddress
+		owner  sdk.AccAddress
+		valid  bool
+	}{
+		{"xxb-781", addr, owner, true},
+		{"", addr, owner, false},
+		{"xxb-781", sdk.AccAddress{}, owner, false},
+		{"xxb-781", addr, sdk.AccAddress{}, false},
+	}
+	for _, tc := range testCase {
+		freezeMsg := NewMsgFreeze(tc.symbol, tc.addr, tc.owner)
+		unfreezeMsg := NewMsgUnfreeze(tc.symbol, tc.addr, tc.owner)
+		require.Equal(t, tc.valid, freezeMsg.ValidateBasic() == nil)
+		require.Equal(t, tc.valid, unfreezeMsg.ValidateBasic() == nil)
+	}
+
+	freezeMsg := NewMsgFreeze("xxb-781", addr, owner)
+	require.Equal(t, RouterKey, freezeMsg.Route())
+	require.Equal(t, "freeze", freezeMsg.Type())
+	require.Equal(t, []sdk.AccAddress{owner}, freezeMsg.GetSigners())
+	require.NotEmpty(t, freezeMsg.GetSignBytes())
+	unfreezeMsg := NewMsgUnfreeze("xxb-781", addr, owner)
+	require.Equal(t, "unfreeze", unfreezeMsg.Type())
+	require.Equal(t, []sdk.AccAddress{owner}, unfreezeMsg.GetSigners())
+	require.NotEmpty(t, unfreezeMsg.GetSignBytes())
+}
+
+func TestNewMsgTimeLockedSend(t *testing.T) {
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100))}
+
+	testCase := []struct {
+		msg   MsgTimeLockedSend
+		valid bool
+	}{
+		{NewMsgTimeLockedSend(from, to, coins, 1000, 1250, 2000), true},
+		{NewMsgTimeLockedSend(sdk.AccAddress{}, to, coins, 1000, 1250, 2000), false},
+		{NewMsgTimeLockedSend(from, sdk.AccAddress{}, coins, 1000, 1250, 2000), false},
+		{NewMsgTimeLockedSend(from, to, sdk.DecCoins{}, 1000, 1250, 2000), false},
+		{NewMsgTimeLockedSend(from, to, coins, 1000, 2500, 2000), false},
+	}
+	for _, tc := range testCase {
+		require.Equal(t, tc.valid, tc.msg.ValidateBasic() == nil)
+	}
+
+	msg := NewMsgTimeLockedSend(from, to, coins, 1000, 1250, 2000)
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, "time-locked-send", msg.Type())
+	require.Equal(t, []sdk.AccAddress{from}, msg.GetSigners())
+	require.NotEmpty(t, msg.GetSignBytes())
+}
+
+func TestNewMsgOwnershipAndMultisig(t *testing.T) {
+	addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	proposeMsg := NewMsgProposeOwnership(addr1, addr2, common.NativeToken)
+	require.Nil(t, proposeMsg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{addr1}, proposeMsg.GetSigners())
+	require.NotNil(t, NewMsgProposeOwnership(addr1, addr1, common.NativeToken).ValidateBasic())
+	require.NotNil(t, NewMsgProposeOwnership(addr1, sdk.AccAddress{}, common.NativeToken).ValidateBasic())
+
+	confirmMsg := NewMsgConfirmOwnership(addr2, common.NativeToken)
+	require.Nil(t, confirmMsg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{addr2}, confirmMsg.GetSigners())
+	require.NotNil(t, NewMsgConfirmOwnership(addr2, "").ValidateBasic())
+
+	members := []sdk.AccAddress{addr1, addr2}
+	require.Nil(t, NewMsgCreateMultisig(addr1, members, 2).ValidateBasic())
+	require.NotNil(t, NewMsgCreateMultisig(addr1, members, 0).ValidateBasic())
+	require.NotNil(t, NewMsgCreateMultisig(addr1, members, 3).ValidateBasic())
+	require.NotNil(t, NewMsgCreateMultisig(addr1, []sdk.AccAddress{addr1, addr1}, 1).ValidateBasic())
+	require.NotNil(t, NewMsgCreateMultisig(addr1, nil, 1).ValidateBasic())
+	// the address does not depend on the order of the members
+	require.Equal(t, NewMultisig(members, 2).Address, NewMultisig([]sdk.AccAddress{addr2, addr1}, 2).Address)
+	require.NotEqual(t, NewMultisig(members, 2).Address, NewMultisig(members, 1).Address)
+
+	multisigAddr := NewMultisig(members, 2).Address
+	submitMsg := NewMsgMultisigSubmit(addr1, NewMsgConfirmOwnership(multisigAddr, common.NativeToken))
+	require.Nil(t, submitMsg.ValidateBasic())
+	require.Equal(t, multisigAddr, submitMsg.Multisig())
+	require.Equal(t, []sdk.AccAddress{addr1}, submitMsg.GetSigners())
+	require.NotNil(t, submitMsg.GetSignBytes())
+	require.NotNil(t, NewMsgMultisigSubmit(addr1, nil).ValidateBasic())
+	require.NotNil(t, NewMsgMultisigSubmit(addr1, NewMsgMultisigApprove(multisigAddr, 0)).ValidateBasic())
+	require.NotNil(t, NewMsgMultisigSubmit(addr1, NewMsgConfirmOwnership(multisigAddr, "")).ValidateBasic())
+
+	require.Nil(t, NewMsgMultisigApprove(addr1, 0).ValidateBasic())
+	require.NotNil(t, NewMsgMultisigApprove(sdk.AccAddress{}, 0).ValidateBasic())
+}
+
+func TestNewMsgDistribute(t *testing.T) {
+	sender := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100))}
+
+	testCase := []struct {
+		msg   MsgDistribute
+		valid bool
+	}{
+		{NewMsgDistribute(sender, "xxb-781", coins), true},
+		{NewMsgDistribute(sdk.AccAddress{}, "xxb-781", coins), false},
+		{NewMsgDistribute(sender, "", coins), false},
+		{NewMsgDistribute(sender, "xxb-781", sdk.DecCoins{}), false},
+	}
+	for _, tc := range testCase {
+		require.Equal(t, tc.valid, tc.msg.ValidateBasic() == nil)
+	}
+
+	msg := NewMsgDistribute(sender, "xxb-781", coins)
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, "distribute", msg.Type())
+	require.Equal(t, []sdk.AccAddress{sender}, msg.GetSigners())
+	require.NotEmpty(t, msg.GetSignBytes())
+}
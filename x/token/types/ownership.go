@@ -0,0 +1,142 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// MaxMultisigMembers is the max number of the members of a multisig
+const MaxMultisigMembers = 20
+
+// OwnershipProposal is a pending transfer of the ownership of a token,
+// which takes effect only after the recipient confirms it before ExpireTime
+type OwnershipProposal struct {
+	Symbol      string         `json:"symbol"`
+	FromAddress sdk.AccAddress `json:"from_address"`
+	ToAddress   sdk.AccAddress `json:"to_address"`
+	ExpireTime  int64          `json:"expire_time"`
+}
+
+func (proposal OwnershipProposal) String() string {
+	b, err := json.Marshal(proposal)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// IsExpired returns whether the proposal can no longer be confirmed at the block time
+func (proposal OwnershipProposal) IsExpired(blockTime int64) bool {
+	return blockTime > proposal.ExpireTime
+}
+
+// Multisig is an on-chain account governed by Threshold approvals of its Members.
+// It can own tokens, and the token msgs signed by it are executed by MsgMultisigSubmit and MsgMultisigApprove
+type Multisig struct {
+	Address   sdk.AccAddress   `json:"address"`
+	Members   []sdk.AccAddress `json:"members"`
+	Threshold uint64           `json:"threshold"`
+}
+
+// NewMultisig creates a multisig, whose address is derived from the sorted members and the threshold
+func NewMultisig(members []sdk.AccAddress, threshold uint64) Multisig {
+	sorted := make([]sdk.AccAddress, len(members))
+	copy(sorted, members)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return Multisig{
+		Address:   GetMultisigAddress(sorted, threshold),
+		Members:   sorted,
+		Threshold: threshold,
+	}
+}
+
+// GetMultisigAddress returns the address of the multisig with the sorted members and the threshold
+func GetMultisigAddress(members []sdk.AccAddress, threshold uint64) sdk.AccAddress {
+	bz := append([]byte(ModuleName+"/multisig"), sdk.Uint64ToBigEndian(threshold)...)
+	for _, member := range members {
+		bz = append(bz, member.Bytes()...)
+	}
+	return sdk.AccAddress(crypto.AddressHash(bz))
+}
+
+// ValidateMultisig checks the members and the threshold of a multisig
+func ValidateMultisig(members []sdk.AccAddress, threshold uint64) sdk.Error {
+	if len(members) == 0 || len(members) > MaxMultisigMembers {
+		return ErrInvalidMultisig(DefaultCodespace, fmt.Sprintf("the number of members should be in [1, %d]", MaxMultisigMembers))
+	}
+	if threshold == 0 || threshold > uint64(len(members)) {
+		return ErrInvalidMultisig(DefaultCodespace, fmt.Sprintf("the threshold should be in [1, %d]", len(members)))
+	}
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		if member.Empty() {
+			return ErrInvalidMultisig(DefaultCodespace, "empty member address")
+		}
+		if seen[member.String()] {
+			return ErrInvalidMultisig(DefaultCodespace, fmt.Sprintf("duplicate member %s", member))
+		}
+		seen[member.String()] = true
+	}
+	return nil
+}
+
+func (multisig Multisig) String() string {
+	b, err := json.Marshal(multisig)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// IsMember returns whether the address is a member of the multisig
+func (multisig Multisig) IsMember(addr sdk.AccAddress) bool {
+	for _, member := range multisig.Members {
+		if member.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// MultisigProposal is a token msg signed by a multisig, which is executed once it gets enough approvals
+type MultisigProposal struct {
+	ID        uint64           `json:"id"`
+	Multisig  sdk.AccAddress   `json:"multisig"`
+	Msg       sdk.Msg          `json:"msg"`
+	Approvals []sdk.AccAddress `json:"approvals"`
+}
+
+func (proposal MultisigProposal) String() string {
+	b, err := json.Marshal(proposal)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// IsApprovedBy returns whether the member has approved the proposal
+func (proposal MultisigProposal) IsApprovedBy(member sdk.AccAddress) bool {
+	for _, approval := range proposal.Approvals {
+		if approval.Equals(member) {
+			return true
+		}
+	}
+	return false
+}
+
+type MultisigProposals []MultisigProposal
+
+func (proposals MultisigProposals) String() string {
+	b, err := json.Marshal(proposals)
+	if err != nil {
+		return "[{}]"
+	}
+	return string(b)
+}
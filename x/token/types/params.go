@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okchain/x/common"
@@ -17,33 +18,68 @@ const (
 	DefaultFeeModify = "0"
 	DefaultFeeSend   = "0"
 	// 0.0125 * 0.8
-	DefaultFeeMultiSend = "0.01"
-	DefaultFeeChown     = "10"
+	DefaultFeeMultiSend  = "0.01"
+	DefaultFeeChown      = "10"
+	DefaultFeeDistribute = "10"
+
+	DefaultMaxSupplyIncreasable = false
+	DefaultMaxSupplyDecreasable = true
+
+	DefaultOwnershipConfirmPeriod = 72 * time.Hour
+
+	DefaultDistributeBatchSize = 1000
+
+	DefaultVestingReleasePeriod = 24 * time.Hour
 )
 
 var (
-	KeyFeeBase      = []byte("FeeBase")
-	KeyFeeIssue     = []byte("FeeIssue")
-	KeyFeeMint      = []byte("FeeMint")
-	KeyFeeBurn      = []byte("FeeBurn")
-	KeyFeeModify    = []byte("FeeModify")
-	KeyFeeSend      = []byte("FeeSend")
-	KeyFeeMultiSend = []byte("FeeMultiSend")
-	KeyFeeChown     = []byte("FeeChown")
+	KeyFeeBase       = []byte("FeeBase")
+	KeyFeeIssue      = []byte("FeeIssue")
+	KeyFeeMint       = []byte("FeeMint")
+	KeyFeeBurn       = []byte("FeeBurn")
+	KeyFeeModify     = []byte("FeeModify")
+	KeyFeeSend       = []byte("FeeSend")
+	KeyFeeMultiSend  = []byte("FeeMultiSend")
+	KeyFeeChown      = []byte("FeeChown")
+	KeyFeeDistribute = []byte("FeeDistribute")
+
+	KeyMaxSupplyIncreasable = []byte("MaxSupplyIncreasable")
+	KeyMaxSupplyDecreasable = []byte("MaxSupplyDecreasable")
+
+	KeyOwnershipConfirmPeriod = []byte("OwnershipConfirmPeriod")
+
+	KeyDistributeBatchSize = []byte("DistributeBatchSize")
+
+	KeyVestingReleasePeriod = []byte("VestingReleasePeriod")
 )
 
 var _ params.ParamSet = &Params{}
 
 // mint parameters
 type Params struct {
-	FeeBase      sdk.DecCoin `json:"base_fee"`
-	FeeIssue     sdk.DecCoin `json:"issue_fee"`
-	FeeMint      sdk.DecCoin `json:"mint_fee"`
-	FeeBurn      sdk.DecCoin `json:"burn_fee"`
-	FeeModify    sdk.DecCoin `json:"modify_fee"`
-	FeeSend      sdk.DecCoin `json:"send_fee"`
-	FeeMultiSend sdk.DecCoin `json:"multi_send_fee"`
-	FeeChown     sdk.DecCoin `json:"transfer_ownership_fee"`
+	FeeBase       sdk.DecCoin `json:"base_fee"`
+	FeeIssue      sdk.DecCoin `json:"issue_fee"`
+	FeeMint       sdk.DecCoin `json:"mint_fee"`
+	FeeBurn       sdk.DecCoin `json:"burn_fee"`
+	FeeModify     sdk.DecCoin `json:"modify_fee"`
+	FeeSend       sdk.DecCoin `json:"send_fee"`
+	FeeMultiSend  sdk.DecCoin `json:"multi_send_fee"`
+	FeeChown      sdk.DecCoin `json:"transfer_ownership_fee"`
+	FeeDistribute sdk.DecCoin `json:"distribute_fee"`
+
+	// whether the owner can raise or remove the max supply of a token
+	MaxSupplyIncreasable bool `json:"max_supply_increasable"`
+	// whether the owner can lower the max supply of a token, or cap an uncapped one, not below its total supply
+	MaxSupplyDecreasable bool `json:"max_supply_decreasable"`
+
+	// the period in which the recipient of an ownership transfer should confirm it
+	OwnershipConfirmPeriod time.Duration `json:"ownership_confirm_period"`
+
+	// the max number of accounts snapshotted and holders paid by the distributions in a block
+	DistributeBatchSize int64 `json:"distribute_batch_size"`
+
+	// the period in which the coins vested by a vesting lock are released, after the first release at its cliff
+	VestingReleasePeriod time.Duration `json:"vesting_release_period"`
 }
 
 // ParamKeyTable for auth module
@@ -64,6 +100,12 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{KeyFeeSend, &p.FeeSend},
 		{KeyFeeMultiSend, &p.FeeMultiSend},
 		{KeyFeeChown, &p.FeeChown},
+		{KeyFeeDistribute, &p.FeeDistribute},
+		{KeyMaxSupplyIncreasable, &p.MaxSupplyIncreasable},
+		{KeyMaxSupplyDecreasable, &p.MaxSupplyDecreasable},
+		{KeyOwnershipConfirmPeriod, &p.OwnershipConfirmPeriod},
+		{KeyDistributeBatchSize, &p.DistributeBatchSize},
+		{KeyVestingReleasePeriod, &p.VestingReleasePeriod},
 	}
 }
 
@@ -77,15 +119,39 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
 	return Params{
-		FeeBase:      sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeBase)),
-		FeeIssue:     sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeIssue)),
-		FeeMint:      sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeMint)),
-		FeeBurn:      sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeBurn)),
-		FeeModify:    sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeModify)),
-		FeeSend:      sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeSend)),
-		FeeMultiSend: sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeMultiSend)),
-		FeeChown:     sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeChown)),
+		FeeBase:       sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeBase)),
+		FeeIssue:      sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeIssue)),
+		FeeMint:       sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeMint)),
+		FeeBurn:       sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeBurn)),
+		FeeModify:     sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeModify)),
+		FeeSend:       sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeSend)),
+		FeeMultiSend:  sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeMultiSend)),
+		FeeChown:      sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeChown)),
+		FeeDistribute: sdk.NewDecCoinFromDec(common.NativeToken, sdk.MustNewDecFromStr(DefaultFeeDistribute)),
+
+		MaxSupplyIncreasable: DefaultMaxSupplyIncreasable,
+		MaxSupplyDecreasable: DefaultMaxSupplyDecreasable,
+
+		OwnershipConfirmPeriod: DefaultOwnershipConfirmPeriod,
+
+		DistributeBatchSize: DefaultDistributeBatchSize,
+
+		VestingReleasePeriod: DefaultVestingReleasePeriod,
+	}
+}
+
+// MultiCoinsFee returns the fee charged on top of the system fee for sending coinNum kinds of coins at once
+func (p Params) MultiCoinsFee(coinNum int) sdk.DecCoins {
+	if coinNum <= 1 {
+		return sdk.ZeroFee().ToCoins()
+	}
+
+	feeAmount := p.FeeMultiSend.Amount.MulInt64(int64(coinNum)).Sub(sdk.GetSystemFee().Amount)
+	if !feeAmount.IsPositive() {
+		// charge nothing, since it's already covered by system fee
+		return sdk.ZeroFee().ToCoins()
 	}
+	return sdk.NewDecCoinsFromDec(sdk.DefaultBondDenom, feeAmount)
 }
 
 // String implements the stringer interface.
@@ -100,6 +166,12 @@ func (p Params) String() string {
 	sb.WriteString(fmt.Sprintf("FeeSend: %s\n", p.FeeSend))
 	sb.WriteString(fmt.Sprintf("FeeMultiSend: %s\n", p.FeeMultiSend))
 	sb.WriteString(fmt.Sprintf("FeeChown: %s\n", p.FeeChown))
+	sb.WriteString(fmt.Sprintf("FeeDistribute: %s\n", p.FeeDistribute))
+	sb.WriteString(fmt.Sprintf("MaxSupplyIncreasable: %t\n", p.MaxSupplyIncreasable))
+	sb.WriteString(fmt.Sprintf("MaxSupplyDecreasable: %t\n", p.MaxSupplyDecreasable))
+	sb.WriteString(fmt.Sprintf("OwnershipConfirmPeriod: %s\n", p.OwnershipConfirmPeriod))
+	sb.WriteString(fmt.Sprintf("DistributeBatchSize: %d\n", p.DistributeBatchSize))
+	sb.WriteString(fmt.Sprintf("VestingReleasePeriod: %s\n", p.VestingReleasePeriod))
 
 	return sb.String()
 }
@@ -18,6 +18,12 @@ FeeModify: 0.00000000okt
 FeeSend: 0.00000000okt
 FeeMultiSend: 0.01000000okt
 FeeChown: 10.00000000okt
+FeeDistribute: 10.00000000okt
+MaxSupplyIncreasable: false
+MaxSupplyDecreasable: true
+OwnershipConfirmPeriod: 72h0m0s
+DistributeBatchSize: 1000
+VestingReleasePeriod: 24h0m0s
 `
 	paramStr := param.String()
 	require.EqualValues(t, expectedString, paramStr)
@@ -31,6 +37,12 @@ FeeChown: 10.00000000okt
 		{Key: KeyFeeSend, Value: &param.FeeSend},
 		{Key: KeyFeeMultiSend, Value: &param.FeeMultiSend},
 		{Key: KeyFeeChown, Value: &param.FeeChown},
+		{Key: KeyFeeDistribute, Value: &param.FeeDistribute},
+		{Key: KeyMaxSupplyIncreasable, Value: &param.MaxSupplyIncreasable},
+		{Key: KeyMaxSupplyDecreasable, Value: &param.MaxSupplyDecreasable},
+		{Key: KeyOwnershipConfirmPeriod, Value: &param.OwnershipConfirmPeriod},
+		{Key: KeyDistributeBatchSize, Value: &param.DistributeBatchSize},
+		{Key: KeyVestingReleasePeriod, Value: &param.VestingReleasePeriod},
 	}
 
 	require.EqualValues(t, psp, param.ParamSetPairs())
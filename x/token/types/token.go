@@ -1,7 +1,11 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -15,6 +19,60 @@ type Token struct {
 	TotalSupply         sdk.Dec        `json:"total_supply" v2:"total_supply"`                   // e.g. 1000000000.00000000
 	Owner               sdk.AccAddress `json:"owner" v2:"owner"`                                 // e.g. okchain1upyg3vl6vqaxqvzts69zpus2c027p7paw63s99
 	Mintable            bool           `json:"mintable" v2:"mintable"`                           // e.g. false
+	Freezable           bool           `json:"freezable,omitempty" v2:"freezable"`               // e.g. false
+	MaxSupply           sdk.Dec        `json:"max_supply" v2:"max_supply"`                       // e.g. 2000000000.00000000, zero means no cap
+	Decimals            int64          `json:"decimals" v2:"decimals,string"`                    // e.g. 8, quoted as in amino json
+	Metadata            TokenMetadata  `json:"metadata" v2:"metadata"`                           // display information for wallets
+}
+
+// TokenMetadata is the display information of a token
+type TokenMetadata struct {
+	URI      string `json:"uri" v2:"uri"`             // e.g. "https://www.okex.com/okt.json"
+	Website  string `json:"website" v2:"website"`     // e.g. "https://www.okex.com"
+	LogoHash string `json:"logo_hash" v2:"logo_hash"` // hex encoded sha256 hash of the logo
+}
+
+// NewTokenMetadata creates a new TokenMetadata instance
+func NewTokenMetadata(uri, website, logoHash string) TokenMetadata {
+	return TokenMetadata{
+		URI:      uri,
+		Website:  website,
+		LogoHash: strings.ToLower(logoHash),
+	}
+}
+
+// Validate checks the length of the links and the format of the logo hash
+func (metadata TokenMetadata) Validate() sdk.Error {
+	if len(metadata.URI) > MetadataLenLimit {
+		return sdk.ErrUnknownRequest("invalid metadata uri")
+	}
+	if len(metadata.Website) > MetadataLenLimit {
+		return sdk.ErrUnknownRequest("invalid metadata website")
+	}
+	if metadata.LogoHash != "" {
+		if b, err := hex.DecodeString(metadata.LogoHash); err != nil || len(b) != sha256.Size {
+			return sdk.ErrUnknownRequest("invalid metadata logo hash, expect a hex encoded sha256 hash")
+		}
+	}
+	return nil
+}
+
+// ValidateDecimals checks whether the decimals is within the precision of sdk.Dec
+func ValidateDecimals(decimals int64) sdk.Error {
+	if decimals < 0 || decimals > MaxDecimals {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("invalid decimals %d, expect [0, %d]", decimals, MaxDecimals))
+	}
+	return nil
+}
+
+// IsCapped returns whether the total supply of the token is capped by its max supply
+func (token Token) IsCapped() bool {
+	return !token.MaxSupply.IsNil() && token.MaxSupply.IsPositive()
+}
+
+// ExceedsMaxSupply returns whether the total supply exceeds the max supply of the token
+func (token Token) ExceedsMaxSupply(totalSupply sdk.Dec) bool {
+	return token.IsCapped() && totalSupply.GT(token.MaxSupply)
 }
 
 func (token Token) String() string {
@@ -117,3 +175,9 @@ type AccCoins struct {
 	Acc   sdk.AccAddress `json:"address"`
 	Coins sdk.DecCoins   `json:"coins"`
 }
+
+// FrozenAccount is an address whose balance of a freezable token is frozen by the token owner
+type FrozenAccount struct {
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+}
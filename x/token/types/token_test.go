@@ -1,8 +1,10 @@
 package types
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/okex/okchain/x/common"
@@ -73,7 +75,7 @@ func TestToken(t *testing.T) {
 			TotalSupply:         sdk.ZeroDec(),
 			Owner:               nil,
 			Mintable:            false,
-		}, `{"description":"my token","symbol":"okt","original_symbol":"okt","whole_name":"btc","original_total_supply":"1000000.00000000","total_supply":"0.00000000","owner":"","mintable":false}`},
+		}, `{"description":"my token","symbol":"okt","original_symbol":"okt","whole_name":"btc","original_total_supply":"1000000.00000000","total_supply":"0.00000000","owner":"","mintable":false,"max_supply":"0","decimals":0,"metadata":{"uri":"","website":"","logo_hash":""}}`},
 		{Token{
 			Description:         "okblockchain coin",
 			Symbol:              common.NativeToken,
@@ -83,7 +85,7 @@ func TestToken(t *testing.T) {
 			TotalSupply:         sdk.ZeroDec(),
 			Owner:               addr,
 			Mintable:            true,
-		}, `{"description":"okblockchain coin","symbol":"okt","original_symbol":"okt","whole_name":"ok coin","original_total_supply":"1000000000.00000000","total_supply":"0.00000000","owner":"okchain1dfpljpe0g0206jch32fx95lyagq3z5ws2vgwx3","mintable":true}`},
+		}, `{"description":"okblockchain coin","symbol":"okt","original_symbol":"okt","whole_name":"ok coin","original_total_supply":"1000000000.00000000","total_supply":"0.00000000","owner":"okchain1dfpljpe0g0206jch32fx95lyagq3z5ws2vgwx3","mintable":true,"max_supply":"0","decimals":0,"metadata":{"uri":"","website":"","logo_hash":""}}`},
 	}
 	for _, tokenCase := range testCase {
 		b, err := json.Marshal(tokenCase.token)
@@ -109,3 +111,32 @@ func TestKeys(t *testing.T) {
 	b = KeyDexListAsset(asset)
 	require.EqualValues(t, b, []byte(fmt.Sprintf("asset:%s", asset)))
 }
+
+func TestTokenMetadata(t *testing.T) {
+	logoHash := strings.Repeat("ab", sha256.Size)
+	metadata := NewTokenMetadata("https://www.okex.com/okt.json", "https://www.okex.com", strings.ToUpper(logoHash))
+	require.Equal(t, logoHash, metadata.LogoHash)
+	require.Nil(t, metadata.Validate())
+	require.Nil(t, TokenMetadata{}.Validate())
+
+	require.NotNil(t, NewTokenMetadata(strings.Repeat("a", MetadataLenLimit+1), "", "").Validate())
+	require.NotNil(t, NewTokenMetadata("", strings.Repeat("a", MetadataLenLimit+1), "").Validate())
+	require.NotNil(t, NewTokenMetadata("", "", "abcd").Validate())
+	require.NotNil(t, NewTokenMetadata("", "", strings.Repeat("zz", sha256.Size)).Validate())
+
+	require.Nil(t, ValidateDecimals(0))
+	require.Nil(t, ValidateDecimals(MaxDecimals))
+	require.NotNil(t, ValidateDecimals(-1))
+	require.NotNil(t, ValidateDecimals(MaxDecimals+1))
+
+	decimals, err := ParseDecimals("")
+	require.Nil(t, err)
+	require.Equal(t, int64(DefaultDecimals), decimals)
+	decimals, err = ParseDecimals("0")
+	require.Nil(t, err)
+	require.Equal(t, int64(0), decimals)
+	_, err = ParseDecimals("x")
+	require.NotNil(t, err)
+	_, err = ParseDecimals("9")
+	require.NotNil(t, err)
+}
@@ -1,8 +1,10 @@
 package types
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
 	"sort"
 	"strings"
@@ -95,6 +97,93 @@ func StrToTransfers(str string) (transfers []TransferUnit, err error) {
 	return transfers, nil
 }
 
+// ParseTransfersCSV parses the csv rows of address and amount, e.g. `okchain1...,"1okt,2btc"`.
+// The empty lines, the lines starting with '#' and a header row starting with "address" are skipped
+func ParseTransfersCSV(r io.Reader) (transfers []TransferUnit, err error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	// the rows are counted without the skipped lines
+	seen := make(map[string]int)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		if len(record) != 2 {
+			return nil, fmt.Errorf("row %d: expect 2 fields of address and amount, got %d", row, len(record))
+		}
+
+		to, err := sdk.AccAddressFromBech32(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid address：%s", row, record[0])
+		}
+		if dup, ok := seen[to.String()]; ok {
+			return nil, fmt.Errorf("row %d: duplicate address %s of row %d", row, to, dup)
+		}
+		seen[to.String()] = row
+
+		coins, err := sdk.ParseDecCoins(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid amount %s: %s", row, record[1], err)
+		}
+		if !coins.IsValid() || !coins.IsAllPositive() {
+			return nil, fmt.Errorf("row %d: amount %s must be positive", row, record[1])
+		}
+		transfers = append(transfers, TransferUnit{To: to, Coins: coins})
+	}
+	return transfers, nil
+}
+
+// SumTransfers returns the total coins of the transfers
+func SumTransfers(transfers []TransferUnit) sdk.DecCoins {
+	total := sdk.DecCoins{}
+	for _, transfer := range transfers {
+		total = total.Add(transfer.Coins)
+	}
+	return total
+}
+
+// ChunkTransfers splits the transfers into the chunks of at most maxNum transfers,
+// whose MsgMultiSend from the address is encoded in at most maxBytes.
+// The chunks are not sized by gas, because the ante handler runs the txs with an infinite gas meter and
+// the fee of a multi send is fixed by the params, so that a chunk within MultiSendLimit never runs out of gas.
+func ChunkTransfers(from sdk.AccAddress, transfers []TransferUnit, maxNum, maxBytes int) ([][]TransferUnit, error) {
+	if maxNum <= 0 || maxNum > MultiSendLimit {
+		maxNum = MultiSendLimit
+	}
+	baseSize := len(ModuleCdc.MustMarshalBinaryBare(NewMsgMultiSend(from, nil)))
+
+	var chunks [][]TransferUnit
+	var chunk []TransferUnit
+	size := baseSize
+	for i, transfer := range transfers {
+		// the transfer is encoded as a length prefixed field of the msg
+		transferSize := len(ModuleCdc.MustMarshalBinaryLengthPrefixed(transfer)) + 1
+		if baseSize+transferSize > maxBytes {
+			return nil, fmt.Errorf("transfer %d to %s exceeds the max size %d by itself", i, transfer.To, maxBytes)
+		}
+		if len(chunk) == maxNum || size+transferSize > maxBytes {
+			chunks = append(chunks, chunk)
+			chunk, size = nil, baseSize
+		}
+		chunk = append(chunk, transfer)
+		size += transferSize
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
 func BaseAccountToDecAccount(account auth.BaseAccount) DecAccount {
 	var decCoins sdk.DecCoins
 	for _, coin := range account.Coins {
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/okex/okchain/x/common"
@@ -138,3 +140,89 @@ func TestValidCoinName(t *testing.T) {
 	valid := sdk.ValidateDenom(coinName)
 	require.Error(t, valid)
 }
+
+func TestParseTransfersCSV(t *testing.T) {
+	addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	csv := fmt.Sprintf("address,amount\n# comment\n\n%s,1.5okt\n %s ,\"1okt,2btc\"\n", addr1, addr2)
+	transfers, err := ParseTransfersCSV(strings.NewReader(csv))
+	require.Nil(t, err)
+	require.Equal(t, 2, len(transfers))
+	require.Equal(t, addr1, transfers[0].To)
+	require.Equal(t, sdk.MustParseCoins(common.NativeToken, "1.5"), transfers[0].Coins)
+	require.Equal(t, addr2, transfers[1].To)
+	require.Equal(t, 2, len(transfers[1].Coins))
+	require.Equal(t, sdk.NewDecWithPrec(25, 1), SumTransfers(transfers).AmountOf(common.NativeToken))
+
+	invalidCases := []string{
+		fmt.Sprintf("%s,1okt,2btc\n", addr1),
+		"okchain1invalid,1okt\n",
+		fmt.Sprintf("%s,0okt\n", addr1),
+		fmt.Sprintf("%s,-1okt\n", addr1),
+		fmt.Sprintf("%s,abc\n", addr1),
+		fmt.Sprintf("%s,1okt\n%s,2okt\n", addr1, addr1),
+	}
+	for _, c := range invalidCases {
+		_, err := ParseTransfersCSV(strings.NewReader(c))
+		require.NotNil(t, err, c)
+	}
+}
+
+func TestChunkTransfers(t *testing.T) {
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	var transfers []TransferUnit
+	for i := 0; i < 25; i++ {
+		transfers = append(transfers, TransferUnit{
+			To:    sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+			Coins: sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(int64(i+1))),
+		})
+	}
+
+	// split by the number
+	chunks, err := ChunkTransfers(from, transfers, 10, 1<<20)
+	require.Nil(t, err)
+	require.Equal(t, []int{10, 10, 5}, []int{len(chunks[0]), len(chunks[1]), len(chunks[2])})
+
+	// split by the size
+	maxBytes := 1000
+	chunks, err = ChunkTransfers(from, transfers, 0, maxBytes)
+	require.Nil(t, err)
+	require.True(t, len(chunks) > 1)
+	total := 0
+	for _, chunk := range chunks {
+		total += len(chunk)
+		require.True(t, len(ModuleCdc.MustMarshalBinaryBare(NewMsgMultiSend(from, chunk))) <= maxBytes)
+	}
+	require.Equal(t, len(transfers), total)
+	require.Equal(t, transfers[0], chunks[0][0])
+
+	// a single transfer exceeds the size
+	_, err = ChunkTransfers(from, transfers, 0, 50)
+	require.NotNil(t, err)
+}
+
+func TestChunkTransfers_Limit(t *testing.T) {
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	var transfers []TransferUnit
+	for i := 0; i <= MultiSendLimit; i++ {
+		transfers = append(transfers, TransferUnit{
+			To:    sdk.AccAddress(fmt.Sprintf("addr%016d", i)),
+			Coins: sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(1)),
+		})
+	}
+	require.NotNil(t, NewMsgMultiSend(from, transfers).ValidateBasic())
+
+	// the chunks are bounded by the limit of the multi send, whatever the batch size is
+	for _, maxNum := range []int{0, MultiSendLimit, MultiSendLimit + 1} {
+		chunks, err := ChunkTransfers(from, transfers, maxNum, 1<<30)
+		require.Nil(t, err)
+		require.Equal(t, []int{MultiSendLimit, 1}, []int{len(chunks[0]), len(chunks[1])})
+		require.Nil(t, NewMsgMultiSend(from, chunks[0]).ValidateBasic())
+	}
+
+	// a full chunk fits in the default max size of a tx of the airdrop
+	chunks, err := ChunkTransfers(from, transfers, 0, 1<<20-1024)
+	require.Nil(t, err)
+	require.Equal(t, MultiSendLimit, len(chunks[0]))
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VestingLock is the vesting schedule of the coins sent by a MsgTimeLockedSend.
+// The coins vest linearly by block time from StartTime to EndTime, and nothing vests before CliffTime.
+// The vested coins are released at the cliff, then once every VestingReleasePeriod and at the end
+type VestingLock struct {
+	ID        uint64         `json:"id"`
+	From      sdk.AccAddress `json:"from"`
+	Address   sdk.AccAddress `json:"address"`
+	Amount    sdk.DecCoins   `json:"amount"`
+	Released  sdk.DecCoins   `json:"released"`
+	StartTime int64          `json:"start_time"`
+	CliffTime int64          `json:"cliff_time"`
+	EndTime   int64          `json:"end_time"`
+}
+
+func (lock VestingLock) String() string {
+	b, err := json.Marshal(lock)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// VestedCoins returns the coins vested at the block time
+func (lock VestingLock) VestedCoins(blockTime int64) sdk.DecCoins {
+	if blockTime < lock.CliffTime {
+		return sdk.DecCoins{}
+	}
+	if blockTime >= lock.EndTime {
+		return lock.Amount
+	}
+
+	elapsed := blockTime - lock.StartTime
+	duration := sdk.NewDec(lock.EndTime - lock.StartTime)
+	vested := sdk.DecCoins{}
+	for _, coin := range lock.Amount {
+		amount := coin.Amount.MulInt64(elapsed).QuoTruncate(duration)
+		if amount.IsPositive() {
+			vested = append(vested, sdk.NewDecCoinFromDec(coin.Denom, amount))
+		}
+	}
+	return vested
+}
+
+// ValidateSchedule checks the times of the vesting schedule
+func ValidateSchedule(startTime, cliffTime, endTime int64) sdk.Error {
+	if startTime <= 0 {
+		return sdk.ErrUnknownRequest("start time must be positive")
+	}
+	if cliffTime < startTime || cliffTime > endTime {
+		return sdk.ErrUnknownRequest("cliff time must be between the start time and the end time")
+	}
+	if endTime <= startTime {
+		return sdk.ErrUnknownRequest("end time must be later than the start time")
+	}
+	return nil
+}
+
+// VestingInfo is the result of vesting queries, showing the vested and unvested coins of a vesting lock
+type VestingInfo struct {
+	VestingLock
+	Vested   sdk.DecCoins `json:"vested"`
+	Unvested sdk.DecCoins `json:"unvested"`
+}
+
+// NewVestingInfo creates the vesting info of the lock at the block time
+func NewVestingInfo(lock VestingLock, blockTime int64) VestingInfo {
+	vested := lock.VestedCoins(blockTime)
+	unvested, _ := lock.Amount.SafeSub(vested)
+	return VestingInfo{
+		VestingLock: lock,
+		Vested:      vested,
+		Unvested:    unvested,
+	}
+}
+
+type VestingInfos []VestingInfo
+
+func (infos VestingInfos) String() string {
+	b, err := json.Marshal(infos)
+	if err != nil {
+		return "[{}]"
+	}
+	return string(b)
+}
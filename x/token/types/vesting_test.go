@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingLock_VestedCoins(t *testing.T) {
+	lock := VestingLock{
+		Amount: sdk.DecCoins{
+			sdk.NewDecCoinFromDec("btc", sdk.NewDec(100)),
+			sdk.NewDecCoinFromDec("xxb", sdk.NewDec(3)),
+		},
+		StartTime: 1000,
+		CliffTime: 1250,
+		EndTime:   2000,
+	}
+
+	require.True(t, lock.VestedCoins(1249).IsZero())
+	require.Equal(t, sdk.DecCoins{
+		sdk.NewDecCoinFromDec("btc", sdk.NewDec(25)),
+		sdk.NewDecCoinFromDec("xxb", sdk.MustNewDecFromStr("0.75")),
+	}, lock.VestedCoins(1250))
+	require.Equal(t, sdk.DecCoins{
+		sdk.NewDecCoinFromDec("btc", sdk.MustNewDecFromStr("33.3")),
+		sdk.NewDecCoinFromDec("xxb", sdk.MustNewDecFromStr("0.999")),
+	}, lock.VestedCoins(1333))
+	require.Equal(t, lock.Amount, lock.VestedCoins(2000))
+	require.Equal(t, lock.Amount, lock.VestedCoins(3000))
+
+	info := NewVestingInfo(lock, 1500)
+	require.Equal(t, sdk.DecCoins{
+		sdk.NewDecCoinFromDec("btc", sdk.NewDec(50)),
+		sdk.NewDecCoinFromDec("xxb", sdk.MustNewDecFromStr("1.5")),
+	}, info.Vested)
+	require.Equal(t, info.Vested, info.Unvested)
+	require.NotEmpty(t, VestingInfos{info}.String())
+}
+
+func TestValidateSchedule(t *testing.T) {
+	require.Nil(t, ValidateSchedule(1000, 1000, 2000))
+	require.Nil(t, ValidateSchedule(1000, 2000, 2000))
+	require.NotNil(t, ValidateSchedule(0, 1000, 2000))
+	require.NotNil(t, ValidateSchedule(1000, 999, 2000))
+	require.NotNil(t, ValidateSchedule(1000, 2001, 2000))
+	require.NotNil(t, ValidateSchedule(1000, 1000, 1000))
+}
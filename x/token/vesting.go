@@ -0,0 +1,161 @@
+package token
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okchain/x/token/types"
+)
+
+// TimeLockedSend sends the coins into the lock of the recipient, which are released by the vesting schedule
+func (k Keeper) TimeLockedSend(ctx sdk.Context, from, to sdk.AccAddress, coins sdk.DecCoins,
+	startTime, cliffTime, endTime int64) (types.VestingLock, error) {
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, from, types.ModuleName, coins); err != nil {
+		return types.VestingLock{}, err
+	}
+	if err := k.updateLockCoins(ctx, to, coins, true); err != nil {
+		return types.VestingLock{}, err
+	}
+
+	lock := types.VestingLock{
+		ID:        k.getNextVestingID(ctx),
+		From:      from,
+		Address:   to,
+		Amount:    coins,
+		Released:  sdk.DecCoins{},
+		StartTime: startTime,
+		CliffTime: cliffTime,
+		EndTime:   endTime,
+	}
+	k.SetVestingLock(ctx, lock)
+	k.scheduleVestingRelease(ctx, lock, cliffTime)
+	return lock, nil
+}
+
+// ReleaseVestedCoins unlocks the coins vested since the last release of the vesting locks due to be released.
+// A lock is released at its cliff, then once every VestingReleasePeriod and at its end, so that only the locks
+// queued by their next release time are read and written in a block
+func (k Keeper) ReleaseVestedCoins(ctx sdk.Context) {
+	blockTime := ctx.BlockHeader().Time.Unix()
+
+	// the queue keys and the keys of the locks due to be released
+	var queueKeys, lockKeys [][]byte
+	store := ctx.KVStore(k.lockStoreKey)
+	iter := store.Iterator(types.VestingQueueKey, types.GetVestingQueueTimePrefix(blockTime+1))
+	for ; iter.Valid(); iter.Next() {
+		queueKeys = append(queueKeys, append([]byte{}, iter.Key()...))
+		lockKeys = append(lockKeys, append([]byte{}, iter.Value()...))
+	}
+	iter.Close()
+	if len(queueKeys) == 0 {
+		return
+	}
+
+	period := int64(k.GetParams(ctx).VestingReleasePeriod.Seconds())
+	for i, queueKey := range queueKeys {
+		store.Delete(queueKey)
+		bz := store.Get(lockKeys[i])
+		if bz == nil {
+			continue
+		}
+		var lock types.VestingLock
+		k.cdc.MustUnmarshalBinaryBare(bz, &lock)
+
+		// the locks failed to be released are retried at the next release
+		nextRelease := blockTime + period
+		if period <= 0 || nextRelease > lock.EndTime {
+			nextRelease = lock.EndTime
+		}
+		vested := lock.VestedCoins(blockTime)
+		released, isNegative := vested.SafeSub(lock.Released)
+		if isNegative {
+			ctx.Logger().Error(fmt.Sprintf("vesting lock %d of %s released more than vested", lock.ID, lock.Address))
+			k.scheduleVestingRelease(ctx, lock, nextRelease)
+			continue
+		}
+		if !released.IsZero() {
+			if err := k.UnlockCoins(ctx, lock.Address, released, types.LockCoinsTypeVesting); err != nil {
+				ctx.Logger().Error(fmt.Sprintf("failed to release vesting lock %d of %s: %s", lock.ID, lock.Address, err))
+				k.scheduleVestingRelease(ctx, lock, nextRelease)
+				continue
+			}
+		}
+
+		lock.Released = vested
+		if blockTime >= lock.EndTime {
+			k.deleteVestingLock(ctx, lock.Address, lock.ID)
+			continue
+		}
+		k.SetVestingLock(ctx, lock)
+		k.scheduleVestingRelease(ctx, lock, nextRelease)
+	}
+}
+
+// scheduleVestingRelease queues the vesting lock to be released by the first block at or after the release time
+func (k Keeper) scheduleVestingRelease(ctx sdk.Context, lock types.VestingLock, releaseTime int64) {
+	store := ctx.KVStore(k.lockStoreKey)
+	store.Set(types.GetVestingQueueKey(releaseTime, lock.Address, lock.ID), types.GetVestingKey(lock.Address, lock.ID))
+}
+
+// SetVestingLock saves the vesting lock to the lock store
+func (k Keeper) SetVestingLock(ctx sdk.Context, lock types.VestingLock) {
+	store := ctx.KVStore(k.lockStoreKey)
+	store.Set(types.GetVestingKey(lock.Address, lock.ID), k.cdc.MustMarshalBinaryBare(lock))
+}
+
+func (k Keeper) deleteVestingLock(ctx sdk.Context, addr sdk.AccAddress, id uint64) {
+	store := ctx.KVStore(k.lockStoreKey)
+	store.Delete(types.GetVestingKey(addr, id))
+}
+
+// GetVestingLocks gets the vesting locks of the addr
+func (k Keeper) GetVestingLocks(ctx sdk.Context, addr sdk.AccAddress) (locks []types.VestingLock) {
+	store := ctx.KVStore(k.lockStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetVestingPrefix(addr))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var lock types.VestingLock
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &lock)
+		locks = append(locks, lock)
+	}
+	return locks
+}
+
+// IterateVestingLocks iterates all the vesting locks
+func (k Keeper) IterateVestingLocks(ctx sdk.Context, fn func(lock types.VestingLock) (stop bool)) {
+	store := ctx.KVStore(k.lockStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.VestingKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var lock types.VestingLock
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &lock)
+		if fn(lock) {
+			break
+		}
+	}
+}
+
+// GetAllVestingLocks gets all the vesting locks
+func (k Keeper) GetAllVestingLocks(ctx sdk.Context) (locks []types.VestingLock) {
+	k.IterateVestingLocks(ctx, func(lock types.VestingLock) bool {
+		locks = append(locks, lock)
+		return false
+	})
+	return locks
+}
+
+func (k Keeper) getNextVestingID(ctx sdk.Context) (id uint64) {
+	store := ctx.KVStore(k.lockStoreKey)
+	if b := store.Get(types.VestingNumberKey); b != nil {
+		k.cdc.MustUnmarshalBinaryBare(b, &id)
+	}
+	k.setNextVestingID(ctx, id+1)
+	return id
+}
+
+func (k Keeper) setNextVestingID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.lockStoreKey)
+	store.Set(types.VestingNumberKey, k.cdc.MustMarshalBinaryBare(id))
+}
@@ -0,0 +1,106 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/okex/okchain/x/common"
+	"github.com/okex/okchain/x/common/version"
+	"github.com/okex/okchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestTimeLockedSend(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	genAccs, testAccounts := CreateGenAccounts(2,
+		sdk.DecCoins{
+			sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(1000)),
+		})
+	mock.SetGenesis(mapp.App, types.DecAccountArrToBaseAccountArr(genAccs))
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockTime(time.Unix(1000, 0))
+	keeper.SetParams(ctx, types.DefaultParams())
+	handler := NewTokenHandler(keeper, version.CurrentProtocolVersion)
+	from, to := testAccounts[0].baseAccount.Address, testAccounts[1].baseAccount.Address
+
+	coins := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(100))}
+	// the schedule has ended
+	res := handler(ctx, types.NewMsgTimeLockedSend(from, to, coins, 500, 500, 1000))
+	require.False(t, res.IsOK())
+	// insufficient coins
+	res = handler(ctx, types.NewMsgTimeLockedSend(from, to, coins.MulDec(sdk.NewDec(20)), 1000, 1250, 2000))
+	require.False(t, res.IsOK())
+
+	res = handler(ctx, types.NewMsgTimeLockedSend(from, to, coins, 1000, 1250, 2000))
+	require.True(t, res.IsOK())
+	require.Equal(t, sdk.MustParseCoins(common.NativeToken, "900"), keeper.GetCoins(ctx, from))
+	require.Equal(t, sdk.MustParseCoins(common.NativeToken, "1000"), keeper.GetCoins(ctx, to))
+	require.Equal(t, coins, keeper.GetLockCoins(ctx, to))
+	locks := keeper.GetVestingLocks(ctx, to)
+	require.Equal(t, 1, len(locks))
+	require.Equal(t, uint64(0), locks[0].ID)
+
+	// nothing is released before the cliff
+	ctx = ctx.WithBlockTime(time.Unix(1249, 0))
+	BeginBlocker(ctx, keeper, NewSnapshotAccountKeeper(mapp.AccountKeeper, mapp.KeyAccount))
+	require.Equal(t, coins, keeper.GetLockCoins(ctx, to))
+
+	ctx = ctx.WithBlockTime(time.Unix(1500, 0))
+	BeginBlocker(ctx, keeper, NewSnapshotAccountKeeper(mapp.AccountKeeper, mapp.KeyAccount))
+	half := sdk.DecCoins{sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(50))}
+	require.Equal(t, half, keeper.GetLockCoins(ctx, to))
+	require.Equal(t, sdk.MustParseCoins(common.NativeToken, "1050"), keeper.GetCoins(ctx, to))
+
+	// the coins vested since are released by the next release, at the end of the schedule with the default period
+	ctx = ctx.WithBlockTime(time.Unix(1750, 0))
+	BeginBlocker(ctx, keeper, NewSnapshotAccountKeeper(mapp.AccountKeeper, mapp.KeyAccount))
+	require.Equal(t, half, keeper.GetLockCoins(ctx, to))
+
+	querier := NewQuerier(keeper, mapp.AccountKeeper)
+	bz, err := querier(ctx.WithBlockTime(time.Unix(1750, 0)), []string{types.QueryVesting, to.String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var infos types.VestingInfos
+	keeper.cdc.MustUnmarshalJSON(bz, &infos)
+	require.Equal(t, 1, len(infos))
+	require.Equal(t, half, infos[0].Released)
+	require.Equal(t, "75.00000000okt", infos[0].Vested.String())
+	require.Equal(t, "25.00000000okt", infos[0].Unvested.String())
+	_, err = querier(ctx, []string{types.QueryVesting, "invalid"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	// the vesting locks are exported
+	require.Equal(t, keeper.GetAllVestingLocks(ctx), ExportGenesis(ctx, keeper).VestingLocks)
+	_, broken := AllInvariants(keeper, mapp.AccountKeeper)(ctx)
+	require.False(t, broken)
+
+	// all the coins are released at the end
+	ctx = ctx.WithBlockTime(time.Unix(2000, 0))
+	BeginBlocker(ctx, keeper, NewSnapshotAccountKeeper(mapp.AccountKeeper, mapp.KeyAccount))
+	require.True(t, keeper.GetLockCoins(ctx, to).IsZero())
+	require.Equal(t, sdk.MustParseCoins(common.NativeToken, "1100"), keeper.GetCoins(ctx, to))
+	require.Nil(t, keeper.GetVestingLocks(ctx, to))
+
+	res = handler(ctx, types.NewMsgTimeLockedSend(from, to, coins, 2000, 2000, 3000))
+	require.True(t, res.IsOK())
+	require.Equal(t, uint64(1), keeper.GetVestingLocks(ctx, to)[0].ID)
+
+	// the vested coins are released once every period
+	params := types.DefaultParams()
+	params.VestingReleasePeriod = 300 * time.Second
+	keeper.SetParams(ctx, params)
+	for _, release := range []struct {
+		blockTime int64
+		locked    string
+	}{
+		{2000, "100"}, {2100, "100"}, {2300, "70"}, {2599, "70"}, {2600, "40"}, {2900, "10"}, {2999, "10"}, {3000, "0"},
+	} {
+		ctx = ctx.WithBlockTime(time.Unix(release.blockTime, 0))
+		BeginBlocker(ctx, keeper, NewSnapshotAccountKeeper(mapp.AccountKeeper, mapp.KeyAccount))
+		require.Equal(t, sdk.MustNewDecFromStr(release.locked), keeper.GetLockCoins(ctx, to).AmountOf(common.NativeToken),
+			"block time %d", release.blockTime)
+	}
+	require.Nil(t, keeper.GetVestingLocks(ctx, to))
+}